@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Agent information and metrics",
+}
+
+var agentStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Compare run outcomes per agent type",
+	Long:  "Show completion rate, error rate, average runtime, and average cost for each agent type across all projects.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.PrintAgentStats()
+	},
+}
+
+func init() {
+	agentCmd.AddCommand(agentStatsCmd)
+	rootCmd.AddCommand(agentCmd)
+}
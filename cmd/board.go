@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var boardMergeDryRun bool
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Manage the board's tickets",
+}
+
+var boardMergeCmd = &cobra.Command{
+	Use:   "merge <other-board.json>",
+	Short: "Merge tickets from another openkanban's tickets file",
+	Long: `Merge the tickets in another openkanban's tickets file, such as one kept for an
+experiment on a branch, into the current project's board.
+
+A ticket with the same title (ignoring case) and branch as one already on the
+board is skipped as a duplicate. Tickets whose ID is already taken get a new
+one, and epics and blockers follow them; references to tickets on neither
+board are dropped. Tickets in columns this board doesn't have go to Backlog,
+and sprints it doesn't know are cleared. Merged tickets keep their history and
+comments but no agent state. The project is the current directory unless
+--project is given.`,
+	Example: `  openkanban board merge ~/experiments/tickets/3f2a9c1e.json --dry-run
+  openkanban board merge other.json -p ~/src/app`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return app.MergeBoard(cfgFile, projectPath, args[0], boardMergeDryRun)
+	},
+}
+
+func init() {
+	boardMergeCmd.Flags().BoolVarP(&boardMergeDryRun, "dry-run", "n", false, "report what would be merged without saving")
+	boardCmd.AddCommand(boardMergeCmd)
+	rootCmd.AddCommand(boardCmd)
+}
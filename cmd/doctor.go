@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var doctorFix bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose configuration and agent session problems",
+	Long:  "Check the configuration, registered projects, agent session names, and agent preflight checks for problems such as session prefix collisions with other boards or tools, stale status files, and missing agent logins.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return app.Doctor(cfgFile, doctorFix)
+	},
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "migrate legacy status files to board-prefixed session names and remove stale ones")
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var emitMessage string
+
+var emitStatusCmd = &cobra.Command{
+	Use:   "emit-status <session> <status>",
+	Short: "Report an agent status to the board",
+	Long: `Write the status file the board polls for an agent session, so any agent
+wrapper or shell script can drive the status indicators.
+
+Status is one of working, idle, waiting, error, or completed ("done" and
+"permission" are accepted as aliases). Pass "-" as the session to use
+$OPENKANBAN_SESSION, which is set in every spawned agent's environment.`,
+	Example: `  openkanban emit-status - working
+  openkanban emit-status - waiting -m "needs approval to run migrations"`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return app.EmitStatus(args[0], args[1], emitMessage)
+	},
+}
+
+func init() {
+	emitStatusCmd.Flags().StringVarP(&emitMessage, "message", "m", "", "short message shown beside the status")
+	rootCmd.AddCommand(emitStatusCmd)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var (
+	exportQuery  string
+	exportFormat string
+	exportOutput string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the tickets matching a query",
+	Long: `Write the tickets matching a query as JSON or markdown, to hand a subset of
+the board, such as one client's work, to someone without sharing the rest.
+Exports include descriptions and comments but leave out worktree paths, agent
+sessions and ticket history. Archived tickets are never exported.
+
+A query is a list of terms that must all hold: label:, status:, assignee:,
+priority: and project: narrow by field, comma-separated values are
+alternatives, a leading "-" excludes, and bare words match the title or
+description. Without --query every ticket is exported.
+
+All projects are searched unless --project is given. The export goes to
+stdout unless --output names a file.`,
+	Example: `  openkanban export --query "label:client-x"
+  openkanban export -q "label:client-x status:done" --format md -o client-x.md
+  openkanban export -q "-label:internal priority:1,2" --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return app.Export(cfgFile, projectPath, exportQuery, exportFormat, exportOutput)
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportQuery, "query", "q", "", "tickets to export, e.g. \"label:client-x status:done\"")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "json", "export format: json or md")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "write the export to a file instead of stdout")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var (
+	profileOutput string
+	profileForce  bool
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Share key bindings, theme, card layout and saved filters",
+}
+
+var profileExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write a profile bundle",
+	Long: `Write the key bindings, theme, card layout and saved filters as a JSON profile
+bundle that teammates can import. User themes the profile names are included,
+and saved filters name their projects rather than giving their IDs.`,
+	Example: `  openkanban profile export -o team.json
+  openkanban profile export > team.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return app.ExportProfile(cfgFile, profileOutput)
+	},
+}
+
+var profileImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Apply a profile bundle",
+	Long: `Apply a profile bundle written by "openkanban profile export", or "-" to read
+it from stdin. Each section the bundle includes replaces yours; saved filters
+replace yours of the same name, and filters for projects you don't have are
+skipped.
+
+The bundle is checked first: unknown fields, key bindings for unknown actions
+or with clashing keys, unknown themes or bad colors, and card layouts that
+don't parse are all rejected, and nothing is changed. So is a user theme that
+differs from your theme file of the same name, unless --force is given.`,
+	Example: `  openkanban profile import team.json
+  curl -s https://example.com/team.json | openkanban profile import -`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return app.ImportProfile(cfgFile, args[0], profileForce)
+	},
+}
+
+func init() {
+	profileExportCmd.Flags().StringVarP(&profileOutput, "output", "o", "", "write the profile to a file instead of stdout")
+	profileImportCmd.Flags().BoolVar(&profileForce, "force", false, "replace user themes that differ from the bundle's")
+	profileCmd.AddCommand(profileExportCmd)
+	profileCmd.AddCommand(profileImportCmd)
+	rootCmd.AddCommand(profileCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var (
+	replayRun   int
+	replaySpeed float64
+	replayIdle  time.Duration
+	replayList  bool
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <ticket-id>",
+	Short: "Play back a recorded agent session",
+	Long: `Play back a ticket's recorded agent session in the terminal, to audit what the
+agent actually did. Sessions are recorded when behavior.record_sessions is on.
+Ticket IDs may be abbreviated to a unique prefix.
+
+Recordings are asciicast v2 files, so asciinema can play them too.`,
+	Example: `  openkanban replay 1a2b3c
+  openkanban replay 1a2b3c --list
+  openkanban replay 1a2b3c --run 2 --speed 4`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return app.ReplaySession(args[0], replayRun, replaySpeed, replayIdle, replayList)
+	},
+}
+
+func init() {
+	replayCmd.Flags().IntVar(&replayRun, "run", 0, "recorded run to play, counting from 1 for the oldest (default latest)")
+	replayCmd.Flags().Float64VarP(&replaySpeed, "speed", "s", 1, "playback speed multiplier")
+	replayCmd.Flags().DurationVar(&replayIdle, "idle-limit", 2*time.Second, "longest pause to replay (0 keeps pauses as recorded)")
+	replayCmd.Flags().BoolVar(&replayList, "list", false, "list the ticket's recorded runs")
+	rootCmd.AddCommand(replayCmd)
+}
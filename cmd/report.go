@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Reports across all projects",
+}
+
+var reportOutcomesCmd = &cobra.Command{
+	Use:   "outcomes",
+	Short: "Summarize closed-ticket outcomes",
+	Long:  "Aggregate recorded ticket outcomes (shipped, abandoned, needed-human-rewrite) by label and by agent type.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.PrintOutcomeReport()
+	},
+}
+
+var (
+	changelogSince  string
+	changelogOutput string
+)
+
+var reportChangelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Write release notes from Done tickets",
+	Long: `Write a CHANGELOG-style markdown section listing the tickets moved to Done
+since a tag, commit or date, grouped by each ticket's first label, with links
+to their pull requests, issues and linked commits. Abandoned tickets are left
+out.
+
+--since takes a date (YYYY-MM-DD) or a tag or commit, which is looked up in
+the --project repository, or the current directory's. Without it every Done
+ticket is listed. All projects are included unless --project is given.`,
+	Example: `  openkanban report changelog --since v1.2.0
+  openkanban report changelog --since 2026-10-01 -o RELEASE_NOTES.md
+  openkanban report changelog -p ~/src/app --since v2.0.0`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return app.PrintChangelog(projectPath, changelogSince, changelogOutput)
+	},
+}
+
+func init() {
+	reportChangelogCmd.Flags().StringVar(&changelogSince, "since", "", "tag, commit or date (YYYY-MM-DD) the changelog starts from")
+	reportChangelogCmd.Flags().StringVarP(&changelogOutput, "output", "o", "", "write the changelog to a file instead of stdout")
+	reportCmd.AddCommand(reportChangelogCmd)
+	reportCmd.AddCommand(reportOutcomesCmd)
+	rootCmd.AddCommand(reportCmd)
+}
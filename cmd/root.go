@@ -9,11 +9,13 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/techdufus/openkanban/internal/app"
 	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/project"
 )
 
 var (
 	cfgFile     string
 	projectPath string
+	assumeYes   bool
 )
 
 var rootCmd = &cobra.Command{
@@ -46,17 +48,22 @@ for safe parallel development.`,
 			fmt.Fprintf(os.Stderr, "Config warnings:\n%s\n", result.FormatWarnings())
 		}
 
+		cfg.Behavior.Confirm.AssumeYes = assumeYes
 		return app.Run(cfg, projectPath, Version)
 	},
 }
 
 func Execute() error {
-	return rootCmd.Execute()
+	project.AppVersion = Version
+	cmd, err := rootCmd.ExecuteC()
+	countCommand(cmd)
+	return err
 }
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/openkanban/config.json)")
 	rootCmd.PersistentFlags().StringVarP(&projectPath, "project", "p", "", "project or repository path")
+	rootCmd.Flags().BoolVar(&assumeYes, "yes", false, "never ask for confirmation this session (see behavior.confirm)")
 
 	rootCmd.AddCommand(newCmd)
 	rootCmd.AddCommand(listCmd)
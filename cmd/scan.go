@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var (
+	scanYes        bool
+	scanFromCommit bool
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Bootstrap tickets from a repository",
+}
+
+var scanBranchesCmd = &cobra.Command{
+	Use:   "branches",
+	Short: "Create tickets for unmerged branches",
+	Long: `List the local and remote branches not merged into the default branch that no
+ticket has yet, and offer to create a ticket for each. Answer y to create one,
+a to create it and all the rest, or q to stop.
+
+Tickets go into In Progress, linked to their branch and to its worktree when
+it is checked out in one. Remote-only branches get a local tracking branch.
+Titles come from the branch name, or with --from-commit from its last commit.
+The repository is the current directory unless --project is given.`,
+	Example: `  openkanban scan branches
+  openkanban scan branches -p ~/src/app --yes --from-commit`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return app.ScanBranches(cfgFile, projectPath, scanYes, scanFromCommit)
+	},
+}
+
+func init() {
+	scanBranchesCmd.Flags().BoolVarP(&scanYes, "yes", "y", false, "create tickets without asking")
+	scanBranchesCmd.Flags().BoolVar(&scanFromCommit, "from-commit", false, "title tickets with the branch's last commit subject")
+	scanCmd.AddCommand(scanBranchesCmd)
+	rootCmd.AddCommand(scanCmd)
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var searchLogsLimit int
+
+var searchLogsCmd = &cobra.Command{
+	Use:   "search-logs <term>",
+	Short: "Search archived agent transcripts",
+	Long:  "Find which ticket's agent mentioned a file, error message, or other text in its archived prompt, transcript, diff, or session log.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.SearchLogs(strings.Join(args, " "), searchLogsLimit)
+	},
+}
+
+func init() {
+	searchLogsCmd.Flags().IntVarP(&searchLogsLimit, "limit", "n", 50, "maximum number of matches to show (0 for all)")
+	rootCmd.AddCommand(searchLogsCmd)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var (
+	shareFormat string
+	shareTarget string
+	shareOutput string
+)
+
+var shareCmd = &cobra.Command{
+	Use:   "share",
+	Short: "Publish a read-only snapshot of the board",
+	Long: `Render the board as a static markdown or HTML page and upload it, printing a
+URL to send to people who follow the work without running openkanban.
+
+The target is set under "share" in the config: a GitHub gist (through the gh
+CLI), an S3 bucket (through the aws CLI), or a custom command that receives
+the file in $OPENKANBAN_SHARE_FILE and prints the URL. Snapshots list titles,
+labels, assignees, priorities, agent status and branches; descriptions and
+comments are left out. With --format atom it is an Atom feed of recent
+events instead (tickets started and completed, agents that failed), uploaded
+under the same name each time so feed readers can follow one URL.
+
+All projects are included unless --project is given. With --output the
+snapshot is written to a file, or to stdout with "-", and nothing is uploaded.`,
+	Example: `  openkanban share
+  openkanban share -p ~/src/app --format html
+  openkanban share --output board.md
+  openkanban share --format atom --target s3`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return app.Share(cfgFile, projectPath, shareFormat, shareTarget, shareOutput)
+	},
+}
+
+func init() {
+	shareCmd.Flags().StringVar(&shareFormat, "format", "", "snapshot format: markdown, html or atom (default from config)")
+	shareCmd.Flags().StringVar(&shareTarget, "target", "", "upload target: gist, s3 or command (default from config)")
+	shareCmd.Flags().StringVarP(&shareOutput, "output", "o", "", "write the snapshot to a file (- for stdout) instead of uploading")
+	rootCmd.AddCommand(shareCmd)
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var (
+	sprintStart string
+	sprintEnd   string
+)
+
+var sprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Manage sprints",
+}
+
+var sprintListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List sprints with ticket progress",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.ListSprints()
+	},
+}
+
+var sprintCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a sprint",
+	Long:  "Create a sprint. It starts today and lasts two weeks unless --start or --end is given.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.CreateSprint(strings.Join(args, " "), sprintStart, sprintEnd)
+	},
+}
+
+var sprintDeleteCmd = &cobra.Command{
+	Use:   "delete <name-or-id>",
+	Short: "Delete a sprint and unassign its tickets",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.DeleteSprint(strings.Join(args, " "))
+	},
+}
+
+func init() {
+	sprintCreateCmd.Flags().StringVar(&sprintStart, "start", "", "start date (YYYY-MM-DD, default today)")
+	sprintCreateCmd.Flags().StringVar(&sprintEnd, "end", "", "end date (YYYY-MM-DD, default start + 13 days)")
+	sprintCmd.AddCommand(sprintListCmd, sprintCreateCmd, sprintDeleteCmd)
+	rootCmd.AddCommand(sprintCmd)
+}
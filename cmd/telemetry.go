@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/telemetry"
+)
+
+var telemetryCmd = &cobra.Command{
+	Use:   "telemetry",
+	Short: "Opt-in anonymous usage reporting",
+	Long: `Usage reporting is off unless you turn it on. When on, openkanban counts
+which commands, agent types and board sizes are used, and the board sends
+the counts to the configured endpoint when it exits. Ticket titles, descriptions,
+paths, prompts and agent output are never recorded.`,
+}
+
+var telemetryStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether usage reporting is on",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		cfg, err := config.Load(cfgFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		state := "off"
+		if cfg.Telemetry.Enabled {
+			state = "on"
+		}
+		endpoint := cfg.Telemetry.Endpoint
+		if endpoint == "" {
+			endpoint = "(not set)"
+		}
+		fmt.Printf("Usage reporting: %s\n", state)
+		fmt.Printf("Endpoint:        %s\n", endpoint)
+		if !cfg.Telemetry.Enabled {
+			fmt.Println("\nTurn it on with: openkanban telemetry on --endpoint URL")
+		} else if cfg.Telemetry.Endpoint == "" {
+			fmt.Println("\nNo endpoint is set, so nothing is sent.")
+		}
+		return nil
+	},
+}
+
+var telemetryEndpoint string
+
+var telemetryOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Turn usage reporting on",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		cfg, err := config.Load(cfgFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if telemetryEndpoint != "" {
+			cfg.Telemetry.Endpoint = telemetryEndpoint
+		}
+		if cfg.Telemetry.Endpoint == "" {
+			return fmt.Errorf("no endpoint configured; pass --endpoint URL")
+		}
+		cfg.Telemetry.Enabled = true
+		if result := cfg.Validate(); result.HasErrors() {
+			return fmt.Errorf("invalid configuration:\n%s", strings.TrimRight(result.FormatErrors(), "\n"))
+		}
+		if err := cfg.Save(cfgFile); err != nil {
+			return fmt.Errorf("failed to write config: %w", err)
+		}
+		fmt.Printf("Usage reporting is on; counts go to %s\n", cfg.Telemetry.Endpoint)
+		return nil
+	},
+}
+
+var telemetryOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Turn usage reporting off",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		cfg, err := config.Load(cfgFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if !cfg.Telemetry.Enabled {
+			fmt.Println("Usage reporting is already off")
+			return nil
+		}
+		cfg.Telemetry.Enabled = false
+		if err := cfg.Save(cfgFile); err != nil {
+			return fmt.Errorf("failed to write config: %w", err)
+		}
+		fmt.Println("Usage reporting is off")
+		return nil
+	},
+}
+
+// countCommand records a CLI subcommand run, for users who opted in. The
+// count is queued for the board to send, so no command waits on the
+// network. The board counts its own usage, and the telemetry commands
+// aren't counted.
+func countCommand(cmd *cobra.Command) {
+	if cmd == nil || cmd == rootCmd || cmd == telemetryCmd || cmd.Parent() == telemetryCmd {
+		return
+	}
+	cfg, err := config.Load(cfgFile)
+	if err != nil || !cfg.Telemetry.Enabled || cfg.Telemetry.Endpoint == "" {
+		return
+	}
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return
+	}
+	path := strings.Fields(cmd.CommandPath())[1:]
+	_ = telemetry.Queue(filepath.Join(dir, telemetry.QueueFile), "cli."+strings.Join(path, "."))
+}
+
+func init() {
+	telemetryCmd.AddCommand(telemetryStatusCmd)
+	telemetryCmd.AddCommand(telemetryOnCmd)
+	telemetryCmd.AddCommand(telemetryOffCmd)
+	rootCmd.AddCommand(telemetryCmd)
+
+	telemetryOnCmd.Flags().StringVar(&telemetryEndpoint, "endpoint", "", "URL that receives the usage report")
+}
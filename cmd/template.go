@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/techdufus/openkanban/internal/app"
+)
+
+var templateBranch bool
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Work with ticket templates",
+}
+
+var templateTestCmd = &cobra.Command{
+	Use:   "test <ticket-id> [template]",
+	Short: "Render a template against a ticket",
+	Long: `Render a template against a ticket's context and print the result, reporting
+template errors instead of falling back. Ticket IDs may be abbreviated to a
+unique prefix.
+
+Without a template, the init prompt the ticket's agent would be given is
+rendered. With --branch, the board's branch template is rendered instead.`,
+	Example: `  openkanban template test 1a2b3c
+  openkanban template test 1a2b3c '{{.Title}} ({{.RepoName}}): {{.Fields.estimate}}'
+  openkanban template test 1a2b3c --branch '{prefix}{{.Fields.jira}}-{slug}'`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		var text string
+		if len(args) == 2 {
+			text = args[1]
+		}
+		return app.TestTemplate(cfgFile, args[0], text, templateBranch)
+	},
+}
+
+func init() {
+	templateTestCmd.Flags().BoolVar(&templateBranch, "branch", false, "render as a branch template")
+	templateCmd.AddCommand(templateTestCmd)
+	rootCmd.AddCommand(templateCmd)
+}
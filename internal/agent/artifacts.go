@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+// TranscriptTailLines caps how much terminal output is archived per run.
+const TranscriptTailLines = 500
+
+// RunArtifacts is the material archived when an agent run finishes.
+type RunArtifacts struct {
+	Prompt     string
+	Transcript string
+	Diff       string
+}
+
+// ArtifactsDir returns the root directory for archived run artifacts.
+func ArtifactsDir() string {
+	return configSubdir("artifacts")
+}
+
+// configSubdir returns the named directory under the config directory.
+// Falls back to the current working directory on ConfigDir error.
+func configSubdir(name string) string {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		dir = "."
+	}
+	return filepath.Join(dir, name)
+}
+
+// WriteRunArtifacts stores a run's prompt, transcript tail, and diff under
+// baseDir/<ticket-id>/<run-start>/ and returns that directory.
+// Empty artifacts are skipped.
+func WriteRunArtifacts(baseDir string, ticketID board.TicketID, startedAt time.Time, a RunArtifacts) (string, error) {
+	dir := filepath.Join(baseDir, string(ticketID), startedAt.Format("20060102-150405"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create artifacts directory: %w", err)
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"prompt.md", a.Prompt},
+		{"transcript.txt", a.Transcript},
+		{"diff.patch", a.Diff},
+	}
+	for _, f := range files {
+		if f.content == "" {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, f.name), []byte(f.content), 0644); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+	return dir, nil
+}
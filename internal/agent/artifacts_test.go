@@ -0,0 +1,37 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteRunArtifacts(t *testing.T) {
+	base := t.TempDir()
+	started := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+
+	dir, err := WriteRunArtifacts(base, "abc123", started, RunArtifacts{
+		Prompt:     "Fix the bug",
+		Transcript: "done\n",
+	})
+	if err != nil {
+		t.Fatalf("WriteRunArtifacts() error = %v", err)
+	}
+
+	want := filepath.Join(base, "abc123", "20240501-093000")
+	if dir != want {
+		t.Errorf("dir = %q, want %q", dir, want)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "prompt.md"))
+	if err != nil || string(data) != "Fix the bug" {
+		t.Errorf("prompt.md = %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "transcript.txt")); err != nil {
+		t.Errorf("transcript.txt missing: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "diff.patch")); !os.IsNotExist(err) {
+		t.Errorf("empty diff should not be written, stat err = %v", err)
+	}
+}
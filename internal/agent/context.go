@@ -2,57 +2,197 @@ package agent
 
 import (
 	"bytes"
+	"path/filepath"
 	"strings"
 	"text/template"
 
 	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
 )
 
-type ContextData struct {
-	Title        string
-	Description  string
+// PromptContext is the data every ticket template is rendered against: agent
+// init prompts, label prompts, branch templates, and `openkanban template
+// test`. Fields are referenced as {{.Title}}, {{.Fields.estimate}}, etc.
+type PromptContext struct {
+	// Ticket
+	TicketID    string
+	Title       string
+	Description string
+	Status      string
+	Priority    int
+	Assignee    string
+	Labels      []string
+	Comments    []board.Comment
+	// Fields holds custom field values by name.
+	Fields map[string]string
+	// Checklist holds the description's task list items ("- [ ] ...") and
+	// Notes the rest of the description.
+	Checklist []ChecklistItem
+	Notes     string
+	// Link is the ticket's ui.ticket_link.
+	Link string
+
+	// Git
 	BranchName   string
 	BaseBranch   string
-	TicketID     string
-	Status       string
 	WorktreePath string
+	// BranchPrefix and Slug are the parts of the default branch template.
+	BranchPrefix string
+	Slug         string
+
+	// Board
+	BoardName string
+	RepoPath  string
+	RepoName  string
 }
 
-func BuildContextPrompt(promptTemplate string, ticket *board.Ticket) string {
-	if promptTemplate == "" {
-		return ""
-	}
+// ChecklistItem is one task list item of a ticket description.
+type ChecklistItem struct {
+	Text string
+	Done bool
+}
+
+// BoardInfo describes the board a ticket belongs to, for NewPromptContext.
+type BoardInfo struct {
+	Name          string
+	RepoPath      string
+	BranchPrefix  string
+	SlugMaxLength int
+	// TicketLink is the ui.ticket_link pattern, with {id} and {project}
+	// placeholders.
+	TicketLink string
+}
 
-	data := ContextData{
+// NewPromptContext builds the template context for a ticket on a board.
+func NewPromptContext(ticket *board.Ticket, info BoardInfo) PromptContext {
+	ctx := PromptContext{
+		TicketID:     string(ticket.ID),
 		Title:        ticket.Title,
 		Description:  ticket.Description,
+		Status:       string(ticket.Status),
+		Priority:     ticket.Priority,
+		Assignee:     ticket.Assignee,
+		Labels:       ticket.Labels,
+		Comments:     ticket.Comments,
+		Fields:       ticket.Meta,
 		BranchName:   ticket.BranchName,
 		BaseBranch:   ticket.BaseBranch,
-		TicketID:     string(ticket.ID),
-		Status:       string(ticket.Status),
 		WorktreePath: ticket.WorktreePath,
+		BranchPrefix: info.BranchPrefix,
+		Slug:         board.Slugify(ticket.Title, info.SlugMaxLength),
+		BoardName:    info.Name,
+		RepoPath:     info.RepoPath,
+	}
+	if info.RepoPath != "" {
+		ctx.RepoName = filepath.Base(info.RepoPath)
+	}
+	if info.TicketLink != "" {
+		ctx.Link = config.ExpandTicketLink(info.TicketLink, string(ticket.ID), info.Name)
+	}
+	ctx.Checklist, ctx.Notes = SplitChecklist(ticket.Description)
+	if ctx.Fields == nil {
+		ctx.Fields = map[string]string{}
+	}
+	return ctx
+}
+
+func BuildContextPrompt(promptTemplate string, ctx PromptContext) string {
+	if promptTemplate == "" {
+		return ""
 	}
+	prompt, err := RenderContextPrompt(promptTemplate, ctx)
+	if err != nil {
+		return buildFallbackPrompt(ctx)
+	}
+	return prompt
+}
+
+// RenderContextPrompt executes the prompt template against the context and
+// reports template errors instead of falling back, so they can be previewed.
+func RenderContextPrompt(promptTemplate string, ctx PromptContext) (string, error) {
+	return renderTemplate("prompt", promptTemplate, ctx)
+}
+
+// RenderBranchTemplate renders a branch template. The {prefix} and {slug}
+// placeholders are expanded first; anything else uses template syntax.
+func RenderBranchTemplate(branchTemplate string, ctx PromptContext) (string, error) {
+	name := strings.ReplaceAll(branchTemplate, "{prefix}", ctx.BranchPrefix)
+	name = strings.ReplaceAll(name, "{slug}", ctx.Slug)
+	if !strings.Contains(name, "{{") {
+		return name, nil
+	}
+	name, err := renderTemplate("branch", name, ctx)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(name), nil
+}
 
-	tmpl, err := template.New("prompt").Parse(promptTemplate)
+func renderTemplate(name, text string, ctx PromptContext) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
 	if err != nil {
-		return buildFallbackPrompt(ticket)
+		return "", err
 	}
 
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return buildFallbackPrompt(ticket)
+	if err := tmpl.Execute(&buf, ctx); err != nil {
+		return "", err
 	}
+	return buf.String(), nil
+}
+
+// BuildRefreshPrompt tells a running agent that its ticket has changed and
+// restates the context from promptTemplate, or the title and description
+// without one.
+func BuildRefreshPrompt(promptTemplate string, ctx PromptContext) string {
+	prompt := BuildContextPrompt(promptTemplate, ctx)
+	if prompt == "" {
+		prompt = buildFallbackPrompt(ctx)
+	}
+	return "The ticket you are working on has been updated. From now on, work from these instructions:\n\n" + prompt
+}
+
+// SplitChecklist separates a description's markdown task list items from
+// the rest of it.
+func SplitChecklist(description string) ([]ChecklistItem, string) {
+	var items []ChecklistItem
+	var rest []string
+	for _, line := range strings.Split(description, "\n") {
+		if item, ok := parseChecklistItem(strings.TrimSpace(line)); ok {
+			items = append(items, item)
+		} else {
+			rest = append(rest, line)
+		}
+	}
+	return items, strings.TrimSpace(strings.Join(rest, "\n"))
+}
 
-	return buf.String()
+func parseChecklistItem(line string) (ChecklistItem, bool) {
+	rest, ok := strings.CutPrefix(line, "- [")
+	if !ok {
+		rest, ok = strings.CutPrefix(line, "* [")
+	}
+	if !ok || len(rest) < 4 || rest[1] != ']' || rest[2] != ' ' {
+		return ChecklistItem{}, false
+	}
+	item := ChecklistItem{Text: strings.TrimSpace(rest[3:])}
+	switch rest[0] {
+	case ' ':
+	case 'x', 'X':
+		item.Done = true
+	default:
+		return ChecklistItem{}, false
+	}
+	return item, item.Text != ""
 }
 
-func buildFallbackPrompt(ticket *board.Ticket) string {
+func buildFallbackPrompt(ctx PromptContext) string {
 	var sb strings.Builder
 	sb.WriteString("Task: ")
-	sb.WriteString(ticket.Title)
-	if ticket.Description != "" {
+	sb.WriteString(ctx.Title)
+	if ctx.Description != "" {
 		sb.WriteString("\n\n")
-		sb.WriteString(ticket.Description)
+		sb.WriteString(ctx.Description)
 	}
 	return sb.String()
 }
@@ -80,7 +80,7 @@ func TestBuildContextPrompt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := BuildContextPrompt(tt.template, tt.ticket)
+			result := BuildContextPrompt(tt.template, NewPromptContext(tt.ticket, BoardInfo{}))
 
 			if tt.expectEmpty {
 				if result != "" {
@@ -104,7 +104,7 @@ func TestBuildContextPrompt_InvalidTemplate(t *testing.T) {
 		Description: "Some description",
 	}
 
-	result := BuildContextPrompt("{{.InvalidSyntax", ticket)
+	result := BuildContextPrompt("{{.InvalidSyntax", NewPromptContext(ticket, BoardInfo{}))
 
 	if result == "" {
 		t.Error("BuildContextPrompt with invalid template should return fallback, not empty")
@@ -114,6 +114,21 @@ func TestBuildContextPrompt_InvalidTemplate(t *testing.T) {
 	}
 }
 
+func TestRenderContextPrompt_Errors(t *testing.T) {
+	ctx := NewPromptContext(&board.Ticket{Title: "Test ticket"}, BoardInfo{})
+
+	if _, err := RenderContextPrompt("{{.InvalidSyntax", ctx); err == nil {
+		t.Error("expected parse error for unclosed action")
+	}
+	if _, err := RenderContextPrompt("{{.Missing}}", ctx); err == nil {
+		t.Error("expected execution error for unknown field")
+	}
+	got, err := RenderContextPrompt("Work on {{.Title}}", ctx)
+	if err != nil || got != "Work on Test ticket" {
+		t.Errorf("RenderContextPrompt() = %q, %v", got, err)
+	}
+}
+
 func TestBuildFallbackPrompt(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -139,7 +154,7 @@ func TestBuildFallbackPrompt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := buildFallbackPrompt(tt.ticket)
+			result := buildFallbackPrompt(NewPromptContext(tt.ticket, BoardInfo{}))
 			for _, expected := range tt.expectContains {
 				if !strings.Contains(result, expected) {
 					t.Errorf("buildFallbackPrompt() = %q; want to contain %q", result, expected)
@@ -149,6 +164,21 @@ func TestBuildFallbackPrompt(t *testing.T) {
 	}
 }
 
+func TestBuildRefreshPrompt(t *testing.T) {
+	ctx := PromptContext{Title: "Fix login", Description: "- [ ] Handle SSO"}
+
+	got := BuildRefreshPrompt("Work on {{.Title}}", ctx)
+	want := "The ticket you are working on has been updated. From now on, work from these instructions:\n\nWork on Fix login"
+	if got != want {
+		t.Errorf("BuildRefreshPrompt() = %q; want %q", got, want)
+	}
+
+	got = BuildRefreshPrompt("", ctx)
+	if !strings.HasSuffix(got, "\n\nTask: Fix login\n\n- [ ] Handle SSO") {
+		t.Errorf("BuildRefreshPrompt() without a template = %q", got)
+	}
+}
+
 func TestShouldInjectContext(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -179,7 +209,7 @@ func TestShouldInjectContext(t *testing.T) {
 	}
 }
 
-func TestContextData_AllFieldsMapped(t *testing.T) {
+func TestPromptContext_AllFieldsMapped(t *testing.T) {
 	ticket := &board.Ticket{
 		ID:           "test-id-123",
 		Title:        "Test Title",
@@ -188,13 +218,54 @@ func TestContextData_AllFieldsMapped(t *testing.T) {
 		BaseBranch:   "main",
 		Status:       board.StatusInProgress,
 		WorktreePath: "/home/user/project-worktrees/test",
+		Priority:     2,
+		Assignee:     "sam",
+		Labels:       []string{"bug", "ui"},
+		Meta:         map[string]string{"estimate": "3"},
 	}
+	ctx := NewPromptContext(ticket, BoardInfo{Name: "Web", RepoPath: "/home/user/project", BranchPrefix: "feat/"})
 
 	template := "{{.TicketID}}|{{.Title}}|{{.Description}}|{{.BranchName}}|{{.BaseBranch}}|{{.Status}}|{{.WorktreePath}}"
-	result := BuildContextPrompt(template, ticket)
+	result := BuildContextPrompt(template, ctx)
 
 	expected := "test-id-123|Test Title|Test Description|feature/test|main|in_progress|/home/user/project-worktrees/test"
 	if result != expected {
 		t.Errorf("All fields mapping:\ngot:  %q\nwant: %q", result, expected)
 	}
+
+	template = "{{.Priority}}|{{.Assignee}}|{{.Labels}}|{{.Fields.estimate}}|{{.Fields.missing}}|{{.BoardName}}|{{.RepoPath}}|{{.RepoName}}|{{.BranchPrefix}}|{{.Slug}}"
+	result = BuildContextPrompt(template, ctx)
+
+	expected = "2|sam|[bug ui]|3||Web|/home/user/project|project|feat/|test-title"
+	if result != expected {
+		t.Errorf("Board and custom field mapping:\ngot:  %q\nwant: %q", result, expected)
+	}
+}
+
+func TestRenderBranchTemplate(t *testing.T) {
+	ctx := NewPromptContext(&board.Ticket{ID: "abc123", Title: "Fix Login Bug", Meta: map[string]string{"jira": "WEB-42"}},
+		BoardInfo{BranchPrefix: "task/", SlugMaxLength: 40})
+
+	tests := []struct {
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{"{prefix}{slug}", "task/fix-login-bug", false},
+		{"{prefix}{{.Fields.jira}}-{slug}", "task/WEB-42-fix-login-bug", false},
+		{"{{.BranchPrefix}}{{.TicketID}}", "task/abc123", false},
+		{"{{.Nope}}", "", true},
+		{"{{.Title", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := RenderBranchTemplate(tt.template, ctx)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("RenderBranchTemplate(%q) error = %v, wantErr %v", tt.template, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("RenderBranchTemplate(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
 }
@@ -0,0 +1,48 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/tracing"
+)
+
+// GateTimeout bounds a column's gate command, which may wait on something
+// slow such as a CI run.
+const GateTimeout = 5 * time.Minute
+
+// RunGate runs a protected column's gate command in dir with env added to
+// the environment. A move into the column goes ahead only if it succeeds;
+// otherwise the error carries the tail of its output.
+func RunGate(command, dir string, env map[string]string) (err error) {
+	span := tracing.Begin("agent.gate",
+		tracing.String("gate.command", command),
+		tracing.String("gate.column", env["OPENKANBAN_COLUMN"]),
+		tracing.String("ticket.id", env["OPENKANBAN_TICKET_ID"]))
+	defer func() { span.End(err) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), GateTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	out, err := cmd.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("gate timed out after %s", GateTimeout)
+	}
+	if err != nil {
+		if reason := OutputSnippet(string(out), 1); reason != "" {
+			return errors.New(reason)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunGate(t *testing.T) {
+	dir := t.TempDir()
+	env := map[string]string{"OPENKANBAN_TICKET_ID": "abc"}
+
+	if err := RunGate(`test "$OPENKANBAN_TICKET_ID" = abc && test "$PWD" = "`+dir+`"`, dir, env); err != nil {
+		t.Errorf("passing gate: %v", err)
+	}
+	err := RunGate("echo checking; echo 'CI is red'; exit 1", dir, env)
+	if err == nil || err.Error() != "CI is red" {
+		t.Errorf("failing gate error = %v, want the last output line", err)
+	}
+	if err := RunGate("exit 2", dir, env); err == nil || !strings.Contains(err.Error(), "exit status 2") {
+		t.Errorf("silent gate error = %v, want the exit status", err)
+	}
+}
@@ -0,0 +1,130 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// LogMatch is a single line that matched a log search.
+type LogMatch struct {
+	TicketID board.TicketID
+	Source   string // Path relative to the artifacts or session logs root, or "live"
+	Line     int    // 1-based line number within Source
+	Text     string
+}
+
+type logDoc struct {
+	ticketID board.TicketID
+	source   string
+	lines    []string
+	lower    []string
+}
+
+// LogIndex is a simple in-memory full-text index over agent transcripts,
+// prompts, diffs, and session logs.
+type LogIndex struct {
+	docs []logDoc
+}
+
+// BuildLogIndex indexes every archived artifact under baseDir, which is laid
+// out as <ticket-id>/<run>/<file>. A missing baseDir yields an empty index.
+func BuildLogIndex(baseDir string) (*LogIndex, error) {
+	idx := &LogIndex{}
+	err := walkLogs(baseDir, 3, func(ticketID board.TicketID, rel, path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		idx.Add(ticketID, rel, string(data))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+// AddSessionLogs indexes every session log under baseDir, which is laid out
+// as <ticket-id>/<run-start>.log, leaving out the recordings kept beside
+// them. As when viewing a log, only its last SessionLogTailBytes are read,
+// cleaned up so matches read as plain text; line numbers count from there.
+// A missing baseDir adds nothing.
+func (idx *LogIndex) AddSessionLogs(baseDir string) error {
+	return walkLogs(baseDir, 2, func(ticketID board.TicketID, rel, path string) error {
+		if filepath.Ext(path) != ".log" {
+			return nil
+		}
+		lines, _, err := ReadSessionLog(path, SessionLogTailBytes)
+		if err != nil {
+			return err
+		}
+		idx.Add(ticketID, rel, strings.Join(lines, "\n"))
+		return nil
+	})
+}
+
+// walkLogs calls fn for every file under baseDir that sits depth path
+// elements down, the first of which names the ticket.
+func walkLogs(baseDir string, depth int, fn func(ticketID board.TicketID, rel, path string) error) error {
+	return filepath.WalkDir(baseDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == baseDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(baseDir, path)
+		if err != nil {
+			return err
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) != depth {
+			return nil
+		}
+		return fn(board.TicketID(parts[0]), filepath.ToSlash(rel), path)
+	})
+}
+
+// Add indexes content for a ticket under the given source name.
+func (idx *LogIndex) Add(ticketID board.TicketID, source, content string) {
+	lines := strings.Split(content, "\n")
+	lower := make([]string, len(lines))
+	for i, l := range lines {
+		lower[i] = strings.ToLower(l)
+	}
+	idx.docs = append(idx.docs, logDoc{ticketID: ticketID, source: source, lines: lines, lower: lower})
+}
+
+// Search returns lines containing term (case-insensitive), in index order.
+// A limit of 0 or less returns every match.
+func (idx *LogIndex) Search(term string, limit int) []LogMatch {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term == "" {
+		return nil
+	}
+
+	var matches []LogMatch
+	for _, doc := range idx.docs {
+		for i, l := range doc.lower {
+			if !strings.Contains(l, term) {
+				continue
+			}
+			matches = append(matches, LogMatch{
+				TicketID: doc.ticketID,
+				Source:   doc.source,
+				Line:     i + 1,
+				Text:     strings.TrimSpace(doc.lines[i]),
+			})
+			if limit > 0 && len(matches) >= limit {
+				return matches
+			}
+		}
+	}
+	return matches
+}
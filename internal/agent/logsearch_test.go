@@ -0,0 +1,88 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLogIndex_Search(t *testing.T) {
+	base := t.TempDir()
+	runDir := filepath.Join(base, "t1", "20240501-093000")
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	transcript := "reading main.go\npanic: nil map write in store.go\ndone"
+	if err := os.WriteFile(filepath.Join(runDir, "transcript.txt"), []byte(transcript), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := BuildLogIndex(base)
+	if err != nil {
+		t.Fatalf("BuildLogIndex() error = %v", err)
+	}
+	idx.Add("t2", "live", "editing STORE.GO now")
+
+	matches := idx.Search("store.go", 0)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d: %+v", len(matches), matches)
+	}
+	if matches[0].TicketID != "t1" || matches[0].Line != 2 || matches[0].Source != "t1/20240501-093000/transcript.txt" {
+		t.Errorf("unexpected first match: %+v", matches[0])
+	}
+	if matches[1].TicketID != "t2" || matches[1].Source != "live" {
+		t.Errorf("unexpected second match: %+v", matches[1])
+	}
+
+	if got := idx.Search("store.go", 1); len(got) != 1 {
+		t.Errorf("limit not applied, got %d matches", len(got))
+	}
+	if got := idx.Search("  ", 0); got != nil {
+		t.Errorf("blank term should match nothing, got %v", got)
+	}
+}
+
+func TestBuildLogIndex_MissingDir(t *testing.T) {
+	idx, err := BuildLogIndex(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("BuildLogIndex() error = %v", err)
+	}
+	if got := idx.Search("anything", 0); len(got) != 0 {
+		t.Errorf("expected no matches, got %v", got)
+	}
+}
+
+func TestLogIndex_AddSessionLogs(t *testing.T) {
+	base := t.TempDir()
+	logDir := filepath.Join(base, "t1")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	raw := "\x1b[1mbuilding\x1b[0m\r\nprogress 10%\rerror: missing import in api.go\r\n"
+	if err := os.WriteFile(filepath.Join(logDir, "20240501-093000.log"), []byte(raw), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The run's recording holds the same output, and isn't searched twice.
+	cast := `{"version": 2, "width": 80, "height": 24}` + "\n" + `[0.5, "o", "error: missing import in api.go\r\n"]` + "\n"
+	if err := os.WriteFile(filepath.Join(logDir, "20240501-093000.cast"), []byte(cast), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	idx := &LogIndex{}
+	if err := idx.AddSessionLogs(base); err != nil {
+		t.Fatalf("AddSessionLogs() error = %v", err)
+	}
+
+	matches := idx.Search("api.go", 0)
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 match, got %d: %+v", len(matches), matches)
+	}
+	want := LogMatch{TicketID: "t1", Source: "t1/20240501-093000.log", Line: 2, Text: "error: missing import in api.go"}
+	if matches[0] != want {
+		t.Errorf("match = %+v, want %+v", matches[0], want)
+	}
+
+	if err := idx.AddSessionLogs(filepath.Join(base, "missing")); err != nil {
+		t.Errorf("missing dir should add nothing, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+const (
+	// opencodeRegisterTimeout bounds how long RegisterOpencodeSession waits
+	// for a freshly spawned opencode to open its session.
+	opencodeRegisterTimeout = 15 * time.Second
+	opencodeRegisterPoll    = 500 * time.Millisecond
+)
+
+// ErrNoOpencodeSession is returned when the opencode instance never opened a
+// session for the ticket's directory.
+var ErrNoOpencodeSession = errors.New("no opencode session for directory")
+
+// opencodeAPISession is a session as returned by the opencode server's
+// /session endpoint, which nests timestamps unlike the CLI's JSON.
+type opencodeAPISession struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Directory string `json:"directory"`
+	Time      struct {
+		Updated int64 `json:"updated"`
+	} `json:"time"`
+}
+
+// OpencodeSessionTitle is the title a ticket's opencode session is given, so
+// opencode's own session list shows which ticket and branch it belongs to.
+func OpencodeSessionTitle(ticket *board.Ticket) string {
+	if ticket.BranchName == "" {
+		return ticket.Title
+	}
+	return fmt.Sprintf("%s [%s]", ticket.Title, ticket.BranchName)
+}
+
+// RegisterOpencodeSession waits for the opencode instance listening on port
+// to open a session in directory, titles it after the ticket, and returns
+// the session ID. The ticket description already reaches the agent through
+// the init prompt; opencode sessions have no other metadata to carry it.
+func RegisterOpencodeSession(port int, directory string, ticket *board.Ticket) (string, error) {
+	return registerOpencodeSession(fmt.Sprintf("http://localhost:%d", port), directory, ticket, opencodeRegisterTimeout)
+}
+
+func registerOpencodeSession(baseURL, directory string, ticket *board.Ticket, timeout time.Duration) (string, error) {
+	client := &http.Client{Timeout: opencodeAPITimeout}
+	deadline := time.Now().Add(timeout)
+
+	var session *opencodeAPISession
+	for {
+		session = latestOpencodeSession(client, baseURL, directory)
+		if session != nil || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(opencodeRegisterPoll)
+	}
+	if session == nil {
+		return "", ErrNoOpencodeSession
+	}
+
+	title := OpencodeSessionTitle(ticket)
+	if session.Title == title {
+		return session.ID, nil
+	}
+
+	body, err := json.Marshal(map[string]string{"title": title})
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opencodeAPITimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, baseURL+"/session/"+session.ID, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return session.ID, fmt.Errorf("failed to title opencode session: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return session.ID, fmt.Errorf("failed to title opencode session: %s", resp.Status)
+	}
+	return session.ID, nil
+}
+
+// latestOpencodeSession is the most recently updated session in directory,
+// or nil if the server is unreachable or has none.
+func latestOpencodeSession(client *http.Client, baseURL, directory string) *opencodeAPISession {
+	resp, err := client.Get(baseURL + "/session")
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	var sessions []opencodeAPISession
+	if err := json.NewDecoder(resp.Body).Decode(&sessions); err != nil {
+		return nil
+	}
+
+	normalizedDir := normalizePath(directory)
+	var latest *opencodeAPISession
+	for i := range sessions {
+		s := &sessions[i]
+		if normalizePath(s.Directory) != normalizedDir {
+			continue
+		}
+		if latest == nil || s.Time.Updated > latest.Time.Updated {
+			latest = s
+		}
+	}
+	return latest
+}
@@ -0,0 +1,126 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+func TestRegisterOpencodeSession(t *testing.T) {
+	dir := t.TempDir()
+	var patched string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/session":
+			w.Write([]byte(`[
+				{"id": "ses_old", "directory": "` + dir + `", "time": {"updated": 100}},
+				{"id": "ses_other", "directory": "/elsewhere", "time": {"updated": 300}},
+				{"id": "ses_new", "directory": "` + dir + `", "time": {"updated": 200}}
+			]`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/session/ses_new":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			patched = body["title"]
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ticket := &board.Ticket{Title: "Fix login", BranchName: "task/fix-login"}
+	id, err := registerOpencodeSession(srv.URL, dir, ticket, 0)
+	if err != nil {
+		t.Fatalf("registerOpencodeSession() error = %v", err)
+	}
+	if id != "ses_new" {
+		t.Errorf("session ID = %q; want ses_new", id)
+	}
+	if want := "Fix login [task/fix-login]"; patched != want {
+		t.Errorf("patched title = %q; want %q", patched, want)
+	}
+}
+
+func TestRegisterOpencodeSession_NoSession(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	_, err := registerOpencodeSession(srv.URL, t.TempDir(), &board.Ticket{Title: "x"}, 0)
+	if !errors.Is(err, ErrNoOpencodeSession) {
+		t.Errorf("error = %v; want ErrNoOpencodeSession", err)
+	}
+}
+
+func TestDetectOpencodeSessionStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/session/status":
+			w.Write([]byte(`{"ses_busy": {"type": "busy"}, "ses_retry": {"type": "retry"}, "ses_asking": {"type": "busy"}}`))
+		case "/permission":
+			w.Write([]byte(`[{"id": "per_1", "sessionID": "ses_asking"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	tests := []struct {
+		sessionID string
+		want      board.AgentStatus
+	}{
+		{"ses_busy", board.AgentWorking},
+		{"ses_retry", board.AgentError},
+		{"ses_quiet", board.AgentIdle},
+		{"ses_asking", board.AgentWaiting},
+	}
+	for _, tt := range tests {
+		d := NewStatusDetector()
+		d.statusDirs = []string{t.TempDir()}
+		if got := d.DetectOpencodeSessionStatus("status-name", port, tt.sessionID, true, ""); got != tt.want {
+			t.Errorf("DetectOpencodeSessionStatus(%q) = %q; want %q", tt.sessionID, got, tt.want)
+		}
+	}
+
+	d := NewStatusDetector()
+	if got := d.DetectOpencodeSessionStatus("status-name", port, "ses_busy", false, ""); got != board.AgentNone {
+		t.Errorf("DetectOpencodeSessionStatus() without a process = %q; want none", got)
+	}
+
+	// The terminal output is read only when the server can't be asked.
+	const prompt = "Allow this command?\n(y/n)"
+	d = NewStatusDetector()
+	d.statusDirs = []string{t.TempDir()}
+	if got := d.DetectOpencodeSessionStatus("status-name", port, "ses_busy", true, prompt); got != board.AgentWorking {
+		t.Errorf("DetectOpencodeSessionStatus() with the server up = %q; want working", got)
+	}
+	srv.Close()
+	if got := d.DetectOpencodeSessionStatus("status-name", port, "ses_quiet", true, prompt); got != board.AgentWaiting {
+		t.Errorf("DetectOpencodeSessionStatus() with the server down = %q; want waiting", got)
+	}
+}
+
+func TestDetectOpencodeSessionStatus_APIDisabled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ses_busy": {"type": "busy"}}`))
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	d := NewStatusDetector()
+	d.statusDirs = []string{t.TempDir()}
+	d.SetOpencodeAPI(false)
+	if got := d.DetectOpencodeSessionStatus("status-name", port, "ses_busy", true, ""); got != board.AgentNone {
+		t.Errorf("DetectOpencodeSessionStatus() with the API off = %q; want none", got)
+	}
+}
@@ -0,0 +1,55 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/tracing"
+)
+
+// preflightTimeout bounds an agent's preflight command.
+const preflightTimeout = 15 * time.Second
+
+// Preflight checks that an agent can start before a session is spawned for
+// it: its required environment variables are set and its preflight command,
+// if any, succeeds in dir. The error says what is missing.
+func Preflight(name string, cfg config.AgentConfig, dir string) (err error) {
+	span := tracing.Begin("agent.preflight", tracing.String("agent.name", name), tracing.String("agent.dir", dir))
+	defer func() { span.End(err) }()
+
+	for _, key := range cfg.RequiredEnv {
+		if cfg.Env[key] == "" && os.Getenv(key) == "" {
+			return fmt.Errorf("%s needs %s to be set", name, key)
+		}
+	}
+	if cfg.Preflight == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), preflightTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", cfg.Preflight)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	for k, v := range cfg.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	out, err := cmd.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s preflight timed out after %s: %s", name, preflightTimeout, cfg.Preflight)
+	}
+	if err != nil {
+		reason := OutputSnippet(string(out), 3)
+		if reason == "" {
+			reason = err.Error()
+		}
+		return fmt.Errorf("%s preflight failed: %s", name, reason)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+func TestPreflight(t *testing.T) {
+	t.Setenv("OK_PREFLIGHT_SET", "1")
+
+	tests := []struct {
+		name    string
+		cfg     config.AgentConfig
+		wantErr string
+	}{
+		{"no checks", config.AgentConfig{}, ""},
+		{"env from environment", config.AgentConfig{RequiredEnv: []string{"OK_PREFLIGHT_SET"}}, ""},
+		{"env from agent config", config.AgentConfig{RequiredEnv: []string{"OK_PREFLIGHT_KEY"}, Env: map[string]string{"OK_PREFLIGHT_KEY": "x"}}, ""},
+		{"missing env", config.AgentConfig{RequiredEnv: []string{"OK_PREFLIGHT_UNSET"}}, "needs OK_PREFLIGHT_UNSET to be set"},
+		{"passing command", config.AgentConfig{Preflight: "test -n \"$OK_PREFLIGHT_KEY\"", Env: map[string]string{"OK_PREFLIGHT_KEY": "x"}}, ""},
+		{"failing command", config.AgentConfig{Preflight: "echo 'Not logged in'; echo 'Run agent login'; exit 1"}, "preflight failed: Not logged in · Run agent login"},
+		{"silent failure", config.AgentConfig{Preflight: "exit 3"}, "exit status 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Preflight("test-agent", tt.cfg, t.TempDir())
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Preflight() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Preflight() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
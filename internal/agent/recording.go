@@ -0,0 +1,202 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// CastHeader is the first line of an asciicast v2 recording.
+type CastHeader struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+// CastEvent is output ("o") or a resize ("r", data "COLSxROWS") at Time
+// seconds into a recording.
+type CastEvent struct {
+	Time float64
+	Kind string
+	Data string
+}
+
+// Recorder writes a session's output as an asciicast v2 recording, which
+// `openkanban replay` and asciinema can both play back.
+type Recorder struct {
+	mu      sync.Mutex
+	f       *os.File
+	w       *bufio.Writer
+	start   time.Time
+	partial []byte // an incomplete UTF-8 sequence held for the next write
+}
+
+// CreateRecording starts a recording for a run at
+// baseDir/<ticket-id>/<run-start>.cast, numbered like session logs when
+// another run started within the same second.
+func CreateRecording(baseDir string, ticketID board.TicketID, startedAt time.Time, width, height int, title string) (*Recorder, error) {
+	dir := filepath.Join(baseDir, string(ticketID))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	f, err := createRunFile(dir, startedAt, ".cast")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{f: f, w: bufio.NewWriter(f), start: time.Now()}
+	header, _ := json.Marshal(CastHeader{Version: 2, Width: width, Height: height, Timestamp: startedAt.Unix(), Title: title})
+	r.w.Write(append(header, '\n'))
+	return r, nil
+}
+
+// Name returns the recording's path.
+func (r *Recorder) Name() string {
+	return r.f.Name()
+}
+
+// Write records p as output. Output is split wherever the PTY read ended,
+// so a multi-byte character cut in two is held until the rest arrives.
+func (r *Recorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data := append(r.partial, p...)
+	cut := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				cut = i
+			}
+			break
+		}
+	}
+	r.partial = append([]byte(nil), data[cut:]...)
+	if cut > 0 {
+		if err := r.event("o", string(data[:cut])); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Resize records the terminal being resized.
+func (r *Recorder) Resize(width, height int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.event("r", fmt.Sprintf("%dx%d", width, height))
+}
+
+func (r *Recorder) event(kind, data string) error {
+	line, err := json.Marshal([]any{time.Since(r.start).Seconds(), kind, data})
+	if err != nil {
+		return err
+	}
+	_, err = r.w.Write(append(line, '\n'))
+	return err
+}
+
+// Close flushes the recording and closes its file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.partial) > 0 {
+		r.event("o", string(r.partial))
+		r.partial = nil
+	}
+	if err := r.w.Flush(); err != nil {
+		r.f.Close()
+		return err
+	}
+	return r.f.Close()
+}
+
+// ReadRecording parses an asciicast v2 recording. Events other than output
+// and resizes are skipped, as is a final line cut off mid-write.
+func ReadRecording(path string) (CastHeader, []CastEvent, error) {
+	var header CastHeader
+	f, err := os.Open(path)
+	if err != nil {
+		return header, nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return header, nil, err
+		}
+		return header, nil, fmt.Errorf("%s: empty recording", path)
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return header, nil, fmt.Errorf("%s: invalid header: %w", path, err)
+	}
+	if header.Version != 2 {
+		return header, nil, fmt.Errorf("%s: unsupported asciicast version %d", path, header.Version)
+	}
+
+	var events []CastEvent
+	for scanner.Scan() {
+		var raw []json.RawMessage
+		if err := json.Unmarshal(scanner.Bytes(), &raw); err != nil || len(raw) != 3 {
+			continue
+		}
+		var ev CastEvent
+		if json.Unmarshal(raw[0], &ev.Time) != nil || json.Unmarshal(raw[1], &ev.Kind) != nil || json.Unmarshal(raw[2], &ev.Data) != nil {
+			continue
+		}
+		if ev.Kind == "o" || ev.Kind == "r" {
+			events = append(events, ev)
+		}
+	}
+	return header, events, scanner.Err()
+}
+
+// PlayRecording writes a recording's output to w in real time divided by
+// speed, shortening pauses to at most maxWait (0 keeps them). Resizes can't
+// be replayed into another terminal, so they are skipped. It stops early
+// when ctx is done.
+func PlayRecording(ctx context.Context, w io.Writer, events []CastEvent, speed float64, maxWait time.Duration) error {
+	if speed <= 0 {
+		speed = 1
+	}
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+	var last float64
+	for _, ev := range events {
+		wait := time.Duration((ev.Time - last) / speed * float64(time.Second))
+		last = ev.Time
+		if maxWait > 0 && wait > maxWait {
+			wait = maxWait
+		}
+		if wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		if ev.Kind != "o" {
+			continue
+		}
+		if _, err := io.WriteString(w, ev.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecording(t *testing.T) {
+	base := t.TempDir()
+	started := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+
+	rec, err := CreateRecording(base, "abc123", started, 80, 24, "Fix the bug")
+	if err != nil {
+		t.Fatalf("CreateRecording() error = %v", err)
+	}
+	if want := filepath.Join(base, "abc123", "20240501-093000.cast"); rec.Name() != want {
+		t.Errorf("recording path = %q, want %q", rec.Name(), want)
+	}
+	again, err := CreateRecording(base, "abc123", started, 80, 24, "Fix the bug")
+	if err != nil {
+		t.Fatalf("CreateRecording() again error = %v", err)
+	}
+	again.Close()
+	if want := filepath.Join(base, "abc123", "20240501-093000-2.cast"); again.Name() != want {
+		t.Errorf("second recording path = %q, want %q", again.Name(), want)
+	}
+
+	check := []byte("✓ done")
+	rec.Write([]byte("\x1b[32m"))
+	rec.Write(check[:2]) // split inside the check mark
+	rec.Write(check[2:])
+	rec.Resize(100, 30)
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	header, events, err := ReadRecording(rec.Name())
+	if err != nil {
+		t.Fatalf("ReadRecording() error = %v", err)
+	}
+	if header.Width != 80 || header.Height != 24 || header.Title != "Fix the bug" || header.Timestamp != started.Unix() {
+		t.Errorf("header = %+v", header)
+	}
+
+	var output strings.Builder
+	for _, ev := range events {
+		if ev.Kind == "o" {
+			if strings.ContainsRune(ev.Data, '�') {
+				t.Errorf("output event %q has a broken character", ev.Data)
+			}
+			output.WriteString(ev.Data)
+		}
+	}
+	if output.String() != "\x1b[32m✓ done" {
+		t.Errorf("output = %q, want %q", output.String(), "\x1b[32m✓ done")
+	}
+	if last := events[len(events)-1]; last.Kind != "r" || last.Data != "100x30" {
+		t.Errorf("last event = %+v, want resize to 100x30", last)
+	}
+}
+
+func TestPlayRecording(t *testing.T) {
+	events := []CastEvent{
+		{Time: 0.1, Kind: "o", Data: "a"},
+		{Time: 0.2, Kind: "r", Data: "100x30"},
+		{Time: 60, Kind: "o", Data: "b"},
+	}
+
+	var out strings.Builder
+	start := time.Now()
+	if err := PlayRecording(context.Background(), &out, events, 10, 50*time.Millisecond); err != nil {
+		t.Fatalf("PlayRecording() error = %v", err)
+	}
+	if out.String() != "ab" {
+		t.Errorf("output = %q, want %q", out.String(), "ab")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("playback took %v; the idle limit should cap the pause", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := PlayRecording(ctx, &out, events, 1, 0); err == nil {
+		t.Error("PlayRecording() with a cancelled context should fail")
+	}
+}
@@ -0,0 +1,175 @@
+package agent
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// SessionNamespace marks status-file session names written by OpenKanban.
+const SessionNamespace = "ok-"
+
+const sessionBoardIDLength = 8
+
+// SessionPrefix returns the per-board prefix for session names, e.g.
+// "ok-1a2b3c4d-". Namespacing keeps two boards (or another tool that
+// writes to the same status directory) from claiming each other's sessions
+// when they reuse a branch name.
+func SessionPrefix(projectID string) string {
+	id := projectID
+	if len(id) > sessionBoardIDLength {
+		id = id[:sessionBoardIDLength]
+	}
+	return SessionNamespace + id + "-"
+}
+
+// LegacySessionName returns the un-namespaced session name used before
+// board prefixes: AgentSessionID, then branch, then ticket ID.
+func LegacySessionName(ticket *board.Ticket) string {
+	if ticket.AgentSessionID != "" {
+		return ticket.AgentSessionID
+	}
+	if ticket.BranchName != "" {
+		return ticket.BranchName
+	}
+	return string(ticket.ID)
+}
+
+// SessionName returns the namespaced session name exported to agents as
+// OPENKANBAN_SESSION and used to locate their status file.
+func SessionName(ticket *board.Ticket) string {
+	return SessionPrefix(ticket.ProjectID) + LegacySessionName(ticket)
+}
+
+// StatusDir returns the directory agents write status files to.
+func StatusDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".cache", "openkanban-status")
+}
+
+// ListStatusSessions returns the session names of all status files under dir.
+// Slashed names (e.g. "task/my-feature") are returned with forward slashes.
+func ListStatusSessions(dir string) ([]string, error) {
+	var sessions []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".status") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		sessions = append(sessions, strings.TrimSuffix(filepath.ToSlash(rel), ".status"))
+		return nil
+	})
+	return sessions, err
+}
+
+// MigrateStatusFiles renames legacy un-namespaced status files in dir to the
+// board-prefixed names. Existing namespaced files are left alone.
+// Returns the number of files migrated.
+func MigrateStatusFiles(dir string, tickets []*board.Ticket) int {
+	migrated := 0
+	for _, t := range tickets {
+		oldPath := filepath.Join(dir, LegacySessionName(t)+".status")
+		newPath := filepath.Join(dir, SessionName(t)+".status")
+		if _, err := os.Stat(oldPath); err != nil {
+			continue
+		}
+		if _, err := os.Stat(newPath); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+			continue
+		}
+		if err := os.Rename(oldPath, newPath); err == nil {
+			migrated++
+		}
+	}
+	return migrated
+}
+
+// RemoveStaleStatusFiles deletes status files under dir that have gone
+// unchanged for longer than ttl, such as those left by crashed agents.
+// Returns the number of files removed; a zero ttl removes nothing.
+func RemoveStaleStatusFiles(dir string, ttl time.Duration) int {
+	if ttl <= 0 {
+		return 0
+	}
+	sessions, err := ListStatusSessions(dir)
+	if err != nil {
+		return 0
+	}
+	removed := 0
+	for _, s := range sessions {
+		path := filepath.Join(dir, filepath.FromSlash(s)+".status")
+		info, err := os.Stat(path)
+		if err != nil || !IsStaleStatusFile(info, ttl) {
+			continue
+		}
+		if os.Remove(path) == nil {
+			removed++
+		}
+	}
+	return removed
+}
+
+// ClaimRun marks run as owned by this openkanban process.
+func ClaimRun(run *board.AgentRun) {
+	run.OwnerPID = os.Getpid()
+	run.OwnerStarted = selfStartTime()
+}
+
+var selfStartTime = sync.OnceValue(func() string {
+	return ProcessStartTime(os.Getpid())
+})
+
+// ProcessStartTime returns when the process with the given PID started, as
+// ps reports it, or "" if that can't be told. Together with the PID it
+// identifies a process even after the PID is handed out again.
+func ProcessStartTime(pid int) string {
+	cmd := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid))
+	// Pin the format so every instance reports the same process alike
+	cmd.Env = append(os.Environ(), "LC_ALL=C", "TZ=UTC")
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// SessionLost reports whether an open run's session is gone: agents run
+// inside the openkanban that spawned them, so once it has exited, crashed,
+// or the machine has rebooted, nothing is left of the session. A run whose
+// openkanban is still alive belongs to another instance on the same board;
+// a live PID that started at a different time has been reused since.
+func SessionLost(run *board.AgentRun) bool {
+	if run.OwnerPID == 0 || run.OwnerPID == os.Getpid() {
+		return true
+	}
+	proc, err := os.FindProcess(run.OwnerPID)
+	if err != nil {
+		return true
+	}
+	if proc.Signal(syscall.Signal(0)) != nil {
+		return true
+	}
+	if run.OwnerStarted == "" {
+		return false
+	}
+	started := ProcessStartTime(run.OwnerPID)
+	return started != "" && started != run.OwnerStarted
+}
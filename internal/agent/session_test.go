@@ -0,0 +1,118 @@
+package agent
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+func TestSessionName(t *testing.T) {
+	ticket := &board.Ticket{ID: "t1", ProjectID: "1a2b3c4d-0000-0000-0000-000000000000"}
+	if got := SessionName(ticket); got != "ok-1a2b3c4d-t1" {
+		t.Errorf("SessionName() = %q", got)
+	}
+
+	ticket.BranchName = "task/login"
+	if got := SessionName(ticket); got != "ok-1a2b3c4d-task/login" {
+		t.Errorf("SessionName() with branch = %q", got)
+	}
+
+	ticket.AgentSessionID = "ses_42"
+	if got := LegacySessionName(ticket); got != "ses_42" {
+		t.Errorf("LegacySessionName() = %q", got)
+	}
+}
+
+func TestMigrateStatusFiles(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, "task", "login.status")
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte("working\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tickets := []*board.Ticket{
+		{ID: "t1", ProjectID: "1a2b3c4d-x", BranchName: "task/login"},
+		{ID: "t2", ProjectID: "1a2b3c4d-x", BranchName: "task/other"},
+	}
+	if n := MigrateStatusFiles(dir, tickets); n != 1 {
+		t.Fatalf("MigrateStatusFiles() = %d, want 1", n)
+	}
+
+	sessions, err := ListStatusSessions(dir)
+	if err != nil {
+		t.Fatalf("ListStatusSessions() error = %v", err)
+	}
+	if want := []string{"ok-1a2b3c4d-task/login"}; !reflect.DeepEqual(sessions, want) {
+		t.Errorf("sessions = %v, want %v", sessions, want)
+	}
+}
+
+func TestRemoveStaleStatusFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, age time.Duration) {
+		path := filepath.Join(dir, name+".status")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("working\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		mtime := time.Now().Add(-age)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("ok-1a2b3c4d-task/old", 2*time.Hour)
+	write("ok-1a2b3c4d-fresh", time.Minute)
+
+	if got := RemoveStaleStatusFiles(dir, 0); got != 0 {
+		t.Errorf("RemoveStaleStatusFiles() with zero ttl removed %d files", got)
+	}
+	if got := RemoveStaleStatusFiles(dir, time.Hour); got != 1 {
+		t.Errorf("RemoveStaleStatusFiles() = %d, want 1", got)
+	}
+
+	sessions, err := ListStatusSessions(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sessions, []string{"ok-1a2b3c4d-fresh"}) {
+		t.Errorf("remaining sessions = %v", sessions)
+	}
+}
+
+func TestSessionLost(t *testing.T) {
+	exited := exec.Command("true")
+	if err := exited.Run(); err != nil {
+		t.Skipf("can't run true: %v", err)
+	}
+	parentStarted := ProcessStartTime(os.Getppid())
+
+	tests := []struct {
+		name    string
+		pid     int
+		started string
+		want    bool
+	}{
+		{"no owner", 0, "", true},
+		{"this process", os.Getpid(), "", true},
+		{"live process", os.Getppid(), "", false},
+		{"exited process", exited.Process.Pid, "", true},
+		{"live process, same start", os.Getppid(), parentStarted, false},
+		{"reused PID", os.Getppid(), "Thu Jan  1 00:00:00 1970", parentStarted != ""},
+	}
+	for _, tt := range tests {
+		run := &board.AgentRun{OwnerPID: tt.pid, OwnerStarted: tt.started}
+		if got := SessionLost(run); got != tt.want {
+			t.Errorf("%s: SessionLost() = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// SessionLogTailBytes caps how much of a session log is read for viewing.
+const SessionLogTailBytes = 4 << 20
+
+// SessionLogsDir returns the root directory for agent session logs.
+func SessionLogsDir() string {
+	return configSubdir("logs")
+}
+
+// CreateSessionLog creates the log for a run at
+// baseDir/<ticket-id>/<run-start>.log, which receives the session's raw
+// terminal output as it arrives.
+func CreateSessionLog(baseDir string, ticketID board.TicketID, startedAt time.Time) (*os.File, error) {
+	dir := filepath.Join(baseDir, string(ticketID))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	return createRunFile(dir, startedAt, ".log")
+}
+
+// createRunFile creates a new file in dir named for a run's start time.
+// Runs started within the same second, such as a quick retry, get a
+// numbered name instead ("<run-start>-2.log") rather than truncating the
+// earlier run's file.
+func createRunFile(dir string, startedAt time.Time, ext string) (*os.File, error) {
+	stamp := startedAt.Format("20060102-150405")
+	name := stamp + ext
+	for n := 2; ; n++ {
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666)
+		if !os.IsExist(err) {
+			return f, err
+		}
+		name = fmt.Sprintf("%s-%d%s", stamp, n, ext)
+	}
+}
+
+// ReadSessionLog reads the last maxBytes of a session log as plain text.
+// truncated reports whether earlier output was left out.
+func ReadSessionLog(path string, maxBytes int64) (lines []string, truncated bool, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, false, err
+	}
+	if info.Size() > maxBytes {
+		if _, err := f.Seek(-maxBytes, io.SeekEnd); err != nil {
+			return nil, false, err
+		}
+		truncated = true
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, false, err
+	}
+	if truncated {
+		// Skip the line the tail starts partway through
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+	return CleanTerminalOutput(string(data)), truncated, nil
+}
+
+// LastSessionLogLine returns the last line of output in a session log, or
+// "" if there is none yet.
+func LastSessionLogLine(path string) string {
+	lines, _, err := ReadSessionLog(path, 4096)
+	if err != nil || len(lines) == 0 {
+		return ""
+	}
+	return lines[len(lines)-1]
+}
+
+// CleanTerminalOutput turns raw terminal output into plain lines: escape
+// sequences are dropped, a carriage return starts the line over as a
+// terminal would, and runs of blank lines are collapsed.
+func CleanTerminalOutput(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(ansi.Strip(s), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if i := strings.LastIndex(line, "\r"); i >= 0 {
+			line = line[i+1:]
+		}
+		line = strings.TrimRight(line, " \t")
+		if line == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// SessionWriters copies a session's output to each of ws, and passes
+// resizes on to those that record them.
+type SessionWriters []io.WriteCloser
+
+func (ws SessionWriters) Write(p []byte) (int, error) {
+	for _, w := range ws {
+		if _, err := w.Write(p); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Resize tells writers with a Resize method the terminal's new size.
+func (ws SessionWriters) Resize(width, height int) {
+	for _, w := range ws {
+		if r, ok := w.(interface{ Resize(width, height int) }); ok {
+			r.Resize(width, height)
+		}
+	}
+}
+
+// Close closes every writer, returning the first error.
+func (ws SessionWriters) Close() error {
+	var first error
+	for _, w := range ws {
+		if err := w.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,78 @@
+package agent
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCleanTerminalOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"escape sequences", "\x1b[1;32mok\x1b[0m done\r\n", []string{"ok done"}},
+		{"carriage return overwrites", "progress 10%\rprogress 100%\r\nnext\n", []string{"progress 100%", "next"}},
+		{"blank runs collapse", "a\n\n\n\nb\n\n", []string{"a", "", "b"}},
+		{"leading blanks dropped", "\n\n  \na", []string{"a"}},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CleanTerminalOutput(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CleanTerminalOutput(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionLog(t *testing.T) {
+	base := t.TempDir()
+	started := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+
+	f, err := CreateSessionLog(base, "abc123", started)
+	if err != nil {
+		t.Fatalf("CreateSessionLog() error = %v", err)
+	}
+	if want := filepath.Join(base, "abc123", "20240501-093000.log"); f.Name() != want {
+		t.Errorf("log path = %q, want %q", f.Name(), want)
+	}
+	f.WriteString(strings.Repeat("0123456789\r\n", 10) + "last\r\n")
+	f.Close()
+
+	// A run started in the same second doesn't truncate the first one's log.
+	again, err := CreateSessionLog(base, "abc123", started)
+	if err != nil {
+		t.Fatalf("CreateSessionLog() again error = %v", err)
+	}
+	again.Close()
+	if want := filepath.Join(base, "abc123", "20240501-093000-2.log"); again.Name() != want {
+		t.Errorf("second log path = %q, want %q", again.Name(), want)
+	}
+
+	lines, truncated, err := ReadSessionLog(f.Name(), 1<<20)
+	if err != nil || truncated || len(lines) != 11 {
+		t.Fatalf("ReadSessionLog() = %d lines, truncated %v, err %v; want 11, false, nil", len(lines), truncated, err)
+	}
+
+	lines, truncated, err = ReadSessionLog(f.Name(), 20)
+	if err != nil {
+		t.Fatalf("ReadSessionLog() error = %v", err)
+	}
+	if !truncated {
+		t.Error("ReadSessionLog() truncated = false, want true")
+	}
+	if want := []string{"0123456789", "last"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("ReadSessionLog() lines = %q, want %q", lines, want)
+	}
+
+	if got := LastSessionLogLine(f.Name()); got != "last" {
+		t.Errorf("LastSessionLogLine() = %q, want %q", got, "last")
+	}
+	if got := LastSessionLogLine(filepath.Join(base, "missing.log")); got != "" {
+		t.Errorf("LastSessionLogLine(missing) = %q, want empty", got)
+	}
+}
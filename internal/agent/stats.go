@@ -0,0 +1,115 @@
+package agent
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// AgentStats aggregates run outcomes for a single agent type.
+type AgentStats struct {
+	Agent        string
+	Runs         int
+	Completed    int
+	Errors       int
+	Stopped      int
+	TotalRuntime time.Duration
+	TotalCost    float64
+	CostedRuns   int
+}
+
+// CompletionRate returns the fraction of finished runs that completed.
+func (s AgentStats) CompletionRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Completed) / float64(s.Runs)
+}
+
+// ErrorRate returns the fraction of finished runs that ended in error.
+func (s AgentStats) ErrorRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Runs)
+}
+
+// AverageRuntime returns the mean duration of finished runs.
+func (s AgentStats) AverageRuntime() time.Duration {
+	if s.Runs == 0 {
+		return 0
+	}
+	return s.TotalRuntime / time.Duration(s.Runs)
+}
+
+// AverageCost returns the mean cost of runs that reported one.
+func (s AgentStats) AverageCost() float64 {
+	if s.CostedRuns == 0 {
+		return 0
+	}
+	return s.TotalCost / float64(s.CostedRuns)
+}
+
+// ComputeStats aggregates finished agent runs across tickets, grouped by agent type.
+// Runs still in progress are ignored. Results are sorted by agent name.
+func ComputeStats(tickets []*board.Ticket) []AgentStats {
+	byAgent := make(map[string]*AgentStats)
+	for _, t := range tickets {
+		for _, run := range t.AgentRuns {
+			if run.EndedAt == nil {
+				continue
+			}
+			name := run.Agent
+			if name == "" {
+				name = "unknown"
+			}
+			s, ok := byAgent[name]
+			if !ok {
+				s = &AgentStats{Agent: name}
+				byAgent[name] = s
+			}
+			s.Runs++
+			s.TotalRuntime += run.Duration()
+			switch run.Outcome {
+			case board.RunCompleted:
+				s.Completed++
+			case board.RunError:
+				s.Errors++
+			case board.RunStopped:
+				s.Stopped++
+			}
+			if run.CostUSD > 0 {
+				s.TotalCost += run.CostUSD
+				s.CostedRuns++
+			}
+		}
+	}
+
+	result := make([]AgentStats, 0, len(byAgent))
+	for _, s := range byAgent {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Agent < result[j].Agent
+	})
+	return result
+}
+
+var costPattern = regexp.MustCompile(`(?i)cost:?\s*\$([0-9]+(?:\.[0-9]+)?)`)
+
+// ParseCost extracts the last reported session cost (e.g. "Total cost: $0.42")
+// from terminal output. Returns 0 when no cost is found.
+func ParseCost(content string) float64 {
+	matches := costPattern.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return 0
+	}
+	cost, err := strconv.ParseFloat(matches[len(matches)-1][1], 64)
+	if err != nil {
+		return 0
+	}
+	return cost
+}
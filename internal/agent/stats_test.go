@@ -0,0 +1,81 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+func finishedRun(agentType string, outcome board.RunOutcome, d time.Duration, cost float64) board.AgentRun {
+	start := time.Now().Add(-d)
+	end := start.Add(d)
+	return board.AgentRun{Agent: agentType, StartedAt: start, EndedAt: &end, Outcome: outcome, CostUSD: cost}
+}
+
+func TestComputeStats(t *testing.T) {
+	t1 := board.NewTicket("one", "p")
+	t1.AgentRuns = []board.AgentRun{
+		finishedRun("claude", board.RunCompleted, 10*time.Minute, 1.0),
+		finishedRun("claude", board.RunError, 2*time.Minute, 0),
+	}
+	t2 := board.NewTicket("two", "p")
+	t2.AgentRuns = []board.AgentRun{
+		finishedRun("claude", board.RunCompleted, 6*time.Minute, 3.0),
+		finishedRun("aider", board.RunStopped, time.Minute, 0),
+		{Agent: "aider", StartedAt: time.Now(), Outcome: board.RunRunning},
+	}
+
+	stats := ComputeStats([]*board.Ticket{t1, t2})
+	if len(stats) != 2 {
+		t.Fatalf("len(stats) = %d; want 2", len(stats))
+	}
+
+	aider, claude := stats[0], stats[1]
+	if aider.Agent != "aider" || claude.Agent != "claude" {
+		t.Fatalf("agents = %q, %q; want sorted aider, claude", aider.Agent, claude.Agent)
+	}
+
+	if aider.Runs != 1 {
+		t.Errorf("aider.Runs = %d; want 1 (running runs excluded)", aider.Runs)
+	}
+	if claude.Runs != 3 {
+		t.Errorf("claude.Runs = %d; want 3", claude.Runs)
+	}
+	if got := claude.CompletionRate(); got < 0.66 || got > 0.67 {
+		t.Errorf("claude.CompletionRate() = %v; want ~0.667", got)
+	}
+	if got := claude.ErrorRate(); got < 0.33 || got > 0.34 {
+		t.Errorf("claude.ErrorRate() = %v; want ~0.333", got)
+	}
+	if got := claude.AverageRuntime(); got != 6*time.Minute {
+		t.Errorf("claude.AverageRuntime() = %v; want 6m", got)
+	}
+	if got := claude.AverageCost(); got != 2.0 {
+		t.Errorf("claude.AverageCost() = %v; want 2.0 (uncosted runs excluded)", got)
+	}
+	if got := aider.AverageCost(); got != 0 {
+		t.Errorf("aider.AverageCost() = %v; want 0", got)
+	}
+}
+
+func TestParseCost(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected float64
+	}{
+		{"no cost", "hello world", 0},
+		{"claude summary", "Total cost: $0.4213\nTotal duration: 3m", 0.4213},
+		{"last value wins", "cost: $1.00\n...\nCost: $2.50", 2.5},
+		{"lowercase no colon", "session cost $3", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseCost(tt.content); got != tt.expected {
+				t.Errorf("ParseCost() = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -27,12 +29,21 @@ type opencodeSessionStatus struct {
 	Next    int    `json:"next,omitempty"`
 }
 
+// opencodePermission is a pending permission request as listed by the
+// opencode server's /permission endpoint.
+type opencodePermission struct {
+	ID        string `json:"id"`
+	SessionID string `json:"sessionID"`
+}
+
 type StatusDetector struct {
 	statusCache     map[string]cachedStatus
 	statusCacheMu   sync.RWMutex
 	cacheExpiration time.Duration
 	statusDirs      []string
+	statusFileTTL   time.Duration
 	httpClient      *http.Client
+	opencodeAPI     bool
 }
 
 type cachedStatus struct {
@@ -41,20 +52,33 @@ type cachedStatus struct {
 }
 
 func NewStatusDetector() *StatusDetector {
-	homeDir, _ := os.UserHomeDir()
-
 	return &StatusDetector{
 		statusCache:     make(map[string]cachedStatus),
 		cacheExpiration: 500 * time.Millisecond,
 		statusDirs: []string{
-			filepath.Join(homeDir, ".cache", "openkanban-status"),
+			StatusDir(),
 		},
 		httpClient: &http.Client{
 			Timeout: opencodeAPITimeout,
 		},
+		opencodeAPI: true,
 	}
 }
 
+// SetOpencodeAPI turns asking opencode's server for its sessions' state on
+// or off. Off, opencode's status comes from status files and its terminal
+// output like any other agent's.
+func (d *StatusDetector) SetOpencodeAPI(enabled bool) {
+	d.opencodeAPI = enabled
+}
+
+// SetStatusFileTTL makes status files unchanged for longer than ttl count as
+// stale, so a file left behind by a crashed agent stops overriding other
+// detection. Zero disables expiry.
+func (d *StatusDetector) SetStatusFileTTL(ttl time.Duration) {
+	d.statusFileTTL = ttl
+}
+
 func (d *StatusDetector) DetectStatus(agentType, sessionID string, processRunning bool, terminalContent string) board.AgentStatus {
 	return d.DetectStatusWithPort(agentType, sessionID, "", 0, processRunning, terminalContent)
 }
@@ -72,8 +96,10 @@ func (d *StatusDetector) DetectStatusWithPort(agentType, sessionID, worktreePath
 		return status
 	}
 
-	if agentType == "opencode" && port > 0 {
-		return d.queryOpencodeAPIOnPort(port)
+	if agentType == "opencode" && port > 0 && d.opencodeAPI {
+		if status, ok := d.queryOpencodeAPIOnPort(port, ""); ok {
+			return status
+		}
 	}
 
 	if terminalContent != "" {
@@ -87,6 +113,28 @@ func (d *StatusDetector) DetectStatusWithPort(agentType, sessionID, worktreePath
 	return board.AgentNone
 }
 
+// DetectOpencodeSessionStatus reports the state of one registered opencode
+// session rather than of any session the instance on port hosts. Status
+// files still take precedence, and the terminal output is only read when
+// the server can't be asked.
+func (d *StatusDetector) DetectOpencodeSessionStatus(statusName string, port int, sessionID string, processRunning bool, terminalContent string) board.AgentStatus {
+	if !processRunning {
+		return board.AgentNone
+	}
+	if status := d.readStatusFile(statusName); status != board.AgentNone {
+		return status
+	}
+	if d.opencodeAPI {
+		if status, ok := d.queryOpencodeAPIOnPort(port, sessionID); ok {
+			return status
+		}
+	}
+	if terminalContent != "" {
+		return d.detectFromTerminalContent("opencode", terminalContent)
+	}
+	return board.AgentNone
+}
+
 func (d *StatusDetector) detectFromTerminalContent(agentType, content string) board.AgentStatus {
 	contentLower := strings.ToLower(content)
 	lines := strings.Split(content, "\n")
@@ -255,65 +303,81 @@ func (d *StatusDetector) queryOpencodeAPI(sessionID string) board.AgentStatus {
 	return status
 }
 
-func (d *StatusDetector) queryOpencodeAPIOnPort(port int) board.AgentStatus {
-	cacheKey := fmt.Sprintf("opencode-port:%d", port)
+// queryOpencodeAPIOnPort asks the opencode instance on port for its status.
+// With a session ID only that session counts; otherwise any busy session
+// makes the instance working. A pending permission request makes it waiting.
+// ok is false if the server couldn't be asked.
+func (d *StatusDetector) queryOpencodeAPIOnPort(port int, sessionID string) (status board.AgentStatus, ok bool) {
+	cacheKey := fmt.Sprintf("opencode-port:%d:%s", port, sessionID)
 
 	d.statusCacheMu.RLock()
 	cached, exists := d.statusCache[cacheKey]
 	d.statusCacheMu.RUnlock()
 
 	if exists && time.Since(cached.timestamp) < d.cacheExpiration {
-		return cached.status
-	}
-
-	url := fmt.Sprintf("http://localhost:%d/session/status", port)
-	resp, err := d.httpClient.Get(url)
-	if err != nil {
-		return board.AgentNone
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return board.AgentNone
+		return cached.status, true
 	}
 
 	var statusResp opencodeStatusResponse
-	if err := json.NewDecoder(resp.Body).Decode(&statusResp); err != nil {
-		return board.AgentNone
+	if !d.getOpencodeJSON(port, "/session/status", &statusResp) {
+		return board.AgentNone, false
 	}
 
 	// OpenCode's /session/status only contains BUSY sessions.
 	// Empty response {} means all sessions are idle.
-	// If any session is busy, return working.
-	for _, sessionStatus := range statusResp {
-		if sessionStatus.Type == "busy" {
-			d.statusCacheMu.Lock()
-			d.statusCache[cacheKey] = cachedStatus{
-				status:    board.AgentWorking,
-				timestamp: time.Now(),
+	status = board.AgentIdle
+	if sessionID != "" {
+		if sessionStatus, found := statusResp[sessionID]; found {
+			if mapped := d.mapOpencodeStatus(sessionStatus); mapped != board.AgentNone {
+				status = mapped
 			}
-			d.statusCacheMu.Unlock()
-			return board.AgentWorking
 		}
-		if sessionStatus.Type == "retry" {
-			d.statusCacheMu.Lock()
-			d.statusCache[cacheKey] = cachedStatus{
-				status:    board.AgentError,
-				timestamp: time.Now(),
+	} else {
+		// If any session is busy, return working.
+		for _, sessionStatus := range statusResp {
+			if sessionStatus.Type == "busy" {
+				status = board.AgentWorking
+				break
+			}
+			if sessionStatus.Type == "retry" {
+				status = board.AgentError
+				break
+			}
+		}
+	}
+
+	// Versions of opencode without the endpoint can't report waiting.
+	var permissions []opencodePermission
+	if status != board.AgentError && d.getOpencodeJSON(port, "/permission", &permissions) {
+		for _, p := range permissions {
+			if sessionID == "" || p.SessionID == sessionID {
+				status = board.AgentWaiting
+				break
 			}
-			d.statusCacheMu.Unlock()
-			return board.AgentError
 		}
 	}
 
-	// Server responded but no busy sessions = idle
 	d.statusCacheMu.Lock()
 	d.statusCache[cacheKey] = cachedStatus{
-		status:    board.AgentIdle,
+		status:    status,
 		timestamp: time.Now(),
 	}
 	d.statusCacheMu.Unlock()
-	return board.AgentIdle
+	return status, true
+}
+
+// getOpencodeJSON decodes the response to a GET of path on the opencode
+// server on port into v, reporting whether it succeeded.
+func (d *StatusDetector) getOpencodeJSON(port int, path string, v any) bool {
+	resp, err := d.httpClient.Get(fmt.Sprintf("http://localhost:%d%s", port, path))
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	return json.NewDecoder(resp.Body).Decode(v) == nil
 }
 
 func (d *StatusDetector) mapOpencodeStatus(s opencodeSessionStatus) board.AgentStatus {
@@ -390,31 +454,9 @@ func (d *StatusDetector) readStatusFile(sessionName string) board.AgentStatus {
 	}
 
 	var status board.AgentStatus = board.AgentNone
-
-	for _, dir := range d.statusDirs {
-		statusFile := filepath.Join(dir, sessionName+".status")
-		content, err := os.ReadFile(statusFile)
-		if err != nil {
-			continue
-		}
-
-		statusStr := strings.TrimSpace(string(content))
-		switch statusStr {
-		case "working":
-			status = board.AgentWorking
-		case "done", "idle":
-			status = board.AgentIdle
-		case "waiting", "permission":
-			status = board.AgentWaiting
-		case "error":
-			status = board.AgentError
-		case "completed":
-			status = board.AgentCompleted
-		}
-
-		if status != board.AgentNone {
-			break
-		}
+	if content, ok := d.statusFileContent(sessionName); ok {
+		word, _, _ := strings.Cut(content, "\n")
+		status, _ = ParseStatus(word)
 	}
 
 	d.statusCacheMu.Lock()
@@ -439,10 +481,85 @@ func (d *StatusDetector) InvalidateCache(sessionName string) {
 	}
 }
 
+// statusWords is the status-file vocabulary. "done" and "permission" are
+// kept as aliases for existing hooks.
+var statusWords = map[string]board.AgentStatus{
+	"working":    board.AgentWorking,
+	"idle":       board.AgentIdle,
+	"done":       board.AgentIdle,
+	"waiting":    board.AgentWaiting,
+	"permission": board.AgentWaiting,
+	"error":      board.AgentError,
+	"completed":  board.AgentCompleted,
+}
+
+// StatusWords lists the words a status file may contain, sorted.
+func StatusWords() []string {
+	words := make([]string, 0, len(statusWords))
+	for word := range statusWords {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}
+
+// ParseStatus maps a status-file word to an agent status.
+func ParseStatus(word string) (board.AgentStatus, bool) {
+	status, ok := statusWords[strings.ToLower(strings.TrimSpace(word))]
+	if !ok {
+		return board.AgentNone, false
+	}
+	return status, true
+}
+
+// StatusMessage returns the free-form message written after the status
+// word, if any.
+func (d *StatusDetector) StatusMessage(sessionName string) string {
+	if sessionName == "" {
+		return ""
+	}
+	content, ok := d.statusFileContent(sessionName)
+	if !ok {
+		return ""
+	}
+	_, message, _ := strings.Cut(content, "\n")
+	return strings.TrimSpace(message)
+}
+
+// statusFileContent reads the first fresh status file for sessionName.
+func (d *StatusDetector) statusFileContent(sessionName string) (string, bool) {
+	for _, dir := range d.statusDirs {
+		statusFile := filepath.Join(dir, sessionName+".status")
+		info, err := os.Stat(statusFile)
+		if err != nil || IsStaleStatusFile(info, d.statusFileTTL) {
+			continue
+		}
+		content, err := os.ReadFile(statusFile)
+		if err != nil {
+			continue
+		}
+		return string(content), true
+	}
+	return "", false
+}
+
+// IsStaleStatusFile reports whether a status file has gone unchanged for
+// longer than ttl. A zero ttl never expires.
+func IsStaleStatusFile(info os.FileInfo, ttl time.Duration) bool {
+	return ttl > 0 && time.Since(info.ModTime()) > ttl
+}
+
 func WriteStatusFile(sessionName string, status board.AgentStatus) error {
-	homeDir, _ := os.UserHomeDir()
-	statusDir := filepath.Join(homeDir, ".cache", "openkanban-status")
-	statusFile := filepath.Join(statusDir, sessionName+".status")
+	return WriteStatusMessage(sessionName, status, "")
+}
+
+// WriteStatusMessage writes a status file: the status word on the first line,
+// then an optional message for the board to show beside it.
+func WriteStatusMessage(sessionName string, status board.AgentStatus, message string) error {
+	if sessionName == "" || slices.Contains(strings.Split(sessionName, "/"), "..") {
+		return fmt.Errorf("invalid session name %q", sessionName)
+	}
+	statusFile := filepath.Join(StatusDir(), sessionName+".status")
 
 	// Create parent directory for status file (handles slashed session names like "task/my-feature")
 	if err := os.MkdirAll(filepath.Dir(statusFile), 0755); err != nil {
@@ -465,13 +582,29 @@ func WriteStatusFile(sessionName string, status board.AgentStatus) error {
 		statusStr = "idle"
 	}
 
-	return os.WriteFile(statusFile, []byte(statusStr+"\n"), 0644)
+	content := statusStr + "\n"
+	if message = strings.TrimSpace(message); message != "" {
+		content += message + "\n"
+	}
+	return os.WriteFile(statusFile, []byte(content), 0644)
 }
 
 func CleanupStatusFile(sessionName string) error {
-	homeDir, _ := os.UserHomeDir()
-	statusDir := filepath.Join(homeDir, ".cache", "openkanban-status")
-	statusFile := filepath.Join(statusDir, sessionName+".status")
+	statusFile := filepath.Join(StatusDir(), sessionName+".status")
 	os.Remove(statusFile)
 	return nil
 }
+
+// OutputSnippet condenses the last few non-blank lines of terminal output
+// into one line, for explaining why an agent failed to start.
+func OutputSnippet(content string, maxLines int) string {
+	var lines []string
+	all := strings.Split(content, "\n")
+	for i := len(all) - 1; i >= 0 && len(lines) < maxLines; i-- {
+		if line := strings.TrimSpace(all[i]); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	slices.Reverse(lines)
+	return strings.Join(lines, " · ")
+}
@@ -232,3 +232,101 @@ func TestReadStatusFile_FromDisk(t *testing.T) {
 		t.Errorf("readStatusFile should return AgentWorking; got %q", result)
 	}
 }
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		word string
+		want board.AgentStatus
+		ok   bool
+	}{
+		{"working", board.AgentWorking, true},
+		{" Idle\n", board.AgentIdle, true},
+		{"done", board.AgentIdle, true},
+		{"permission", board.AgentWaiting, true},
+		{"completed", board.AgentCompleted, true},
+		{"error", board.AgentError, true},
+		{"busy", board.AgentNone, false},
+		{"", board.AgentNone, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseStatus(tt.word)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseStatus(%q) = %q, %v; want %q, %v", tt.word, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestWriteStatusMessage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := WriteStatusMessage("ok-task/login", board.AgentWaiting, "  needs approval  "); err != nil {
+		t.Fatalf("WriteStatusMessage() error = %v", err)
+	}
+
+	d := NewStatusDetector()
+	d.statusDirs = []string{StatusDir()}
+	if got := d.readStatusFile("ok-task/login"); got != board.AgentWaiting {
+		t.Errorf("readStatusFile() = %q, want %q", got, board.AgentWaiting)
+	}
+	if got := d.StatusMessage("ok-task/login"); got != "needs approval" {
+		t.Errorf("StatusMessage() = %q, want %q", got, "needs approval")
+	}
+
+	if err := WriteStatusFile("ok-task/login", board.AgentWorking); err != nil {
+		t.Fatalf("WriteStatusFile() error = %v", err)
+	}
+	if got := d.StatusMessage("ok-task/login"); got != "" {
+		t.Errorf("StatusMessage() after plain write = %q, want empty", got)
+	}
+
+	for _, name := range []string{"", "../escape", "ok-a/../../b"} {
+		if err := WriteStatusMessage(name, board.AgentIdle, ""); err == nil {
+			t.Errorf("WriteStatusMessage(%q) should fail", name)
+		}
+	}
+}
+
+func TestReadStatusFile_Stale(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	d := NewStatusDetector()
+	d.statusDirs = []string{tmpDir}
+	d.SetStatusFileTTL(time.Hour)
+
+	statusFile := filepath.Join(tmpDir, "test-session.status")
+	if err := os.WriteFile(statusFile, []byte("working\nrunning tests\n"), 0644); err != nil {
+		t.Fatalf("failed to create status file: %v", err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(statusFile, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := d.readStatusFile("test-session"); got != board.AgentNone {
+		t.Errorf("readStatusFile() on stale file = %q, want %q", got, board.AgentNone)
+	}
+	if got := d.StatusMessage("test-session"); got != "" {
+		t.Errorf("StatusMessage() on stale file = %q, want empty", got)
+	}
+
+	// Falls back to terminal detection instead of reporting "working".
+	got := d.DetectStatus("aider", "test-session", true, "Error: something went wrong")
+	if got != board.AgentError {
+		t.Errorf("DetectStatus() with stale file = %q, want %q", got, board.AgentError)
+	}
+}
+
+func TestOutputSnippet(t *testing.T) {
+	content := "Welcome to agent\n\n  Error: invalid API key  \nPlease run `agent login`\n\n\n"
+
+	if got := OutputSnippet(content, 2); got != "Error: invalid API key · Please run `agent login`" {
+		t.Errorf("OutputSnippet() = %q", got)
+	}
+	if got := OutputSnippet(content, 5); got != "Welcome to agent · Error: invalid API key · Please run `agent login`" {
+		t.Errorf("OutputSnippet() with spare lines = %q", got)
+	}
+	if got := OutputSnippet("\n \n", 3); got != "" {
+		t.Errorf("OutputSnippet() of blank output = %q, want empty", got)
+	}
+}
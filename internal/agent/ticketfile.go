@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+)
+
+// TicketFileName is the file behavior.ticket_file writes at the root of a
+// ticket's worktree.
+const TicketFileName = "TICKET.md"
+
+// DefaultTicketFileTemplate lays out TICKET.md when
+// behavior.ticket_file_template is unset.
+const DefaultTicketFileTemplate = `# {{.Title}}
+
+- Status: {{.Status}}
+{{- with .BranchName}}
+- Branch: ` + "`{{.}}`" + `
+{{- end}}
+{{- with .Assignee}}
+- Assignee: {{.}}
+{{- end}}
+{{- with .Labels}}
+- Labels:{{range .}} {{.}}{{end}}
+{{- end}}
+{{- with .Link}}
+- Link: {{.}}
+{{- end}}
+{{- with .Notes}}
+
+## Description
+
+{{.}}
+{{- end}}
+{{- with .Checklist}}
+
+## Checklist
+{{range .}}
+- [{{if .Done}}x{{else}} {{end}}] {{.Text}}
+{{- end}}
+{{- end}}
+
+---
+_Written by openkanban from the ticket and rewritten when it is edited;
+changes made here are overwritten._
+`
+
+// RenderTicketFile renders a TICKET.md template, the default one if it is
+// empty.
+func RenderTicketFile(tmpl string, ctx PromptContext) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultTicketFileTemplate
+	}
+	return renderTemplate("ticket_file", tmpl, ctx)
+}
+
+// WriteTicketFile writes content to TICKET.md in dir, leaving the file alone
+// when it already holds it.
+func WriteTicketFile(dir, content string) error {
+	path := filepath.Join(dir, TicketFileName)
+	if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, []byte(content)) {
+		return nil
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
@@ -0,0 +1,87 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+func TestSplitChecklist(t *testing.T) {
+	items, notes := SplitChecklist("Fix the redirect.\n\n- [ ] Reproduce\n  * [x] Write a test\n- [?] not an item\n- [ ]\n\nThen ship.")
+
+	want := []ChecklistItem{{Text: "Reproduce"}, {Text: "Write a test", Done: true}}
+	if len(items) != len(want) {
+		t.Fatalf("items = %+v; want %+v", items, want)
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("items[%d] = %+v; want %+v", i, items[i], want[i])
+		}
+	}
+	if wantNotes := "Fix the redirect.\n\n- [?] not an item\n- [ ]\n\nThen ship."; notes != wantNotes {
+		t.Errorf("notes = %q; want %q", notes, wantNotes)
+	}
+}
+
+func TestRenderTicketFile(t *testing.T) {
+	ticket := &board.Ticket{
+		ID:          "t-1",
+		Title:       "Fix login",
+		Description: "Redirect loops after SSO.\n- [x] Reproduce\n- [ ] Fix",
+		Status:      board.StatusInProgress,
+		BranchName:  "task/fix-login",
+		Labels:      []string{"bug", "auth"},
+	}
+	ctx := NewPromptContext(ticket, BoardInfo{Name: "web app", TicketLink: "https://kanban.example.com/{project}/{id}"})
+
+	got, err := RenderTicketFile("", ctx)
+	if err != nil {
+		t.Fatalf("RenderTicketFile() error = %v", err)
+	}
+	for _, want := range []string{
+		"# Fix login\n\n- Status: in_progress\n- Branch: `task/fix-login`\n- Labels: bug auth\n- Link: https://kanban.example.com/web%20app/t-1\n",
+		"## Description\n\nRedirect loops after SSO.\n",
+		"## Checklist\n\n- [x] Reproduce\n- [ ] Fix\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ticket file missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Assignee") {
+		t.Errorf("ticket file lists an unset assignee:\n%s", got)
+	}
+
+	if got, _ := RenderTicketFile("{{.Title}} ({{len .Checklist}})", ctx); got != "Fix login (2)" {
+		t.Errorf("custom template = %q", got)
+	}
+	if _, err := RenderTicketFile("{{.Title", ctx); err == nil {
+		t.Error("RenderTicketFile() accepted a broken template")
+	}
+}
+
+func TestWriteTicketFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, TicketFileName)
+
+	if err := WriteTicketFile(dir, "one"); err != nil {
+		t.Fatalf("WriteTicketFile() error = %v", err)
+	}
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(path, old, old)
+	if err := WriteTicketFile(dir, "one"); err != nil {
+		t.Fatalf("WriteTicketFile() error = %v", err)
+	}
+	if info, _ := os.Stat(path); !info.ModTime().Equal(old) {
+		t.Error("unchanged ticket file was rewritten")
+	}
+	if err := WriteTicketFile(dir, "two"); err != nil {
+		t.Fatalf("WriteTicketFile() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "two" {
+		t.Errorf("ticket file = %q; want %q", data, "two")
+	}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/project"
+)
+
+// PrintAgentStats prints a per-agent comparison of run outcomes across all projects.
+func PrintAgentStats() error {
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	stats := agent.ComputeStats(globalStore.All())
+	if len(stats) == 0 {
+		fmt.Println("No finished agent runs recorded yet.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "AGENT\tRUNS\tCOMPLETED\tERRORS\tAVG RUNTIME\tAVG COST")
+	for _, s := range stats {
+		avgCost := "-"
+		if s.CostedRuns > 0 {
+			avgCost = fmt.Sprintf("$%.2f", s.AverageCost())
+		}
+		fmt.Fprintf(w, "%s\t%d\t%.0f%%\t%.0f%%\t%s\t%s\n",
+			s.Agent,
+			s.Runs,
+			s.CompletionRate()*100,
+			s.ErrorRate()*100,
+			s.AverageRuntime().Round(time.Second),
+			avgCost,
+		)
+	}
+	return w.Flush()
+}
+
+// EmitStatus writes a status file for session so custom agents and wrapper
+// scripts can drive the board's status indicators. A session of "-" means
+// the OPENKANBAN_SESSION the agent was spawned with.
+func EmitStatus(session, status, message string) error {
+	if session == "-" {
+		session = os.Getenv("OPENKANBAN_SESSION")
+		if session == "" {
+			return fmt.Errorf("OPENKANBAN_SESSION is not set")
+		}
+	}
+	agentStatus, ok := agent.ParseStatus(status)
+	if !ok {
+		return fmt.Errorf("unknown status %q (valid: %s)", status, strings.Join(agent.StatusWords(), ", "))
+	}
+	return agent.WriteStatusMessage(session, agentStatus, message)
+}
@@ -6,17 +6,24 @@ import (
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/board"
 	"github.com/techdufus/openkanban/internal/config"
 	"github.com/techdufus/openkanban/internal/git"
 	"github.com/techdufus/openkanban/internal/project"
+	"github.com/techdufus/openkanban/internal/telemetry"
+	"github.com/techdufus/openkanban/internal/tracing"
 	"github.com/techdufus/openkanban/internal/ui"
 	"github.com/techdufus/openkanban/internal/update"
 )
 
 func Run(cfg *config.Config, filterPath, version string) error {
+	if err := board.SetIDScheme(cfg.Defaults.IDScheme); err != nil {
+		return err
+	}
 	registry, err := project.LoadRegistry()
 	if err != nil {
 		return fmt.Errorf("failed to load project registry: %w", err)
@@ -31,6 +38,16 @@ func Run(cfg *config.Config, filterPath, version string) error {
 		return fmt.Errorf("no projects registered. Create one with: openkanban new")
 	}
 
+	sprints, err := project.LoadSprints()
+	if err != nil {
+		return fmt.Errorf("failed to load sprints: %w", err)
+	}
+
+	// Status files from before board-prefixed session names, and ones left
+	// behind by agents that died without clearing them.
+	agent.MigrateStatusFiles(agent.StatusDir(), globalStore.All())
+	agent.RemoveStaleStatusFiles(agent.StatusDir(), time.Duration(cfg.Behavior.StatusFileTTL)*time.Second)
+
 	var filterProjectID string
 	if filterPath != "" {
 		absPath, _ := filepath.Abs(filterPath)
@@ -40,6 +57,31 @@ func Run(cfg *config.Config, filterPath, version string) error {
 		}
 	}
 
+	if cfg.Tracing.Endpoint != "" {
+		err := tracing.Start(tracing.Options{
+			Endpoint:       cfg.Tracing.Endpoint,
+			Headers:        cfg.Tracing.Headers,
+			ServiceName:    cfg.Tracing.ServiceName,
+			ServiceVersion: version,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to start tracing: %v\n", err)
+		} else {
+			defer tracing.Stop()
+		}
+	}
+
+	if cfg.Telemetry.Enabled && cfg.Telemetry.Endpoint != "" {
+		if err := telemetry.Start(telemetry.Options{Endpoint: cfg.Telemetry.Endpoint, Version: version}); err == nil {
+			defer telemetry.Stop()
+			if dir, err := config.ConfigDir(); err == nil {
+				telemetry.LoadQueued(filepath.Join(dir, telemetry.QueueFile))
+			}
+			telemetry.Count("board.projects." + telemetry.SizeBucket(len(registry.List())))
+			telemetry.Count("board.tickets." + telemetry.SizeBucket(len(globalStore.All())))
+		}
+	}
+
 	agentMgr := agent.NewManager(cfg)
 
 	opencodeServer := agent.NewOpencodeServer(cfg)
@@ -54,7 +96,7 @@ func Run(cfg *config.Config, filterPath, version string) error {
 	}
 
 	updateChecker := update.NewChecker(version)
-	model := ui.NewModel(cfg, globalStore, registry, agentMgr, opencodeServer, filterProjectID, updateChecker)
+	model := ui.NewModel(cfg, globalStore, registry, sprints, agentMgr, opencodeServer, filterProjectID, updateChecker)
 
 	defer model.Cleanup()
 
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/project"
+)
+
+// doctorReport collects check results for printing.
+type doctorReport struct {
+	failures int
+	warnings int
+}
+
+func (r *doctorReport) ok(format string, args ...any) {
+	fmt.Printf("  ✓ "+format+"\n", args...)
+}
+
+func (r *doctorReport) warn(format string, args ...any) {
+	r.warnings++
+	fmt.Printf("  ! "+format+"\n", args...)
+}
+
+func (r *doctorReport) fail(format string, args ...any) {
+	r.failures++
+	fmt.Printf("  ✗ "+format+"\n", args...)
+}
+
+// Doctor checks the configuration, registered projects, agent session
+// namespace, and agent preflight checks for problems. With fix set, legacy
+// status files are migrated to board-prefixed names and stale status files
+// are removed.
+func Doctor(cfgPath string, fix bool) error {
+	r := &doctorReport{}
+
+	fmt.Println("Config")
+	cfg, result, err := config.LoadWithValidation(cfgPath)
+	switch {
+	case err != nil && result == nil:
+		r.fail("failed to read config: %v", err)
+	case result != nil && result.HasErrors():
+		r.fail("%d config error(s); run 'openkanban config validate'", len(result.Errors))
+	case result != nil && result.HasWarnings():
+		r.warn("%d config warning(s); run 'openkanban config validate'", len(result.Warnings))
+	default:
+		r.ok("configuration is valid")
+	}
+
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	fmt.Println("\nProjects")
+	projects := registry.List()
+	if len(projects) == 0 {
+		r.warn("no projects registered; create one with 'openkanban new'")
+	}
+	for _, p := range projects {
+		if _, err := os.Stat(p.RepoPath); err != nil {
+			r.fail("%s: repository missing at %s", p.Name, p.RepoPath)
+		} else {
+			r.ok("%s (%s)", p.Name, p.RepoPath)
+		}
+		if store, err := project.LoadTicketStore(p); err != nil {
+			r.fail("%s: tickets can't be loaded: %v", p.Name, err)
+		} else {
+			if err := store.IntegrityErr(); err != nil {
+				r.warn("%s: %v; the board asks before saving over it", p.Name, err)
+			}
+			if err := store.CompatWarning(); err != nil {
+				r.warn("%s: %v", p.Name, err)
+			}
+		}
+	}
+
+	fmt.Println("\nSession namespace")
+	checkSessionNamespace(r, projects, globalStore, fix)
+
+	fmt.Println("\nStatus files")
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	checkStaleStatusFiles(r, time.Duration(cfg.Behavior.StatusFileTTL)*time.Second, fix)
+
+	fmt.Println("\nAgent preflight")
+	checkAgentPreflight(r, cfg)
+
+	fmt.Println()
+	if r.failures > 0 {
+		return fmt.Errorf("doctor found %d problem(s) and %d warning(s)", r.failures, r.warnings)
+	}
+	if r.warnings > 0 {
+		fmt.Printf("No problems found (%d warning(s)).\n", r.warnings)
+		return nil
+	}
+	fmt.Println("No problems found.")
+	return nil
+}
+
+// checkSessionNamespace reports session prefix collisions: boards whose
+// prefixes coincide, status files under an unknown board prefix (another
+// board or tool using the same namespace), and legacy un-prefixed files
+// that match one of our tickets.
+func checkSessionNamespace(r *doctorReport, projects []*project.Project, globalStore *project.GlobalTicketStore, fix bool) {
+	prefixOwners := make(map[string][]string)
+	for _, p := range projects {
+		prefix := agent.SessionPrefix(p.ID)
+		prefixOwners[prefix] = append(prefixOwners[prefix], p.Name)
+	}
+	collided := false
+	for prefix, owners := range prefixOwners {
+		if len(owners) > 1 {
+			collided = true
+			r.fail("boards %s share session prefix %q", strings.Join(owners, ", "), prefix)
+		}
+	}
+	if !collided {
+		r.ok("board session prefixes are unique")
+	}
+
+	dir := agent.StatusDir()
+	sessions, err := agent.ListStatusSessions(dir)
+	if err != nil {
+		r.warn("cannot read status directory %s: %v", dir, err)
+		return
+	}
+
+	legacyNames := make(map[string]bool)
+	for _, t := range globalStore.All() {
+		legacyNames[agent.LegacySessionName(t)] = true
+	}
+
+	foreign := make(map[string]int)
+	var legacy []string
+	for _, s := range sessions {
+		if strings.HasPrefix(s, agent.SessionNamespace) {
+			known := false
+			for prefix := range prefixOwners {
+				if strings.HasPrefix(s, prefix) {
+					known = true
+					break
+				}
+			}
+			if !known {
+				id, _, _ := strings.Cut(strings.TrimPrefix(s, agent.SessionNamespace), "-")
+				foreign[agent.SessionNamespace+id+"-"]++
+			}
+			continue
+		}
+		if legacyNames[s] {
+			legacy = append(legacy, s)
+		}
+	}
+
+	if len(foreign) == 0 {
+		r.ok("no status files from unknown boards")
+	}
+	foreignPrefixes := make([]string, 0, len(foreign))
+	for prefix := range foreign {
+		foreignPrefixes = append(foreignPrefixes, prefix)
+	}
+	sort.Strings(foreignPrefixes)
+	for _, prefix := range foreignPrefixes {
+		r.warn("%d status file(s) under unknown prefix %q (another board or tool?)", foreign[prefix], prefix)
+	}
+
+	if len(legacy) == 0 {
+		r.ok("no legacy un-prefixed status files")
+		return
+	}
+	sort.Strings(legacy)
+	if fix {
+		migrated := agent.MigrateStatusFiles(dir, globalStore.All())
+		r.ok("migrated %d legacy status file(s)", migrated)
+		return
+	}
+	r.warn("%d legacy status file(s) may collide with other tools: %s (run with --fix to migrate)",
+		len(legacy), strings.Join(legacy, ", "))
+}
+
+// checkStaleStatusFiles reports status files unchanged for longer than ttl,
+// which the board ignores; they usually belong to agents that crashed.
+func checkStaleStatusFiles(r *doctorReport, ttl time.Duration, fix bool) {
+	if ttl <= 0 {
+		r.ok("status file expiry disabled")
+		return
+	}
+	dir := agent.StatusDir()
+	sessions, err := agent.ListStatusSessions(dir)
+	if err != nil {
+		return
+	}
+
+	var stale []string
+	for _, s := range sessions {
+		info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(s)+".status"))
+		if err == nil && agent.IsStaleStatusFile(info, ttl) {
+			stale = append(stale, s)
+		}
+	}
+	if len(stale) == 0 {
+		r.ok("no stale status files")
+		return
+	}
+	if fix {
+		removed := agent.RemoveStaleStatusFiles(dir, ttl)
+		r.ok("removed %d stale status file(s)", removed)
+		return
+	}
+	sort.Strings(stale)
+	r.warn("%d status file(s) unchanged for over %s: %s (run with --fix to remove)",
+		len(stale), ttl, strings.Join(stale, ", "))
+}
+
+// checkAgentPreflight runs each agent's preflight checks, so missing logins
+// and credentials show up before a spawn fails.
+func checkAgentPreflight(r *doctorReport, cfg *config.Config) {
+	names := make([]string, 0, len(cfg.Agents))
+	for name, a := range cfg.Agents {
+		if a.Preflight != "" || len(a.RequiredEnv) > 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		r.ok("no agent preflight checks configured")
+		return
+	}
+	sort.Strings(names)
+
+	dir, _ := os.Getwd()
+	for _, name := range names {
+		if err := agent.Preflight(name, cfg.Agents[name], dir); err != nil {
+			if name == cfg.Defaults.DefaultAgent {
+				r.fail("%v", err)
+			} else {
+				r.warn("%v", err)
+			}
+			continue
+		}
+		r.ok("%s preflight passed", name)
+	}
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/project"
+	"github.com/techdufus/openkanban/internal/share"
+)
+
+// Export writes the unarchived tickets matching query as JSON or markdown,
+// in board order, to output or to stdout when output is empty or "-". With
+// repoPath only that project's tickets are considered.
+func Export(cfgPath, repoPath, query, format, output string) error {
+	q, err := board.ParseQuery(query)
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+	if format != "json" && format != "md" && format != "markdown" {
+		return fmt.Errorf("unknown format %q (use json or md)", format)
+	}
+
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+	proj, err := findProject(registry, repoPath)
+	if err != nil {
+		return err
+	}
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	export := share.Export{Query: query, Generated: time.Now()}
+	for _, col := range cfg.BoardColumns() {
+		var tickets []*board.Ticket
+		for _, t := range globalStore.GetByStatus(col.Status) {
+			if t.Archived || (proj != nil && t.ProjectID != proj.ID) {
+				continue
+			}
+			tickets = append(tickets, t)
+		}
+		board.SortTickets(tickets, cfg.ColumnSort(col.ID))
+
+		for _, t := range tickets {
+			name := ""
+			if p := globalStore.GetProjectForTicket(t); p != nil {
+				name = p.Name
+			}
+			if q.Matches(t, name) {
+				export.Tickets = append(export.Tickets, share.NewExportedTicket(t, name, col.Name))
+			}
+		}
+	}
+
+	content := share.ExportMarkdown(export)
+	if format == "json" {
+		if content, err = share.ExportJSON(export); err != nil {
+			return fmt.Errorf("failed to export tickets: %w", err)
+		}
+	}
+
+	if output == "" || output == "-" {
+		_, err := os.Stdout.Write(content)
+		return err
+	}
+	if err := os.WriteFile(output, content, 0644); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d tickets to %s\n", len(export.Tickets), output)
+	return nil
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/project"
+)
+
+// MergeBoard merges the tickets in another openkanban's tickets file into
+// the project at repoPath, the current directory by default, and reports
+// what was merged. Tickets already on the board, by title and branch, are
+// skipped; clashing IDs are replaced. Tickets in columns this board doesn't
+// have go to Backlog, and sprints it doesn't know are dropped. With dryRun
+// the report is printed but nothing is saved.
+func MergeBoard(cfgPath, repoPath, file string, dryRun bool) error {
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := board.SetIDScheme(cfg.Defaults.IDScheme); err != nil {
+		return err
+	}
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+	if repoPath == "" {
+		repoPath, _ = os.Getwd()
+	}
+	proj, err := findProject(registry, repoPath)
+	if err != nil {
+		return fmt.Errorf("%w; create one with: openkanban new", err)
+	}
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+	other, err := project.ReadTicketsFile(file)
+	if err != nil {
+		return err
+	}
+
+	columns := make(map[board.TicketStatus]bool)
+	for _, col := range cfg.BoardColumns() {
+		columns[col.Status] = true
+	}
+	sprints, err := project.LoadSprints()
+	if err != nil {
+		return fmt.Errorf("failed to load sprints: %w", err)
+	}
+
+	result, err := globalStore.Merge(proj.ID, other.Tickets, filepath.Base(file))
+	if err != nil {
+		return err
+	}
+	var toBacklog, unsprinted int
+	for _, t := range result.Added {
+		if !columns[t.Status] && t.Status != board.StatusArchived {
+			t.Status = board.StatusBacklog
+			toBacklog++
+		}
+		if t.SprintID != "" && sprints.Get(t.SprintID) == nil {
+			t.SprintID = ""
+			unsprinted++
+		}
+	}
+
+	renamedFrom := make(map[board.TicketID]board.TicketID, len(result.Renamed))
+	for from, to := range result.Renamed {
+		renamedFrom[to] = from
+	}
+	fmt.Printf("Merging %s into %s:\n\n", file, proj.Name)
+	for _, t := range result.Added {
+		line := fmt.Sprintf("  + %s [%s]", t.Title, t.Status)
+		if from, ok := renamedFrom[t.ID]; ok {
+			line += fmt.Sprintf(" (ID %s is taken, now %s)", shortID(from), shortID(t.ID))
+		}
+		fmt.Println(line)
+	}
+	dups := make([]board.TicketID, 0, len(result.Duplicates))
+	for id := range result.Duplicates {
+		dups = append(dups, id)
+	}
+	sort.Slice(dups, func(i, j int) bool { return dups[i] < dups[j] })
+	for _, id := range dups {
+		fmt.Printf("  = %s (already on the board)\n", result.Duplicates[id].Title)
+	}
+
+	fmt.Printf("\n%d ticket(s) merged, %d already on the board, %d renumbered\n",
+		len(result.Added), len(result.Duplicates), len(result.Renamed))
+	if toBacklog > 0 {
+		fmt.Printf("%d ticket(s) were in columns this board doesn't have and went to Backlog\n", toBacklog)
+	}
+	if unsprinted > 0 {
+		fmt.Printf("%d ticket(s) lost sprints this board doesn't have\n", unsprinted)
+	}
+
+	if dryRun {
+		fmt.Println("Dry run: nothing was saved")
+		return nil
+	}
+	if len(result.Added) > 0 {
+		// Saving any of the project's tickets writes its whole file.
+		if err := globalStore.Save(result.Added[0]); err != nil {
+			return fmt.Errorf("failed to save tickets: %w", err)
+		}
+	}
+	return nil
+}
+
+func shortID(id board.TicketID) string {
+	if len(id) > 8 {
+		return string(id[:8])
+	}
+	return string(id)
+}
@@ -0,0 +1,196 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/project"
+	"github.com/techdufus/openkanban/internal/ui"
+)
+
+// ExportProfile writes the key bindings, theme, card layout and saved
+// filters as a profile bundle to output, or to stdout when output is empty
+// or "-".
+func ExportProfile(cfgPath, output string) error {
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+	filters, err := project.LoadFilterRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load saved filters: %w", err)
+	}
+
+	profile := cfg.Profile()
+	for _, f := range filters.List() {
+		pf := config.ProfileFilter{
+			Name:      f.Name,
+			Statuses:  f.Statuses,
+			Labels:    f.Labels,
+			IsDefault: f.IsDefault,
+		}
+		for _, id := range f.ProjectIDs {
+			if p, err := registry.Get(id); err == nil && p != nil {
+				pf.Projects = append(pf.Projects, p.Name)
+			}
+		}
+		profile.Filters = append(profile.Filters, pf)
+	}
+	sort.Slice(profile.Filters, func(i, j int) bool {
+		return profile.Filters[i].Name < profile.Filters[j].Name
+	})
+
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if output == "" || output == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+	fmt.Printf("Wrote %s\n", output)
+	return nil
+}
+
+// ImportProfile reads a profile bundle from input, or stdin for "-", and
+// applies it: the sections it includes replace the config's, and its saved
+// filters replace those of the same name. The bundle, and the config it
+// would produce, are checked before anything is written, so a bad bundle is
+// rejected whole rather than leaving the board unusable. A user theme that
+// differs from the theme file of the same name is only replaced with force.
+func ImportProfile(cfgPath, input string, force bool) error {
+	var data []byte
+	var err error
+	if input == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(input)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	var profile config.Profile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&profile); err != nil {
+		return fmt.Errorf("invalid profile: %w", err)
+	}
+	if err := profile.Validate(ui.ValidateKeys); err != nil {
+		return err
+	}
+
+	cfg, result, err := config.LoadWithValidation(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if result != nil && result.HasErrors() {
+		return fmt.Errorf("the current config is invalid; fix it before importing a profile:\n%s", result.FormatErrors())
+	}
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+	filters, err := project.LoadFilterRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load saved filters: %w", err)
+	}
+
+	cfg.ApplyProfile(&profile)
+	if result := cfg.Validate(); result.HasErrors() {
+		return fmt.Errorf("profile would leave an invalid config:\n%s", result.FormatErrors())
+	}
+	if err := config.WriteProfileThemes(&profile, cfgPath, force); err != nil {
+		return fmt.Errorf("failed to write themes: %w", err)
+	}
+
+	imported, skipped := importFilters(filters, registry, profile.Filters)
+	if err := cfg.Save(cfgPath); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	if len(profile.Filters) > 0 {
+		if err := filters.Save(); err != nil {
+			return fmt.Errorf("failed to save filters: %w", err)
+		}
+	}
+
+	var parts []string
+	if profile.Keys != nil {
+		parts = append(parts, "keys")
+	}
+	if profile.Theme != "" {
+		parts = append(parts, "theme")
+	}
+	if profile.Card != nil {
+		parts = append(parts, "card layout")
+	}
+	if imported > 0 {
+		parts = append(parts, fmt.Sprintf("%d filter(s)", imported))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "nothing")
+	}
+	fmt.Printf("Imported %s\n", strings.Join(parts, ", "))
+	for _, s := range skipped {
+		fmt.Printf("  skipped %s\n", s)
+	}
+	return nil
+}
+
+// importFilters adds the profile's filters to the registry, replacing saved
+// filters of the same name, with project names resolved to this machine's
+// projects. A filter none of whose projects are here is skipped, since it
+// would otherwise match every project. It returns how many were added and
+// what was skipped.
+func importFilters(filters *project.FilterRegistry, registry *project.ProjectRegistry, profile []config.ProfileFilter) (int, []string) {
+	byName := make(map[string]string)
+	for _, p := range registry.List() {
+		byName[p.Name] = p.ID
+	}
+
+	imported := 0
+	var skipped []string
+	for _, pf := range profile {
+		f := project.NewFilter(pf.Name)
+		f.Statuses = pf.Statuses
+		f.Labels = pf.Labels
+		f.IsDefault = pf.IsDefault
+		for _, name := range pf.Projects {
+			id, ok := byName[name]
+			if !ok {
+				skipped = append(skipped, fmt.Sprintf("project %q in filter %q: no such project", name, pf.Name))
+				continue
+			}
+			f.ProjectIDs = append(f.ProjectIDs, id)
+		}
+		if len(pf.Projects) > 0 && len(f.ProjectIDs) == 0 {
+			skipped = append(skipped, fmt.Sprintf("filter %q: none of its projects are here", pf.Name))
+			continue
+		}
+
+		for id, existing := range filters.Filters {
+			if existing.Name == f.Name {
+				delete(filters.Filters, id)
+			} else if f.IsDefault {
+				existing.IsDefault = false
+			}
+		}
+		filters.Filters[f.ID] = f
+		imported++
+	}
+	return imported, skipped
+}
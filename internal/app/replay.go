@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/project"
+)
+
+// replayReset undoes terminal modes a replayed agent may have left on:
+// attributes, a hidden cursor, mouse reporting, bracketed paste and the
+// alternate screen.
+const replayReset = "\x1b[0m\x1b[?25h\x1b[?1000l\x1b[?1002l\x1b[?1003l\x1b[?1006l\x1b[?2004l\x1b[?1049l"
+
+// ReplaySession plays back a recorded agent session of a ticket in the
+// terminal. run counts the ticket's recorded runs from 1, oldest first; 0
+// means the latest. With list set the recorded runs are printed instead.
+func ReplaySession(ticketID string, run int, speed float64, idleLimit time.Duration, list bool) error {
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	ticket, err := findTicket(globalStore, ticketID)
+	if err != nil {
+		return err
+	}
+
+	var runs []board.AgentRun
+	for _, r := range ticket.AgentRuns {
+		if r.Recording != "" {
+			runs = append(runs, r)
+		}
+	}
+	if len(runs) == 0 {
+		return fmt.Errorf("no recorded sessions for %q (set behavior.record_sessions to record them)", ticket.Title)
+	}
+
+	if list {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "RUN\tAGENT\tSTARTED\tOUTCOME\tDURATION")
+		for i, r := range runs {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", i+1, r.Agent, r.StartedAt.Local().Format("2006-01-02 15:04"), r.Outcome, r.Duration().Round(time.Second))
+		}
+		return w.Flush()
+	}
+
+	if run == 0 {
+		run = len(runs)
+	}
+	if run < 1 || run > len(runs) {
+		return fmt.Errorf("run %d not found; %q has %d recorded runs", run, ticket.Title, len(runs))
+	}
+	selected := runs[run-1]
+
+	header, events, err := agent.ReadRecording(selected.Recording)
+	if err != nil {
+		return fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Print("\x1b[H\x1b[2J")
+	err = agent.PlayRecording(ctx, os.Stdout, events, speed, idleLimit)
+	fmt.Print(replayReset)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	status := "Replayed"
+	if err != nil {
+		status = "Stopped replaying"
+	}
+	fmt.Printf("\n%s run %d of %d of %q (%s, %s, %s; recorded at %dx%d)\n",
+		status, run, len(runs), ticket.Title, selected.Agent,
+		selected.StartedAt.Local().Format("2006-01-02 15:04"), selected.Outcome, header.Width, header.Height)
+	return nil
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/git"
+	"github.com/techdufus/openkanban/internal/project"
+	"github.com/techdufus/openkanban/internal/share"
+)
+
+// PrintOutcomeReport prints closed-ticket outcomes grouped by label and by agent type.
+func PrintOutcomeReport() error {
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	byLabel, byAgent := board.SummarizeOutcomes(globalStore.All())
+	if len(byAgent) == 0 {
+		fmt.Println("No ticket outcomes recorded yet.")
+		return nil
+	}
+
+	fmt.Println("By label")
+	if err := printOutcomeTable(os.Stdout, "LABEL", byLabel); err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Println("By agent")
+	return printOutcomeTable(os.Stdout, "AGENT", byAgent)
+}
+
+func printOutcomeTable(out io.Writer, keyHeader string, rows []board.OutcomeCounts) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tSHIPPED\tABANDONED\tREWRITE\tTOTAL\tSHIP %%\n", keyHeader)
+	for _, c := range rows {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%.0f%%\n",
+			c.Key,
+			c.Shipped,
+			c.Abandoned,
+			c.Rewrite,
+			c.Total(),
+			c.ShipRate()*100,
+		)
+	}
+	return w.Flush()
+}
+
+// PrintChangelog writes a CHANGELOG-style markdown section of the tickets
+// finished since a tag, commit or date, grouped by label, to stdout or to
+// output. With repoPath only that project's tickets are included, and tags
+// are looked up in its repository rather than the current directory's.
+func PrintChangelog(repoPath, since, output string) error {
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+	proj, err := findProject(registry, repoPath)
+	if err != nil {
+		return err
+	}
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	var from time.Time
+	if since != "" {
+		repo := "."
+		if proj != nil {
+			repo = proj.RepoPath
+		}
+		if from, err = changelogStart(repo, since); err != nil {
+			return err
+		}
+	}
+
+	var tickets []*board.Ticket
+	for _, t := range globalStore.All() {
+		if proj == nil || t.ProjectID == proj.ID {
+			tickets = append(tickets, t)
+		}
+	}
+	var projectName func(*board.Ticket) string
+	if proj == nil && len(globalStore.Projects()) > 1 {
+		projectName = func(t *board.Ticket) string {
+			if p := globalStore.GetProjectForTicket(t); p != nil {
+				return p.Name
+			}
+			return ""
+		}
+	}
+
+	content := share.ChangelogMarkdown(share.Changelog{
+		Since:     since,
+		Generated: time.Now(),
+		Groups:    share.ChangelogGroups(tickets, projectName, from),
+	})
+	if output == "" {
+		_, err := os.Stdout.Write(content)
+		return err
+	}
+	if err := os.WriteFile(output, content, 0644); err != nil {
+		return fmt.Errorf("failed to write changelog: %w", err)
+	}
+	fmt.Printf("Wrote %s\n", output)
+	return nil
+}
+
+// changelogStart reads since as a date, YYYY-MM-DD or RFC 3339, or else as
+// a tag or commit in repo, which starts the changelog at its commit time.
+func changelogStart(repo, since string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", since, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t, nil
+	}
+	t, err := git.LastCommitTime(repo, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--since %q is neither a date (YYYY-MM-DD) nor a tag or commit in %s", since, repo)
+	}
+	return t, nil
+}
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/git"
+	"github.com/techdufus/openkanban/internal/project"
+)
+
+// ScanBranches lists the branches in repoPath's repository that are not
+// merged into its default branch and have no ticket yet, and offers to file
+// a ticket for each in In Progress, linked to the branch and its worktree.
+// With yes, tickets are created without asking; with fromCommit, titles come
+// from each branch's last commit instead of its name.
+func ScanBranches(cfgPath, repoPath string, yes, fromCommit bool) error {
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := board.SetIDScheme(cfg.Defaults.IDScheme); err != nil {
+		return err
+	}
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+
+	if repoPath == "" {
+		repoPath, _ = os.Getwd()
+	}
+	repoPath, err = filepath.Abs(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+	repoPath = git.ResolveMainRepo(repoPath)
+	proj, _ := registry.FindByPath(repoPath)
+	if proj == nil {
+		return fmt.Errorf("no project for %s; create one with: openkanban new", repoPath)
+	}
+
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+	owned := map[string]bool{}
+	for _, t := range globalStore.All() {
+		if t.ProjectID == proj.ID {
+			for _, b := range t.Attempts() {
+				owned[b] = true
+			}
+		}
+	}
+
+	mgr := git.NewWorktreeManager(proj)
+	base, _ := mgr.GetDefaultBranch()
+	unmerged, err := mgr.UnmergedBranches(base)
+	if err != nil {
+		return err
+	}
+	var branches []git.Branch
+	for _, b := range unmerged {
+		if !owned[b.Name] {
+			branches = append(branches, b)
+		}
+	}
+	if len(branches) == 0 {
+		fmt.Printf("No unmerged branches without a ticket in %s\n", proj.Name)
+		return nil
+	}
+
+	prefix := boardInfo(cfg, proj).BranchPrefix
+
+	fmt.Printf("Unmerged branches in %s (against %s):\n\n", proj.Name, base)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  BRANCH\tLAST COMMIT")
+	for _, b := range branches {
+		fmt.Fprintf(w, "  %s\t%s\n", b.Ref(), truncate(b.Subject, 60))
+	}
+	w.Flush()
+	fmt.Println()
+
+	in := bufio.NewReader(os.Stdin)
+	created := 0
+	for _, b := range branches {
+		title := branchTitle(b.Name, prefix)
+		if fromCommit && b.Subject != "" {
+			title = b.Subject
+		}
+		if !yes {
+			answer, err := ask(in, fmt.Sprintf("Create %q for %s? [y/N/a/q] ", title, b.Ref()))
+			if err != nil || answer == "q" {
+				break
+			}
+			if answer == "a" {
+				yes = true
+			} else if answer != "y" {
+				continue
+			}
+		}
+
+		ticket, err := ticketForBranch(mgr, proj, b, title)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  %s: %v\n", b.Ref(), err)
+			continue
+		}
+		globalStore.Add(ticket)
+		if err := globalStore.Save(ticket); err != nil {
+			return fmt.Errorf("failed to save tickets: %w", err)
+		}
+		created++
+
+		where := "worktree created on first spawn"
+		if ticket.WorktreePath != "" {
+			where = ticket.WorktreePath
+		}
+		fmt.Printf("  Created %s (%s)\n", title, where)
+	}
+
+	fmt.Printf("\n%d ticket(s) created in In Progress\n", created)
+	return nil
+}
+
+// ticketForBranch files an In Progress ticket adopting branch b, first
+// creating a local branch to track it if it only exists on a remote.
+func ticketForBranch(mgr *git.WorktreeManager, proj *project.Project, b git.Branch, title string) (*board.Ticket, error) {
+	if b.Remote != "" {
+		if err := mgr.TrackBranch(b); err != nil {
+			return nil, err
+		}
+	}
+	adoption, err := mgr.Inspect(b.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := board.NewTicket(title, proj.ID)
+	adoption.Apply(ticket)
+	ticket.SetStatus(board.StatusInProgress)
+	return ticket, nil
+}
+
+// branchTitle turns a branch name like "agent/fix-login_page" into a ticket
+// title like "Fix login page".
+func branchTitle(branch, prefix string) string {
+	name := strings.TrimPrefix(branch, prefix)
+	if i := strings.LastIndex(name, "/"); i >= 0 && i < len(name)-1 {
+		name = name[i+1:]
+	}
+	name = strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	}), " ")
+	if name == "" {
+		return branch
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// ask prompts on stdout and reads a lowercased one-line answer.
+func ask(in *bufio.Reader, prompt string) (string, error) {
+	fmt.Print(prompt)
+	line, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		fmt.Println()
+		return "", err
+	}
+	return strings.ToLower(strings.TrimSpace(line)), nil
+}
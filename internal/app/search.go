@@ -0,0 +1,55 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/project"
+)
+
+// SearchLogs prints archived transcript and session log lines matching term, with the ticket they belong to.
+func SearchLogs(term string, limit int) error {
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	idx, err := agent.BuildLogIndex(agent.ArtifactsDir())
+	if err != nil {
+		return fmt.Errorf("failed to index transcripts: %w", err)
+	}
+	if err := idx.AddSessionLogs(agent.SessionLogsDir()); err != nil {
+		return fmt.Errorf("failed to index session logs: %w", err)
+	}
+
+	matches := idx.Search(term, limit)
+	if len(matches) == 0 {
+		fmt.Printf("No matches for %q.\n", term)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TICKET\tSOURCE\tMATCH")
+	for _, match := range matches {
+		title := string(match.TicketID)
+		if ticket, _ := globalStore.Get(match.TicketID); ticket != nil {
+			title = ticket.Title
+		}
+		fmt.Fprintf(w, "%s\t%s:%d\t%s\n", truncate(title, 40), match.Source, match.Line, truncate(match.Text, 80))
+	}
+	return w.Flush()
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/git"
+	"github.com/techdufus/openkanban/internal/project"
+	"github.com/techdufus/openkanban/internal/share"
+)
+
+// Share renders a read-only snapshot of the board and uploads it to the
+// configured share target, printing the URL. Format and target override the
+// config when set. With repoPath only that project's tickets are included.
+// With output the snapshot is written there ("-" for stdout) instead of
+// being uploaded.
+func Share(cfgPath, repoPath, format, target, output string) error {
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if format != "" {
+		cfg.Share.Format = format
+	}
+	if target != "" {
+		cfg.Share.Target = target
+	}
+	for _, e := range cfg.Validate().Errors {
+		if e.Section == "share" {
+			return fmt.Errorf("share.%s %s", e.Field, e.Message)
+		}
+	}
+
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+	proj, err := findProject(registry, repoPath)
+	if err != nil {
+		return err
+	}
+
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	snapshot := boardSnapshot(cfg, globalStore, proj, time.Now())
+	content := share.Markdown(snapshot)
+	ext := ".md"
+	switch cfg.Share.Format {
+	case "html":
+		ext = ".html"
+		if content, err = share.HTML(snapshot); err != nil {
+			return fmt.Errorf("failed to render board: %w", err)
+		}
+	case "atom":
+		ext = ".atom"
+		if content, err = share.Atom(snapshot, boardEvents(globalStore, proj)); err != nil {
+			return fmt.Errorf("failed to render feed: %w", err)
+		}
+	}
+
+	switch output {
+	case "":
+	case "-":
+		_, err := os.Stdout.Write(content)
+		return err
+	default:
+		if err := os.WriteFile(output, content, 0644); err != nil {
+			return fmt.Errorf("failed to write snapshot: %w", err)
+		}
+		fmt.Printf("Wrote %s\n", output)
+		return nil
+	}
+
+	dir, err := os.MkdirTemp("", "openkanban-share-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	name := fmt.Sprintf("openkanban-%s-%s%s",
+		board.Slugify(snapshot.Title, 40), snapshot.Generated.Format("20060102-1504"), ext)
+	if ext == ".atom" {
+		// Feed readers poll one URL, so each upload replaces the last.
+		name = fmt.Sprintf("openkanban-%s%s", board.Slugify(snapshot.Title, 40), ext)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	url, err := share.Upload(cfg.Share, path, snapshot.Title+" board snapshot")
+	if err != nil {
+		return fmt.Errorf("failed to share board: %w", err)
+	}
+	fmt.Println(url)
+	return nil
+}
+
+// boardSnapshot collects the unarchived tickets, of proj only if it is set,
+// into the board's columns in the order the board sorts them.
+func boardSnapshot(cfg *config.Config, globalStore *project.GlobalTicketStore, proj *project.Project, now time.Time) share.Board {
+	snapshot := share.Board{Title: "openkanban", Generated: now}
+	if proj != nil {
+		snapshot.Title = proj.Name
+	}
+
+	for _, col := range cfg.BoardColumns() {
+		var tickets []*board.Ticket
+		for _, t := range globalStore.GetByStatus(col.Status) {
+			if t.Archived || (proj != nil && t.ProjectID != proj.ID) {
+				continue
+			}
+			tickets = append(tickets, t)
+		}
+		board.SortTickets(tickets, cfg.ColumnSort(col.ID))
+
+		column := share.Column{Name: col.Name, Limit: col.Limit}
+		for _, t := range tickets {
+			name := ""
+			if p := globalStore.GetProjectForTicket(t); p != nil && proj == nil {
+				name = p.Name
+			}
+			column.Cards = append(column.Cards, share.NewCard(t, name))
+		}
+		snapshot.Columns = append(snapshot.Columns, column)
+	}
+	return snapshot
+}
+
+// boardEvents is the feed of recent events on proj's tickets, or on every
+// board's when proj is nil. Archived tickets are included: their events
+// still happened.
+func boardEvents(globalStore *project.GlobalTicketStore, proj *project.Project) []share.FeedEntry {
+	var tickets []*board.Ticket
+	for _, t := range globalStore.All() {
+		if proj == nil || t.ProjectID == proj.ID {
+			tickets = append(tickets, t)
+		}
+	}
+	var projectName func(*board.Ticket) string
+	if proj == nil {
+		projectName = func(t *board.Ticket) string {
+			if p := globalStore.GetProjectForTicket(t); p != nil {
+				return p.Name
+			}
+			return ""
+		}
+	}
+	return share.FeedEvents(tickets, projectName, share.FeedLimit)
+}
+
+// findProject returns the registered project at repoPath, or nil when
+// repoPath is empty.
+func findProject(registry *project.ProjectRegistry, repoPath string) (*project.Project, error) {
+	if repoPath == "" {
+		return nil, nil
+	}
+	repoPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+	repoPath = git.ResolveMainRepo(repoPath)
+	proj, _ := registry.FindByPath(repoPath)
+	if proj == nil {
+		return nil, fmt.Errorf("no project for %s", repoPath)
+	}
+	return proj, nil
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/project"
+)
+
+const sprintDateFormat = "2006-01-02"
+
+// ListSprints prints every sprint with its ticket roll-up.
+func ListSprints() error {
+	sprints, err := project.LoadSprints()
+	if err != nil {
+		return fmt.Errorf("failed to load sprints: %w", err)
+	}
+	if len(sprints.Sprints) == 0 {
+		fmt.Println("No sprints yet. Create one with: openkanban sprint create <name>")
+		return nil
+	}
+
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTART\tEND\tSTATE\tDONE\tIN PROGRESS\tTOTAL")
+	for _, s := range sprints.Sprints {
+		p := board.SummarizeProgress(globalStore.GetBySprint(s.ID))
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%d\n",
+			s.Name,
+			s.Start.Format(sprintDateFormat),
+			s.End.Format(sprintDateFormat),
+			sprintState(s, now),
+			p.Done,
+			p.InProgress,
+			p.Total,
+		)
+	}
+	return w.Flush()
+}
+
+func sprintState(s *board.Sprint, now time.Time) string {
+	switch {
+	case s.Active(now):
+		return fmt.Sprintf("active (%dd left)", s.DaysLeft(now))
+	case now.Before(s.Start):
+		return "upcoming"
+	default:
+		return "ended"
+	}
+}
+
+// CreateSprint adds a sprint. An empty start means today; an empty end means
+// start plus the default sprint length.
+func CreateSprint(name, start, end string) error {
+	startDate := time.Now()
+	if start != "" {
+		var err error
+		if startDate, err = time.ParseInLocation(sprintDateFormat, start, time.Local); err != nil {
+			return fmt.Errorf("invalid start date %q (want YYYY-MM-DD)", start)
+		}
+	}
+	endDate := startDate.Add(board.DefaultSprintLength - 24*time.Hour)
+	if end != "" {
+		var err error
+		if endDate, err = time.ParseInLocation(sprintDateFormat, end, time.Local); err != nil {
+			return fmt.Errorf("invalid end date %q (want YYYY-MM-DD)", end)
+		}
+	}
+	if endDate.Before(startDate) {
+		return fmt.Errorf("end date is before start date")
+	}
+
+	sprints, err := project.LoadSprints()
+	if err != nil {
+		return fmt.Errorf("failed to load sprints: %w", err)
+	}
+	s, err := sprints.Add(name, startDate, endDate)
+	if err != nil {
+		return fmt.Errorf("failed to create sprint: %w", err)
+	}
+
+	fmt.Printf("Created sprint %s (%s to %s)\n", s.Name, s.Start.Format(sprintDateFormat), s.End.Format(sprintDateFormat))
+	return nil
+}
+
+// DeleteSprint removes a sprint and unassigns its tickets.
+func DeleteSprint(nameOrID string) error {
+	sprints, err := project.LoadSprints()
+	if err != nil {
+		return fmt.Errorf("failed to load sprints: %w", err)
+	}
+	s, err := sprints.Find(nameOrID)
+	if err != nil {
+		return err
+	}
+
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	cleared := globalStore.ClearSprint(s.ID)
+	if err := globalStore.SaveAll(); err != nil {
+		return fmt.Errorf("failed to save tickets: %w", err)
+	}
+	if err := sprints.Remove(s.ID); err != nil {
+		return fmt.Errorf("failed to delete sprint: %w", err)
+	}
+
+	fmt.Printf("Deleted sprint %s (%d tickets unassigned)\n", s.Name, cleared)
+	return nil
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/project"
+)
+
+// TestTemplate renders a template against a ticket's PromptContext and prints
+// the result, reporting template errors instead of falling back. With no
+// template, the init prompt the ticket's agent would get is rendered; with
+// branch set, the board's branch template.
+func TestTemplate(cfgPath, ticketID, text string, branch bool) error {
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	registry, err := project.LoadRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load project registry: %w", err)
+	}
+	globalStore, err := project.LoadGlobalTicketStore(registry)
+	if err != nil {
+		return fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	ticket, err := findTicket(globalStore, ticketID)
+	if err != nil {
+		return err
+	}
+	proj := globalStore.GetProjectForTicket(ticket)
+	ctx := agent.NewPromptContext(ticket, boardInfo(cfg, proj))
+
+	if branch {
+		if text == "" {
+			text = branchTemplate(cfg, proj)
+		}
+		name, err := agent.RenderBranchTemplate(text, ctx)
+		if err != nil {
+			return fmt.Errorf("template error: %w", err)
+		}
+		fmt.Println(name)
+		return nil
+	}
+
+	if text == "" {
+		agentType := ticket.AgentType
+		if agentType == "" {
+			agentType = cfg.Defaults.DefaultAgent
+		}
+		text = cfg.InitPromptFor(agentType, ticket.Labels)
+		if text == "" {
+			return fmt.Errorf("no init prompt configured for agent %q", agentType)
+		}
+	}
+	prompt, err := agent.RenderContextPrompt(text, ctx)
+	if err != nil {
+		return fmt.Errorf("template error: %w", err)
+	}
+	fmt.Println(prompt)
+	return nil
+}
+
+// findTicket looks a ticket up by ID or unique ID prefix.
+func findTicket(globalStore *project.GlobalTicketStore, id string) (*board.Ticket, error) {
+	if ticket, err := globalStore.Get(board.TicketID(id)); err == nil && ticket != nil {
+		return ticket, nil
+	}
+	var match *board.Ticket
+	for _, t := range globalStore.All() {
+		if !strings.HasPrefix(string(t.ID), id) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("ticket ID prefix %q is ambiguous", id)
+		}
+		match = t
+	}
+	if match == nil {
+		return nil, fmt.Errorf("ticket %q not found", id)
+	}
+	return match, nil
+}
+
+// boardInfo resolves the board settings a PromptContext needs, with project
+// settings overriding the config defaults as they do on the board.
+func boardInfo(cfg *config.Config, proj *project.Project) agent.BoardInfo {
+	info := agent.BoardInfo{
+		BranchPrefix:  cfg.Defaults.BranchPrefix,
+		SlugMaxLength: cfg.Defaults.SlugMaxLength,
+		TicketLink:    cfg.UI.TicketLink,
+	}
+	if proj != nil {
+		info.Name = proj.Name
+		info.RepoPath = proj.RepoPath
+		if proj.Settings.BranchPrefix != "" {
+			info.BranchPrefix = proj.Settings.BranchPrefix
+		}
+		if proj.Settings.SlugMaxLength > 0 {
+			info.SlugMaxLength = proj.Settings.SlugMaxLength
+		}
+	}
+	if info.BranchPrefix == "" {
+		info.BranchPrefix = "task/"
+	}
+	return info
+}
+
+func branchTemplate(cfg *config.Config, proj *project.Project) string {
+	if proj != nil && proj.Settings.BranchTemplate != "" {
+		return proj.Settings.BranchTemplate
+	}
+	if cfg.Defaults.BranchTemplate != "" {
+		return cfg.Defaults.BranchTemplate
+	}
+	return "{prefix}{slug}"
+}
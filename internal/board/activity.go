@@ -0,0 +1,66 @@
+package board
+
+import (
+	"strings"
+	"time"
+)
+
+// activityDayFormat keys Activity by local calendar day.
+const activityDayFormat = "2006-01-02"
+
+// DayActivity counts what happened on the board on one day.
+type DayActivity struct {
+	Completed int // Tickets moved to Done
+	Runs      int // Agent sessions started
+}
+
+// Total is the day's completions and runs together.
+func (d DayActivity) Total() int {
+	return d.Completed + d.Runs
+}
+
+// Activity is the board's activity by local calendar day.
+type Activity map[string]DayActivity
+
+// On returns the activity on the day containing t.
+func (a Activity) On(t time.Time) DayActivity {
+	return a[t.Local().Format(activityDayFormat)]
+}
+
+// DailyActivity counts, for each day from since onwards, the tickets moved
+// to Done and the agent runs started, from the tickets' history. A ticket
+// whose history has no move to Done, such as one completed before history
+// was kept, counts on its completion date instead.
+func DailyActivity(tickets []*Ticket, since time.Time) Activity {
+	a := Activity{}
+	add := func(at time.Time, completed, runs int) {
+		if at.Before(since) {
+			return
+		}
+		key := at.Local().Format(activityDayFormat)
+		day := a[key]
+		day.Completed += completed
+		day.Runs += runs
+		a[key] = day
+	}
+
+	for _, t := range tickets {
+		recorded := false
+		for _, e := range t.History {
+			if e.Kind != EventMoved {
+				continue
+			}
+			if _, to, _ := strings.Cut(e.Detail, " → "); to == string(StatusDone) {
+				add(e.At, 1, 0)
+				recorded = true
+			}
+		}
+		if !recorded && t.CompletedAt != nil {
+			add(*t.CompletedAt, 1, 0)
+		}
+		for _, run := range t.AgentRuns {
+			add(run.StartedAt, 0, 1)
+		}
+	}
+	return a
+}
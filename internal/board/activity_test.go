@@ -0,0 +1,49 @@
+package board
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyActivity(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.Local) }
+
+	// Done twice: reopened and finished again.
+	a := NewTicket("A", "p")
+	a.History = []TicketEvent{
+		{Kind: EventMoved, At: day(2), Detail: moveDetail(StatusInProgress, StatusDone)},
+		{Kind: EventMoved, At: day(3), Detail: moveDetail(StatusDone, StatusInProgress)},
+		{Kind: EventMoved, At: day(5), Detail: moveDetail(StatusInProgress, StatusDone)},
+	}
+	a.AgentRuns = []AgentRun{{StartedAt: day(2)}, {StartedAt: day(5)}, {StartedAt: day(5)}}
+
+	// Completed before history was kept.
+	b := NewTicket("B", "p")
+	b.History = nil
+	completed := day(5)
+	b.CompletedAt = &completed
+
+	// Before the window.
+	c := NewTicket("C", "p")
+	c.History = []TicketEvent{{Kind: EventMoved, At: day(1), Detail: moveDetail(StatusBacklog, StatusDone)}}
+
+	activity := DailyActivity([]*Ticket{a, b, c}, day(2).Add(-time.Hour))
+
+	tests := []struct {
+		day  int
+		want DayActivity
+	}{
+		{1, DayActivity{}},
+		{2, DayActivity{Completed: 1, Runs: 1}},
+		{3, DayActivity{}},
+		{5, DayActivity{Completed: 2, Runs: 2}},
+	}
+	for _, tt := range tests {
+		if got := activity.On(day(tt.day)); got != tt.want {
+			t.Errorf("On(March %d) = %+v; want %+v", tt.day, got, tt.want)
+		}
+	}
+	if got := activity.On(day(5)).Total(); got != 4 {
+		t.Errorf("Total() = %d; want 4", got)
+	}
+}
@@ -1,12 +1,13 @@
 package board
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 	"unicode"
-
-	"github.com/google/uuid"
 )
 
 var nonAlphanumericRegex = regexp.MustCompile(`[^a-z0-9-]+`)
@@ -42,10 +43,6 @@ func Slugify(s string, maxLen int) string {
 
 type TicketID string
 
-func NewTicketID() TicketID {
-	return TicketID(uuid.New().String())
-}
-
 type TicketStatus string
 
 const (
@@ -55,6 +52,18 @@ const (
 	StatusArchived   TicketStatus = "archived"
 )
 
+type TicketOutcome string
+
+const (
+	OutcomeNone      TicketOutcome = ""
+	OutcomeShipped   TicketOutcome = "shipped"
+	OutcomeAbandoned TicketOutcome = "abandoned"
+	OutcomeRewrite   TicketOutcome = "needed-human-rewrite"
+)
+
+// TicketOutcomes lists the selectable outcomes in display order.
+var TicketOutcomes = []TicketOutcome{OutcomeShipped, OutcomeAbandoned, OutcomeRewrite}
+
 type AgentStatus string
 
 const (
@@ -78,23 +87,124 @@ type Ticket struct {
 	BranchName   string `json:"branch_name,omitempty"`
 	BaseBranch   string `json:"base_branch,omitempty"`
 
+	// PRURL and IssueURL link the ticket's pull request and the issue it
+	// tracks elsewhere.
+	PRURL    string `json:"pr_url,omitempty"`
+	IssueURL string `json:"issue_url,omitempty"`
+
+	// PreviousBranches are earlier attempts at the ticket, oldest first,
+	// left behind by retries.
+	PreviousBranches []string `json:"previous_branches,omitempty"`
+
 	AgentType      string      `json:"agent_type,omitempty"`
 	AgentStatus    AgentStatus `json:"agent_status"`
 	AgentSpawnedAt *time.Time  `json:"agent_spawned_at,omitempty"`
 	AgentPort      int         `json:"agent_port,omitempty"`
 	AgentSessionID string      `json:"agent_session_id,omitempty"`
 
+	// Pipeline names the agent pipeline working the ticket, and
+	// PipelineStage the index of the stage its agent is running.
+	Pipeline      string `json:"pipeline,omitempty"`
+	PipelineStage int    `json:"pipeline_stage,omitempty"`
+
+	// Muted stops the ticket's agent raising waiting and error
+	// notifications; its card still shows the status.
+	Muted bool `json:"muted,omitempty"`
+
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	StartedAt   *time.Time `json:"started_at,omitempty"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 
+	// Archived tickets are hidden from the board but keep their history.
+	Archived   bool       `json:"archived,omitempty"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+
 	Labels   []string          `json:"labels,omitempty"`
 	Priority int               `json:"priority,omitempty"`
 	Meta     map[string]string `json:"meta,omitempty"`
+	Assignee string            `json:"assignee,omitempty"`
 
 	// Dependencies - tickets that block this one (informational only, no enforcement)
 	BlockedBy []TicketID `json:"blocked_by,omitempty"`
+
+	// Position is the ticket's place in its column when sorted manually;
+	// zero means it has not been placed and follows the placed tickets.
+	Position int `json:"position,omitempty"`
+
+	// ParentID groups this ticket under an epic.
+	ParentID TicketID `json:"parent_id,omitempty"`
+
+	// SprintID assigns this ticket to a sprint.
+	SprintID string `json:"sprint_id,omitempty"`
+
+	// AgentRuns records every agent session spawned for this ticket.
+	AgentRuns []AgentRun `json:"agent_runs,omitempty"`
+
+	// Outcome is recorded when the ticket is closed, for retrospectives.
+	Outcome TicketOutcome `json:"outcome,omitempty"`
+
+	// Comments is a worklog shared between humans and agents across sessions.
+	Comments []Comment `json:"comments,omitempty"`
+
+	// History is an audit log of mutations, oldest first.
+	History []TicketEvent `json:"history,omitempty"`
+
+	// Extra holds fields written by a newer openkanban that this build
+	// doesn't know, kept so that saving the ticket doesn't drop them.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+type Comment struct {
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+	Text      string    `json:"text"`
+}
+
+type RunOutcome string
+
+const (
+	RunRunning   RunOutcome = "running"
+	RunCompleted RunOutcome = "completed"
+	RunError     RunOutcome = "error"
+	RunStopped   RunOutcome = "stopped"
+)
+
+// AgentRun is a single agent session on a ticket, kept for outcome metrics.
+type AgentRun struct {
+	Agent     string     `json:"agent"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Outcome   RunOutcome `json:"outcome"`
+	CostUSD   float64    `json:"cost_usd,omitempty"`
+
+	// ArtifactsDir points at the archived prompt, transcript, and diff, if captured.
+	ArtifactsDir string `json:"artifacts_dir,omitempty"`
+
+	// LogFile is the session's full terminal output, if logged.
+	LogFile string `json:"log_file,omitempty"`
+
+	// Recording is the session's asciicast recording, if recorded.
+	Recording string `json:"recording,omitempty"`
+
+	// StartupError is the output of an agent that failed to start.
+	StartupError string `json:"startup_error,omitempty"`
+
+	// OwnerPID is the openkanban process the session runs in, which it
+	// doesn't outlive.
+	OwnerPID int `json:"owner_pid,omitempty"`
+
+	// OwnerStarted is when that process started, which tells it apart
+	// from a later process given the same PID.
+	OwnerStarted string `json:"owner_started,omitempty"`
+}
+
+// Duration returns how long the run lasted, or zero if it has not ended.
+func (r AgentRun) Duration() time.Duration {
+	if r.EndedAt == nil {
+		return 0
+	}
+	return r.EndedAt.Sub(r.StartedAt)
 }
 
 func NewTicket(title, projectID string) *Ticket {
@@ -111,6 +221,7 @@ func NewTicket(title, projectID string) *Ticket {
 		UpdatedAt:   now,
 		Labels:      []string{},
 		Meta:        map[string]string{},
+		History:     []TicketEvent{{Kind: EventCreated, At: now}},
 	}
 }
 
@@ -120,17 +231,167 @@ func (t *Ticket) Touch() {
 
 func (t *Ticket) SetStatus(status TicketStatus) {
 	now := time.Now()
+	if status != t.Status {
+		t.Record(EventMoved, moveDetail(t.Status, status))
+		t.Position = 0
+	}
 	t.Status = status
 	t.UpdatedAt = now
 
 	switch status {
+	case StatusBacklog:
+		t.Outcome = OutcomeNone
 	case StatusInProgress:
 		t.StartedAt = &now
+		t.Outcome = OutcomeNone
 	case StatusDone:
 		t.CompletedAt = &now
 	}
 }
 
+// Archive hides the ticket from the board columns.
+func (t *Ticket) Archive() {
+	now := time.Now()
+	t.Archived = true
+	t.ArchivedAt = &now
+	t.UpdatedAt = now
+	t.Record(EventArchived, "")
+}
+
+// Unarchive restores the ticket to its column.
+func (t *Ticket) Unarchive() {
+	t.Archived = false
+	t.ArchivedAt = nil
+	t.Touch()
+	t.Record(EventUnarchived, "")
+}
+
+// Retry moves the ticket onto a fresh branch whose worktree has yet to be
+// created, and forgets the agent session so the next spawn starts over.
+// The old branch is left alone and kept as a previous attempt.
+func (t *Ticket) Retry(branchName string) {
+	t.Record(EventRetried, t.BranchName+" → "+branchName)
+	t.switchBranch(branchName)
+}
+
+// KeepAttempt goes back to an earlier attempt's branch, keeping the current
+// one as a previous attempt. Like Retry, the worktree is recreated and the
+// agent session starts over.
+func (t *Ticket) KeepAttempt(branchName string) {
+	t.Record(EventAttemptKept, t.BranchName+" → "+branchName)
+	t.switchBranch(branchName)
+}
+
+// Adopt links the ticket to a branch created outside openkanban, and to the
+// worktree it is checked out in, if any. A branch the ticket was already on
+// is kept as a previous attempt.
+func (t *Ticket) Adopt(branchName, worktreePath, baseBranch string) {
+	t.Record(EventAdopted, branchName)
+	t.switchBranch(branchName)
+	t.WorktreePath = worktreePath
+	t.BaseBranch = baseBranch
+}
+
+// Attempts lists the ticket's branches, oldest first, ending with the
+// current one.
+func (t *Ticket) Attempts() []string {
+	attempts := slices.Clone(t.PreviousBranches)
+	if t.BranchName != "" {
+		attempts = append(attempts, t.BranchName)
+	}
+	return attempts
+}
+
+func (t *Ticket) switchBranch(branchName string) {
+	if t.BranchName != "" && t.BranchName != branchName && !slices.Contains(t.PreviousBranches, t.BranchName) {
+		t.PreviousBranches = append(t.PreviousBranches, t.BranchName)
+	}
+	t.PreviousBranches = slices.DeleteFunc(t.PreviousBranches, func(b string) bool { return b == branchName })
+	t.BranchName = branchName
+	t.WorktreePath = ""
+	t.AgentStatus = AgentNone
+	t.AgentSpawnedAt = nil
+	t.AgentSessionID = ""
+	t.Touch()
+}
+
+// AddComment appends a comment to the ticket's worklog.
+func (t *Ticket) AddComment(author, text string) {
+	t.Comments = append(t.Comments, Comment{
+		Author:    author,
+		CreatedAt: time.Now(),
+		Text:      text,
+	})
+	t.Touch()
+}
+
+// StartAgentRun opens a new run for agentType, closing any run left open.
+func (t *Ticket) StartAgentRun(agentType string) {
+	t.EndAgentRun(RunStopped, 0)
+	t.AgentRuns = append(t.AgentRuns, AgentRun{
+		Agent:     agentType,
+		StartedAt: time.Now(),
+		Outcome:   RunRunning,
+	})
+	t.Record(EventAgentSpawned, agentType)
+}
+
+// EndAgentRun closes the current run with the given outcome.
+// It is a no-op when no run is open.
+func (t *Ticket) EndAgentRun(outcome RunOutcome, costUSD float64) {
+	run := t.CurrentAgentRun()
+	if run == nil {
+		return
+	}
+	now := time.Now()
+	run.EndedAt = &now
+	run.Outcome = outcome
+	if costUSD > 0 {
+		run.CostUSD = costUSD
+	}
+	t.Record(EventAgentStopped, run.Agent+" ("+string(outcome)+")")
+}
+
+// StartPipeline puts the ticket at the first stage of the named pipeline.
+func (t *Ticket) StartPipeline(name string) {
+	t.Pipeline = name
+	t.PipelineStage = 0
+	t.Record(EventPipeline, name+" started")
+	t.Touch()
+}
+
+// AdvancePipeline moves the ticket on to its pipeline's next stage, which
+// runs agentType.
+func (t *Ticket) AdvancePipeline(agentType string) {
+	t.PipelineStage++
+	t.Record(EventPipeline, fmt.Sprintf("%s stage %d: %s", t.Pipeline, t.PipelineStage+1, agentType))
+	t.Touch()
+}
+
+// EndPipeline takes the ticket out of its pipeline, saying why.
+// It is a no-op when the ticket isn't in one.
+func (t *Ticket) EndPipeline(reason string) {
+	if t.Pipeline == "" {
+		return
+	}
+	t.Record(EventPipeline, t.Pipeline+" "+reason)
+	t.Pipeline = ""
+	t.PipelineStage = 0
+	t.Touch()
+}
+
+// CurrentAgentRun returns the open run, or nil if none is in progress.
+func (t *Ticket) CurrentAgentRun() *AgentRun {
+	if len(t.AgentRuns) == 0 {
+		return nil
+	}
+	run := &t.AgentRuns[len(t.AgentRuns)-1]
+	if run.EndedAt != nil {
+		return nil
+	}
+	return run
+}
+
 type Column struct {
 	ID     string       `json:"id"`
 	Name   string       `json:"name"`
@@ -149,6 +410,7 @@ func DefaultColumns() []Column {
 
 var (
 	ErrTicketNotFound = &BoardError{Message: "ticket not found"}
+	ErrInvalidParent  = &BoardError{Message: "ticket cannot be its own ancestor"}
 )
 
 type BoardError struct {
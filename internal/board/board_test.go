@@ -1,6 +1,7 @@
 package board
 
 import (
+	"slices"
 	"testing"
 	"time"
 )
@@ -338,3 +339,180 @@ func TestAgentStatus_Constants(t *testing.T) {
 		t.Errorf("AgentError = %q; want %q", AgentError, "error")
 	}
 }
+
+func TestTicket_AgentRuns(t *testing.T) {
+	ticket := NewTicket("Test", "project-1")
+
+	if ticket.CurrentAgentRun() != nil {
+		t.Fatal("new ticket should have no open run")
+	}
+
+	ticket.StartAgentRun("claude")
+	run := ticket.CurrentAgentRun()
+	if run == nil {
+		t.Fatal("expected open run after StartAgentRun")
+	}
+	if run.Agent != "claude" || run.Outcome != RunRunning {
+		t.Errorf("run = %+v; want running claude run", *run)
+	}
+
+	ticket.StartAgentRun("aider")
+	if len(ticket.AgentRuns) != 2 {
+		t.Fatalf("len(AgentRuns) = %d; want 2", len(ticket.AgentRuns))
+	}
+	if ticket.AgentRuns[0].Outcome != RunStopped || ticket.AgentRuns[0].EndedAt == nil {
+		t.Errorf("previous run should be closed as stopped; got %+v", ticket.AgentRuns[0])
+	}
+
+	ticket.EndAgentRun(RunCompleted, 1.5)
+	last := ticket.AgentRuns[1]
+	if last.Outcome != RunCompleted || last.CostUSD != 1.5 || last.EndedAt == nil {
+		t.Errorf("last run = %+v; want completed with cost 1.5", last)
+	}
+	if ticket.CurrentAgentRun() != nil {
+		t.Error("no run should be open after EndAgentRun")
+	}
+
+	ticket.EndAgentRun(RunError, 0)
+	if ticket.AgentRuns[1].Outcome != RunCompleted {
+		t.Error("EndAgentRun without an open run should be a no-op")
+	}
+}
+
+func TestTicket_Pipeline(t *testing.T) {
+	ticket := NewTicket("Test", "project-1")
+	ticket.EndPipeline("stopped")
+	if len(ticket.History) != 1 {
+		t.Errorf("EndPipeline outside a pipeline recorded %v", ticket.History[1:])
+	}
+
+	ticket.StartPipeline("ship")
+	ticket.AdvancePipeline("codex")
+	if ticket.Pipeline != "ship" || ticket.PipelineStage != 1 {
+		t.Errorf("pipeline = %q stage %d; want ship stage 1", ticket.Pipeline, ticket.PipelineStage)
+	}
+	if got := ticket.History[len(ticket.History)-1]; got.Kind != EventPipeline || got.Detail != "ship stage 2: codex" {
+		t.Errorf("last event = %+v; want ship stage 2: codex", got)
+	}
+
+	ticket.EndPipeline("finished")
+	if ticket.Pipeline != "" || ticket.PipelineStage != 0 {
+		t.Errorf("pipeline = %q stage %d after EndPipeline", ticket.Pipeline, ticket.PipelineStage)
+	}
+	if got := ticket.History[len(ticket.History)-1].Detail; got != "ship finished" {
+		t.Errorf("last event detail = %q; want ship finished", got)
+	}
+}
+
+func TestTicket_AddComment(t *testing.T) {
+	ticket := NewTicket("Test", "project-1")
+	before := ticket.UpdatedAt
+	time.Sleep(time.Millisecond)
+
+	ticket.AddComment("alice", "tried approach A, tests flaky")
+
+	if len(ticket.Comments) != 1 {
+		t.Fatalf("len(Comments) = %d; want 1", len(ticket.Comments))
+	}
+	c := ticket.Comments[0]
+	if c.Author != "alice" || c.Text != "tried approach A, tests flaky" {
+		t.Errorf("comment = %+v; want alice/text", c)
+	}
+	if c.CreatedAt.IsZero() {
+		t.Error("CreatedAt should be set")
+	}
+	if !ticket.UpdatedAt.After(before) {
+		t.Error("AddComment should touch UpdatedAt")
+	}
+}
+
+func TestTicket_ArchiveUnarchive(t *testing.T) {
+	ticket := NewTicket("Test", "project-1")
+	ticket.SetStatus(StatusDone)
+
+	ticket.Archive()
+	if !ticket.Archived || ticket.ArchivedAt == nil {
+		t.Fatalf("Archive() should set Archived and ArchivedAt; got %v/%v", ticket.Archived, ticket.ArchivedAt)
+	}
+	if ticket.Status != StatusDone {
+		t.Errorf("Archive() should keep status; got %q", ticket.Status)
+	}
+
+	ticket.Unarchive()
+	if ticket.Archived || ticket.ArchivedAt != nil {
+		t.Errorf("Unarchive() should clear Archived and ArchivedAt; got %v/%v", ticket.Archived, ticket.ArchivedAt)
+	}
+}
+
+func TestTicket_Retry(t *testing.T) {
+	ticket := NewTicket("Test", "project-1")
+	ticket.SetStatus(StatusInProgress)
+	spawnedAt := time.Now()
+	ticket.BranchName = "agent/test"
+	ticket.WorktreePath = "/tmp/worktrees/test"
+	ticket.AgentStatus = AgentError
+	ticket.AgentSpawnedAt = &spawnedAt
+	ticket.AgentSessionID = "abc"
+
+	ticket.Retry("agent/test-v2")
+
+	if ticket.BranchName != "agent/test-v2" || ticket.WorktreePath != "" {
+		t.Errorf("Retry() branch/worktree = %q/%q; want agent/test-v2 and no worktree", ticket.BranchName, ticket.WorktreePath)
+	}
+	if ticket.AgentStatus != AgentNone || ticket.AgentSpawnedAt != nil || ticket.AgentSessionID != "" {
+		t.Errorf("Retry() should reset the agent session; got %q/%v/%q", ticket.AgentStatus, ticket.AgentSpawnedAt, ticket.AgentSessionID)
+	}
+	if ticket.Status != StatusInProgress {
+		t.Errorf("Retry() should keep status; got %q", ticket.Status)
+	}
+	if got := ticket.Attempts(); !slices.Equal(got, []string{"agent/test", "agent/test-v2"}) {
+		t.Errorf("Attempts() = %v", got)
+	}
+	last := ticket.History[len(ticket.History)-1]
+	if last.Kind != EventRetried || last.Detail != "agent/test → agent/test-v2" {
+		t.Errorf("Retry() recorded %+v", last)
+	}
+}
+
+func TestTicket_KeepAttempt(t *testing.T) {
+	ticket := NewTicket("Test", "project-1")
+	ticket.BranchName = "agent/test"
+	ticket.Retry("agent/test-v2")
+	ticket.Retry("agent/test-v3")
+	ticket.WorktreePath = "/tmp/worktrees/test-v3"
+
+	ticket.KeepAttempt("agent/test-v2")
+
+	if ticket.BranchName != "agent/test-v2" || ticket.WorktreePath != "" {
+		t.Errorf("KeepAttempt() branch/worktree = %q/%q", ticket.BranchName, ticket.WorktreePath)
+	}
+	if got := ticket.Attempts(); !slices.Equal(got, []string{"agent/test", "agent/test-v3", "agent/test-v2"}) {
+		t.Errorf("Attempts() = %v", got)
+	}
+	last := ticket.History[len(ticket.History)-1]
+	if last.Kind != EventAttemptKept || last.Detail != "agent/test-v3 → agent/test-v2" {
+		t.Errorf("KeepAttempt() recorded %+v", last)
+	}
+}
+
+func TestTicket_Adopt(t *testing.T) {
+	ticket := NewTicket("Test", "project-1")
+	ticket.BranchName = "agent/test"
+	ticket.AgentSessionID = "session"
+
+	ticket.Adopt("feature/login", "/src/login", "main")
+
+	if ticket.BranchName != "feature/login" || ticket.WorktreePath != "/src/login" || ticket.BaseBranch != "main" {
+		t.Errorf("Adopt() branch/worktree/base = %q/%q/%q", ticket.BranchName, ticket.WorktreePath, ticket.BaseBranch)
+	}
+	if ticket.AgentSessionID != "" {
+		t.Error("Adopt() should forget the agent session")
+	}
+	if got := ticket.Attempts(); !slices.Equal(got, []string{"agent/test", "feature/login"}) {
+		t.Errorf("Attempts() = %v", got)
+	}
+	last := ticket.History[len(ticket.History)-1]
+	if last.Kind != EventAdopted || last.Detail != "feature/login" {
+		t.Errorf("Adopt() recorded %+v", last)
+	}
+}
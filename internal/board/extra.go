@@ -0,0 +1,96 @@
+package board
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// ticketFields are the JSON keys of the fields Ticket knows.
+var ticketFields = JSONFields(reflect.TypeOf(Ticket{}))
+
+// ticketJSON is Ticket without its JSON methods, to encode the known fields
+// the default way.
+type ticketJSON Ticket
+
+// UnmarshalJSON decodes a ticket, keeping the fields a newer openkanban
+// wrote that this build doesn't know in Extra.
+func (t *Ticket) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*ticketJSON)(t)); err != nil {
+		return err
+	}
+	extra, err := UnknownFields(data, ticketFields)
+	if err != nil {
+		return err
+	}
+	t.Extra = extra
+	return nil
+}
+
+// MarshalJSON encodes a ticket along with the unknown fields it was read
+// with, so saving doesn't drop them.
+func (t Ticket) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal((*ticketJSON)(&t))
+	if err != nil {
+		return nil, err
+	}
+	return MergeFields(data, t.Extra)
+}
+
+// JSONFields returns the JSON keys a struct type's fields encode as.
+func JSONFields(t reflect.Type) map[string]bool {
+	fields := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		fields[name] = true
+	}
+	return fields
+}
+
+// UnknownFields returns the members of the JSON object data that aren't
+// among known, or nil if there are none.
+func UnknownFields(data []byte, known map[string]bool) (map[string]json.RawMessage, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	var extra map[string]json.RawMessage
+	for k, v := range members {
+		if known[k] {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[k] = v
+	}
+	return extra, nil
+}
+
+// MergeFields adds extra members to the JSON object data, leaving the ones
+// it already has alone.
+func MergeFields(data []byte, extra map[string]json.RawMessage) ([]byte, error) {
+	if len(extra) == 0 {
+		return data, nil
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	for k, v := range extra {
+		if _, ok := members[k]; !ok {
+			members[k] = v
+		}
+	}
+	return json.Marshal(members)
+}
@@ -0,0 +1,62 @@
+package board
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTicket_KeepsUnknownFields(t *testing.T) {
+	data := []byte(`{"id": "t1", "title": "Ship it", "status": "backlog", "estimate": {"points": 3}, "reviewers": ["ana"]}`)
+
+	var ticket Ticket
+	if err := json.Unmarshal(data, &ticket); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if ticket.Title != "Ship it" || ticket.Status != StatusBacklog {
+		t.Errorf("known fields = %q, %q", ticket.Title, ticket.Status)
+	}
+	if len(ticket.Extra) != 2 {
+		t.Fatalf("Extra = %v, want estimate and reviewers", ticket.Extra)
+	}
+
+	ticket.Title = "Ship it today"
+	out, err := json.Marshal(ticket)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(out, &fields); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if string(fields["estimate"]) != `{"points":3}` || string(fields["reviewers"]) != `["ana"]` {
+		t.Errorf("unknown fields after round trip = %s, %s", fields["estimate"], fields["reviewers"])
+	}
+	if string(fields["title"]) != `"Ship it today"` {
+		t.Errorf("title = %s, want the edited title", fields["title"])
+	}
+}
+
+func TestTicket_NoUnknownFields(t *testing.T) {
+	ticket := NewTicket("Plain", "p1")
+	data, err := json.Marshal(ticket)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	var loaded Ticket
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if loaded.Extra != nil {
+		t.Errorf("Extra = %v, want nil", loaded.Extra)
+	}
+}
+
+func TestMergeFields_KnownFieldsWin(t *testing.T) {
+	out, err := MergeFields([]byte(`{"title":"new"}`), map[string]json.RawMessage{"title": json.RawMessage(`"old"`)})
+	if err != nil {
+		t.Fatalf("MergeFields() error: %v", err)
+	}
+	if string(out) != `{"title":"new"}` {
+		t.Errorf("MergeFields() = %s", out)
+	}
+}
@@ -0,0 +1,72 @@
+package board
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+type EventKind string
+
+const (
+	EventCreated      EventKind = "created"
+	EventMoved        EventKind = "moved"
+	EventEdited       EventKind = "edited"
+	EventAgentSpawned EventKind = "agent_spawned"
+	EventAgentStopped EventKind = "agent_stopped"
+	EventArchived     EventKind = "archived"
+	EventUnarchived   EventKind = "unarchived"
+	EventRetried      EventKind = "retried"
+	EventAttemptKept  EventKind = "attempt_kept"
+	EventAdopted      EventKind = "adopted"
+	EventMerged       EventKind = "merged"
+	EventCommit       EventKind = "commit"
+	EventPipeline     EventKind = "pipeline"
+)
+
+// MaxHistoryEvents bounds the per-ticket log; the oldest events are dropped.
+const MaxHistoryEvents = 500
+
+// TicketEvent is one entry in a ticket's audit log.
+type TicketEvent struct {
+	Kind   EventKind `json:"kind"`
+	At     time.Time `json:"at"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// Record appends an event to the ticket's history.
+func (t *Ticket) Record(kind EventKind, detail string) {
+	t.History = append(t.History, TicketEvent{
+		Kind:   kind,
+		At:     time.Now(),
+		Detail: detail,
+	})
+	if over := len(t.History) - MaxHistoryEvents; over > 0 {
+		t.History = slices.Delete(t.History, 0, over)
+	}
+}
+
+// ChangedFields lists the user-editable fields that differ between two
+// versions of a ticket, in form order.
+func ChangedFields(before, after *Ticket) []string {
+	var changed []string
+	add := func(name string, differs bool) {
+		if differs {
+			changed = append(changed, name)
+		}
+	}
+	add("title", before.Title != after.Title)
+	add("description", before.Description != after.Description)
+	add("branch", before.BranchName != after.BranchName)
+	add("labels", !slices.Equal(before.Labels, after.Labels))
+	add("assignee", before.Assignee != after.Assignee)
+	add("priority", before.Priority != after.Priority)
+	add("worktree", before.UseWorktree != after.UseWorktree)
+	add("agent", before.AgentType != after.AgentType)
+	add("blocked_by", !slices.Equal(before.BlockedBy, after.BlockedBy))
+	return changed
+}
+
+func moveDetail(from, to TicketStatus) string {
+	return fmt.Sprintf("%s → %s", from, to)
+}
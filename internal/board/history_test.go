@@ -0,0 +1,76 @@
+package board
+
+import (
+	"slices"
+	"testing"
+)
+
+func historyKinds(t *Ticket) []EventKind {
+	var kinds []EventKind
+	for _, e := range t.History {
+		kinds = append(kinds, e.Kind)
+	}
+	return kinds
+}
+
+func TestTicket_HistoryRecordsMutations(t *testing.T) {
+	ticket := NewTicket("Test", "project-1")
+	ticket.SetStatus(StatusInProgress)
+	ticket.SetStatus(StatusInProgress)
+	ticket.StartAgentRun("claude")
+	ticket.EndAgentRun(RunCompleted, 0)
+	ticket.SetStatus(StatusDone)
+	ticket.Archive()
+	ticket.Unarchive()
+
+	want := []EventKind{
+		EventCreated,
+		EventMoved,
+		EventAgentSpawned,
+		EventAgentStopped,
+		EventMoved,
+		EventArchived,
+		EventUnarchived,
+	}
+	if got := historyKinds(ticket); !slices.Equal(got, want) {
+		t.Fatalf("history = %v; want %v", got, want)
+	}
+	if d := ticket.History[1].Detail; d != "backlog → in_progress" {
+		t.Errorf("move detail = %q", d)
+	}
+	if d := ticket.History[3].Detail; d != "claude (completed)" {
+		t.Errorf("stop detail = %q", d)
+	}
+}
+
+func TestTicket_HistoryIsBounded(t *testing.T) {
+	ticket := NewTicket("Test", "project-1")
+	for i := 0; i < MaxHistoryEvents+10; i++ {
+		ticket.Record(EventEdited, "title")
+	}
+
+	if len(ticket.History) != MaxHistoryEvents {
+		t.Fatalf("len(History) = %d; want %d", len(ticket.History), MaxHistoryEvents)
+	}
+	if ticket.History[0].Kind != EventEdited {
+		t.Error("oldest events should be dropped first")
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	before := NewTicket("Test", "project-1")
+	after := *before
+	after.Title = "Renamed"
+	after.Labels = []string{"bug"}
+	after.Priority = 1
+
+	got := ChangedFields(before, &after)
+	want := []string{"title", "labels", "priority"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ChangedFields = %v; want %v", got, want)
+	}
+
+	if got := ChangedFields(before, before); len(got) != 0 {
+		t.Errorf("ChangedFields(same) = %v; want none", got)
+	}
+}
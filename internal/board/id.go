@@ -0,0 +1,143 @@
+package board
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ID schemes for new tickets, chosen with defaults.id_scheme.
+const (
+	// IDSchemeUUID makes random UUIDv4 IDs. It is the default.
+	IDSchemeUUID = "uuid"
+	// IDSchemeULID makes ULIDs, which sort by creation time.
+	IDSchemeULID = "ulid"
+	// IDSchemeSequential numbers tickets 1, 2, 3... across all boards.
+	IDSchemeSequential = "sequential"
+)
+
+// IDSchemes lists the ticket ID schemes, the default first.
+var IDSchemes = []string{IDSchemeUUID, IDSchemeULID, IDSchemeSequential}
+
+// ids is the state behind NewTicketID: the scheme, the highest sequential
+// ID seen, and the last ULID's time and randomness, which the next ULID in
+// the same millisecond increments so that IDs keep their order.
+var ids = struct {
+	sync.Mutex
+	scheme  string
+	seq     int
+	ulidMS  uint64
+	entropy [10]byte
+}{scheme: IDSchemeUUID}
+
+// SetIDScheme chooses how NewTicketID makes IDs. An empty scheme is UUID.
+// Tickets keep the IDs they have.
+func SetIDScheme(scheme string) error {
+	if scheme == "" {
+		scheme = IDSchemeUUID
+	}
+	switch scheme {
+	case IDSchemeUUID, IDSchemeULID, IDSchemeSequential:
+	default:
+		return fmt.Errorf("unknown ID scheme %q (want one of: %s)", scheme, strings.Join(IDSchemes, ", "))
+	}
+	ids.Lock()
+	ids.scheme = scheme
+	ids.Unlock()
+	return nil
+}
+
+// NoteTicketID tells the sequential scheme about an existing ticket, so the
+// next number is past it. Stores call it for every ticket they load or add.
+func NoteTicketID(id TicketID) {
+	n, ok := sequentialNumber(id)
+	if !ok {
+		return
+	}
+	ids.Lock()
+	ids.seq = max(ids.seq, n)
+	ids.Unlock()
+}
+
+// NewTicketID makes an ID for a new ticket in the chosen scheme.
+func NewTicketID() TicketID {
+	ids.Lock()
+	defer ids.Unlock()
+	switch ids.scheme {
+	case IDSchemeULID:
+		return TicketID(nextULID(time.Now()))
+	case IDSchemeSequential:
+		ids.seq++
+		return TicketID(strconv.Itoa(ids.seq))
+	}
+	return TicketID(uuid.New().String())
+}
+
+// CompareIDs orders IDs for display: numerically when both are sequential,
+// otherwise as strings, which for ULIDs is creation order.
+func CompareIDs(a, b TicketID) int {
+	na, aok := sequentialNumber(a)
+	nb, bok := sequentialNumber(b)
+	if aok && bok {
+		return na - nb
+	}
+	return strings.Compare(string(a), string(b))
+}
+
+func sequentialNumber(id TicketID) (int, bool) {
+	if id == "" || id[0] == '0' || id[0] == '+' {
+		return 0, false
+	}
+	n, err := strconv.Atoi(string(id))
+	return n, err == nil && n > 0
+}
+
+// crockford is the base32 alphabet ULIDs are written in.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// nextULID returns a 26 character ULID: 48 bits of Unix milliseconds, then
+// 80 random bits. Callers hold ids' lock.
+func nextULID(now time.Time) string {
+	ms := uint64(now.UnixMilli())
+	if ms == ids.ulidMS {
+		for i := len(ids.entropy) - 1; i >= 0; i-- {
+			ids.entropy[i]++
+			if ids.entropy[i] != 0 {
+				break
+			}
+		}
+	} else {
+		ids.ulidMS = ms
+		_, _ = rand.Read(ids.entropy[:])
+	}
+
+	hi := ms<<16 | uint64(ids.entropy[0])<<8 | uint64(ids.entropy[1])
+	var lo uint64
+	for _, b := range ids.entropy[2:] {
+		lo = lo<<8 | uint64(b)
+	}
+
+	// 128 bits in 26 characters of 5 bits: the first carries only 3.
+	var out [26]byte
+	for i := range out {
+		out[i] = crockford[shiftRight(hi, lo, uint(125-5*i))&31]
+	}
+	return string(out[:])
+}
+
+// shiftRight shifts the 128-bit number hi:lo right by s bits and returns
+// the low 64.
+func shiftRight(hi, lo uint64, s uint) uint64 {
+	switch {
+	case s >= 64:
+		return hi >> (s - 64)
+	case s == 0:
+		return lo
+	}
+	return lo>>s | hi<<(64-s)
+}
@@ -0,0 +1,69 @@
+package board
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// useIDScheme switches the ID scheme for one test.
+func useIDScheme(t *testing.T, scheme string) {
+	t.Helper()
+	if err := SetIDScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = SetIDScheme(IDSchemeUUID)
+		ids.seq = 0
+	})
+}
+
+func TestNewTicketID_ULID(t *testing.T) {
+	useIDScheme(t, IDSchemeULID)
+
+	var prev TicketID
+	for i := 0; i < 100; i++ {
+		id := NewTicketID()
+		if len(id) != 26 || strings.Trim(string(id), crockford) != "" {
+			t.Fatalf("%q is not a ULID", id)
+		}
+		if id <= prev {
+			t.Fatalf("%q sorts before the earlier %q", id, prev)
+		}
+		prev = id
+	}
+
+	// The time comes first, so IDs from different milliseconds order by it.
+	early := nextULID(time.UnixMilli(1_700_000_000_000))
+	if !strings.HasPrefix(early, "01HF") || early >= string(prev) {
+		t.Errorf("ULID for 2023 = %q; want it to start 01HF and sort before %q", early, prev)
+	}
+}
+
+func TestNewTicketID_Sequential(t *testing.T) {
+	useIDScheme(t, IDSchemeSequential)
+
+	NoteTicketID("41")
+	NoteTicketID("7")
+	NoteTicketID("01HF0000000000000000000000")
+	NoteTicketID("0f6c1c8e-2c4e-4d0c-9c57-5b8f1d0a8f1a")
+	if got := NewTicketID(); got != "42" {
+		t.Errorf("first ID = %q; want 42", got)
+	}
+	if got := NewTicketID(); got != "43" {
+		t.Errorf("second ID = %q; want 43", got)
+	}
+	if CompareIDs("9", "10") >= 0 {
+		t.Error("sequential IDs should compare as numbers")
+	}
+}
+
+func TestSetIDScheme(t *testing.T) {
+	useIDScheme(t, "")
+	if id := NewTicketID(); len(id) != 36 {
+		t.Errorf("default ID = %q; want a UUID", id)
+	}
+	if err := SetIDScheme("snowflake"); err == nil {
+		t.Error("SetIDScheme(snowflake): want an error")
+	}
+}
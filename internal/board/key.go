@@ -0,0 +1,79 @@
+package board
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ValidTicketKey reports whether key can name the board in ticket keys: a
+// letter, then letters or digits, like OK.
+func ValidTicketKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i, r := range key {
+		isLetter := r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z'
+		if !isLetter && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// TicketKey is how commit messages refer to a ticket: the board's key, a
+// dash and the ticket's ID, like OK-42.
+func TicketKey(key string, id TicketID) string {
+	return key + "-" + string(id)
+}
+
+// KeyRefs finds the tickets text refers to by key, in order and without
+// repeats. The key matches in any case. exists says whether a ticket has an
+// ID, so that "OK-42-fix" can be read as OK-42.
+func KeyRefs(text, key string, exists func(TicketID) bool) []TicketID {
+	if key == "" {
+		return nil
+	}
+	re := regexp.MustCompile(`(?i)(?:^|[^0-9A-Za-z])` + regexp.QuoteMeta(key) + `-([0-9A-Za-z][0-9A-Za-z-]*)`)
+
+	var refs []TicketID
+	seen := make(map[TicketID]bool)
+	for _, match := range re.FindAllStringSubmatch(text, -1) {
+		candidate := match[1]
+		for {
+			id := TicketID(candidate)
+			if exists(id) {
+				if !seen[id] {
+					seen[id] = true
+					refs = append(refs, id)
+				}
+				break
+			}
+			i := strings.LastIndex(candidate, "-")
+			if i <= 0 {
+				break
+			}
+			candidate = candidate[:i]
+		}
+	}
+	return refs
+}
+
+// LinkCommit records a commit that mentions the ticket in its history,
+// unless it is there already, and reports whether it was added.
+func (t *Ticket) LinkCommit(hash, subject, author string) bool {
+	short := hash
+	if len(short) > 7 {
+		short = short[:7]
+	}
+	for _, e := range t.History {
+		if e.Kind == EventCommit && strings.HasPrefix(e.Detail, short+" ") {
+			return false
+		}
+	}
+	detail := short + " " + subject
+	if author != "" {
+		detail += " (" + author + ")"
+	}
+	t.Record(EventCommit, detail)
+	return true
+}
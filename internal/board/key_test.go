@@ -0,0 +1,56 @@
+package board
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValidTicketKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"OK": true, "ok": true, "OK2": true,
+		"": false, "2OK": false, "O-K": false, "OK ": false,
+	} {
+		if got := ValidTicketKey(key); got != want {
+			t.Errorf("ValidTicketKey(%q) = %v; want %v", key, got, want)
+		}
+	}
+}
+
+func TestKeyRefs(t *testing.T) {
+	ids := map[TicketID]bool{"4": true, "42": true, "0f6c1c8e-2c4e": true}
+	exists := func(id TicketID) bool { return ids[id] }
+
+	tests := []struct {
+		text string
+		want []TicketID
+	}{
+		{"Fix login (OK-42)", []TicketID{"42"}},
+		{"ok-4, OK-42 and OK-4 again", []TicketID{"4", "42"}},
+		{"OK-42-fix the parser", []TicketID{"42"}},
+		{"OK-0f6c1c8e-2c4e: tidy", []TicketID{"0f6c1c8e-2c4e"}},
+		{"BOOK-42 and OK-7", nil},
+		{"no keys here", nil},
+	}
+	for _, tt := range tests {
+		if got := KeyRefs(tt.text, "OK", exists); !slices.Equal(got, tt.want) {
+			t.Errorf("KeyRefs(%q) = %v; want %v", tt.text, got, tt.want)
+		}
+	}
+	if got := KeyRefs("OK-42", "", exists); got != nil {
+		t.Errorf("KeyRefs with no key = %v; want none", got)
+	}
+}
+
+func TestLinkCommit(t *testing.T) {
+	ticket := NewTicket("Fix login", "p")
+	if !ticket.LinkCommit("abcdef1234567890", "Fix login (OK-42)", "Jane") {
+		t.Fatal("LinkCommit of a new commit = false")
+	}
+	if ticket.LinkCommit("abcdef1234567890", "Fix login (OK-42)", "Jane") {
+		t.Error("LinkCommit of a linked commit = true")
+	}
+	last := ticket.History[len(ticket.History)-1]
+	if last.Kind != EventCommit || last.Detail != "abcdef1 Fix login (OK-42) (Jane)" {
+		t.Errorf("last event = %+v", last)
+	}
+}
@@ -0,0 +1,82 @@
+package board
+
+import "sort"
+
+// OutcomeCounts tallies closed tickets by outcome.
+type OutcomeCounts struct {
+	Key       string
+	Shipped   int
+	Abandoned int
+	Rewrite   int
+}
+
+// Total returns the number of tickets with a recorded outcome.
+func (c OutcomeCounts) Total() int {
+	return c.Shipped + c.Abandoned + c.Rewrite
+}
+
+// ShipRate returns the fraction of recorded outcomes that shipped.
+func (c OutcomeCounts) ShipRate() float64 {
+	if c.Total() == 0 {
+		return 0
+	}
+	return float64(c.Shipped) / float64(c.Total())
+}
+
+func (c *OutcomeCounts) add(outcome TicketOutcome) {
+	switch outcome {
+	case OutcomeShipped:
+		c.Shipped++
+	case OutcomeAbandoned:
+		c.Abandoned++
+	case OutcomeRewrite:
+		c.Rewrite++
+	}
+}
+
+// SummarizeOutcomes groups tickets with a recorded outcome by label and by
+// agent type. Unlabeled tickets are grouped under "(none)"; a ticket with
+// several labels counts once per label.
+func SummarizeOutcomes(tickets []*Ticket) (byLabel, byAgent []OutcomeCounts) {
+	labels := make(map[string]*OutcomeCounts)
+	agents := make(map[string]*OutcomeCounts)
+
+	tally := func(m map[string]*OutcomeCounts, key string, outcome TicketOutcome) {
+		c, ok := m[key]
+		if !ok {
+			c = &OutcomeCounts{Key: key}
+			m[key] = c
+		}
+		c.add(outcome)
+	}
+
+	for _, t := range tickets {
+		if t.Outcome == OutcomeNone {
+			continue
+		}
+		if len(t.Labels) == 0 {
+			tally(labels, "(none)", t.Outcome)
+		}
+		for _, label := range t.Labels {
+			tally(labels, label, t.Outcome)
+		}
+		agentType := t.AgentType
+		if agentType == "" {
+			agentType = "(none)"
+		}
+		tally(agents, agentType, t.Outcome)
+	}
+
+	return sortedCounts(labels), sortedCounts(agents)
+}
+
+func sortedCounts(m map[string]*OutcomeCounts) []OutcomeCounts {
+	result := make([]OutcomeCounts, 0, len(m))
+	for _, c := range m {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
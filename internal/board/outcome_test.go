@@ -0,0 +1,46 @@
+package board
+
+import "testing"
+
+func TestSummarizeOutcomes(t *testing.T) {
+	newClosed := func(agentType string, outcome TicketOutcome, labels ...string) *Ticket {
+		ticket := NewTicket("t", "p")
+		ticket.AgentType = agentType
+		ticket.Outcome = outcome
+		ticket.Labels = labels
+		return ticket
+	}
+
+	tickets := []*Ticket{
+		newClosed("claude", OutcomeShipped, "frontend"),
+		newClosed("claude", OutcomeRewrite, "frontend", "bug"),
+		newClosed("aider", OutcomeAbandoned),
+		newClosed("aider", OutcomeNone, "frontend"),
+	}
+
+	byLabel, byAgent := SummarizeOutcomes(tickets)
+
+	wantLabels := map[string]OutcomeCounts{
+		"(none)":   {Key: "(none)", Abandoned: 1},
+		"bug":      {Key: "bug", Rewrite: 1},
+		"frontend": {Key: "frontend", Shipped: 1, Rewrite: 1},
+	}
+	if len(byLabel) != len(wantLabels) {
+		t.Fatalf("len(byLabel) = %d; want %d", len(byLabel), len(wantLabels))
+	}
+	for _, got := range byLabel {
+		if want := wantLabels[got.Key]; got != want {
+			t.Errorf("byLabel[%q] = %+v; want %+v", got.Key, got, want)
+		}
+	}
+
+	if len(byAgent) != 2 || byAgent[0].Key != "aider" || byAgent[1].Key != "claude" {
+		t.Fatalf("byAgent = %+v; want sorted aider, claude", byAgent)
+	}
+	if byAgent[1].Total() != 2 || byAgent[1].ShipRate() != 0.5 {
+		t.Errorf("claude = %+v; want 2 total, 50%% shipped", byAgent[1])
+	}
+	if byAgent[0].Total() != 1 {
+		t.Errorf("aider total = %d; want 1 (tickets without outcome excluded)", byAgent[0].Total())
+	}
+}
@@ -0,0 +1,32 @@
+package board
+
+// Progress rolls up the status of a group of tickets, such as an epic's
+// children or a sprint.
+type Progress struct {
+	Done       int
+	InProgress int
+	Total      int
+}
+
+// Fraction returns the share of tickets that are done, from 0 to 1.
+func (p Progress) Fraction() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Done) / float64(p.Total)
+}
+
+// SummarizeProgress counts tickets by status.
+func SummarizeProgress(tickets []*Ticket) Progress {
+	var p Progress
+	for _, t := range tickets {
+		p.Total++
+		switch t.Status {
+		case StatusDone:
+			p.Done++
+		case StatusInProgress:
+			p.InProgress++
+		}
+	}
+	return p
+}
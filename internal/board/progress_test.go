@@ -0,0 +1,25 @@
+package board
+
+import "testing"
+
+func TestSummarizeProgress(t *testing.T) {
+	a := NewTicket("A", "p")
+	b := NewTicket("B", "p")
+	b.SetStatus(StatusInProgress)
+	c := NewTicket("C", "p")
+	c.SetStatus(StatusDone)
+	d := NewTicket("D", "p")
+	d.SetStatus(StatusDone)
+
+	p := SummarizeProgress([]*Ticket{a, b, c, d})
+	if p.Total != 4 || p.Done != 2 || p.InProgress != 1 {
+		t.Errorf("SummarizeProgress = %+v; want 2 done, 1 in progress of 4", p)
+	}
+	if p.Fraction() != 0.5 {
+		t.Errorf("Fraction() = %v; want 0.5", p.Fraction())
+	}
+
+	if f := SummarizeProgress(nil).Fraction(); f != 0 {
+		t.Errorf("empty Fraction() = %v; want 0", f)
+	}
+}
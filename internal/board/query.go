@@ -0,0 +1,104 @@
+package board
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// queryFields are the keys a query term may narrow by.
+var queryFields = []string{"label", "status", "assignee", "priority", "project"}
+
+// QueryTerm is one condition of a Query: a field and the values any of which
+// it may match, or free text when Field is empty.
+type QueryTerm struct {
+	Field  string
+	Values []string
+	Negate bool
+}
+
+// Query selects tickets with conditions such as
+// "label:client-x status:in_progress,done -assignee:bob login". Every term
+// must hold; comma-separated values in a term are alternatives, and a
+// leading "-" excludes matching tickets. Bare words match the title or
+// description. Matching ignores case.
+type Query struct {
+	Terms []QueryTerm
+}
+
+// ParseQuery parses a query string; an empty one matches every ticket.
+func ParseQuery(s string) (Query, error) {
+	var q Query
+	for _, word := range strings.Fields(s) {
+		term := QueryTerm{}
+		if strings.HasPrefix(word, "-") && len(word) > 1 {
+			term.Negate = true
+			word = word[1:]
+		}
+		field, value, ok := strings.Cut(word, ":")
+		if !ok {
+			term.Values = []string{strings.ToLower(word)}
+			q.Terms = append(q.Terms, term)
+			continue
+		}
+
+		field = strings.ToLower(field)
+		if !slices.Contains(queryFields, field) {
+			return Query{}, fmt.Errorf("unknown query field %q (use %s)", field, strings.Join(queryFields, ", "))
+		}
+		term.Field = field
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.ToLower(strings.TrimSpace(v)); v == "" {
+				continue
+			}
+			if field == "priority" {
+				if _, err := strconv.Atoi(v); err != nil {
+					return Query{}, fmt.Errorf("priority must be a number, got %q", v)
+				}
+			}
+			term.Values = append(term.Values, v)
+		}
+		if len(term.Values) == 0 {
+			return Query{}, fmt.Errorf("%s: needs a value", field)
+		}
+		q.Terms = append(q.Terms, term)
+	}
+	return q, nil
+}
+
+// Matches reports whether ticket, which belongs to the named project,
+// satisfies every term of the query.
+func (q Query) Matches(ticket *Ticket, projectName string) bool {
+	for _, term := range q.Terms {
+		if term.matches(ticket, projectName) == term.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (t QueryTerm) matches(ticket *Ticket, projectName string) bool {
+	return slices.ContainsFunc(t.Values, func(v string) bool {
+		switch t.Field {
+		case "label":
+			return slices.ContainsFunc(ticket.Labels, func(l string) bool { return strings.EqualFold(l, v) })
+		case "status":
+			return normalizeStatus(string(ticket.Status)) == normalizeStatus(v)
+		case "assignee":
+			return strings.EqualFold(ticket.Assignee, v)
+		case "priority":
+			return strconv.Itoa(ticket.Priority) == v
+		case "project":
+			return strings.Contains(strings.ToLower(projectName), v)
+		default:
+			return strings.Contains(strings.ToLower(ticket.Title), v) ||
+				strings.Contains(strings.ToLower(ticket.Description), v)
+		}
+	})
+}
+
+// normalizeStatus lets "in-progress" and "In_Progress" name the same status.
+func normalizeStatus(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), "-", "_")
+}
@@ -0,0 +1,60 @@
+package board
+
+import "testing"
+
+func TestQuery_Matches(t *testing.T) {
+	a := NewTicket("Fix login redirect", "p")
+	a.Labels = []string{"client-x", "bug"}
+	a.Status = StatusInProgress
+	a.Assignee = "alice"
+	a.Priority = 1
+	b := NewTicket("Invoice export", "p")
+	b.Labels = []string{"Client-Y"}
+	b.Description = "CSV for the LOGIN audit"
+	b.Priority = 3
+	c := NewTicket("Onboarding docs", "p")
+	c.Status = StatusDone
+	c.Assignee = "bob"
+	c.Priority = 2
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "ABC"},
+		{"label:client-x", "A"},
+		{"label:client-y,bug", "AB"},
+		{"-label:client-x", "BC"},
+		{"login", "AB"},
+		{"login -label:bug", "B"},
+		{"status:in-progress", "A"},
+		{"status:backlog,done", "BC"},
+		{"assignee:Bob", "C"},
+		{"priority:1,3", "AB"},
+		{"project:app", "ABC"},
+		{"project:web", ""},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q): %v", tt.query, err)
+		}
+		got := ""
+		for i, tk := range []*Ticket{a, b, c} {
+			if q.Matches(tk, "myapp") {
+				got += string(rune('A' + i))
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%q matched %q; want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	for _, s := range []string{"colour:red", "label:", "priority:high"} {
+		if _, err := ParseQuery(s); err == nil {
+			t.Errorf("ParseQuery(%q) succeeded; want an error", s)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package board
+
+import "sort"
+
+// SortMode orders the tickets within a column.
+type SortMode string
+
+const (
+	SortManual      SortMode = "manual"       // Position, then oldest first
+	SortPriority    SortMode = "priority"     // Highest priority first
+	SortUpdated     SortMode = "updated"      // Most recently updated first
+	SortCreated     SortMode = "created"      // Newest first
+	SortAgentStatus SortMode = "agent_status" // Agents needing attention first
+)
+
+// SortModes lists the modes in the order the board cycles through them.
+var SortModes = []SortMode{SortManual, SortPriority, SortUpdated, SortCreated, SortAgentStatus}
+
+// Next returns the mode after m, wrapping around. The empty mode is manual.
+func (m SortMode) Next() SortMode {
+	if m == "" {
+		m = SortManual
+	}
+	for i, mode := range SortModes {
+		if mode == m {
+			return SortModes[(i+1)%len(SortModes)]
+		}
+	}
+	return SortModes[0]
+}
+
+// agentStatusRank puts agents that need a human ahead of busy ones, and
+// tickets without an agent last.
+var agentStatusRank = map[AgentStatus]int{
+	AgentWaiting:   0,
+	AgentError:     1,
+	AgentWorking:   2,
+	AgentIdle:      3,
+	AgentCompleted: 4,
+	AgentNone:      5,
+}
+
+// SortTickets orders tickets in place. Ties, and unknown modes, fall back to
+// the manual order so the result is stable.
+func SortTickets(tickets []*Ticket, mode SortMode) {
+	sort.SliceStable(tickets, func(i, j int) bool {
+		a, b := tickets[i], tickets[j]
+		switch mode {
+		case SortPriority:
+			if pa, pb := priorityRank(a), priorityRank(b); pa != pb {
+				return pa < pb
+			}
+		case SortUpdated:
+			if !a.UpdatedAt.Equal(b.UpdatedAt) {
+				return a.UpdatedAt.After(b.UpdatedAt)
+			}
+		case SortCreated:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		case SortAgentStatus:
+			if ra, rb := statusRank(a), statusRank(b); ra != rb {
+				return ra < rb
+			}
+		}
+		return manualLess(a, b)
+	})
+}
+
+// manualLess orders by Position; tickets never placed by hand (position 0)
+// follow, oldest first.
+func manualLess(a, b *Ticket) bool {
+	if a.Position != b.Position {
+		if a.Position == 0 || b.Position == 0 {
+			return b.Position == 0
+		}
+		return a.Position < b.Position
+	}
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return CompareIDs(a.ID, b.ID) < 0
+}
+
+// priorityRank sorts unset priorities after the lowest.
+func priorityRank(t *Ticket) int {
+	if t.Priority <= 0 {
+		return 6
+	}
+	return t.Priority
+}
+
+func statusRank(t *Ticket) int {
+	if rank, ok := agentStatusRank[t.AgentStatus]; ok {
+		return rank
+	}
+	return len(agentStatusRank)
+}
@@ -0,0 +1,57 @@
+package board
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSortTickets(t *testing.T) {
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	newTicket := func(title string, created int) *Ticket {
+		tk := NewTicket(title, "p")
+		tk.CreatedAt = base.Add(time.Duration(created) * time.Hour)
+		tk.UpdatedAt = tk.CreatedAt
+		return tk
+	}
+
+	a := newTicket("A", 0)
+	b := newTicket("B", 1)
+	c := newTicket("C", 2)
+	d := newTicket("D", 3)
+	a.Priority, b.Priority, c.Priority, d.Priority = 3, 1, 0, 1
+	c.UpdatedAt = base.Add(10 * time.Hour)
+	b.AgentStatus, d.AgentStatus = AgentWorking, AgentWaiting
+	c.Position, a.Position = 1, 2
+
+	tests := []struct {
+		mode SortMode
+		want string
+	}{
+		{SortManual, "CABD"},
+		{SortPriority, "BDAC"},
+		{SortUpdated, "CDBA"},
+		{SortCreated, "DCBA"},
+		{SortAgentStatus, "DBCA"},
+		{"bogus", "CABD"},
+	}
+	for _, tt := range tests {
+		tickets := []*Ticket{d, b, a, c}
+		SortTickets(tickets, tt.mode)
+		got := ""
+		for _, tk := range tickets {
+			got += tk.Title
+		}
+		if got != tt.want {
+			t.Errorf("SortTickets(%s) = %s; want %s", tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestSortMode_Next(t *testing.T) {
+	if got := SortAgentStatus.Next(); got != SortManual {
+		t.Errorf("SortAgentStatus.Next() = %s; want manual", got)
+	}
+	if got := SortMode("").Next(); got != SortPriority {
+		t.Errorf("empty Next() = %s; want priority", got)
+	}
+}
@@ -0,0 +1,53 @@
+package board
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultSprintLength is used when a sprint is created without an end date.
+const DefaultSprintLength = 14 * 24 * time.Hour
+
+// Sprint is a timebox that tickets can be assigned to. Start and End are
+// dates; a sprint covers both days in full.
+type Sprint struct {
+	ID    string    `json:"id"`
+	Name  string    `json:"name"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+func NewSprint(name string, start, end time.Time) *Sprint {
+	return &Sprint{
+		ID:    uuid.New().String(),
+		Name:  name,
+		Start: truncateDay(start),
+		End:   truncateDay(end),
+	}
+}
+
+// Active reports whether now falls within the sprint.
+func (s *Sprint) Active(now time.Time) bool {
+	day := truncateDay(now)
+	return !day.Before(s.Start) && !day.After(s.End)
+}
+
+// DaysLeft returns the number of days remaining including today, or zero
+// once the sprint has ended.
+func (s *Sprint) DaysLeft(now time.Time) int {
+	day := truncateDay(now)
+	if day.After(s.End) {
+		return 0
+	}
+	if day.Before(s.Start) {
+		day = s.Start
+	}
+	// Round so a DST change inside the sprint doesn't lose a day.
+	return int(s.End.Sub(day).Round(24*time.Hour).Hours()/24) + 1
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
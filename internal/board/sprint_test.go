@@ -0,0 +1,35 @@
+package board
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSprint_ActiveAndDaysLeft(t *testing.T) {
+	start := time.Date(2026, 3, 2, 15, 0, 0, 0, time.Local)
+	end := time.Date(2026, 3, 13, 9, 0, 0, 0, time.Local)
+	s := NewSprint("Sprint 1", start, end)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		active   bool
+		daysLeft int
+	}{
+		{"before start", time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local), false, 12},
+		{"first day", time.Date(2026, 3, 2, 8, 0, 0, 0, time.Local), true, 12},
+		{"last day", time.Date(2026, 3, 13, 23, 0, 0, 0, time.Local), true, 1},
+		{"after end", time.Date(2026, 3, 14, 0, 0, 0, 0, time.Local), false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Active(tt.now); got != tt.active {
+				t.Errorf("Active() = %v; want %v", got, tt.active)
+			}
+			if got := s.DaysLeft(tt.now); got != tt.daysLeft {
+				t.Errorf("DaysLeft() = %d; want %d", got, tt.daysLeft)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TicketText is the part of a ticket that can be edited as a file: the
+// title and labels in a front matter header, the description below it.
+type TicketText struct {
+	Title       string
+	Labels      []string
+	Description string
+}
+
+const textFence = "---"
+
+// FormatText writes a ticket's title, labels and description as markdown
+// with a YAML front matter header, for editing in a text editor.
+func FormatText(t *Ticket) string {
+	var b strings.Builder
+	b.WriteString(textFence + "\n")
+	b.WriteString("# Save and quit to update the ticket. Clear the title to cancel.\n")
+	fmt.Fprintf(&b, "title: %s\n", t.Title)
+	fmt.Fprintf(&b, "labels: %s\n", strings.Join(t.Labels, ", "))
+	b.WriteString(textFence + "\n\n")
+	if t.Description != "" {
+		b.WriteString(t.Description + "\n")
+	}
+	return b.String()
+}
+
+// ParseText reads a file written by FormatText back. Comment lines in the
+// header are ignored; the description is everything after it.
+func ParseText(s string) (TicketText, error) {
+	s = strings.TrimPrefix(s, "\ufeff")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	lines := strings.Split(s, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != textFence {
+		return TicketText{}, fmt.Errorf("missing %s header", textFence)
+	}
+
+	var text TicketText
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == textFence {
+			text.Description = strings.TrimSpace(strings.Join(lines[i+1:], "\n"))
+			return text, nil
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return TicketText{}, fmt.Errorf("line %d: expected key: value", i+1)
+		}
+		value = unquote(strings.TrimSpace(value))
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "title":
+			text.Title = value
+		case "labels":
+			text.Labels = splitLabels(value)
+		default:
+			return TicketText{}, fmt.Errorf("line %d: unknown field %q", i+1, strings.TrimSpace(key))
+		}
+	}
+	return TicketText{}, fmt.Errorf("header is not closed with %s", textFence)
+}
+
+// splitLabels reads a comma-separated list, or a YAML flow list like
+// [bug, ui].
+func splitLabels(s string) []string {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	var labels []string
+	for _, p := range strings.Split(s, ",") {
+		if label := unquote(strings.TrimSpace(p)); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
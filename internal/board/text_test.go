@@ -0,0 +1,54 @@
+package board
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	ticket := NewTicket("Fix login: redirect loop", "p")
+	ticket.Labels = []string{"bug", "auth"}
+	ticket.Description = "# Steps\n\n1. Log in\n---\n2. Loop"
+
+	got, err := ParseText(FormatText(ticket))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != ticket.Title || got.Description != ticket.Description || !slices.Equal(got.Labels, ticket.Labels) {
+		t.Errorf("ParseText(FormatText) = %+v; want the ticket's fields", got)
+	}
+}
+
+func TestParseText(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    TicketText
+		wantErr bool
+	}{
+		{
+			name:  "yaml list and quotes",
+			input: "---\ntitle: \"Quoted\"\nlabels: [ui, 'docs']\n---\nBody\n",
+			want:  TicketText{Title: "Quoted", Labels: []string{"ui", "docs"}, Description: "Body"},
+		},
+		{
+			name:  "cleared title",
+			input: "---\ntitle:\nlabels:\n---\n",
+			want:  TicketText{},
+		},
+		{name: "no header", input: "title: x\n", wantErr: true},
+		{name: "unclosed header", input: "---\ntitle: x\n", wantErr: true},
+		{name: "unknown field", input: "---\nstatus: done\n---\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseText(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseText() error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if got.Title != tt.want.Title || got.Description != tt.want.Description || !slices.Equal(got.Labels, tt.want.Labels) {
+				t.Errorf("ParseText() = %+v; want %+v", got, tt.want)
+			}
+		})
+	}
+}
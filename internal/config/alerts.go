@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Alert policies: which events ring the bell or flash the screen.
+const (
+	AlertsNever   = "never"
+	AlertsErrors  = "errors"  // agent errors and failed starts
+	AlertsWaiting = "waiting" // errors, and agents waiting for input
+	AlertsAll     = "all"     // errors, waiting agents, and agents done
+)
+
+// AlertPolicies are the accepted values of ui.alerts.policy.
+var AlertPolicies = []string{AlertsNever, AlertsErrors, AlertsWaiting, AlertsAll}
+
+// Alert events, from most to least urgent.
+const (
+	AlertError = iota
+	AlertWaiting
+	AlertDone
+)
+
+// AlertSettings chooses which agent events ring the terminal bell or flash
+// the header, on top of the toast each of them shows.
+type AlertSettings struct {
+	Policy     string `json:"policy"`                // never | errors | waiting | all
+	Bell       bool   `json:"bell"`                  // Ring the terminal bell
+	Flash      bool   `json:"flash"`                 // Flash the header in the event's color
+	QuietHours string `json:"quiet_hours,omitempty"` // "22:00-07:00": no alerts between these local times
+}
+
+// Allows reports whether the policy alerts for event at now.
+func (a AlertSettings) Allows(event int, now time.Time) bool {
+	if !a.Bell && !a.Flash {
+		return false
+	}
+	var worst int
+	switch a.Policy {
+	case AlertsErrors:
+		worst = AlertError
+	case AlertsWaiting:
+		worst = AlertWaiting
+	case AlertsAll:
+		worst = AlertDone
+	default:
+		return false
+	}
+	if event > worst {
+		return false
+	}
+	quiet, _ := InQuietHours(a.QuietHours, now)
+	return !quiet
+}
+
+// InQuietHours reports whether now falls within a "HH:MM-HH:MM" window of
+// local time, which may wrap past midnight. An empty window never does.
+func InQuietHours(window string, now time.Time) (bool, error) {
+	if window == "" {
+		return false, nil
+	}
+	startText, endText, ok := strings.Cut(window, "-")
+	if !ok {
+		return false, fmt.Errorf("%q is not a HH:MM-HH:MM range", window)
+	}
+	start, err := parseClock(startText)
+	if err != nil {
+		return false, err
+	}
+	end, err := parseClock(endText)
+	if err != nil {
+		return false, err
+	}
+
+	minute := now.Hour()*60 + now.Minute()
+	if start <= end {
+		return minute >= start && minute < end, nil
+	}
+	return minute >= start || minute < end, nil
+}
+
+// parseClock reads a HH:MM time of day as minutes since midnight.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// validateAlerts validates the alert policy and quiet hours
+func (c *Config) validateAlerts(r *ValidationResult) {
+	a := c.UI.Alerts
+	if !slices.Contains(AlertPolicies, a.Policy) {
+		r.AddError("ui.alerts", "policy",
+			fmt.Sprintf("must be one of: %s", strings.Join(AlertPolicies, ", ")),
+			a.Policy)
+	}
+	if _, err := InQuietHours(a.QuietHours, time.Now()); err != nil {
+		r.AddError("ui.alerts", "quiet_hours", err.Error(), a.QuietHours)
+	}
+}
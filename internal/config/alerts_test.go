@@ -0,0 +1,87 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAlertSettingsAllows(t *testing.T) {
+	noon := time.Date(2026, 3, 2, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		policy string
+		want   [3]bool // error, waiting, done
+	}{
+		{AlertsNever, [3]bool{false, false, false}},
+		{AlertsErrors, [3]bool{true, false, false}},
+		{AlertsWaiting, [3]bool{true, true, false}},
+		{AlertsAll, [3]bool{true, true, true}},
+	}
+	for _, tt := range tests {
+		a := AlertSettings{Policy: tt.policy, Bell: true}
+		for event, want := range tt.want {
+			if got := a.Allows(event, noon); got != want {
+				t.Errorf("policy %s: Allows(%d) = %v, want %v", tt.policy, event, got, want)
+			}
+		}
+	}
+
+	a := AlertSettings{Policy: AlertsAll}
+	if a.Allows(AlertError, noon) {
+		t.Error("Allows() with neither bell nor flash = true, want false")
+	}
+	a.Flash = true
+	a.QuietHours = "11:30-13:00"
+	if a.Allows(AlertError, noon) {
+		t.Error("Allows() in quiet hours = true, want false")
+	}
+}
+
+func TestInQuietHours(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 2, hour, minute, 0, 0, time.Local)
+	}
+	tests := []struct {
+		window string
+		now    time.Time
+		want   bool
+	}{
+		{"", at(23, 0), false},
+		{"09:00-17:00", at(9, 0), true},
+		{"09:00-17:00", at(17, 0), false},
+		{"22:00-07:00", at(23, 30), true},
+		{"22:00-07:00", at(6, 59), true},
+		{"22:00-07:00", at(7, 0), false},
+		{"22:00-07:00", at(12, 0), false},
+	}
+	for _, tt := range tests {
+		got, err := InQuietHours(tt.window, tt.now)
+		if err != nil || got != tt.want {
+			t.Errorf("InQuietHours(%q, %s) = %v, %v; want %v", tt.window, tt.now.Format("15:04"), got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"22:00", "10pm-7am", "25:00-07:00"} {
+		if _, err := InQuietHours(bad, at(0, 0)); err == nil {
+			t.Errorf("InQuietHours(%q): want an error", bad)
+		}
+	}
+}
+
+func TestValidate_Alerts(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.UI.Alerts = AlertSettings{Policy: AlertsWaiting, Bell: true, QuietHours: "22:00-07:00"}
+	if result := cfg.Validate(); result.HasErrors() {
+		t.Errorf("alerts: unexpected errors %v", result.Errors)
+	}
+
+	cfg.UI.Alerts = AlertSettings{Policy: "loud", QuietHours: "night"}
+	fields := map[string]bool{}
+	for _, e := range cfg.Validate().Errors {
+		if e.Section == "ui.alerts" {
+			fields[e.Field] = true
+		}
+	}
+	if !fields["policy"] || !fields["quiet_hours"] {
+		t.Errorf("expected errors for ui.alerts policy and quiet_hours, got %v", fields)
+	}
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CardSettings lays out the face of ticket cards
+type CardSettings struct {
+	// Layout lists the card's lines top to bottom. Each is a template of
+	// {element} placeholders and the text between them; see CardElements.
+	Layout []string `json:"layout"`
+}
+
+// CardElements are the placeholders a card layout line can use.
+var CardElements = []string{
+	"title", "priority", "project", "deps", "session", "epic", "description",
+	"agent", "status", "assignee", "message", "labels", "fields", "cost",
+	"branch", "age", "stage",
+}
+
+// DefaultCardLayout is the built-in card face.
+var DefaultCardLayout = []string{
+	"{priority}  {project}  {deps}  {session}",
+	"{title}",
+	"{epic}",
+	"{description}",
+	"{agent} {stage} {status} {assignee}",
+	"{message}",
+	"{labels}",
+	"{fields}",
+}
+
+// CardLine is a parsed card layout line. Text before the first element and
+// after the last is kept whenever the line shows anything; text between two
+// elements only separates them when both are shown.
+type CardLine struct {
+	Lead     string
+	Elements []CardElement
+	Trail    string
+}
+
+// CardElement is a placeholder in a card line and the text before it.
+type CardElement struct {
+	Sep  string
+	Name string
+}
+
+// ParseCardLine parses a card layout line such as "{assignee} · {cost}".
+func ParseCardLine(line string) (CardLine, error) {
+	var parsed CardLine
+	text := line
+	for {
+		open := strings.Index(text, "{")
+		if open < 0 {
+			break
+		}
+		end := strings.Index(text[open:], "}")
+		if end < 0 {
+			return CardLine{}, fmt.Errorf("unclosed { in %q", line)
+		}
+		name := text[open+1 : open+end]
+		if !slices.Contains(CardElements, name) {
+			return CardLine{}, fmt.Errorf("unknown element {%s} in %q", name, line)
+		}
+		if len(parsed.Elements) == 0 {
+			parsed.Lead = text[:open]
+			parsed.Elements = append(parsed.Elements, CardElement{Name: name})
+		} else {
+			parsed.Elements = append(parsed.Elements, CardElement{Sep: text[:open], Name: name})
+		}
+		text = text[open+end+1:]
+	}
+	if strings.Contains(text, "}") {
+		return CardLine{}, fmt.Errorf("unmatched } in %q", line)
+	}
+	if len(parsed.Elements) == 0 {
+		return CardLine{}, fmt.Errorf("no {element} in %q", line)
+	}
+	parsed.Trail = text
+	return parsed, nil
+}
+
+// Lines parses the card layout, falling back to the default layout if it is
+// empty or invalid.
+func (c CardSettings) Lines() []CardLine {
+	layout := c.Layout
+	if len(layout) == 0 {
+		layout = DefaultCardLayout
+	}
+	var lines []CardLine
+	for _, l := range layout {
+		parsed, err := ParseCardLine(l)
+		if err != nil {
+			return CardSettings{Layout: DefaultCardLayout}.Lines()
+		}
+		lines = append(lines, parsed)
+	}
+	return lines
+}
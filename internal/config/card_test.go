@@ -0,0 +1,43 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCardLine(t *testing.T) {
+	got, err := ParseCardLine("[{priority}] {assignee} · {cost}!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := CardLine{
+		Lead: "[",
+		Elements: []CardElement{
+			{Name: "priority"},
+			{Sep: "] ", Name: "assignee"},
+			{Sep: " · ", Name: "cost"},
+		},
+		Trail: "!",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCardLine() = %+v, want %+v", got, want)
+	}
+
+	for _, line := range []string{"{title", "{owner}", "{title}}", "just text", ""} {
+		if _, err := ParseCardLine(line); err == nil {
+			t.Errorf("ParseCardLine(%q) should fail", line)
+		}
+	}
+}
+
+func TestCardSettings_Lines(t *testing.T) {
+	if n := len((CardSettings{}).Lines()); n != len(DefaultCardLayout) {
+		t.Errorf("empty layout gave %d lines, want the default %d", n, len(DefaultCardLayout))
+	}
+	if n := len((CardSettings{Layout: []string{"{title}", "{nope}"}}).Lines()); n != len(DefaultCardLayout) {
+		t.Errorf("invalid layout gave %d lines, want the default %d", n, len(DefaultCardLayout))
+	}
+	if n := len((CardSettings{Layout: []string{"{priority} {title}"}}).Lines()); n != 1 {
+		t.Errorf("one-line layout gave %d lines", n)
+	}
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// ColumnLayout overrides how a board column is titled, colored, sized,
+// sorted and grouped, and which agents it spawns.
+// Columns without an entry keep their defaults and share the board width
+// equally.
+type ColumnLayout struct {
+	Name   string `json:"name,omitempty"`   // Display name (default: built-in name)
+	Color  string `json:"color,omitempty"`  // Header color as #rgb or #rrggbb (default: theme)
+	Weight int    `json:"weight,omitempty"` // Relative share of the flexible width (default: 1)
+	Width  int    `json:"width,omitempty"`  // Fixed width in cells; takes precedence over weight
+	Pinned bool   `json:"pinned,omitempty"` // Keep visible when the board scrolls horizontally
+	Sort   string `json:"sort,omitempty"`   // manual | priority | updated | created | agent_status
+	Agent  string `json:"agent,omitempty"`  // Agent spawned from this column, overriding the ticket's
+	Group  string `json:"group,omitempty"`  // "label" to cluster tickets under their first label
+
+	// Protected asks for confirmation before a ticket moves into the column.
+	Protected bool `json:"protected,omitempty"`
+	// Gate is a shell command that must succeed, such as a CI check, before
+	// a ticket moves into the column.
+	Gate string `json:"gate,omitempty"`
+	// OnAgentDone is the ID of the column tickets move to when their agent
+	// completes while they are in this one.
+	OnAgentDone string `json:"on_agent_done,omitempty"`
+	// Reviewer is an agent spawned in a fresh session of its own whenever
+	// a ticket enters the column, with ReviewPrompt as its init prompt
+	// template (a built-in review prompt by default).
+	Reviewer     string `json:"reviewer,omitempty"`
+	ReviewPrompt string `json:"review_prompt,omitempty"`
+}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// IsHexColor reports whether s is a #rgb or #rrggbb color.
+func IsHexColor(s string) bool {
+	return hexColorPattern.MatchString(s)
+}
+
+// BoardColumns applies the configured names and order to the built-in
+// columns plus any extra ones.
+func (c *Config) BoardColumns() []board.Column {
+	defaults := board.DefaultColumns()
+	for _, id := range c.Defaults.ExtraColumns {
+		defaults = append(defaults, board.Column{ID: id, Name: id, Status: board.TicketStatus(id)})
+	}
+	byID := make(map[string]board.Column, len(defaults))
+	ids := make([]string, len(defaults))
+	for i, col := range defaults {
+		byID[col.ID] = col
+		ids[i] = col.ID
+	}
+
+	var columns []board.Column
+	for _, id := range c.OrderColumns(ids) {
+		col := byID[id]
+		if name := c.ColumnLayout(id).Name; name != "" {
+			col.Name = name
+		}
+		columns = append(columns, col)
+	}
+	return columns
+}
+
+// GroupByLabel is the column grouping that clusters tickets under their
+// first label.
+const GroupByLabel = "label"
+
+// ColumnGroup is the configured grouping for a column, "" for none.
+func (c *Config) ColumnGroup(columnID string) string {
+	return c.ColumnLayout(columnID).Group
+}
+
+// ColumnSort is the configured sort mode for a column, manual by default.
+func (c *Config) ColumnSort(columnID string) board.SortMode {
+	if mode := board.SortMode(c.ColumnLayout(columnID).Sort); mode != "" {
+		return mode
+	}
+	return board.SortManual
+}
+
+// ColumnLayout returns the layout override for a column ID, if any.
+func (c *Config) ColumnLayout(columnID string) ColumnLayout {
+	return c.Defaults.Columns[columnID]
+}
+
+// SetColumnLayout stores the override for a column ID, removing the entry
+// when it no longer overrides anything.
+func (c *Config) SetColumnLayout(columnID string, l ColumnLayout) {
+	if l == (ColumnLayout{}) {
+		delete(c.Defaults.Columns, columnID)
+		return
+	}
+	if c.Defaults.Columns == nil {
+		c.Defaults.Columns = make(map[string]ColumnLayout)
+	}
+	c.Defaults.Columns[columnID] = l
+}
+
+// OrderColumns sorts column IDs by defaults.column_order. IDs missing from
+// the order keep their relative position after the listed ones; unknown IDs
+// in the order are ignored.
+func (c *Config) OrderColumns(ids []string) []string {
+	ordered := make([]string, 0, len(ids))
+	for _, id := range c.Defaults.ColumnOrder {
+		if slices.Contains(ids, id) && !slices.Contains(ordered, id) {
+			ordered = append(ordered, id)
+		}
+	}
+	for _, id := range ids {
+		if !slices.Contains(ordered, id) {
+			ordered = append(ordered, id)
+		}
+	}
+	return ordered
+}
+
+// reservedColumnIDs are the built-in column IDs and ticket statuses, which
+// extra columns can't reuse.
+var reservedColumnIDs = []string{"backlog", "in-progress", "in_progress", "done", "archived"}
+
+var columnIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ColumnID derives an extra column's ID from its name: "Code Review"
+// becomes "code-review".
+func ColumnID(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// checkColumnID reports why id can't name an extra column, if it can't.
+func (c *Config) checkColumnID(id string) error {
+	switch {
+	case !columnIDPattern.MatchString(id):
+		return fmt.Errorf("invalid column ID %q", id)
+	case slices.Contains(reservedColumnIDs, id):
+		return fmt.Errorf("%q is a built-in column", id)
+	}
+	return nil
+}
+
+// AddColumn appends an extra column with the given ID.
+func (c *Config) AddColumn(id string) error {
+	if err := c.checkColumnID(id); err != nil {
+		return err
+	}
+	if slices.Contains(c.Defaults.ExtraColumns, id) {
+		return fmt.Errorf("column %q already exists", id)
+	}
+	c.Defaults.ExtraColumns = append(c.Defaults.ExtraColumns, id)
+	return nil
+}
+
+// RemoveColumn drops an extra column along with its layout and order
+// entries, and stops other columns moving tickets to it. Built-in columns
+// can't be removed.
+func (c *Config) RemoveColumn(id string) bool {
+	i := slices.Index(c.Defaults.ExtraColumns, id)
+	if i < 0 {
+		return false
+	}
+	c.Defaults.ExtraColumns = slices.Delete(c.Defaults.ExtraColumns, i, i+1)
+	c.Defaults.ColumnOrder = slices.DeleteFunc(c.Defaults.ColumnOrder, func(o string) bool { return o == id })
+	delete(c.Defaults.Columns, id)
+	for other, l := range c.Defaults.Columns {
+		if l.OnAgentDone == id {
+			l.OnAgentDone = ""
+			c.SetColumnLayout(other, l)
+		}
+	}
+	return true
+}
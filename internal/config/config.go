@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
 const defaultGlobalPrompt = `You have been spawned by OpenKanban to work on a ticket.
@@ -20,6 +23,19 @@ const defaultGlobalPrompt = `You have been spawned by OpenKanban to work on a ti
 
 Focus on completing this ticket. Ask clarifying questions if the description is unclear.`
 
+// defaultReviewPrompt is the init prompt of reviewer agents spawned as
+// tickets enter their column.
+const defaultReviewPrompt = `You have been spawned by OpenKanban to review the work done on a ticket.
+
+**Title:** {{.Title}}
+
+**Description:**
+{{.Description}}
+
+**Branch:** {{.BranchName}} (from {{.BaseBranch}})
+
+Review the changes on this branch against {{.BaseBranch}}: check that they do what the ticket asks, and look for bugs, missing tests, and unclear code. Don't change the code; report what you find, most important first.`
+
 const defaultOpencodePrompt = `You have been spawned by OpenKanban, a kanban board system for managing development tasks.
 
 ## Your Assignment
@@ -193,13 +209,22 @@ func DetectAvailableAgent(agents map[string]AgentConfig) string {
 
 // Config holds the global application configuration
 type Config struct {
-	Defaults BoardSettings          `json:"defaults"`
-	Agents   map[string]AgentConfig `json:"agents"`
-	UI       UIConfig               `json:"ui"`
-	Cleanup  CleanupSettings        `json:"cleanup"`
-	Behavior BehaviorSettings       `json:"behavior"`
-	Opencode OpencodeSettings       `json:"opencode"`
-	Keys     map[string]string      `json:"keys,omitempty"`
+	Defaults  BoardSettings          `json:"defaults"`
+	Agents    map[string]AgentConfig `json:"agents"`
+	UI        UIConfig               `json:"ui"`
+	Cleanup   CleanupSettings        `json:"cleanup"`
+	Behavior  BehaviorSettings       `json:"behavior"`
+	Opencode  OpencodeSettings       `json:"opencode"`
+	Share     ShareSettings          `json:"share"`
+	Tracing   TracingSettings        `json:"tracing"`
+	Telemetry TelemetrySettings      `json:"telemetry"`
+	Keys      map[string]string      `json:"keys,omitempty"`
+	// Hooks maps hook events to shell commands, templated with the ticket's
+	// fields, that run when the event happens on the board.
+	Hooks map[string]string `json:"hooks,omitempty"`
+	// Pipelines maps a pipeline name to the agents that work a ticket in
+	// turn, each starting when the one before it completes.
+	Pipelines map[string][]string `json:"pipelines,omitempty"`
 }
 
 // OpencodeSettings controls OpenCode server integration
@@ -221,6 +246,37 @@ type BoardSettings struct {
 	BranchTemplate   string `json:"branch_template"` // e.g., "{prefix}{slug}"
 	SlugMaxLength    int    `json:"slug_max_length"` // default: 40
 	InitPrompt       string `json:"init_prompt"`
+
+	// IDScheme is how new tickets get their IDs: uuid (the default), ulid
+	// or sequential.
+	IDScheme string `json:"id_scheme,omitempty"`
+
+	// TicketKey names the board in ticket keys, like OK in OK-42. When set,
+	// commits whose messages mention a ticket's key are linked to it.
+	TicketKey string `json:"ticket_key,omitempty"`
+
+	CustomFields []CustomField `json:"custom_fields,omitempty"`
+
+	// TitleLint warns about ticket titles that break the board's style.
+	TitleLint TitleLint `json:"title_lint"`
+
+	// LabelPrompts maps ticket labels to guidance appended to the init
+	// prompt of tickets carrying them.
+	LabelPrompts map[string]string `json:"label_prompts,omitempty"`
+
+	// Title replaces "OpenKanban" in the header.
+	Title string `json:"title,omitempty"`
+
+	// Columns overrides column names, colors, widths and pinning, keyed by
+	// column ID (backlog, in-progress, done, or an extra column).
+	Columns map[string]ColumnLayout `json:"columns,omitempty"`
+
+	// ExtraColumns adds columns after the built-in ones. Each ID is also the
+	// status of the tickets in that column.
+	ExtraColumns []string `json:"extra_columns,omitempty"`
+
+	// ColumnOrder lists column IDs left to right; unlisted columns follow.
+	ColumnOrder []string `json:"column_order,omitempty"`
 }
 
 // AgentConfig defines how to spawn and monitor an AI agent
@@ -230,18 +286,55 @@ type AgentConfig struct {
 	Env        map[string]string `json:"env"`
 	StatusFile string            `json:"status_file"`
 	InitPrompt string            `json:"init_prompt"`
+
+	// FirstOutputTimeout is how many seconds a spawned agent may stay silent
+	// before it is treated as having failed to start (default: 30).
+	FirstOutputTimeout int `json:"first_output_timeout,omitempty"`
+
+	// Preflight is a shell command run before spawning, such as an auth
+	// status check; if it fails the spawn is aborted with its output.
+	Preflight string `json:"preflight,omitempty"`
+	// RequiredEnv lists environment variables that must be set to spawn.
+	RequiredEnv []string `json:"required_env,omitempty"`
+
+	// Headless runs the agent as a background process without a terminal,
+	// its output going to the ticket's session log. Suited to one-shot
+	// prompts, such as claude -p.
+	Headless bool `json:"headless,omitempty"`
 }
 
 // UIConfig holds UI-related preferences
 type UIConfig struct {
-	Theme           string       `json:"theme"`
-	CustomColors    *ThemeColors `json:"custom_colors,omitempty"`
-	ShowAgentStatus bool         `json:"show_agent_status"`
-	RefreshInterval int          `json:"refresh_interval"`
-	ColumnWidth     int          `json:"column_width"`
-	TicketHeight    int          `json:"ticket_height"`
-	SidebarVisible  bool         `json:"sidebar_visible"`
-	ScrollbackLines int          `json:"scrollback_lines"`
+	Theme           string        `json:"theme"`       // Theme name, or "auto" to follow the terminal background
+	LightTheme      string        `json:"light_theme"` // Theme for light terminal backgrounds when theme is "auto"
+	DarkTheme       string        `json:"dark_theme"`  // Theme for dark terminal backgrounds when theme is "auto"
+	CustomColors    *ThemeColors  `json:"custom_colors,omitempty"`
+	ShowAgentStatus bool          `json:"show_agent_status"`
+	RefreshInterval int           `json:"refresh_interval"`
+	ColumnWidth     int           `json:"column_width"`
+	TicketHeight    int           `json:"ticket_height"`
+	SidebarVisible  bool          `json:"sidebar_visible"`
+	SplitView       bool          `json:"split_view"`    // Show the selected ticket's detail beside the board
+	CompactCards    bool          `json:"compact_cards"` // One line per ticket instead of bordered cards
+	ScrollbackLines int           `json:"scrollback_lines"`
+	ReduceMotion    bool          `json:"reduce_motion"`    // Disable card animations and the spinner tick
+	ASCII           bool          `json:"ascii"`            // Draw with plain ASCII instead of Unicode symbols and box drawing
+	AnimationFPS    int           `json:"animation_fps"`    // Frame rate cap for card and spinner animations
+	RenderBudgetMS  int           `json:"render_budget_ms"` // Suspend animations while frames render slower than this (0 disables)
+	PreviewLines    int           `json:"preview_lines"`    // Agent output lines shown in the preview panel
+	TicketLink      string        `json:"ticket_link"`      // Link to a ticket, with {id} and {project} placeholders
+	Aging           AgingSettings `json:"aging"`
+	Card            CardSettings  `json:"card"`
+	Alerts          AlertSettings `json:"alerts"`
+}
+
+// AgingSettings tints the borders of cards that haven't been updated in a
+// while, so neglected tickets stand out
+type AgingSettings struct {
+	Enabled   bool     `json:"enabled"`
+	StartDays int      `json:"start_days"` // Days without an update before a card starts to tint
+	FullDays  int      `json:"full_days"`  // Days without an update at which a card reaches the last color
+	Colors    []string `json:"colors"`     // Gradient from fresh to neglected: theme color names or hex colors
 }
 
 // CleanupSettings controls cleanup behavior when deleting tickets
@@ -253,7 +346,62 @@ type CleanupSettings struct {
 
 // BehaviorSettings controls application behavior preferences
 type BehaviorSettings struct {
-	ConfirmQuitWithAgents bool `json:"confirm_quit_with_agents"` // Prompt before quitting with running agents
+	ConfirmQuitWithAgents bool            `json:"confirm_quit_with_agents"`       // Prompt before quitting with running agents
+	CaptureArtifacts      bool            `json:"capture_artifacts"`              // Archive prompt, transcript tail, and diff when a run ends
+	SessionLogs           bool            `json:"session_logs"`                   // Write each run's full terminal output to a log file
+	RecordSessions        bool            `json:"record_sessions"`                // Record each run with timing for `openkanban replay`
+	TicketFile            bool            `json:"ticket_file"`                    // Write TICKET.md with the ticket's context into its worktree
+	TicketFileTemplate    string          `json:"ticket_file_template,omitempty"` // Go template for TICKET.md (default: built in)
+	StatusFileTTL         int             `json:"status_file_ttl"`                // Seconds before an unchanged status file is stale; 0 never expires
+	StaleAfterDays        int             `json:"stale_after_days"`               // Days an In Progress ticket may sit without an agent or commits before :hygiene flags it; 0 never
+	RespawnLostAgents     bool            `json:"respawn_lost_agents"`            // Respawn, on startup, agents whose openkanban exited without stopping them
+	Confirm               ConfirmSettings `json:"confirm"`
+}
+
+// ConfirmSettings chooses which destructive actions ask before going ahead.
+// Never skips every confirmation, including quitting with agents running,
+// like answering yes up front.
+type ConfirmSettings struct {
+	DeleteTicket   bool `json:"delete_ticket"`   // Deleting tickets, including from the archive
+	StopAgent      bool `json:"stop_agent"`      // Stopping a running agent
+	RemoveWorktree bool `json:"remove_worktree"` // Retrying, adopting, or keeping an attempt, and deleting a ticket with uncommitted changes
+	Never          bool `json:"never"`           // Never ask
+
+	// AssumeYes is Never for one session, set by --yes and never saved.
+	AssumeYes bool `json:"-"`
+}
+
+// ShareSettings controls where `openkanban share` publishes board snapshots
+type ShareSettings struct {
+	Target  string `json:"target"`             // "gist" | "s3" | "command"
+	Format  string `json:"format"`             // "markdown" | "html" | "atom"
+	Public  bool   `json:"public"`             // Create public rather than secret gists
+	S3URI   string `json:"s3_uri,omitempty"`   // Bucket and prefix to upload to, e.g. s3://team-boards/openkanban/
+	BaseURL string `json:"base_url,omitempty"` // Public URL of s3_uri; presigned links are printed if unset
+	Command string `json:"command,omitempty"`  // Shell command run with OPENKANBAN_SHARE_FILE set; prints the URL
+}
+
+// TracingSettings controls exporting OpenTelemetry spans for git, agent,
+// and terminal pane operations. Tracing is off unless an endpoint is set.
+type TracingSettings struct {
+	Endpoint    string            `json:"endpoint,omitempty"`     // OTLP/HTTP collector, e.g. http://localhost:4318
+	Headers     map[string]string `json:"headers,omitempty"`      // Sent with every export, e.g. for authentication
+	ServiceName string            `json:"service_name,omitempty"` // Defaults to "openkanban"
+}
+
+// TelemetrySettings controls the opt-in usage report: counts of the
+// commands, agent types, and board sizes used, never board content, sent to
+// Endpoint when openkanban exits. Nothing is counted unless Enabled is set.
+type TelemetrySettings struct {
+	Enabled  bool   `json:"enabled"`
+	Endpoint string `json:"endpoint,omitempty"` // Receives the report as a JSON POST
+}
+
+// IsBuiltinAgent reports whether name is one of the agents openkanban
+// configures out of the box.
+func IsBuiltinAgent(name string) bool {
+	_, ok := defaultAgents()[name]
+	return ok
 }
 
 func defaultAgents() map[string]AgentConfig {
@@ -321,12 +469,31 @@ func DefaultConfig() *Config {
 		Agents: agents,
 		UI: UIConfig{
 			Theme:           "catppuccin-mocha",
+			LightTheme:      "catppuccin-latte",
+			DarkTheme:       "catppuccin-mocha",
 			ShowAgentStatus: true,
 			RefreshInterval: 5,
 			ColumnWidth:     40,
 			TicketHeight:    4,
 			SidebarVisible:  true,
 			ScrollbackLines: 10000,
+			AnimationFPS:    30,
+			RenderBudgetMS:  50,
+			PreviewLines:    10,
+			TicketLink:      "openkanban://ticket/{id}",
+			Aging: AgingSettings{
+				Enabled:   true,
+				StartDays: 3,
+				FullDays:  14,
+				Colors:    []string{"surface", "warning", "error"},
+			},
+			Card: CardSettings{
+				Layout: slices.Clone(DefaultCardLayout),
+			},
+			Alerts: AlertSettings{
+				Policy: AlertsNever,
+				Bell:   true,
+			},
 		},
 		Cleanup: CleanupSettings{
 			DeleteWorktree:       true,
@@ -335,6 +502,13 @@ func DefaultConfig() *Config {
 		},
 		Behavior: BehaviorSettings{
 			ConfirmQuitWithAgents: true,
+			SessionLogs:           true,
+			StatusFileTTL:         900,
+			StaleAfterDays:        3,
+			Confirm: ConfirmSettings{
+				DeleteTicket:   true,
+				RemoveWorktree: true,
+			},
 		},
 		Opencode: OpencodeSettings{
 			ServerEnabled:  true,
@@ -342,6 +516,10 @@ func DefaultConfig() *Config {
 			PollInterval:   1,
 			StartupTimeout: 10,
 		},
+		Share: ShareSettings{
+			Target: "gist",
+			Format: "markdown",
+		},
 	}
 }
 
@@ -384,6 +562,7 @@ func Load(path string) (*Config, error) {
 			return DefaultConfig(), nil
 		}
 	}
+	LoadUserThemes(themesDir(path))
 
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -429,8 +608,63 @@ func (c *Config) GetEffectiveInitPrompt(agentType string) string {
 	return defaultGlobalPrompt
 }
 
-func (c *Config) GetTheme() Theme {
-	return GetTheme(c.UI.Theme, c.UI.CustomColors)
+// InitPromptFor is the agent's init prompt template with the label_prompts
+// fragments for the given labels appended, in label order.
+func (c *Config) InitPromptFor(agentType string, labels []string) string {
+	return c.withLabelPrompts(c.GetEffectiveInitPrompt(agentType), labels)
+}
+
+// ReviewPromptFor is the init prompt template of the column's reviewer,
+// review_prompt or the built-in one, with the label_prompts fragments for
+// the given labels appended.
+func (c *Config) ReviewPromptFor(columnID string, labels []string) string {
+	prompt := c.ColumnLayout(columnID).ReviewPrompt
+	if prompt == "" {
+		prompt = defaultReviewPrompt
+	}
+	return c.withLabelPrompts(prompt, labels)
+}
+
+func (c *Config) withLabelPrompts(prompt string, labels []string) string {
+	var fragments []string
+	for _, label := range labels {
+		fragment := strings.TrimSpace(c.Defaults.LabelPrompts[label])
+		if fragment != "" && !slices.Contains(fragments, fragment) {
+			fragments = append(fragments, fragment)
+		}
+	}
+	if len(fragments) == 0 {
+		return prompt
+	}
+	return prompt + "\n\n## Additional Guidance\n\n" + strings.Join(fragments, "\n\n")
+}
+
+// GetTheme returns the configured theme. darkBackground picks between the
+// light and dark variants when the theme is "auto".
+func (c *Config) GetTheme(darkBackground bool) Theme {
+	name := c.UI.Theme
+	if name == AutoTheme {
+		name = c.UI.LightTheme
+		if darkBackground {
+			name = c.UI.DarkTheme
+		}
+	}
+	return GetTheme(name, c.UI.CustomColors)
+}
+
+// TicketLink returns ui.ticket_link for a ticket, with its placeholders
+// filled in and escaped for a URL path.
+func (c *Config) TicketLink(ticketID, projectName string) string {
+	return ExpandTicketLink(c.UI.TicketLink, ticketID, projectName)
+}
+
+// ExpandTicketLink fills in a ticket link pattern's {id} and {project}
+// placeholders, escaped for a URL path.
+func ExpandTicketLink(pattern, ticketID, projectName string) string {
+	return strings.NewReplacer(
+		"{id}", url.PathEscape(ticketID),
+		"{project}", url.PathEscape(projectName),
+	).Replace(pattern)
 }
 
 // Save writes configuration to file
@@ -465,12 +699,15 @@ func LoadWithValidation(path string) (*Config, *ValidationResult, error) {
 			return DefaultConfig(), nil, nil
 		}
 	}
+	themeErrs := LoadUserThemes(themesDir(path))
 
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			cfg := DefaultConfig()
-			return cfg, cfg.Validate(), nil
+			result := cfg.Validate()
+			addThemeWarnings(result, themeErrs)
+			return cfg, result, nil
 		}
 		return nil, nil, err
 	}
@@ -488,10 +725,19 @@ func LoadWithValidation(path string) (*Config, *ValidationResult, error) {
 
 	cfg.mergeAgentDefaults()
 	result := cfg.Validate()
+	addThemeWarnings(result, themeErrs)
 
 	return cfg, result, nil
 }
 
+// addThemeWarnings reports theme files that failed to load as warnings, so a
+// broken theme the config doesn't use never blocks startup.
+func addThemeWarnings(r *ValidationResult, errs []error) {
+	for _, err := range errs {
+		r.AddWarning("themes", "", err.Error(), nil)
+	}
+}
+
 // formatJSONError attempts to provide better JSON error context
 func formatJSONError(err error) string {
 	var syntaxErr *json.SyntaxError
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -286,6 +287,44 @@ func TestSave(t *testing.T) {
 	}
 }
 
+func TestConfirmSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	// Settings a config file leaves out keep their defaults.
+	data := `{"behavior": {"confirm": {"stop_agent": true}}}`
+	if err := os.WriteFile(configPath, []byte(data), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	want := ConfirmSettings{DeleteTicket: true, StopAgent: true, RemoveWorktree: true}
+	if cfg.Behavior.Confirm != want {
+		t.Errorf("Behavior.Confirm = %+v; want %+v", cfg.Behavior.Confirm, want)
+	}
+
+	cfg = DefaultConfig()
+	want = ConfirmSettings{DeleteTicket: true, RemoveWorktree: true}
+	if cfg.Behavior.Confirm != want {
+		t.Errorf("default Behavior.Confirm = %+v; want %+v", cfg.Behavior.Confirm, want)
+	}
+
+	// --yes lasts one session: saving doesn't turn prompts off for good.
+	cfg.Behavior.Confirm.AssumeYes = true
+	if err := cfg.Save(configPath); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if loaded.Behavior.Confirm.AssumeYes {
+		t.Error("AssumeYes was saved")
+	}
+}
+
 func TestSave_CreatesDirectory(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "nested", "dir", "config.json")
@@ -351,6 +390,53 @@ func TestGetEffectiveInitPrompt(t *testing.T) {
 	})
 }
 
+func TestInitPromptFor(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults.InitPrompt = "Work on {{.Title}}"
+	cfg.Defaults.LabelPrompts = map[string]string{
+		"frontend": "Match the existing component styles.\n",
+		"ui":       "Match the existing component styles.",
+		"bug":      "Add a regression test.",
+	}
+
+	if got := cfg.InitPromptFor("custom", []string{"docs"}); got != "Work on {{.Title}}" {
+		t.Errorf("InitPromptFor() without matching labels = %q", got)
+	}
+
+	want := "Work on {{.Title}}\n\n## Additional Guidance\n\nAdd a regression test.\n\nMatch the existing component styles."
+	if got := cfg.InitPromptFor("custom", []string{"bug", "frontend", "ui"}); got != want {
+		t.Errorf("InitPromptFor() = %q; want %q", got, want)
+	}
+}
+
+func TestReviewPromptFor(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults.LabelPrompts = map[string]string{"bug": "Add a regression test."}
+
+	if got := cfg.ReviewPromptFor("review", nil); got != defaultReviewPrompt {
+		t.Errorf("ReviewPromptFor() without review_prompt = %q; want the built-in prompt", got)
+	}
+
+	cfg.Defaults.Columns = map[string]ColumnLayout{"review": {Reviewer: "claude", ReviewPrompt: "Review {{.Title}}"}}
+	want := "Review {{.Title}}\n\n## Additional Guidance\n\nAdd a regression test."
+	if got := cfg.ReviewPromptFor("review", []string{"bug"}); got != want {
+		t.Errorf("ReviewPromptFor() = %q; want %q", got, want)
+	}
+}
+
+func TestTicketLink(t *testing.T) {
+	cfg := DefaultConfig()
+	if got := cfg.TicketLink("abc-123", "web"); got != "openkanban://ticket/abc-123" {
+		t.Errorf("TicketLink() = %q", got)
+	}
+
+	cfg.UI.TicketLink = "https://kanban.example.com/{project}/tickets/{id}"
+	want := "https://kanban.example.com/my%20app/tickets/abc-123"
+	if got := cfg.TicketLink("abc-123", "my app"); got != want {
+		t.Errorf("TicketLink() = %q; want %q", got, want)
+	}
+}
+
 func TestMergeAgentDefaults(t *testing.T) {
 	cfg := &Config{
 		Agents: map[string]AgentConfig{
@@ -607,3 +693,80 @@ func TestLoadWithValidation_InvalidConfig(t *testing.T) {
 		t.Error("validation result should have errors for invalid config")
 	}
 }
+
+func TestOrderColumns(t *testing.T) {
+	cfg := DefaultConfig()
+	ids := []string{"backlog", "in-progress", "done"}
+
+	if got := cfg.OrderColumns(ids); !reflect.DeepEqual(got, ids) {
+		t.Errorf("OrderColumns() without order = %v; want %v", got, ids)
+	}
+
+	cfg.Defaults.ColumnOrder = []string{"done", "review", "backlog", "done"}
+	want := []string{"done", "backlog", "in-progress"}
+	if got := cfg.OrderColumns(ids); !reflect.DeepEqual(got, want) {
+		t.Errorf("OrderColumns() = %v; want %v", got, want)
+	}
+}
+
+func TestSetColumnLayout(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SetColumnLayout("done", ColumnLayout{Name: "Shipped"})
+	if got := cfg.ColumnLayout("done").Name; got != "Shipped" {
+		t.Errorf("ColumnLayout(done).Name = %q; want Shipped", got)
+	}
+
+	cfg.SetColumnLayout("done", ColumnLayout{})
+	if _, ok := cfg.Defaults.Columns["done"]; ok {
+		t.Error("empty layout should remove the override")
+	}
+}
+
+func TestColumnID(t *testing.T) {
+	tests := map[string]string{
+		"Review":           "review",
+		"Code Review":      "code-review",
+		"  QA / Staging! ": "qa-staging",
+		"v2 Ready":         "v2-ready",
+		"???":              "",
+	}
+	for name, want := range tests {
+		if got := ColumnID(name); got != want {
+			t.Errorf("ColumnID(%q) = %q; want %q", name, got, want)
+		}
+	}
+}
+
+func TestAddRemoveColumn(t *testing.T) {
+	cfg := DefaultConfig()
+	if err := cfg.AddColumn("review"); err != nil {
+		t.Fatalf("AddColumn(review) error = %v", err)
+	}
+	for _, id := range []string{"review", "done", "in_progress", "Bad ID"} {
+		if err := cfg.AddColumn(id); err == nil {
+			t.Errorf("AddColumn(%q) should fail", id)
+		}
+	}
+
+	cfg.SetColumnLayout("review", ColumnLayout{Name: "Code Review"})
+	cfg.SetColumnLayout("in-progress", ColumnLayout{OnAgentDone: "review"})
+	cfg.Defaults.ColumnOrder = []string{"backlog", "review", "done"}
+	if cfg.RemoveColumn("done") {
+		t.Error("RemoveColumn(done) should refuse built-in columns")
+	}
+	if !cfg.RemoveColumn("review") {
+		t.Fatal("RemoveColumn(review) = false")
+	}
+	if len(cfg.Defaults.ExtraColumns) != 0 {
+		t.Errorf("ExtraColumns = %v; want empty", cfg.Defaults.ExtraColumns)
+	}
+	if want := []string{"backlog", "done"}; !reflect.DeepEqual(cfg.Defaults.ColumnOrder, want) {
+		t.Errorf("ColumnOrder = %v; want %v", cfg.Defaults.ColumnOrder, want)
+	}
+	if _, ok := cfg.Defaults.Columns["review"]; ok {
+		t.Error("RemoveColumn should drop the layout override")
+	}
+	if _, ok := cfg.Defaults.Columns["in-progress"]; ok {
+		t.Error("RemoveColumn should drop on_agent_done pointing at the column")
+	}
+}
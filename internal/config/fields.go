@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Custom field types
+const (
+	FieldString = "string"
+	FieldNumber = "number"
+	FieldEnum   = "enum"
+)
+
+// CustomField describes a board-level field whose per-ticket values are
+// stored in the ticket's Meta map under Name.
+type CustomField struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`              // "string" | "number" | "enum"
+	Options    []string `json:"options,omitempty"` // Allowed values for enum fields
+	ShowOnCard bool     `json:"show_on_card"`      // Render the value on board cards
+}
+
+// CheckValue reports whether value is acceptable for the field.
+// An empty value always clears the field and is accepted.
+func (f CustomField) CheckValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	switch f.Type {
+	case FieldNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%s must be a number", f.Name)
+		}
+	case FieldEnum:
+		if !slices.Contains(f.Options, value) {
+			return fmt.Errorf("%s must be one of %v", f.Name, f.Options)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Hook events, the keys of hooks.
+const (
+	HookTicketCreated  = "ticket_created"
+	HookTicketMoved    = "ticket_moved"
+	HookMovedToDone    = "moved_to_done"
+	HookAgentSpawned   = "agent_spawned"
+	HookAgentCompleted = "agent_completed"
+	HookAgentFailed    = "agent_failed"
+)
+
+// HookEvents are the events a hook can run on.
+var HookEvents = []string{
+	HookTicketCreated, HookTicketMoved, HookMovedToDone,
+	HookAgentSpawned, HookAgentCompleted, HookAgentFailed,
+}
+
+// hookFuncs names the functions hook commands can call, so their templates
+// parse here; the hooks package supplies the real ones.
+var hookFuncs = template.FuncMap{
+	"sh": func(any) string { return "" },
+}
+
+// validateHooks validates the hook events and command templates
+func (c *Config) validateHooks(r *ValidationResult) {
+	events := make([]string, 0, len(c.Hooks))
+	for event := range c.Hooks {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+
+	for _, event := range events {
+		command := c.Hooks[event]
+		if !slices.Contains(HookEvents, event) {
+			r.AddError("hooks", event,
+				fmt.Sprintf("unknown event (events: %s)", strings.Join(HookEvents, ", ")),
+				nil)
+			continue
+		}
+		if strings.TrimSpace(command) == "" {
+			r.AddError("hooks", event, "command is empty", nil)
+			continue
+		}
+		if _, err := template.New(event).Funcs(hookFuncs).Parse(command); err != nil {
+			r.AddError("hooks", event,
+				fmt.Sprintf("invalid template: %v", err),
+				command)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PipelineNames lists the configured pipelines in order.
+func (c *Config) PipelineNames() []string {
+	names := make([]string, 0, len(c.Pipelines))
+	for name := range c.Pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validatePipelines validates that each pipeline has stages and that they
+// name configured agents
+func (c *Config) validatePipelines(r *ValidationResult) {
+	for _, name := range c.PipelineNames() {
+		stages := c.Pipelines[name]
+		if len(stages) == 0 {
+			r.AddError("pipelines", name, "has no stages", nil)
+			continue
+		}
+		for i, stage := range stages {
+			if _, ok := c.Agents[stage]; !ok {
+				r.AddError("pipelines", name,
+					fmt.Sprintf("stage %d runs unknown agent %q", i+1, stage),
+					stage)
+			}
+		}
+	}
+}
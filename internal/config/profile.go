@@ -0,0 +1,282 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ProfileVersion is the version of the profile bundle format.
+const ProfileVersion = 1
+
+// Profile is a shareable bundle of how the board looks and is driven: key
+// bindings, theme, card layout and saved filters. Sections left out of a
+// bundle are left alone when it is imported.
+type Profile struct {
+	Version int `json:"version"`
+
+	Keys map[string]string `json:"keys,omitempty"`
+
+	Theme        string       `json:"theme,omitempty"`
+	LightTheme   string       `json:"light_theme,omitempty"`
+	DarkTheme    string       `json:"dark_theme,omitempty"`
+	CustomColors *ThemeColors `json:"custom_colors,omitempty"`
+	// Themes carries the user themes the profile names, so it works on a
+	// machine without their theme files.
+	Themes map[string]Theme `json:"themes,omitempty"`
+
+	Card *CardSettings `json:"card,omitempty"`
+
+	Filters []ProfileFilter `json:"filters,omitempty"`
+}
+
+// ProfileFilter is a saved filter in a profile. Projects are named rather
+// than given by ID, since project IDs differ from machine to machine.
+type ProfileFilter struct {
+	Name      string   `json:"name"`
+	Projects  []string `json:"projects,omitempty"`
+	Statuses  []string `json:"statuses,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	IsDefault bool     `json:"is_default,omitempty"`
+}
+
+// Profile bundles the config's key bindings, theme and card layout, with
+// the user themes it names. Saved filters are added by the caller.
+func (c *Config) Profile() *Profile {
+	p := &Profile{
+		Version:      ProfileVersion,
+		Keys:         c.Keys,
+		Theme:        c.UI.Theme,
+		CustomColors: c.UI.CustomColors,
+	}
+	names := []string{c.UI.Theme}
+	if c.UI.Theme == AutoTheme {
+		p.LightTheme, p.DarkTheme = c.UI.LightTheme, c.UI.DarkTheme
+		names = []string{c.UI.LightTheme, c.UI.DarkTheme}
+	}
+	for _, name := range names {
+		if theme, ok := userThemes[name]; ok {
+			if p.Themes == nil {
+				p.Themes = make(map[string]Theme)
+			}
+			p.Themes[name] = theme
+		}
+	}
+	if len(c.UI.Card.Layout) > 0 {
+		card := c.UI.Card
+		p.Card = &card
+	}
+	return p
+}
+
+// Validate checks every part of a profile and reports all the problems it
+// finds. Key bindings are checked by validateKeys, since the UI owns the
+// actions they name.
+func (p *Profile) Validate(validateKeys func(map[string]string) []error) error {
+	var errs []string
+	if p.Version != ProfileVersion {
+		errs = append(errs, fmt.Sprintf("version: unsupported profile version %d (expected %d)", p.Version, ProfileVersion))
+	}
+	if validateKeys != nil {
+		for _, err := range validateKeys(p.Keys) {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	themeNames := make([]string, 0, len(p.Themes))
+	for name := range p.Themes {
+		themeNames = append(themeNames, name)
+	}
+	sort.Strings(themeNames)
+	for _, name := range themeNames {
+		if name == "" || name == AutoTheme || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+			errs = append(errs, fmt.Sprintf("themes: %q is not a usable theme name", name))
+			continue
+		}
+		colors := p.Themes[name].Colors
+		for _, key := range themeColorKeys {
+			if value, _ := colors.Lookup(key); !IsHexColor(value) {
+				errs = append(errs, fmt.Sprintf("themes.%s.colors.%s: %q is not a #rgb or #rrggbb color", name, key, value))
+			}
+		}
+	}
+
+	known := func(name string) bool {
+		_, bundled := p.Themes[name]
+		return bundled || IsValidTheme(name)
+	}
+	if p.Theme == AutoTheme {
+		for _, v := range []struct{ field, name string }{{"light_theme", p.LightTheme}, {"dark_theme", p.DarkTheme}} {
+			if !known(v.name) {
+				errs = append(errs, fmt.Sprintf("%s: unknown theme %q", v.field, v.name))
+			}
+		}
+	} else if p.Theme != "" && !known(p.Theme) {
+		errs = append(errs, fmt.Sprintf("theme: unknown theme %q", p.Theme))
+	}
+	if p.CustomColors != nil {
+		for _, key := range themeColorKeys {
+			if value, _ := p.CustomColors.Lookup(key); value != "" && !IsHexColor(value) {
+				errs = append(errs, fmt.Sprintf("custom_colors.%s: %q is not a #rgb or #rrggbb color", key, value))
+			}
+		}
+	}
+
+	if p.Card != nil {
+		if len(p.Card.Layout) == 0 {
+			errs = append(errs, "card.layout: is empty")
+		}
+		hasTitle := false
+		for i, line := range p.Card.Layout {
+			parsed, err := ParseCardLine(line)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("card.layout[%d]: %v", i, err))
+				continue
+			}
+			for _, e := range parsed.Elements {
+				hasTitle = hasTitle || e.Name == "title"
+			}
+		}
+		if len(p.Card.Layout) > 0 && !hasTitle {
+			errs = append(errs, "card.layout: has no {title}")
+		}
+	}
+
+	defaults := 0
+	for i, f := range p.Filters {
+		if strings.TrimSpace(f.Name) == "" {
+			errs = append(errs, fmt.Sprintf("filters[%d]: has no name", i))
+		}
+		if f.IsDefault {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		errs = append(errs, "filters: more than one is marked as the default")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid profile:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+// ApplyProfile replaces the config's key bindings, theme and card layout
+// with those the profile includes. The profile's user themes are registered
+// so the theme it names resolves, but nothing is written: WriteProfileThemes
+// saves them once the result has been validated.
+func (c *Config) ApplyProfile(p *Profile) {
+	for name, theme := range p.Themes {
+		registerUserTheme(name, theme)
+	}
+
+	if p.Keys != nil {
+		c.Keys = p.Keys
+	}
+	if p.Theme != "" {
+		c.UI.Theme = p.Theme
+		if p.Theme == AutoTheme {
+			c.UI.LightTheme, c.UI.DarkTheme = p.LightTheme, p.DarkTheme
+		}
+		c.UI.CustomColors = p.CustomColors
+	}
+	if p.Card != nil {
+		c.UI.Card = *p.Card
+	}
+}
+
+// WriteProfileThemes saves the profile's user themes as theme files in the
+// themes directory beside the config file at path. A theme file that
+// already holds the same theme is left as it is; one that differs is only
+// replaced when overwrite is set, and otherwise nothing is written.
+func WriteProfileThemes(p *Profile, path string, overwrite bool) error {
+	if len(p.Themes) == 0 {
+		return nil
+	}
+	if path == "" {
+		var err error
+		path, err = ConfigPath()
+		if err != nil {
+			return err
+		}
+	}
+	dir := themesDir(path)
+
+	names := make([]string, 0, len(p.Themes))
+	for name := range p.Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var write, clashes []string
+	existing := make(map[string][]string)
+	for _, name := range names {
+		files := themeFiles(dir, name)
+		if len(files) == 0 {
+			write = append(write, name)
+			continue
+		}
+		if hasTheme(files[len(files)-1], name, p.Themes[name]) {
+			continue
+		}
+		existing[name] = files
+		clashes = append(clashes, name)
+		write = append(write, name)
+	}
+	if len(clashes) > 0 && !overwrite {
+		return fmt.Errorf("themes/ already has different %s; pass --force to replace them", strings.Join(clashes, ", "))
+	}
+	if len(write) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, name := range write {
+		theme := p.Themes[name]
+		data, err := json.MarshalIndent(themeFile{Name: theme.Name, Colors: theme.Colors}, "", "  ")
+		if err != nil {
+			return err
+		}
+		// A YAML file of the same name would still win over the new one
+		for _, file := range existing[name] {
+			if err := os.Remove(file); err != nil {
+				return fmt.Errorf("failed to replace theme %s: %w", name, err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), data, 0644); err != nil {
+			return fmt.Errorf("failed to write theme %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// hasTheme reports whether the theme file holds theme, as it would be
+// registered under name.
+func hasTheme(file, name string, theme Theme) bool {
+	loaded, err := loadThemeFile(file)
+	if err != nil {
+		return false
+	}
+	if loaded.Name == "" {
+		loaded.Name = name
+	}
+	return loaded == theme
+}
+
+// themeFiles lists the theme files in dir named name, in the order
+// LoadUserThemes reads them, so the last is the one that counts.
+func themeFiles(dir, name string) []string {
+	var files []string
+	for _, ext := range []string{".json", ".yaml", ".yml"} {
+		file := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(file); err == nil {
+			files = append(files, file)
+		}
+	}
+	return files
+}
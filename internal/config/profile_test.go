@@ -0,0 +1,159 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProfileValidate(t *testing.T) {
+	good := func() *Profile {
+		return &Profile{
+			Version: ProfileVersion,
+			Keys:    map[string]string{"new_ticket": "N"},
+			Theme:   "midnight",
+			Themes: map[string]Theme{
+				"midnight": {Name: "Midnight", Colors: GetTheme("nord", nil).Colors},
+			},
+			Card:    &CardSettings{Layout: []string{"{title}", "{labels}"}},
+			Filters: []ProfileFilter{{Name: "Bugs", Labels: []string{"bug"}, IsDefault: true}},
+		}
+	}
+	if err := good().Validate(nil); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+
+	tests := []struct {
+		name  string
+		edit  func(p *Profile)
+		wants []string
+	}{
+		{"version", func(p *Profile) { p.Version = 2 }, []string{"version"}},
+		{"unknown theme", func(p *Profile) { p.Theme = "nope" }, []string{`theme: unknown theme "nope"`}},
+		{"auto variants", func(p *Profile) { p.Theme, p.LightTheme, p.DarkTheme = AutoTheme, "nord", "nope" }, []string{"dark_theme"}},
+		{"bad bundled color", func(p *Profile) {
+			theme := p.Themes["midnight"]
+			theme.Colors.Primary = "blue"
+			p.Themes["midnight"] = theme
+		}, []string{"themes.midnight.colors.primary"}},
+		{"theme name path", func(p *Profile) { p.Themes["../evil"] = p.Themes["midnight"] }, []string{`"../evil" is not a usable theme name`}},
+		{"custom colors", func(p *Profile) { p.CustomColors = &ThemeColors{Base: "black"} }, []string{"custom_colors.base"}},
+		{"card", func(p *Profile) { p.Card.Layout = []string{"{nope}"} }, []string{"card.layout[0]", "has no {title}"}},
+		{"filters", func(p *Profile) {
+			p.Filters = append(p.Filters, ProfileFilter{Name: " ", IsDefault: true})
+		}, []string{"filters[1]: has no name", "more than one"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := good()
+			tt.edit(p)
+			err := p.Validate(nil)
+			if err == nil {
+				t.Fatal("Validate() = nil, want an error")
+			}
+			for _, want := range tt.wants {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() = %v, want it to mention %q", err, want)
+				}
+			}
+		})
+	}
+
+	p := good()
+	err := p.Validate(func(map[string]string) []error { return []error{errors.New("keys: bad")} })
+	if err == nil || !strings.Contains(err.Error(), "keys: bad") {
+		t.Errorf("Validate() = %v, want the key errors included", err)
+	}
+}
+
+func TestProfileRoundTrip(t *testing.T) {
+	withUserThemes(t, map[string]string{
+		"midnight.json": `{"name": "Midnight", "extends": "nord", "colors": {"primary": "#112233"}}`,
+	})
+
+	cfg := DefaultConfig()
+	cfg.Keys = map[string]string{"new_ticket": "N"}
+	cfg.UI.Theme = "midnight"
+	cfg.UI.Card.Layout = []string{"{title}"}
+
+	p := cfg.Profile()
+	if p.Themes["midnight"].Colors.Primary != "#112233" {
+		t.Fatalf("Profile() themes = %+v, want midnight bundled", p.Themes)
+	}
+	if err := p.Validate(nil); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+
+	// A machine without the theme file gets it from the bundle.
+	userThemes = map[string]Theme{}
+	userThemeNames = nil
+	path := filepath.Join(t.TempDir(), "config.json")
+	themePath := filepath.Join(filepath.Dir(path), "themes", "midnight.json")
+	other := DefaultConfig()
+	other.ApplyProfile(p)
+	if other.Keys["new_ticket"] != "N" || other.UI.Theme != "midnight" || other.UI.Card.Layout[0] != "{title}" {
+		t.Errorf("ApplyProfile() config = keys %v, theme %q, card %v", other.Keys, other.UI.Theme, other.UI.Card.Layout)
+	}
+	if got := other.GetTheme(true).Colors.Primary; got != "#112233" {
+		t.Errorf("GetTheme() primary = %q, want the bundled theme's", got)
+	}
+	if _, err := os.Stat(themePath); !os.IsNotExist(err) {
+		t.Errorf("ApplyProfile() wrote the theme file: %v", err)
+	}
+	if err := WriteProfileThemes(p, path, false); err != nil {
+		t.Fatalf("WriteProfileThemes() = %v", err)
+	}
+	if _, err := os.Stat(themePath); err != nil {
+		t.Errorf("theme file not written: %v", err)
+	}
+	// Writing the same theme again is fine.
+	if err := WriteProfileThemes(p, path, false); err != nil {
+		t.Errorf("WriteProfileThemes() of an identical theme = %v", err)
+	}
+
+	// Sections left out of a bundle are kept.
+	other.ApplyProfile(&Profile{Version: ProfileVersion})
+	if other.UI.Theme != "midnight" || other.Keys == nil {
+		t.Errorf("empty profile changed the config: theme %q, keys %v", other.UI.Theme, other.Keys)
+	}
+}
+
+func TestWriteProfileThemes_Existing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	dir := filepath.Join(filepath.Dir(path), "themes")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	mine := "name: Midnight\nextends: nord\ncolors:\n  primary: \"#445566\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "midnight.yaml"), []byte(mine), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	theirs := GetTheme("nord", &ThemeColors{Primary: "#112233"})
+	theirs.Name = "Midnight"
+	p := &Profile{Version: ProfileVersion, Themes: map[string]Theme{"midnight": theirs}}
+
+	err := WriteProfileThemes(p, path, false)
+	if err == nil || !strings.Contains(err.Error(), "midnight") {
+		t.Fatalf("WriteProfileThemes() = %v, want the clashing theme refused", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "midnight.yaml")); string(data) != mine {
+		t.Errorf("refused import changed the theme file: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "midnight.json")); !os.IsNotExist(err) {
+		t.Errorf("refused import wrote a theme file: %v", err)
+	}
+
+	if err := WriteProfileThemes(p, path, true); err != nil {
+		t.Fatalf("WriteProfileThemes(overwrite) = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "midnight.yaml")); !os.IsNotExist(err) {
+		t.Errorf("replaced theme's YAML file kept: %v", err)
+	}
+	got, err := loadThemeFile(filepath.Join(dir, "midnight.json"))
+	if err != nil || got != theirs {
+		t.Errorf("replaced theme = %+v, %v; want %+v", got, err, theirs)
+	}
+}
@@ -388,11 +388,87 @@ var BuiltinThemes = map[string]Theme{
 			Info:      "#35a77c",
 		},
 	},
+
+	// Accessible themes: maximum contrast, and palettes that stay distinct
+	// with red-green color blindness (deuteranopia and protanopia), built on
+	// the Okabe-Ito colors instead of pairing red with green.
+	"high-contrast-dark": {
+		Name: "High Contrast Dark",
+		Colors: ThemeColors{
+			Base:      "#000000",
+			Surface:   "#1a1a1a",
+			Overlay:   "#3d3d3d",
+			Text:      "#ffffff",
+			Subtext:   "#e6e6e6",
+			Muted:     "#b3b3b3",
+			Primary:   "#00e5ff",
+			Secondary: "#ff8cff",
+			Success:   "#00ff6a",
+			Warning:   "#ffff00",
+			Error:     "#ff5c5c",
+			Info:      "#7ab8ff",
+		},
+	},
+	"high-contrast-light": {
+		Name: "High Contrast Light",
+		Colors: ThemeColors{
+			Base:      "#ffffff",
+			Surface:   "#f0f0f0",
+			Overlay:   "#cccccc",
+			Text:      "#000000",
+			Subtext:   "#1a1a1a",
+			Muted:     "#4d4d4d",
+			Primary:   "#0000c8",
+			Secondary: "#86008a",
+			Success:   "#005c00",
+			Warning:   "#7a4f00",
+			Error:     "#b00000",
+			Info:      "#00507a",
+		},
+	},
+	"colorblind-dark": {
+		Name: "Colorblind Safe Dark",
+		Colors: ThemeColors{
+			Base:      "#1b1b1f",
+			Surface:   "#2b2b31",
+			Overlay:   "#3e3e46",
+			Text:      "#f2f2f2",
+			Subtext:   "#cfcfcf",
+			Muted:     "#8f8f8f",
+			Primary:   "#56b4e9",
+			Secondary: "#cc79a7",
+			Success:   "#009e73",
+			Warning:   "#f0e442",
+			Error:     "#d55e00",
+			Info:      "#e69f00",
+		},
+	},
+	"colorblind-light": {
+		Name: "Colorblind Safe Light",
+		Colors: ThemeColors{
+			Base:      "#ffffff",
+			Surface:   "#f0f0f0",
+			Overlay:   "#dcdcdc",
+			Text:      "#111111",
+			Subtext:   "#333333",
+			Muted:     "#6b6b6b",
+			Primary:   "#0072b2",
+			Secondary: "#a6457f",
+			Success:   "#007a5a",
+			Warning:   "#a66f00",
+			Error:     "#b84a00",
+			Info:      "#3d6f94",
+		},
+	},
 }
 
-// ThemeNames returns a sorted list of all available theme names
+// AutoTheme is the theme name that follows the terminal background, using
+// ui.light_theme or ui.dark_theme.
+const AutoTheme = "auto"
+
+// ThemeNames returns the built-in theme names followed by any user themes
 func ThemeNames() []string {
-	return []string{
+	names := []string{
 		"catppuccin-mocha",
 		"catppuccin-macchiato",
 		"catppuccin-frappe",
@@ -413,12 +489,25 @@ func ThemeNames() []string {
 		"kanagawa",
 		"everforest-dark",
 		"everforest-light",
+		"high-contrast-dark",
+		"high-contrast-light",
+		"colorblind-dark",
+		"colorblind-light",
 	}
+	for _, name := range userThemeNames {
+		if _, builtin := BuiltinThemes[name]; !builtin {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
 // GetTheme returns a theme by name, with optional custom color overrides
 func GetTheme(name string, customColors *ThemeColors) Theme {
-	theme, exists := BuiltinThemes[name]
+	theme, exists := userThemes[name]
+	if !exists {
+		theme, exists = BuiltinThemes[name]
+	}
 	if !exists {
 		// Fall back to catppuccin-mocha
 		theme = BuiltinThemes["catppuccin-mocha"]
@@ -466,8 +555,44 @@ func GetTheme(name string, customColors *ThemeColors) Theme {
 	return theme
 }
 
+// Lookup returns the color for a theme color name such as "warning", or
+// name itself if it is a hex color.
+func (c ThemeColors) Lookup(name string) (string, bool) {
+	if IsHexColor(name) {
+		return name, true
+	}
+	switch name {
+	case "base":
+		return c.Base, true
+	case "surface":
+		return c.Surface, true
+	case "overlay":
+		return c.Overlay, true
+	case "text":
+		return c.Text, true
+	case "subtext":
+		return c.Subtext, true
+	case "muted":
+		return c.Muted, true
+	case "primary":
+		return c.Primary, true
+	case "secondary":
+		return c.Secondary, true
+	case "success":
+		return c.Success, true
+	case "warning":
+		return c.Warning, true
+	case "error":
+		return c.Error, true
+	case "info":
+		return c.Info, true
+	}
+	return "", false
+}
+
 // IsValidTheme checks if a theme name is valid
 func IsValidTheme(name string) bool {
 	_, exists := BuiltinThemes[name]
-	return exists
+	_, user := userThemes[name]
+	return exists || user
 }
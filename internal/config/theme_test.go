@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"math"
 	"regexp"
 	"testing"
 )
@@ -8,8 +10,8 @@ import (
 func TestThemeNames(t *testing.T) {
 	names := ThemeNames()
 
-	if len(names) != 20 {
-		t.Errorf("ThemeNames() returned %d themes; want 20", len(names))
+	if len(names) != 24 {
+		t.Errorf("ThemeNames() returned %d themes; want 24", len(names))
 	}
 
 	for _, name := range names {
@@ -170,6 +172,29 @@ func TestGetTheme_AllCustomColorsCanBeOverridden(t *testing.T) {
 	}
 }
 
+func TestThemeColors_Lookup(t *testing.T) {
+	colors := BuiltinThemes["catppuccin-mocha"].Colors
+
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{"warning", colors.Warning, true},
+		{"error", colors.Error, true},
+		{"surface", colors.Surface, true},
+		{"#abc", "#abc", true},
+		{"orange", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := colors.Lookup(tt.name)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Lookup(%q) = %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
 func TestIsValidTheme(t *testing.T) {
 	validThemes := []string{
 		"catppuccin-mocha",
@@ -235,8 +260,8 @@ func TestBuiltinThemes_AllHaveRequiredColors(t *testing.T) {
 }
 
 func TestBuiltinThemes_Count(t *testing.T) {
-	if len(BuiltinThemes) != 20 {
-		t.Errorf("BuiltinThemes has %d themes; want 20", len(BuiltinThemes))
+	if len(BuiltinThemes) != 24 {
+		t.Errorf("BuiltinThemes has %d themes; want 24", len(BuiltinThemes))
 	}
 }
 
@@ -262,6 +287,10 @@ func TestBuiltinThemes_ExpectedThemesExist(t *testing.T) {
 		"kanagawa",
 		"everforest-dark",
 		"everforest-light",
+		"high-contrast-dark",
+		"high-contrast-light",
+		"colorblind-dark",
+		"colorblind-light",
 	}
 
 	for _, name := range expectedThemes {
@@ -270,3 +299,73 @@ func TestBuiltinThemes_ExpectedThemesExist(t *testing.T) {
 		}
 	}
 }
+
+func TestConfig_GetTheme_Auto(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.UI.Theme = AutoTheme
+	cfg.UI.LightTheme = "gruvbox-light"
+	cfg.UI.DarkTheme = "nord"
+
+	if got := cfg.GetTheme(false).Name; got != BuiltinThemes["gruvbox-light"].Name {
+		t.Errorf("GetTheme(false) = %q; want the light theme", got)
+	}
+	if got := cfg.GetTheme(true).Name; got != BuiltinThemes["nord"].Name {
+		t.Errorf("GetTheme(true) = %q; want the dark theme", got)
+	}
+
+	cfg.UI.Theme = "dracula"
+	if got := cfg.GetTheme(false).Name; got != BuiltinThemes["dracula"].Name {
+		t.Errorf("GetTheme(false) = %q; a named theme should ignore the background", got)
+	}
+}
+
+func TestAccessibleThemes_Contrast(t *testing.T) {
+	// WCAG contrast: 7:1 is AAA for text; accents are used for short labels
+	// and glyphs, so high contrast holds them to 4.5:1 and the colorblind
+	// themes to 3:1.
+	tests := []struct {
+		theme     string
+		minText   float64
+		minAccent float64
+	}{
+		{"high-contrast-dark", 7, 4.5},
+		{"high-contrast-light", 7, 4.5},
+		{"colorblind-dark", 7, 3},
+		{"colorblind-light", 7, 3},
+	}
+	for _, tt := range tests {
+		c := BuiltinThemes[tt.theme].Colors
+		if r := contrastRatio(c.Text, c.Base); r < tt.minText {
+			t.Errorf("%s: text contrast %.1f, want at least %.1f", tt.theme, r, tt.minText)
+		}
+		for name, color := range map[string]string{
+			"primary": c.Primary, "secondary": c.Secondary, "success": c.Success,
+			"warning": c.Warning, "error": c.Error, "info": c.Info,
+		} {
+			if r := contrastRatio(color, c.Base); r < tt.minAccent {
+				t.Errorf("%s: %s contrast %.1f, want at least %.1f", tt.theme, name, r, tt.minAccent)
+			}
+		}
+	}
+}
+
+func contrastRatio(a, b string) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+func relativeLuminance(hex string) float64 {
+	var r, g, b int
+	fmt.Sscanf(hex, "#%02x%02x%02x", &r, &g, &b)
+	channel := func(v int) float64 {
+		c := float64(v) / 255
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(r) + 0.7152*channel(g) + 0.0722*channel(b)
+}
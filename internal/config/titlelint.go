@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"unicode/utf8"
+)
+
+// TitleLint holds optional style rules for ticket titles. Violations are
+// shown as warnings in the ticket form and never block saving.
+type TitleLint struct {
+	MaxLength int         `json:"max_length,omitempty"` // 0 disables the length check
+	Rules     []TitleRule `json:"rules,omitempty"`
+}
+
+// TitleRule flags titles matching Pattern, or with MustMatch, titles that
+// don't match it.
+type TitleRule struct {
+	Pattern   string `json:"pattern"`              // Go regexp
+	Message   string `json:"message"`              // Shown when the rule is violated
+	MustMatch bool   `json:"must_match,omitempty"` // Warn when the pattern does not match
+}
+
+// titlePatterns caches compiled rule patterns; titles are checked on every
+// render of the form.
+var titlePatterns sync.Map
+
+func compileTitlePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := titlePatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	titlePatterns.Store(pattern, re)
+	return re, nil
+}
+
+// Check returns a warning for each rule the title breaks. Rules with invalid
+// patterns are skipped; Validate reports them.
+func (l TitleLint) Check(title string) []string {
+	if title == "" {
+		return nil
+	}
+
+	var warnings []string
+	if l.MaxLength > 0 {
+		if n := utf8.RuneCountInString(title); n > l.MaxLength {
+			warnings = append(warnings, fmt.Sprintf("Longer than %d characters (%d)", l.MaxLength, n))
+		}
+	}
+	for _, rule := range l.Rules {
+		re, err := compileTitlePattern(rule.Pattern)
+		if err != nil {
+			continue
+		}
+		if re.MatchString(title) != rule.MustMatch {
+			message := rule.Message
+			if message == "" {
+				message = "Does not follow " + rule.Pattern
+			}
+			warnings = append(warnings, message)
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// userThemes holds the themes loaded from the themes directory, by file
+// name; a user theme named like a built-in one replaces it.
+var (
+	userThemes     = map[string]Theme{}
+	userThemeNames []string
+)
+
+// themeFile is the on-disk form of a user theme. Colors left out are taken
+// from the theme it extends.
+type themeFile struct {
+	Name    string      `json:"name"`
+	Extends string      `json:"extends"`
+	Colors  ThemeColors `json:"colors"`
+}
+
+// LoadUserThemes registers the *.json, *.yaml and *.yml themes in dir under
+// their file names, so ui.theme can name them. A file that can't be read or
+// has invalid colors is skipped and reported in the returned errors.
+func LoadUserThemes(dir string) []error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return []error{err}
+	}
+
+	var errs []error
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".json" && ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		name := strings.TrimSuffix(e.Name(), ext)
+		theme, err := loadThemeFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Name(), err))
+			continue
+		}
+		if theme.Name == "" {
+			theme.Name = name
+		}
+		registerUserTheme(name, theme)
+	}
+	return errs
+}
+
+// registerUserTheme makes theme available as name, replacing any user theme
+// already registered under it.
+func registerUserTheme(name string, theme Theme) {
+	if _, seen := userThemes[name]; !seen {
+		userThemeNames = append(userThemeNames, name)
+		sort.Strings(userThemeNames)
+	}
+	userThemes[name] = theme
+}
+
+// themesDir is the themes directory beside the config file at path.
+func themesDir(path string) string {
+	return filepath.Join(filepath.Dir(path), "themes")
+}
+
+func loadThemeFile(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	var f themeFile
+	if filepath.Ext(path) == ".json" {
+		err = json.Unmarshal(data, &f)
+	} else {
+		f, err = parseThemeYAML(data)
+	}
+	if err != nil {
+		return Theme{}, err
+	}
+
+	colors := f.Colors
+	if f.Extends != "" {
+		if _, ok := BuiltinThemes[f.Extends]; !ok {
+			return Theme{}, fmt.Errorf("extends unknown theme %q", f.Extends)
+		}
+		colors = GetTheme(f.Extends, &f.Colors).Colors
+	}
+
+	var missing []string
+	for _, key := range themeColorKeys {
+		value, _ := colors.Lookup(key)
+		if value == "" {
+			missing = append(missing, key)
+		} else if !IsHexColor(value) {
+			return Theme{}, fmt.Errorf("colors.%s: %q is not a #rgb or #rrggbb color", key, value)
+		}
+	}
+	if len(missing) > 0 {
+		return Theme{}, fmt.Errorf("missing colors: %s (or set \"extends\" to a built-in theme)", strings.Join(missing, ", "))
+	}
+	return Theme{Name: f.Name, Colors: colors}, nil
+}
+
+var themeColorKeys = []string{
+	"base", "surface", "overlay", "text", "subtext", "muted",
+	"primary", "secondary", "success", "warning", "error", "info",
+}
+
+// parseThemeYAML reads the YAML form of a theme file: top-level name and
+// extends, and a colors mapping one level deep. Unquoted colors such as
+// #1e1e2e are accepted although YAML would read them as comments.
+func parseThemeYAML(data []byte) (themeFile, error) {
+	var f themeFile
+	fields := map[string]*string{
+		"base": &f.Colors.Base, "surface": &f.Colors.Surface, "overlay": &f.Colors.Overlay,
+		"text": &f.Colors.Text, "subtext": &f.Colors.Subtext, "muted": &f.Colors.Muted,
+		"primary": &f.Colors.Primary, "secondary": &f.Colors.Secondary, "success": &f.Colors.Success,
+		"warning": &f.Colors.Warning, "error": &f.Colors.Error, "info": &f.Colors.Info,
+	}
+
+	inColors := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return f, fmt.Errorf("line %d: expected key: value", n)
+		}
+		key = strings.TrimSpace(key)
+		value, err := yamlScalar(value)
+		if err != nil {
+			return f, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		indented := line[0] == ' ' || line[0] == '\t'
+		switch {
+		case indented && inColors:
+			field, known := fields[key]
+			if !known {
+				return f, fmt.Errorf("line %d: unknown color %q", n, key)
+			}
+			*field = value
+		case indented:
+			return f, fmt.Errorf("line %d: unexpected indentation", n)
+		case key == "colors" && value == "":
+			inColors = true
+		case key == "name":
+			f.Name, inColors = value, false
+		case key == "extends":
+			f.Extends, inColors = value, false
+		default:
+			return f, fmt.Errorf("line %d: unknown key %q", n, key)
+		}
+	}
+	return f, scanner.Err()
+}
+
+// yamlScalar unquotes a YAML value and drops a trailing comment.
+func yamlScalar(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, `"`):
+		end := strings.LastIndex(s, `"`)
+		if end == 0 {
+			return "", fmt.Errorf("unterminated string")
+		}
+		return strconv.Unquote(s[:end+1])
+	case strings.HasPrefix(s, "'"):
+		end := strings.LastIndex(s, "'")
+		if end == 0 {
+			return "", fmt.Errorf("unterminated string")
+		}
+		return strings.ReplaceAll(s[1:end], "''", "'"), nil
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	return s, nil
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// withUserThemes loads the given theme files and restores the registry
+// afterwards.
+func withUserThemes(t *testing.T, files map[string]string) []error {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "themes")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		userThemes = map[string]Theme{}
+		userThemeNames = nil
+	})
+	return LoadUserThemes(dir)
+}
+
+func TestLoadUserThemes(t *testing.T) {
+	errs := withUserThemes(t, map[string]string{
+		"midnight.json": `{"name": "Midnight", "extends": "nord", "colors": {"primary": "#112233", "error": "#f00"}}`,
+		"paper.yaml": `# A light theme
+name: 'Paper'
+colors:
+  base: "#ffffff"
+  surface: "#eeeeee"
+  overlay: "#dddddd"
+  text: "#111111"
+  subtext: "#333333"
+  muted: "#777777"
+  primary: #0055cc   # unquoted
+  secondary: "#8800aa"
+  success: "#228822"
+  warning: "#cc8800"
+  error: "#cc2222"
+  info: "#118899"
+`,
+		"notes.txt": "ignored",
+	})
+	if len(errs) > 0 {
+		t.Fatalf("LoadUserThemes() errors = %v", errs)
+	}
+
+	midnight := GetTheme("midnight", nil)
+	nord := BuiltinThemes["nord"]
+	if midnight.Name != "Midnight" || midnight.Colors.Primary != "#112233" || midnight.Colors.Error != "#f00" {
+		t.Errorf("midnight = %+v; want name and overridden colors", midnight)
+	}
+	if midnight.Colors.Base != nord.Colors.Base {
+		t.Errorf("midnight base = %q; want nord's %q", midnight.Colors.Base, nord.Colors.Base)
+	}
+
+	paper := GetTheme("paper", nil)
+	if paper.Name != "Paper" || paper.Colors.Primary != "#0055cc" || paper.Colors.Info != "#118899" {
+		t.Errorf("paper = %+v; want colors from YAML", paper)
+	}
+
+	names := ThemeNames()
+	if !slices.Contains(names, "midnight") || !slices.Contains(names, "paper") || slices.Contains(names, "notes") {
+		t.Errorf("ThemeNames() = %v; want user themes midnight and paper", names)
+	}
+	if !IsValidTheme("paper") {
+		t.Error("IsValidTheme(paper) = false; want true")
+	}
+}
+
+func TestLoadUserThemes_Invalid(t *testing.T) {
+	errs := withUserThemes(t, map[string]string{
+		"badhex.json":  `{"extends": "nord", "colors": {"primary": "blue"}}`,
+		"partial.json": `{"colors": {"primary": "#123456"}}`,
+		"unknown.yml":  "extends: solarized-neon\n",
+		"broken.json":  `{"colors":`,
+	})
+
+	if len(errs) != 4 {
+		t.Fatalf("LoadUserThemes() = %d errors; want 4: %v", len(errs), errs)
+	}
+	for _, want := range []string{`"blue" is not a #rgb or #rrggbb color`, "missing colors: base", `unknown theme "solarized-neon"`, "broken.json"} {
+		found := false
+		for _, err := range errs {
+			if strings.Contains(err.Error(), want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("no error mentioning %q in %v", want, errs)
+		}
+	}
+	if IsValidTheme("badhex") || len(userThemeNames) != 0 {
+		t.Errorf("invalid themes were registered: %v", userThemeNames)
+	}
+}
+
+func TestLoadWithValidation_UserTheme(t *testing.T) {
+	t.Cleanup(func() {
+		userThemes = map[string]Theme{}
+		userThemeNames = nil
+	})
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "themes"), 0755)
+	os.WriteFile(filepath.Join(dir, "themes", "midnight.json"), []byte(`{"extends": "nord"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "themes", "broken.json"), []byte(`{`), 0644)
+	path := filepath.Join(dir, "config.json")
+	os.WriteFile(path, []byte(`{"ui": {"theme": "midnight", "column_width": 40, "ticket_height": 4, "refresh_interval": 5, "animation_fps": 30}}`), 0644)
+
+	cfg, result, err := LoadWithValidation(path)
+	if err != nil {
+		t.Fatalf("LoadWithValidation() error = %v", err)
+	}
+	if result.HasErrors() {
+		t.Errorf("unexpected errors: %v", result.Errors)
+	}
+	for _, w := range result.Warnings {
+		if w.Field == "theme" {
+			t.Errorf("ui.theme naming a user theme should not warn: %s", w.Message)
+		}
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Section != "themes" {
+		t.Errorf("warnings = %v; want one for broken.json", result.Warnings)
+	}
+	if got := cfg.GetTheme(true).Colors.Base; got != BuiltinThemes["nord"].Colors.Base {
+		t.Errorf("GetTheme() base = %q; want nord's", got)
+	}
+}
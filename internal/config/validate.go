@@ -2,9 +2,13 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os/exec"
+	"slices"
 	"strings"
 	"text/template"
+
+	"github.com/techdufus/openkanban/internal/board"
 )
 
 // ValidationError represents a single config validation issue
@@ -94,6 +98,12 @@ func (c *Config) Validate() *ValidationResult {
 	c.validateAgents(result)
 	c.validateUI(result)
 	c.validateOpencode(result)
+	c.validateBehavior(result)
+	c.validateShare(result)
+	c.validateTracing(result)
+	c.validateTelemetry(result)
+	c.validateHooks(result)
+	c.validatePipelines(result)
 	return result
 }
 
@@ -107,6 +117,18 @@ func (c *Config) validateDefaults(r *ValidationResult) {
 			c.Defaults.BranchNaming)
 	}
 
+	if c.Defaults.IDScheme != "" && !slices.Contains(board.IDSchemes, c.Defaults.IDScheme) {
+		r.AddError("defaults", "id_scheme",
+			fmt.Sprintf("must be one of: %s (got %q)", strings.Join(board.IDSchemes, ", "), c.Defaults.IDScheme),
+			c.Defaults.IDScheme)
+	}
+
+	if c.Defaults.TicketKey != "" && !board.ValidTicketKey(c.Defaults.TicketKey) {
+		r.AddError("defaults", "ticket_key",
+			fmt.Sprintf("must be a letter followed by letters or digits, like OK (got %q)", c.Defaults.TicketKey),
+			c.Defaults.TicketKey)
+	}
+
 	// SlugMaxLength must be positive if set
 	if c.Defaults.SlugMaxLength < 0 {
 		r.AddError("defaults", "slug_max_length",
@@ -123,13 +145,20 @@ func (c *Config) validateDefaults(r *ValidationResult) {
 		}
 	}
 
-	// BranchTemplate should contain placeholders (warning only)
-	if c.Defaults.BranchTemplate != "" {
-		if !strings.Contains(c.Defaults.BranchTemplate, "{slug}") &&
-			!strings.Contains(c.Defaults.BranchTemplate, "{prefix}") {
+	// BranchTemplate should contain placeholders (warning only); template
+	// syntax must parse.
+	if tmpl := c.Defaults.BranchTemplate; strings.Contains(tmpl, "{{") {
+		if err := validateTemplate(tmpl); err != nil {
+			r.AddError("defaults", "branch_template",
+				fmt.Sprintf("invalid Go template syntax: %v", err),
+				tmpl)
+		}
+	} else if tmpl != "" {
+		if !strings.Contains(tmpl, "{slug}") &&
+			!strings.Contains(tmpl, "{prefix}") {
 			r.AddWarning("defaults", "branch_template",
 				"should contain {slug} or {prefix} placeholder",
-				c.Defaults.BranchTemplate)
+				tmpl)
 		}
 	}
 
@@ -141,6 +170,133 @@ func (c *Config) validateDefaults(r *ValidationResult) {
 				nil)
 		}
 	}
+
+	for label, fragment := range c.Defaults.LabelPrompts {
+		if err := validateTemplate(fragment); err != nil {
+			r.AddError("defaults.label_prompts", label,
+				fmt.Sprintf("invalid Go template syntax: %v", err),
+				nil)
+		}
+	}
+
+	c.validateCustomFields(r)
+	c.validateTitleLint(r)
+	c.validateColumns(r)
+}
+
+var validSorts = map[string]bool{
+	"": true, "manual": true, "priority": true, "updated": true, "created": true, "agent_status": true,
+}
+
+// validateColumns validates the column layout overrides
+func (c *Config) validateColumns(r *ValidationResult) {
+	for id, l := range c.Defaults.Columns {
+		section := fmt.Sprintf("defaults.columns.%s", id)
+		if l.Weight < 0 {
+			r.AddError(section, "weight", "must not be negative", l.Weight)
+		}
+		if l.Width < 0 {
+			r.AddError(section, "width", "must not be negative", l.Width)
+		}
+		if l.Width > 0 && l.Weight > 0 {
+			r.AddWarning(section, "weight", "ignored when width is set", l.Weight)
+		}
+		if !validSorts[l.Sort] {
+			r.AddError(section, "sort",
+				fmt.Sprintf("must be one of: manual, priority, updated, created, agent_status (got %q)", l.Sort),
+				l.Sort)
+		}
+		if l.Group != "" && l.Group != GroupByLabel {
+			r.AddError(section, "group", fmt.Sprintf("must be %q or empty (got %q)", GroupByLabel, l.Group), l.Group)
+		}
+		if l.Agent != "" {
+			if _, exists := c.Agents[l.Agent]; !exists {
+				r.AddError(section, "agent", fmt.Sprintf("references undefined agent %q", l.Agent), l.Agent)
+			}
+		}
+		if l.Reviewer != "" {
+			if _, exists := c.Agents[l.Reviewer]; !exists {
+				r.AddError(section, "reviewer", fmt.Sprintf("references undefined agent %q", l.Reviewer), l.Reviewer)
+			} else if l.Agent != "" && l.Agent != l.Reviewer {
+				r.AddError(section, "reviewer", fmt.Sprintf("must match the column's agent %q", l.Agent), l.Reviewer)
+			}
+		}
+		if err := validateTemplate(l.ReviewPrompt); err != nil {
+			r.AddError(section, "review_prompt", fmt.Sprintf("invalid template: %v", err), l.ReviewPrompt)
+		}
+		if l.Color != "" && !IsHexColor(l.Color) {
+			r.AddError(section, "color", "must be a hex color like #89b4fa", l.Color)
+		}
+		if l.OnAgentDone != "" {
+			if l.OnAgentDone == id {
+				r.AddError(section, "on_agent_done", "must be another column", l.OnAgentDone)
+			} else if !slices.ContainsFunc(c.BoardColumns(), func(col board.Column) bool { return col.ID == l.OnAgentDone }) {
+				r.AddError(section, "on_agent_done", fmt.Sprintf("references unknown column %q", l.OnAgentDone), l.OnAgentDone)
+			}
+		}
+	}
+
+	for i, id := range c.Defaults.ExtraColumns {
+		if err := c.checkColumnID(id); err != nil {
+			r.AddError("defaults", "extra_columns", err.Error(), id)
+		} else if slices.Index(c.Defaults.ExtraColumns, id) < i {
+			r.AddError("defaults", "extra_columns", fmt.Sprintf("lists %q more than once", id), id)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, id := range c.Defaults.ColumnOrder {
+		if seen[id] {
+			r.AddError("defaults", "column_order", fmt.Sprintf("lists %q more than once", id), id)
+		}
+		seen[id] = true
+	}
+}
+
+// validateTitleLint validates the title style rules
+func (c *Config) validateTitleLint(r *ValidationResult) {
+	lint := c.Defaults.TitleLint
+	if lint.MaxLength < 0 {
+		r.AddError("defaults.title_lint", "max_length", "must not be negative", lint.MaxLength)
+	}
+	for i, rule := range lint.Rules {
+		section := fmt.Sprintf("defaults.title_lint.rules[%d]", i)
+		if _, err := compileTitlePattern(rule.Pattern); err != nil {
+			r.AddError(section, "pattern", fmt.Sprintf("invalid regexp: %v", err), rule.Pattern)
+		}
+		if rule.Message == "" {
+			r.AddWarning(section, "message", "is empty; the pattern is shown instead", nil)
+		}
+	}
+}
+
+// validateCustomFields validates the custom field schema
+func (c *Config) validateCustomFields(r *ValidationResult) {
+	seen := make(map[string]bool)
+	for i, f := range c.Defaults.CustomFields {
+		section := fmt.Sprintf("defaults.custom_fields[%d]", i)
+		if f.Name == "" {
+			r.AddError(section, "name", "is required but missing", nil)
+		} else if seen[f.Name] {
+			r.AddError(section, "name", fmt.Sprintf("duplicate field name %q", f.Name), f.Name)
+		}
+		seen[f.Name] = true
+
+		switch f.Type {
+		case FieldString, FieldNumber:
+			if len(f.Options) > 0 {
+				r.AddWarning(section, "options", "only used by enum fields", f.Options)
+			}
+		case FieldEnum:
+			if len(f.Options) == 0 {
+				r.AddError(section, "options", "enum fields need at least one option", nil)
+			}
+		default:
+			r.AddError(section, "type",
+				fmt.Sprintf("must be one of: string, number, enum (got %q)", f.Type),
+				f.Type)
+		}
+	}
 }
 
 func (c *Config) validateAgents(r *ValidationResult) {
@@ -164,17 +320,44 @@ func (c *Config) validateAgents(r *ValidationResult) {
 					nil)
 			}
 		}
+
+		for _, key := range agent.RequiredEnv {
+			if key == "" || strings.ContainsAny(key, "= ") {
+				r.AddError(section, "required_env",
+					"must list environment variable names",
+					key)
+			}
+		}
+
+		if agent.FirstOutputTimeout < 0 {
+			r.AddError(section, "first_output_timeout",
+				"must be a positive number of seconds",
+				agent.FirstOutputTimeout)
+		}
 	}
 }
 
 // validateUI validates the UI section
 func (c *Config) validateUI(r *ValidationResult) {
-	if c.UI.Theme != "" && !IsValidTheme(c.UI.Theme) {
+	if c.UI.Theme != "" && c.UI.Theme != AutoTheme && !IsValidTheme(c.UI.Theme) {
 		r.AddWarning("ui", "theme",
 			fmt.Sprintf("unknown theme %q, falling back to catppuccin-mocha. Available: %v",
 				c.UI.Theme, ThemeNames()),
 			c.UI.Theme)
 	}
+	if c.UI.Theme == AutoTheme {
+		variants := []struct{ field, name string }{
+			{"light_theme", c.UI.LightTheme},
+			{"dark_theme", c.UI.DarkTheme},
+		}
+		for _, v := range variants {
+			if !IsValidTheme(v.name) {
+				r.AddWarning("ui", v.field,
+					fmt.Sprintf("unknown theme %q, falling back to catppuccin-mocha", v.name),
+					v.name)
+			}
+		}
+	}
 
 	if c.UI.ColumnWidth <= 0 {
 		r.AddError("ui", "column_width",
@@ -193,6 +376,78 @@ func (c *Config) validateUI(r *ValidationResult) {
 			"must be a positive number",
 			c.UI.RefreshInterval)
 	}
+
+	if c.UI.AnimationFPS < 1 || c.UI.AnimationFPS > 60 {
+		r.AddError("ui", "animation_fps",
+			"must be between 1 and 60",
+			c.UI.AnimationFPS)
+	}
+
+	if c.UI.RenderBudgetMS < 0 {
+		r.AddError("ui", "render_budget_ms",
+			"must not be negative",
+			c.UI.RenderBudgetMS)
+	}
+
+	if c.UI.PreviewLines < 1 {
+		r.AddError("ui", "preview_lines",
+			"must be a positive number",
+			c.UI.PreviewLines)
+	}
+
+	if !strings.Contains(c.UI.TicketLink, "{id}") {
+		r.AddWarning("ui", "ticket_link",
+			"should contain the {id} placeholder",
+			c.UI.TicketLink)
+	}
+
+	c.validateAging(r)
+	c.validateCard(r)
+	c.validateAlerts(r)
+}
+
+// validateAging validates the card aging gradient
+func (c *Config) validateAging(r *ValidationResult) {
+	a := c.UI.Aging
+	if !a.Enabled {
+		return
+	}
+	if a.StartDays < 0 {
+		r.AddError("ui.aging", "start_days", "must not be negative", a.StartDays)
+	}
+	if a.FullDays <= a.StartDays {
+		r.AddError("ui.aging", "full_days", "must be greater than start_days", a.FullDays)
+	}
+	if len(a.Colors) < 2 {
+		r.AddError("ui.aging", "colors", "needs at least two colors to blend between", a.Colors)
+	}
+	for _, name := range a.Colors {
+		if _, ok := (ThemeColors{}).Lookup(name); !ok {
+			r.AddError("ui.aging", "colors",
+				fmt.Sprintf("%q is neither a theme color name (surface, warning, error, ...) nor a hex color", name),
+				name)
+		}
+	}
+}
+
+// validateCard validates the card layout
+func (c *Config) validateCard(r *ValidationResult) {
+	hasTitle := false
+	for i, line := range c.UI.Card.Layout {
+		parsed, err := ParseCardLine(line)
+		if err != nil {
+			r.AddError("ui.card", fmt.Sprintf("layout[%d]", i),
+				fmt.Sprintf("%v (elements: %s)", err, strings.Join(CardElements, ", ")),
+				nil)
+			continue
+		}
+		for _, e := range parsed.Elements {
+			hasTitle = hasTitle || e.Name == "title"
+		}
+	}
+	if len(c.UI.Card.Layout) > 0 && !hasTitle {
+		r.AddWarning("ui.card", "layout", "has no {title}; cards will not show ticket titles", nil)
+	}
 }
 
 // validateOpencode validates the opencode server settings
@@ -210,6 +465,76 @@ func (c *Config) validateOpencode(r *ValidationResult) {
 	}
 }
 
+func (c *Config) validateBehavior(r *ValidationResult) {
+	if c.Behavior.StatusFileTTL < 0 {
+		r.AddError("behavior", "status_file_ttl",
+			"must be zero (never expire) or a positive number of seconds",
+			c.Behavior.StatusFileTTL)
+	}
+	if c.Behavior.StaleAfterDays < 0 {
+		r.AddError("behavior", "stale_after_days",
+			"must be zero (never flag) or a positive number of days",
+			c.Behavior.StaleAfterDays)
+	}
+	if tmpl := c.Behavior.TicketFileTemplate; tmpl != "" {
+		if err := validateTemplate(tmpl); err != nil {
+			r.AddError("behavior", "ticket_file_template",
+				fmt.Sprintf("invalid Go template syntax: %v", err),
+				nil)
+		}
+	}
+}
+
+// validateShare validates the share section
+func (c *Config) validateShare(r *ValidationResult) {
+	s := c.Share
+	switch s.Target {
+	case "", "gist":
+	case "s3":
+		if !strings.HasPrefix(s.S3URI, "s3://") {
+			r.AddError("share", "s3_uri", "must be an s3:// URI when target is s3", s.S3URI)
+		}
+	case "command":
+		if strings.TrimSpace(s.Command) == "" {
+			r.AddError("share", "command", "is required when target is command", nil)
+		}
+	default:
+		r.AddError("share", "target",
+			fmt.Sprintf("must be one of: gist, s3, command (got %q)", s.Target),
+			s.Target)
+	}
+	if s.Format != "" && s.Format != "markdown" && s.Format != "html" && s.Format != "atom" {
+		r.AddError("share", "format",
+			fmt.Sprintf("must be one of: markdown, html, atom (got %q)", s.Format),
+			s.Format)
+	}
+}
+
+// validateTracing validates the tracing section
+func (c *Config) validateTracing(r *ValidationResult) {
+	if c.Tracing.Endpoint == "" {
+		return
+	}
+	u, err := url.Parse(c.Tracing.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		r.AddError("tracing", "endpoint", "must be an http:// or https:// URL", c.Tracing.Endpoint)
+	}
+}
+
+// validateTelemetry validates the telemetry section
+func (c *Config) validateTelemetry(r *ValidationResult) {
+	if c.Telemetry.Endpoint == "" {
+		if c.Telemetry.Enabled {
+			r.AddWarning("telemetry", "endpoint", "no endpoint set, so nothing is sent", "")
+		}
+		return
+	}
+	u, err := url.Parse(c.Telemetry.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		r.AddError("telemetry", "endpoint", "must be an http:// or https:// URL", c.Telemetry.Endpoint)
+	}
+}
+
 // validateTemplate checks if a string is a valid Go template
 func validateTemplate(tmpl string) error {
 	_, err := template.New("check").Parse(tmpl)
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -109,6 +110,47 @@ func TestValidate_BranchTemplateMissingPlaceholders(t *testing.T) {
 	}
 }
 
+func TestValidate_BranchTemplateSyntax(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults.BranchTemplate = "{{.BranchPrefix}}{{.TicketID}}"
+
+	result := cfg.Validate()
+	if result.HasErrors() || result.HasWarnings() {
+		t.Errorf("template branch_template should be valid, got errors %v warnings %v", result.Errors, result.Warnings)
+	}
+
+	cfg.Defaults.BranchTemplate = "{prefix}{{.Slug"
+	result = cfg.Validate()
+	found := false
+	for _, e := range result.Errors {
+		if e.Section == "defaults" && e.Field == "branch_template" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected error for unparseable defaults.branch_template")
+	}
+}
+
+func TestValidate_NegativeFirstOutputTimeout(t *testing.T) {
+	cfg := DefaultConfig()
+	claude := cfg.Agents["claude"]
+	claude.FirstOutputTimeout = -5
+	cfg.Agents["claude"] = claude
+
+	result := cfg.Validate()
+
+	found := false
+	for _, e := range result.Errors {
+		if e.Section == "agents.claude" && e.Field == "first_output_timeout" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected error for agents.claude.first_output_timeout")
+	}
+}
+
 func TestValidate_MissingAgentCommand(t *testing.T) {
 	cfg := DefaultConfig()
 	cfg.Agents["custom"] = AgentConfig{
@@ -285,6 +327,45 @@ func TestValidate_ZeroUIRefreshInterval(t *testing.T) {
 	}
 }
 
+func TestValidate_AnimationSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		fps     int
+		budget  int
+		field   string
+		wantErr bool
+	}{
+		{"defaults", 30, 50, "", false},
+		{"budget disabled", 30, 0, "", false},
+		{"zero fps", 0, 50, "animation_fps", true},
+		{"fps too high", 120, 50, "animation_fps", true},
+		{"negative budget", 30, -1, "render_budget_ms", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.UI.AnimationFPS = tt.fps
+			cfg.UI.RenderBudgetMS = tt.budget
+
+			result := cfg.Validate()
+
+			found := false
+			for _, e := range result.Errors {
+				if e.Section == "ui" && (e.Field == "animation_fps" || e.Field == "render_budget_ms") {
+					found = true
+					if e.Field != tt.field {
+						t.Errorf("error on %s, want %s", e.Field, tt.field)
+					}
+				}
+			}
+			if found != tt.wantErr {
+				t.Errorf("got error = %v, want %v", found, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidate_InvalidServerPort(t *testing.T) {
 	tests := []struct {
 		name string
@@ -339,6 +420,215 @@ func TestValidate_NegativePollInterval(t *testing.T) {
 	}
 }
 
+func TestValidate_NegativeStatusFileTTL(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Behavior.StatusFileTTL = -1
+
+	result := cfg.Validate()
+
+	found := false
+	for _, e := range result.Errors {
+		if e.Section == "behavior" && e.Field == "status_file_ttl" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected error for behavior.status_file_ttl")
+	}
+
+	cfg.Behavior.StatusFileTTL = 0
+	if result := cfg.Validate(); result.HasErrors() {
+		t.Errorf("status_file_ttl 0 should be valid, got %v", result.Errors)
+	}
+}
+
+func TestValidate_NegativeStaleAfterDays(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Behavior.StaleAfterDays = -1
+
+	found := false
+	for _, e := range cfg.Validate().Errors {
+		if e.Section == "behavior" && e.Field == "stale_after_days" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected error for behavior.stale_after_days")
+	}
+
+	cfg.Behavior.StaleAfterDays = 0
+	if result := cfg.Validate(); result.HasErrors() {
+		t.Errorf("stale_after_days 0 should be valid, got %v", result.Errors)
+	}
+}
+
+func TestValidate_TicketFileTemplate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Behavior.TicketFileTemplate = "# {{.Title"
+
+	found := false
+	for _, e := range cfg.Validate().Errors {
+		if e.Section == "behavior" && e.Field == "ticket_file_template" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected error for behavior.ticket_file_template")
+	}
+
+	cfg.Behavior.TicketFileTemplate = "# {{.Title}}\n\n{{.Notes}}"
+	if result := cfg.Validate(); result.HasErrors() {
+		t.Errorf("valid ticket_file_template rejected: %v", result.Errors)
+	}
+}
+
+func TestValidate_Aging(t *testing.T) {
+	tests := []struct {
+		name  string
+		aging AgingSettings
+		field string
+	}{
+		{"negative start", AgingSettings{Enabled: true, StartDays: -1, FullDays: 5, Colors: []string{"surface", "error"}}, "start_days"},
+		{"full before start", AgingSettings{Enabled: true, StartDays: 5, FullDays: 5, Colors: []string{"surface", "error"}}, "full_days"},
+		{"one color", AgingSettings{Enabled: true, StartDays: 1, FullDays: 5, Colors: []string{"error"}}, "colors"},
+		{"unknown color", AgingSettings{Enabled: true, StartDays: 1, FullDays: 5, Colors: []string{"surface", "orange"}}, "colors"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.UI.Aging = tt.aging
+			found := false
+			for _, e := range cfg.Validate().Errors {
+				if e.Section == "ui.aging" && e.Field == tt.field {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected error for ui.aging.%s", tt.field)
+			}
+		})
+	}
+
+	cfg := DefaultConfig()
+	cfg.UI.Aging = AgingSettings{Colors: []string{"orange"}}
+	if result := cfg.Validate(); result.HasErrors() {
+		t.Errorf("disabled aging should not be validated, got %v", result.Errors)
+	}
+}
+
+func TestValidate_Share(t *testing.T) {
+	tests := []struct {
+		name  string
+		share ShareSettings
+		field string
+	}{
+		{"unknown target", ShareSettings{Target: "dropbox"}, "target"},
+		{"unknown format", ShareSettings{Format: "pdf"}, "format"},
+		{"s3 without uri", ShareSettings{Target: "s3", S3URI: "team-boards"}, "s3_uri"},
+		{"command without command", ShareSettings{Target: "command"}, "command"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Share = tt.share
+			found := false
+			for _, e := range cfg.Validate().Errors {
+				if e.Section == "share" && e.Field == tt.field {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected error for share.%s", tt.field)
+			}
+		})
+	}
+
+	cfg := DefaultConfig()
+	cfg.Share = ShareSettings{Target: "s3", S3URI: "s3://team-boards/", Format: "html"}
+	if result := cfg.Validate(); result.HasErrors() {
+		t.Errorf("s3 share config should be valid, got %v", result.Errors)
+	}
+}
+
+func TestValidate_Tracing(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		wantErr  bool
+	}{
+		{"", false},
+		{"http://localhost:4318", false},
+		{"https://otel.example.com/v1/traces", false},
+		{"localhost:4318", true},
+		{"grpc://localhost:4317", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Tracing.Endpoint = tt.endpoint
+			gotErr := false
+			for _, e := range cfg.Validate().Errors {
+				if e.Section == "tracing" && e.Field == "endpoint" {
+					gotErr = true
+				}
+			}
+			if gotErr != tt.wantErr {
+				t.Errorf("endpoint %q: got error %v, want %v", tt.endpoint, gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Telemetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		endpoint string
+		wantErr  bool
+		wantWarn bool
+	}{
+		{"off", false, "", false, false},
+		{"on", true, "https://usage.example.com/report", false, false},
+		{"on without endpoint", true, "", false, true},
+		{"bad endpoint", false, "usage.example.com", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Telemetry = TelemetrySettings{Enabled: tt.enabled, Endpoint: tt.endpoint}
+			result := cfg.Validate()
+			gotErr, gotWarn := false, false
+			for _, e := range result.Errors {
+				gotErr = gotErr || e.Section == "telemetry"
+			}
+			for _, w := range result.Warnings {
+				gotWarn = gotWarn || w.Section == "telemetry"
+			}
+			if gotErr != tt.wantErr || gotWarn != tt.wantWarn {
+				t.Errorf("got error %v, warning %v; want %v, %v", gotErr, gotWarn, tt.wantErr, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestValidate_RequiredEnv(t *testing.T) {
+	cfg := DefaultConfig()
+	agent := cfg.Agents["claude"]
+	agent.RequiredEnv = []string{"ANTHROPIC_API_KEY", "KEY=value", ""}
+	cfg.Agents["claude"] = agent
+
+	result := cfg.Validate()
+
+	count := 0
+	for _, e := range result.Errors {
+		if e.Section == "agents.claude" && e.Field == "required_env" {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected 2 required_env errors, got %d: %v", count, result.Errors)
+	}
+}
+
 func TestValidationResult_FormatErrors(t *testing.T) {
 	r := &ValidationResult{}
 	r.AddError("defaults", "branch_naming", "must be valid", "invalid")
@@ -471,3 +761,348 @@ func TestValidate_ValidTemplatePrompt(t *testing.T) {
 		}
 	}
 }
+
+func TestValidate_CustomFields(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults.CustomFields = []CustomField{
+		{Name: "estimate", Type: FieldNumber},
+		{Name: "estimate", Type: FieldString},
+		{Name: "size", Type: FieldEnum},
+		{Name: "customer", Type: "date"},
+	}
+
+	result := cfg.Validate()
+
+	want := map[string]bool{
+		"defaults.custom_fields[1].name":    false,
+		"defaults.custom_fields[2].options": false,
+		"defaults.custom_fields[3].type":    false,
+	}
+	for _, e := range result.Errors {
+		key := e.Section + "." + e.Field
+		if _, ok := want[key]; !ok {
+			t.Errorf("unexpected error %s: %s", key, e.Message)
+		}
+		want[key] = true
+	}
+	for key, found := range want {
+		if !found {
+			t.Errorf("expected error for %s", key)
+		}
+	}
+}
+
+func TestValidate_Columns(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults.Columns = map[string]ColumnLayout{
+		"backlog":     {Weight: -1, Color: "blue", Reviewer: "nope", ReviewPrompt: "{{.Title"},
+		"in-progress": {Width: 50, Weight: 2, Pinned: true, Color: "#f9e2af", Agent: "claude", Group: GroupByLabel, Reviewer: "codex"},
+		"done":        {Width: -10, Sort: "alphabetical", Agent: "reviewer", Group: "assignee"},
+	}
+	cfg.Defaults.ColumnOrder = []string{"done", "backlog", "done"}
+	cfg.Defaults.ExtraColumns = []string{"review", "review"}
+
+	result := cfg.Validate()
+
+	want := map[string]bool{
+		"defaults.columns.backlog.weight":        false,
+		"defaults.columns.backlog.color":         false,
+		"defaults.columns.backlog.reviewer":      false,
+		"defaults.columns.backlog.review_prompt": false,
+		"defaults.columns.in-progress.reviewer":  false,
+		"defaults.columns.done.width":            false,
+		"defaults.columns.done.sort":             false,
+		"defaults.columns.done.group":            false,
+		"defaults.columns.done.agent":            false,
+		"defaults.column_order":                  false,
+		"defaults.extra_columns":                 false,
+	}
+	for _, e := range result.Errors {
+		key := e.Section + "." + e.Field
+		if _, ok := want[key]; !ok {
+			t.Errorf("unexpected error %s: %s", key, e.Message)
+		}
+		want[key] = true
+	}
+	for key, found := range want {
+		if !found {
+			t.Errorf("expected error for %s", key)
+		}
+	}
+	warned := false
+	for _, w := range result.Warnings {
+		if w.Section == "defaults.columns.in-progress" && w.Field == "weight" {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Error("expected warning for weight alongside width")
+	}
+}
+
+func TestValidate_LabelPrompts(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults.LabelPrompts = map[string]string{
+		"frontend": "Keep {{.Title}} accessible.",
+		"backend":  "Broken {{.Title",
+	}
+
+	result := cfg.Validate()
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected 1 error, got %v", result.Errors)
+	}
+	if e := result.Errors[0]; e.Section != "defaults.label_prompts" || e.Field != "backend" {
+		t.Errorf("error for %s.%s; want defaults.label_prompts.backend", e.Section, e.Field)
+	}
+}
+
+func TestValidate_TitleLint(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults.TitleLint = TitleLint{
+		MaxLength: -1,
+		Rules: []TitleRule{
+			{Pattern: "^[A-Z]", Message: "Start with a capital", MustMatch: true},
+			{Pattern: "(unclosed"},
+		},
+	}
+
+	result := cfg.Validate()
+
+	want := map[string]bool{
+		"defaults.title_lint.max_length":       false,
+		"defaults.title_lint.rules[1].pattern": false,
+	}
+	for _, e := range result.Errors {
+		key := e.Section + "." + e.Field
+		if _, ok := want[key]; !ok {
+			t.Errorf("unexpected error %s: %s", key, e.Message)
+		}
+		want[key] = true
+	}
+	for key, found := range want {
+		if !found {
+			t.Errorf("expected error for %s", key)
+		}
+	}
+}
+
+func TestTitleLint_Check(t *testing.T) {
+	lint := TitleLint{
+		MaxLength: 20,
+		Rules: []TitleRule{
+			{Pattern: "^[A-Z]", Message: "Start with a capital", MustMatch: true},
+			{Pattern: `(?i)^(added|fixed|updated)\b`, Message: "Use the imperative mood"},
+			{Pattern: "(unclosed", Message: "never reported"},
+		},
+	}
+
+	tests := []struct {
+		title string
+		want  []string
+	}{
+		{"", nil},
+		{"Fix login redirect", nil},
+		{"fix login", []string{"Start with a capital"}},
+		{"Fixed the login redirect loop", []string{"Longer than 20 characters (29)", "Use the imperative mood"}},
+	}
+	for _, tt := range tests {
+		got := lint.Check(tt.title)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Check(%q) = %v; want %v", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestCustomField_CheckValue(t *testing.T) {
+	tests := []struct {
+		field   CustomField
+		value   string
+		wantErr bool
+	}{
+		{CustomField{Name: "customer", Type: FieldString}, "Acme", false},
+		{CustomField{Name: "estimate", Type: FieldNumber}, "3.5", false},
+		{CustomField{Name: "estimate", Type: FieldNumber}, "lots", true},
+		{CustomField{Name: "size", Type: FieldEnum, Options: []string{"S", "M"}}, "M", false},
+		{CustomField{Name: "size", Type: FieldEnum, Options: []string{"S", "M"}}, "XL", true},
+		{CustomField{Name: "size", Type: FieldEnum, Options: []string{"S", "M"}}, "", false},
+	}
+
+	for _, tt := range tests {
+		err := tt.field.CheckValue(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s.CheckValue(%q) error = %v, wantErr %v", tt.field.Name, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidate_CardLayout(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.UI.Card.Layout = []string{"{title}", "{owner}"}
+	found := false
+	for _, e := range cfg.Validate().Errors {
+		if e.Section == "ui.card" && e.Field == "layout[1]" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected error for ui.card.layout[1]")
+	}
+
+	cfg.UI.Card.Layout = []string{"{priority} {assignee}"}
+	result := cfg.Validate()
+	if result.HasErrors() {
+		t.Errorf("unexpected errors: %v", result.Errors)
+	}
+	found = false
+	for _, w := range result.Warnings {
+		if w.Section == "ui.card" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected a warning for a layout without {title}")
+	}
+}
+
+func TestValidate_AutoTheme(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.UI.Theme = AutoTheme
+	if result := cfg.Validate(); result.HasWarnings() {
+		t.Errorf("auto theme with default variants should not warn, got %v", result.Warnings)
+	}
+
+	cfg.UI.DarkTheme = "auto"
+	found := false
+	for _, w := range cfg.Validate().Warnings {
+		if w.Section == "ui" && w.Field == "dark_theme" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected warning for ui.dark_theme")
+	}
+}
+
+func TestValidate_PreviewLines(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.UI.PreviewLines = 0
+	found := false
+	for _, e := range cfg.Validate().Errors {
+		if e.Section == "ui" && e.Field == "preview_lines" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected error for ui.preview_lines")
+	}
+}
+
+func TestValidate_IDScheme(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults.IDScheme = "ulid"
+	if result := cfg.Validate(); result.HasErrors() {
+		t.Errorf("id_scheme ulid: unexpected errors %v", result.Errors)
+	}
+
+	cfg.Defaults.IDScheme = "snowflake"
+	found := false
+	for _, e := range cfg.Validate().Errors {
+		if e.Section == "defaults" && e.Field == "id_scheme" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected error for defaults.id_scheme")
+	}
+}
+
+func TestValidate_TicketKey(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults.TicketKey = "OK"
+	if result := cfg.Validate(); result.HasErrors() {
+		t.Errorf("ticket_key OK: unexpected errors %v", result.Errors)
+	}
+
+	cfg.Defaults.TicketKey = "OK-"
+	found := false
+	for _, e := range cfg.Validate().Errors {
+		if e.Section == "defaults" && e.Field == "ticket_key" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected error for defaults.ticket_key")
+	}
+}
+
+func TestValidate_OnAgentDone(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults.ExtraColumns = []string{"review"}
+	cfg.SetColumnLayout("in-progress", ColumnLayout{OnAgentDone: "review"})
+	if result := cfg.Validate(); result.HasErrors() {
+		t.Errorf("on_agent_done review: unexpected errors %v", result.Errors)
+	}
+
+	for _, target := range []string{"in-progress", "qa"} {
+		cfg.SetColumnLayout("in-progress", ColumnLayout{OnAgentDone: target})
+		found := false
+		for _, e := range cfg.Validate().Errors {
+			if e.Section == "defaults.columns.in-progress" && e.Field == "on_agent_done" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected error for on_agent_done %q", target)
+		}
+	}
+}
+
+func TestValidate_Hooks(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Hooks = map[string]string{HookMovedToDone: `notify-send Done {{sh .Title}}`}
+	if result := cfg.Validate(); result.HasErrors() {
+		t.Errorf("moved_to_done hook: unexpected errors %v", result.Errors)
+	}
+
+	for _, hooks := range []map[string]string{
+		{"moved_to_review": "true"},
+		{HookTicketCreated: " "},
+		{HookAgentSpawned: "echo {{.Title"},
+		{HookAgentFailed: "echo {{quote .Title}}"},
+	} {
+		cfg.Hooks = hooks
+		found := false
+		for _, e := range cfg.Validate().Errors {
+			if e.Section == "hooks" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("hooks %v: expected an error", hooks)
+		}
+	}
+}
+
+func TestValidate_Pipelines(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Pipelines = map[string][]string{"review": {"claude", "opencode"}}
+	if result := cfg.Validate(); result.HasErrors() {
+		t.Errorf("claude → opencode pipeline: unexpected errors %v", result.Errors)
+	}
+
+	for _, pipelines := range []map[string][]string{
+		{"empty": nil},
+		{"typo": {"claude", "cluade"}},
+	} {
+		cfg.Pipelines = pipelines
+		found := false
+		for _, e := range cfg.Validate().Errors {
+			if e.Section == "pipelines" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("pipelines %v: expected an error", pipelines)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+
+	"github.com/techdufus/openkanban/internal/tracing"
+)
+
+// The git commands below run through these so each one is traced as a
+// "git <subcommand>" span when tracing is on.
+
+func cmdRun(cmd *exec.Cmd) error {
+	span := beginCommand(cmd)
+	err := cmd.Run()
+	endCommand(span, err)
+	return err
+}
+
+func cmdOutput(cmd *exec.Cmd) ([]byte, error) {
+	span := beginCommand(cmd)
+	out, err := cmd.Output()
+	endCommand(span, err)
+	return out, err
+}
+
+func cmdCombinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	span := beginCommand(cmd)
+	out, err := cmd.CombinedOutput()
+	endCommand(span, err)
+	return out, err
+}
+
+func beginCommand(cmd *exec.Cmd) *tracing.Span {
+	if !tracing.Enabled() || len(cmd.Args) < 2 {
+		return nil
+	}
+	return tracing.Begin("git "+cmd.Args[1],
+		tracing.String("git.args", strings.Join(cmd.Args[1:], " ")),
+		tracing.String("git.dir", cmd.Dir))
+}
+
+func endCommand(span *tracing.Span, err error) {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		span.SetAttrs(tracing.Int("process.exit_code", exitErr.ExitCode()))
+	}
+	span.End(err)
+}
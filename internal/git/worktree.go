@@ -1,12 +1,17 @@
 package git
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/techdufus/openkanban/internal/board"
 	"github.com/techdufus/openkanban/internal/project"
 )
 
@@ -46,11 +51,11 @@ func (m *WorktreeManager) CreateWorktree(branchName, baseBranch string) (string,
 	cmd := exec.Command("git", "worktree", "add", "-b", branchName, worktreePath, baseBranch)
 	cmd.Dir = m.repoPath
 
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := cmdCombinedOutput(cmd); err != nil {
 		if strings.Contains(string(output), "already exists") {
 			cmd = exec.Command("git", "worktree", "add", worktreePath, branchName)
 			cmd.Dir = m.repoPath
-			if output2, err2 := cmd.CombinedOutput(); err2 != nil {
+			if output2, err2 := cmdCombinedOutput(cmd); err2 != nil {
 				return "", fmt.Errorf("failed to create worktree: %s: %w", string(output2), err2)
 			}
 			return worktreePath, nil
@@ -75,7 +80,7 @@ func (m *WorktreeManager) RemoveWorktree(worktreePath string) error {
 	cmd := exec.Command("git", "worktree", "remove", worktreePath, "--force")
 	cmd.Dir = m.repoPath
 
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := cmdCombinedOutput(cmd); err != nil {
 		if !strings.Contains(string(output), "not a working tree") {
 			return fmt.Errorf("failed to remove worktree: %s: %w", string(output), err)
 		}
@@ -94,7 +99,7 @@ func (m *WorktreeManager) ListWorktrees() ([]Worktree, error) {
 	cmd := exec.Command("git", "worktree", "list", "--porcelain")
 	cmd.Dir = m.repoPath
 
-	output, err := cmd.Output()
+	output, err := cmdOutput(cmd)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list worktrees: %w", err)
 	}
@@ -136,7 +141,7 @@ func (m *WorktreeManager) GetDefaultBranch() (string, error) {
 	cmd := exec.Command("git", "symbolic-ref", "refs/remotes/origin/HEAD")
 	cmd.Dir = m.repoPath
 
-	output, err := cmd.Output()
+	output, err := cmdOutput(cmd)
 	if err == nil {
 		branch := strings.TrimSpace(string(output))
 		branch = strings.TrimPrefix(branch, "refs/remotes/origin/")
@@ -146,7 +151,7 @@ func (m *WorktreeManager) GetDefaultBranch() (string, error) {
 	for _, branch := range []string{"main", "master"} {
 		cmd := exec.Command("git", "rev-parse", "--verify", branch)
 		cmd.Dir = m.repoPath
-		if err := cmd.Run(); err == nil {
+		if err := cmdRun(cmd); err == nil {
 			return branch, nil
 		}
 	}
@@ -158,7 +163,7 @@ func (m *WorktreeManager) DeleteBranch(branchName string) error {
 	cmd := exec.Command("git", "branch", "-D", branchName)
 	cmd.Dir = m.repoPath
 
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := cmdCombinedOutput(cmd); err != nil {
 		return fmt.Errorf("failed to delete branch: %s: %w", string(output), err)
 	}
 
@@ -168,14 +173,183 @@ func (m *WorktreeManager) DeleteBranch(branchName string) error {
 func (m *WorktreeManager) BranchExists(branchName string) bool {
 	cmd := exec.Command("git", "rev-parse", "--verify", branchName)
 	cmd.Dir = m.repoPath
-	return cmd.Run() == nil
+	return cmdRun(cmd) == nil
+}
+
+// Adoption is what git knows about a branch created outside openkanban.
+type Adoption struct {
+	Branch       string
+	WorktreePath string // empty when the branch is not checked out
+	MainWorktree bool   // checked out in the repository itself
+	BaseBranch   string
+}
+
+// Inspect resolves ref, either a local branch name or the path of one of the
+// repository's worktrees, to its branch, the worktree it is checked out in
+// and the branch it is based on.
+func (m *WorktreeManager) Inspect(ref string) (Adoption, error) {
+	worktrees, err := m.ListWorktrees()
+	if err != nil {
+		return Adoption{}, err
+	}
+
+	var a Adoption
+	if info, err := os.Stat(ref); err == nil && info.IsDir() {
+		i := slices.IndexFunc(worktrees, func(wt Worktree) bool { return samePath(wt.Path, ref) })
+		if i < 0 {
+			return Adoption{}, fmt.Errorf("%s is not a worktree of this repository", ref)
+		}
+		if worktrees[i].Branch == "" {
+			return Adoption{}, fmt.Errorf("%s has a detached HEAD", ref)
+		}
+		a.Branch = worktrees[i].Branch
+	} else {
+		a.Branch = strings.TrimPrefix(ref, "refs/heads/")
+		if !m.BranchExists("refs/heads/" + a.Branch) {
+			return Adoption{}, fmt.Errorf("no branch or worktree named %s", ref)
+		}
+	}
+
+	if i := slices.IndexFunc(worktrees, func(wt Worktree) bool { return wt.Branch == a.Branch }); i >= 0 {
+		a.WorktreePath = worktrees[i].Path
+		a.MainWorktree = i == 0
+	}
+
+	a.BaseBranch, _ = m.GetDefaultBranch()
+	if a.Branch == a.BaseBranch {
+		return Adoption{}, fmt.Errorf("%s is the default branch", a.Branch)
+	}
+	return a, nil
+}
+
+// Branch is a branch that has not been merged into the base branch.
+type Branch struct {
+	Name    string // without the remote, e.g. "fix-login"
+	Remote  string // set when the branch only exists on a remote
+	Subject string // of its last commit
+}
+
+// Ref is the name git knows the branch by.
+func (b Branch) Ref() string {
+	if b.Remote != "" {
+		return b.Remote + "/" + b.Name
+	}
+	return b.Name
+}
+
+// UnmergedBranches lists local and remote branches with commits that are not
+// on baseBranch. A remote branch is left out when a local one has its name.
+func (m *WorktreeManager) UnmergedBranches(baseBranch string) ([]Branch, error) {
+	merged := baseBranch
+	if !m.BranchExists(merged) && m.BranchExists("origin/"+merged) {
+		merged = "origin/" + merged
+	}
+	cmd := exec.Command("git", "for-each-ref", "--no-merged="+merged,
+		"--format=%(refname)%09%(symref)%09%(subject)", "refs/heads", "refs/remotes")
+	cmd.Dir = m.repoPath
+	output, err := cmdOutput(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	return parseBranchList(string(output), baseBranch), nil
+}
+
+func parseBranchList(output, baseBranch string) []Branch {
+	var branches []Branch
+	seen := map[string]bool{baseBranch: true}
+	var remote []Branch
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		ref, rest, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		symref, subject, _ := strings.Cut(rest, "\t")
+		if symref != "" {
+			continue // origin/HEAD
+		}
+		if name, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+			seen[name] = true
+			branches = append(branches, Branch{Name: name, Subject: subject})
+		} else if name, ok := strings.CutPrefix(ref, "refs/remotes/"); ok {
+			if r, n, ok := strings.Cut(name, "/"); ok {
+				remote = append(remote, Branch{Name: n, Remote: r, Subject: subject})
+			}
+		}
+	}
+	for _, b := range remote {
+		if !seen[b.Name] {
+			seen[b.Name] = true
+			branches = append(branches, b)
+		}
+	}
+	return branches
+}
+
+// TrackBranch creates a local branch following a remote one.
+func (m *WorktreeManager) TrackBranch(b Branch) error {
+	cmd := exec.Command("git", "branch", "--track", b.Name, b.Ref())
+	cmd.Dir = m.repoPath
+
+	if output, err := cmdCombinedOutput(cmd); err != nil {
+		return fmt.Errorf("failed to track %s: %s: %w", b.Ref(), string(output), err)
+	}
+
+	return nil
+}
+
+// Apply links ticket to the adopted branch. Where the branch is checked out
+// decides whether the ticket runs in its own worktree or in the repository
+// itself; a branch not checked out anywhere keeps the ticket's setting.
+func (a Adoption) Apply(ticket *board.Ticket) {
+	switch {
+	case a.MainWorktree:
+		ticket.UseWorktree = false
+	case a.WorktreePath != "":
+		ticket.UseWorktree = true
+	}
+	ticket.Adopt(a.Branch, a.WorktreePath, a.BaseBranch)
+}
+
+// samePath reports whether a and b name the same directory.
+func samePath(a, b string) bool {
+	resolve := func(p string) string {
+		if abs, err := filepath.Abs(p); err == nil {
+			p = abs
+		}
+		if real, err := filepath.EvalSymlinks(p); err == nil {
+			p = real
+		}
+		return p
+	}
+	return resolve(a) == resolve(b)
+}
+
+// RetryBranch names the branch for another attempt at branchName: the same
+// name with the next free -vN suffix, starting at -v2.
+func (m *WorktreeManager) RetryBranch(branchName string) string {
+	return retryBranchName(branchName, m.BranchExists)
+}
+
+func retryBranchName(branchName string, taken func(string) bool) string {
+	base := branchName
+	if i := strings.LastIndex(branchName, "-v"); i > 0 {
+		if n := branchName[i+2:]; n != "" && strings.Trim(n, "0123456789") == "" {
+			base = branchName[:i]
+		}
+	}
+	for n := 2; ; n++ {
+		name := fmt.Sprintf("%s-v%d", base, n)
+		if name != branchName && !taken(name) {
+			return name
+		}
+	}
 }
 
 func (m *WorktreeManager) CreateBranch(branchName, baseBranch string) error {
 	cmd := exec.Command("git", "branch", branchName, baseBranch)
 	cmd.Dir = m.repoPath
 
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := cmdCombinedOutput(cmd); err != nil {
 		return fmt.Errorf("failed to create branch: %s: %w", string(output), err)
 	}
 
@@ -186,7 +360,7 @@ func (m *WorktreeManager) CheckoutBranch(branchName string) error {
 	cmd := exec.Command("git", "checkout", branchName)
 	cmd.Dir = m.repoPath
 
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := cmdCombinedOutput(cmd); err != nil {
 		return fmt.Errorf("failed to checkout branch: %s: %w", string(output), err)
 	}
 
@@ -202,11 +376,44 @@ func (m *WorktreeManager) SetupBranch(branchName, baseBranch string) error {
 	return m.CheckoutBranch(branchName)
 }
 
+// ExcludeFile adds name, at the root of the checkout, to the repository's
+// info/exclude, so git status and "git add -A" pass over it in the main
+// checkout and every worktree.
+func (m *WorktreeManager) ExcludeFile(name string) error {
+	cmd := exec.Command("git", "rev-parse", "--git-common-dir")
+	cmd.Dir = m.repoPath
+	output, err := cmdOutput(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to find git directory: %w", err)
+	}
+	gitDir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(m.repoPath, gitDir)
+	}
+
+	path := filepath.Join(gitDir, "info", "exclude")
+	pattern := "/" + name
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if slices.Contains(strings.Split(string(data), "\n"), pattern) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		data = append(data, '\n')
+	}
+	return os.WriteFile(path, append(data, pattern+"\n"...), 0644)
+}
+
 func (m *WorktreeManager) HasUncommittedChanges(worktreePath string) (bool, error) {
 	cmd := exec.Command("git", "status", "--porcelain")
 	cmd.Dir = worktreePath
 
-	output, err := cmd.Output()
+	output, err := cmdOutput(cmd)
 	if err != nil {
 		return false, fmt.Errorf("failed to check git status: %w", err)
 	}
@@ -247,3 +454,146 @@ func ResolveMainRepo(path string) string {
 
 	return path
 }
+
+// UserName returns the git user.name configured for the repository,
+// falling back to $USER when git has none.
+func UserName(repoPath string) string {
+	cmd := exec.Command("git", "config", "user.name")
+	cmd.Dir = repoPath
+	if output, err := cmdOutput(cmd); err == nil {
+		if name := strings.TrimSpace(string(output)); name != "" {
+			return name
+		}
+	}
+	return os.Getenv("USER")
+}
+
+// Diff returns the changes in workdir relative to baseBranch, including
+// uncommitted edits to tracked files. An empty baseBranch diffs against HEAD.
+func Diff(workdir, baseBranch string) (string, error) {
+	if baseBranch == "" {
+		baseBranch = "HEAD"
+	}
+	cmd := exec.Command("git", "diff", baseBranch)
+	cmd.Dir = workdir
+	output, err := cmdOutput(cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to diff against %s: %w", baseBranch, err)
+	}
+	return string(output), nil
+}
+
+// DiffCmd is the command that shows a ticket's changes against baseBranch
+// through git's pager: the worktree's, uncommitted edits included, when
+// workdir is set, and otherwise branch's since it left baseBranch.
+func DiffCmd(repoPath, workdir, baseBranch, branch string) *exec.Cmd {
+	if workdir != "" {
+		cmd := exec.Command("git", "diff", baseBranch)
+		cmd.Dir = workdir
+		return cmd
+	}
+	cmd := exec.Command("git", "diff", baseBranch+"..."+branch)
+	cmd.Dir = repoPath
+	return cmd
+}
+
+// Commits lists the commits on branch since it left baseBranch, newest
+// first, as "<short hash> <subject>" lines.
+func Commits(repoPath, baseBranch, branch string) ([]string, error) {
+	cmd := exec.Command("git", "log", "--oneline", "--no-decorate", baseBranch+".."+branch)
+	cmd.Dir = repoPath
+	output, err := cmdOutput(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits on %s: %w", branch, err)
+	}
+	if len(strings.TrimSpace(string(output))) == 0 {
+		return nil, nil
+	}
+	return strings.Split(strings.TrimSpace(string(output)), "\n"), nil
+}
+
+// DiffStat summarizes what branch changes since it left baseBranch, in
+// git's --stat format fitted to width columns.
+func DiffStat(repoPath, baseBranch, branch string, width int) (string, error) {
+	cmd := exec.Command("git", "diff", fmt.Sprintf("--stat=%d", width), baseBranch+"..."+branch)
+	cmd.Dir = repoPath
+	output, err := cmdOutput(cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to diff %s against %s: %w", branch, baseBranch, err)
+	}
+	return strings.TrimRight(string(output), "\n"), nil
+}
+
+// LastCommitTime is when the commit at the tip of branch was made.
+func LastCommitTime(repoPath, branch string) (time.Time, error) {
+	cmd := exec.Command("git", "log", "-1", "--format=%ct", branch, "--")
+	cmd.Dir = repoPath
+	output, err := cmdOutput(cmd)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read last commit on %s: %w", branch, err)
+	}
+	secs, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read last commit on %s: %w", branch, err)
+	}
+	return time.Unix(secs, 0), nil
+}
+
+// IsMerged reports whether every commit on branch is already on baseBranch.
+// Squash and rebase merges leave the branch looking unmerged.
+func IsMerged(repoPath, baseBranch, branch string) (bool, error) {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", branch, baseBranch)
+	cmd.Dir = repoPath
+	err := cmdRun(cmd)
+	if err == nil {
+		return true, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to compare %s with %s: %w", branch, baseBranch, err)
+}
+
+// Commit is a commit read by CommitsSince.
+type Commit struct {
+	Hash    string
+	Author  string
+	Message string
+	When    time.Time
+}
+
+// CommitsSince lists the commits on any branch, including remote-tracking
+// ones, made since the given time, newest first. Merge commits are left
+// out.
+func CommitsSince(repoPath string, since time.Time) ([]Commit, error) {
+	cmd := exec.Command("git", "log", "--all", "--no-merges",
+		"--since="+since.Format(time.RFC3339), "--format=%H%x1f%an%x1f%ct%x1f%B%x1e")
+	cmd.Dir = repoPath
+	output, err := cmdOutput(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recent commits: %w", err)
+	}
+	return parseCommitLog(string(output)), nil
+}
+
+func parseCommitLog(output string) []Commit {
+	var commits []Commit
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		secs, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		commits = append(commits, Commit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Message: strings.TrimSpace(fields[3]),
+			When:    time.Unix(secs, 0),
+		})
+	}
+	return commits
+}
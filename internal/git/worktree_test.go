@@ -2,8 +2,12 @@ package git
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestIsValidWorktree(t *testing.T) {
@@ -111,6 +115,34 @@ func TestSanitizeBranchName(t *testing.T) {
 	}
 }
 
+func TestRetryBranchName(t *testing.T) {
+	taken := map[string]bool{
+		"agent/fix-login-v2": true,
+		"agent/fix-login-v3": true,
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"agent/add-search", "agent/add-search-v2"},
+		{"agent/fix-login", "agent/fix-login-v4"},
+		{"agent/fix-login-v2", "agent/fix-login-v4"},
+		{"agent/add-search-v2", "agent/add-search-v3"},
+		{"agent/dev-vm", "agent/dev-vm-v2"},
+		{"agent/retry-v", "agent/retry-v-v2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := retryBranchName(tt.input, func(name string) bool { return taken[name] })
+			if result != tt.expected {
+				t.Errorf("retryBranchName(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestParseWorktreeList(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -237,3 +269,240 @@ func TestNewWorktreeManagerFromPaths(t *testing.T) {
 		t.Errorf("baseDir = %q; want %q", mgr.baseDir, "/worktrees/path")
 	}
 }
+
+func TestCommitsAndDiffStat(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	gitRun("init", "-q", "-b", "main")
+	gitRun("commit", "-q", "--allow-empty", "-m", "init")
+	gitRun("checkout", "-q", "-b", "attempt")
+	if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte("one\ntwo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun("add", "a.txt")
+	gitRun("commit", "-q", "-m", "add a")
+	gitRun("checkout", "-q", "main")
+
+	commits, err := Commits(repo, "main", "attempt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 1 || !strings.HasSuffix(commits[0], " add a") {
+		t.Errorf("Commits() = %q; want one \"add a\" commit", commits)
+	}
+
+	if commits, err := Commits(repo, "main", "main"); err != nil || len(commits) != 0 {
+		t.Errorf("Commits() on base = %q, %v; want none", commits, err)
+	}
+
+	stat, err := DiffStat(repo, "main", "attempt", 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stat, "a.txt") || !strings.Contains(stat, "1 file changed, 2 insertions(+)") {
+		t.Errorf("DiffStat() = %q", stat)
+	}
+
+	if _, err := Commits(repo, "main", "missing"); err == nil {
+		t.Error("Commits() on a missing branch should fail")
+	}
+
+	if merged, err := IsMerged(repo, "main", "attempt"); err != nil || merged {
+		t.Errorf("IsMerged(main, attempt) = %v, %v; want false", merged, err)
+	}
+	if merged, err := IsMerged(repo, "attempt", "main"); err != nil || !merged {
+		t.Errorf("IsMerged(attempt, main) = %v, %v; want true", merged, err)
+	}
+	if _, err := IsMerged(repo, "main", "missing"); err == nil {
+		t.Error("IsMerged() on a missing branch should fail")
+	}
+
+	if at, err := LastCommitTime(repo, "attempt"); err != nil || time.Since(at) > time.Hour {
+		t.Errorf("LastCommitTime() = %v, %v", at, err)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	gitRun("init", "-q", "-b", "main")
+	gitRun("commit", "-q", "--allow-empty", "-m", "init")
+	gitRun("branch", "loose")
+	gitRun("checkout", "-q", "-b", "in-repo")
+	worktree := filepath.Join(t.TempDir(), "linked")
+	gitRun("worktree", "add", "-q", "-b", "linked", worktree, "main")
+
+	mgr := NewWorktreeManagerFromPaths(repo, t.TempDir())
+	tests := []struct {
+		ref  string
+		want Adoption
+	}{
+		{"loose", Adoption{Branch: "loose", BaseBranch: "main"}},
+		{"refs/heads/loose", Adoption{Branch: "loose", BaseBranch: "main"}},
+		{"linked", Adoption{Branch: "linked", WorktreePath: worktree, BaseBranch: "main"}},
+		{worktree, Adoption{Branch: "linked", WorktreePath: worktree, BaseBranch: "main"}},
+		{"in-repo", Adoption{Branch: "in-repo", WorktreePath: repo, MainWorktree: true, BaseBranch: "main"}},
+	}
+	for _, tt := range tests {
+		got, err := mgr.Inspect(tt.ref)
+		if err != nil {
+			t.Errorf("Inspect(%q) error: %v", tt.ref, err)
+			continue
+		}
+		if !samePath(got.WorktreePath, tt.want.WorktreePath) {
+			t.Errorf("Inspect(%q) worktree = %q, want %q", tt.ref, got.WorktreePath, tt.want.WorktreePath)
+		}
+		got.WorktreePath = tt.want.WorktreePath
+		if got != tt.want {
+			t.Errorf("Inspect(%q) = %+v, want %+v", tt.ref, got, tt.want)
+		}
+	}
+
+	for _, ref := range []string{"missing", "main", t.TempDir()} {
+		if _, err := mgr.Inspect(ref); err == nil {
+			t.Errorf("Inspect(%q) should fail", ref)
+		}
+	}
+}
+
+func TestUnmergedBranches(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	gitRun := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	gitRun(repo, "init", "-q", "-b", "main")
+	gitRun(repo, "commit", "-q", "--allow-empty", "-m", "init")
+	gitRun(repo, "branch", "merged")
+	gitRun(repo, "checkout", "-q", "-b", "fix-login")
+	gitRun(repo, "commit", "-q", "--allow-empty", "-m", "Fix the login page")
+	gitRun(repo, "checkout", "-q", "-b", "remote-only")
+	gitRun(repo, "commit", "-q", "--allow-empty", "-m", "Remote work")
+	gitRun(repo, "checkout", "-q", "main")
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	gitRun(repo, "clone", "-q", repo, clone)
+	gitRun(clone, "branch", "fix-login", "origin/fix-login")
+
+	mgr := NewWorktreeManagerFromPaths(clone, t.TempDir())
+	branches, err := mgr.UnmergedBranches("main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Branch{
+		{Name: "fix-login", Subject: "Fix the login page"},
+		{Name: "remote-only", Remote: "origin", Subject: "Remote work"},
+	}
+	if !slices.Equal(branches, want) {
+		t.Fatalf("UnmergedBranches() = %+v, want %+v", branches, want)
+	}
+
+	if err := mgr.TrackBranch(branches[1]); err != nil {
+		t.Fatal(err)
+	}
+	if !mgr.BranchExists("refs/heads/remote-only") {
+		t.Error("TrackBranch() should create a local branch")
+	}
+}
+
+func TestExcludeFile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	gitRun := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	gitRun(repo, "init", "-q", "-b", "main")
+	gitRun(repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	mgr := NewWorktreeManagerFromPaths(repo, t.TempDir())
+	path, err := mgr.CreateWorktree("task/notes", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "TICKET.md"), []byte("# Notes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if err := mgr.ExcludeFile("TICKET.md"); err != nil {
+			t.Fatalf("ExcludeFile() error = %v", err)
+		}
+	}
+
+	if dirty, err := mgr.HasUncommittedChanges(path); err != nil || dirty {
+		t.Errorf("HasUncommittedChanges() = %v, %v; want the excluded file ignored", dirty, err)
+	}
+	data, _ := os.ReadFile(filepath.Join(repo, ".git", "info", "exclude"))
+	if n := strings.Count(string(data), "/TICKET.md\n"); n != 1 {
+		t.Errorf("exclude lists the file %d times:\n%s", n, data)
+	}
+}
+
+func TestCommitsSince(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	gitRun("init", "-q", "-b", "main")
+	gitRun("commit", "-q", "--allow-empty", "-m", "init")
+	gitRun("checkout", "-q", "-b", "elsewhere")
+	gitRun("commit", "-q", "--allow-empty", "-m", "Fix login", "-m", "Closes OK-42.")
+	gitRun("checkout", "-q", "main")
+
+	commits, err := CommitsSince(repo, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("CommitsSince() = %+v; want both commits", commits)
+	}
+	newest := commits[0]
+	if newest.Author != "test" || newest.Message != "Fix login\n\nCloses OK-42." || len(newest.Hash) != 40 {
+		t.Errorf("newest commit = %+v", newest)
+	}
+
+	if commits, err := CommitsSince(repo, time.Now().Add(time.Hour)); err != nil || len(commits) != 0 {
+		t.Errorf("CommitsSince(the future) = %v, %v; want none", commits, err)
+	}
+}
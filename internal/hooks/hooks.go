@@ -0,0 +1,113 @@
+// Package hooks runs the shell commands configured under hooks when ticket
+// and agent events happen on the board.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/tracing"
+)
+
+// Timeout bounds a hook command.
+const Timeout = 60 * time.Second
+
+// Event is what a hook command is rendered against: the ticket's template
+// context, as for init prompts, and the event. Fields are referenced as
+// {{.Title}}, {{.Event}}, {{.To}}, etc.
+type Event struct {
+	agent.PromptContext
+
+	// Event is the hook event, such as moved_to_done.
+	Event string
+	// From and To are the statuses of a move.
+	From string
+	To   string
+	// Agent is the agent type spawned, completed or failed.
+	Agent string
+}
+
+// Funcs are the functions hook commands can call: {{sh .Title}} quotes a
+// value as a single shell word, as anything templated into a command
+// should be.
+var Funcs = template.FuncMap{
+	"sh": func(v any) string { return ShellQuote(fmt.Sprint(v)) },
+}
+
+// ShellQuote wraps s in single quotes, so sh reads it as one word whatever
+// it holds.
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Render fills the command template in with the event.
+func Render(command string, e Event) (string, error) {
+	tmpl, err := template.New(e.Event).Option("missingkey=zero").Funcs(Funcs).Parse(command)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, e); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Env is the event as OPENKANBAN_* environment variables. Commands can quote
+// these safely where a templated title, say, could break the shell syntax.
+func Env(e Event) []string {
+	return []string{
+		"OPENKANBAN_EVENT=" + e.Event,
+		"OPENKANBAN_TICKET_ID=" + e.TicketID,
+		"OPENKANBAN_TICKET_TITLE=" + e.Title,
+		"OPENKANBAN_TICKET_STATUS=" + e.Status,
+		"OPENKANBAN_TICKET_PRIORITY=" + strconv.Itoa(e.Priority),
+		"OPENKANBAN_TICKET_LABELS=" + strings.Join(e.Labels, ","),
+		"OPENKANBAN_BRANCH=" + e.BranchName,
+		"OPENKANBAN_WORKTREE=" + e.WorktreePath,
+		"OPENKANBAN_PROJECT=" + e.BoardName,
+		"OPENKANBAN_FROM=" + e.From,
+		"OPENKANBAN_TO=" + e.To,
+		"OPENKANBAN_AGENT=" + e.Agent,
+	}
+}
+
+// Run renders the command and runs it with sh in dir, with the event in its
+// environment. The error carries the tail of the command's output.
+func Run(command string, e Event, dir string) (err error) {
+	span := tracing.Begin("hook.run", tracing.String("hook.event", e.Event), tracing.String("ticket.id", e.TicketID))
+	defer func() { span.End(err) }()
+
+	rendered, err := Render(command, e)
+	if err != nil {
+		return fmt.Errorf("template error: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", rendered)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), Env(e)...)
+
+	out, err := cmd.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", Timeout)
+	}
+	if err != nil {
+		reason := agent.OutputSnippet(string(out), 3)
+		if reason == "" {
+			reason = err.Error()
+		}
+		return errors.New(reason)
+	}
+	return nil
+}
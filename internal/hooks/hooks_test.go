@@ -0,0 +1,83 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/techdufus/openkanban/internal/agent"
+)
+
+func testEvent() Event {
+	return Event{
+		PromptContext: agent.PromptContext{
+			TicketID:  "abc123",
+			Title:     `Fix "quoted" $HOME bug`,
+			Status:    "done",
+			BoardName: "app",
+			Fields:    map[string]string{"jira": "OK-7"},
+		},
+		Event: "moved_to_done",
+		From:  "in_progress",
+		To:    "done",
+	}
+}
+
+func TestRender(t *testing.T) {
+	got, err := Render("{{.Event}} {{.TicketID}} {{.From}}->{{.To}} {{.Fields.jira}} {{.Fields.missing}}|", testEvent())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := "moved_to_done abc123 in_progress->done OK-7 |"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	got, err = Render("echo {{sh .Title}} {{sh .Fields.missing}}", testEvent())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := `echo 'Fix "quoted" $HOME bug' ''`; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	if _, err := Render("{{.Title", testEvent()); err == nil {
+		t.Error("Render() of a broken template: want an error")
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+
+	// The environment carries text a template would have to quote.
+	if err := Run(`printf '%s|%s|%s' "$OPENKANBAN_TICKET_TITLE" "$OPENKANBAN_TO" "$(pwd)" > out`, testEvent(), dir); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDir, _ := filepath.EvalSymlinks(dir)
+	if want := `Fix "quoted" $HOME bug|done|` + wantDir; string(data) != want {
+		t.Errorf("hook wrote %q, want %q", data, want)
+	}
+
+	// So does a quoted template value, even one holding a quote.
+	e := testEvent()
+	e.Title = `it's $(rm -rf .) done`
+	if err := Run(`printf '%s' {{sh .Title}} > out`, e, dir); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "out")); string(data) != e.Title {
+		t.Errorf("hook wrote %q, want %q", data, e.Title)
+	}
+
+	err = Run("echo 'webhook refused'; exit 1", testEvent(), dir)
+	if err == nil || !strings.Contains(err.Error(), "webhook refused") {
+		t.Errorf("Run() error = %v, want the command's output", err)
+	}
+	err = Run("{{.Title", testEvent(), dir)
+	if err == nil || !strings.Contains(err.Error(), "template error") {
+		t.Errorf("Run() error = %v, want a template error", err)
+	}
+}
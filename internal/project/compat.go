@@ -0,0 +1,171 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// AppVersion is the openkanban version stamped into the tickets files it
+// saves. The command sets it at startup.
+var AppVersion = "dev"
+
+// Board features a tickets file can record using. An openkanban that finds
+// one it doesn't know still loads and saves the file, keeping the data it
+// can't show, and warns that it is older than the board.
+const (
+	FeatureComments      = "comments"
+	FeatureCustomColumns = "custom_columns"
+	FeatureEpics         = "epics"
+)
+
+var knownFeatures = map[string]bool{
+	FeatureComments:      true,
+	FeatureCustomColumns: true,
+	FeatureEpics:         true,
+}
+
+var ticketStoreFields = board.JSONFields(reflect.TypeOf(TicketStore{}))
+
+// ticketStoreJSON is TicketStore without its JSON methods.
+type ticketStoreJSON TicketStore
+
+// UnmarshalJSON decodes a tickets file, keeping the top-level fields this
+// build doesn't know so the next save writes them back.
+func (s *TicketStore) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*ticketStoreJSON)(s)); err != nil {
+		return err
+	}
+	extra, err := board.UnknownFields(data, ticketStoreFields)
+	if err != nil {
+		return err
+	}
+	s.extra = extra
+	return nil
+}
+
+// MarshalJSON encodes a tickets file along with the unknown fields it was
+// read with.
+func (s *TicketStore) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal((*ticketStoreJSON)(s))
+	if err != nil {
+		return nil, err
+	}
+	return board.MergeFields(data, s.extra)
+}
+
+// usedFeatures lists the features the store's tickets use, plus any the
+// file was read with that this build doesn't know, since their data is
+// still in it.
+func (s *TicketStore) usedFeatures() []string {
+	used := make(map[string]bool)
+	for _, f := range s.Features {
+		if !knownFeatures[f] {
+			used[f] = true
+		}
+	}
+	for _, t := range s.Tickets {
+		if len(t.Comments) > 0 {
+			used[FeatureComments] = true
+		}
+		if t.ParentID != "" {
+			used[FeatureEpics] = true
+		}
+		if !builtinStatus(t.Status) {
+			used[FeatureCustomColumns] = true
+		}
+	}
+	features := make([]string, 0, len(used))
+	for f := range used {
+		features = append(features, f)
+	}
+	slices.Sort(features)
+	return features
+}
+
+func builtinStatus(status board.TicketStatus) bool {
+	switch status {
+	case board.StatusBacklog, board.StatusInProgress, board.StatusDone, board.StatusArchived:
+		return true
+	}
+	return false
+}
+
+// checkCompatibility notes, for CompatWarning, when a freshly read file
+// came from a newer openkanban: a higher version stamp, features this build
+// doesn't know, or fields it would otherwise drop.
+func (s *TicketStore) checkCompatibility(path string) {
+	var unknown []string
+	for _, f := range s.Features {
+		if !knownFeatures[f] {
+			unknown = append(unknown, f)
+		}
+	}
+	unknownFields := len(s.extra) > 0
+	for _, t := range s.Tickets {
+		if len(t.Extra) > 0 {
+			unknownFields = true
+			break
+		}
+	}
+	newer := versionNewer(s.WrittenBy, AppVersion)
+	if !newer && len(unknown) == 0 && !unknownFields {
+		return
+	}
+
+	msg := path + " was saved by a newer openkanban"
+	if s.WrittenBy != "" {
+		msg = fmt.Sprintf("%s was saved by openkanban %s", path, s.WrittenBy)
+	}
+	if len(unknown) > 0 {
+		msg += fmt.Sprintf(" using features this version doesn't support (%s)", strings.Join(unknown, ", "))
+	}
+	s.compatWarning = fmt.Errorf("%s; data this version can't show is kept as is, upgrade openkanban to see it", msg)
+}
+
+// CompatWarning reports that the store was loaded from a file written by a
+// newer openkanban.
+func (s *TicketStore) CompatWarning() error {
+	return s.compatWarning
+}
+
+// versionNewer reports whether release version a is later than b. Versions
+// that aren't releases, such as "dev", compare as neither.
+func versionNewer(a, b string) bool {
+	va, ok := parseVersion(a)
+	if !ok {
+		return false
+	}
+	vb, ok := parseVersion(b)
+	if !ok {
+		return false
+	}
+	return slices.Compare(va, vb) > 0
+}
+
+// parseVersion reads "v1.2.3" or "1.2.3", ignoring any pre-release or build
+// suffix.
+func parseVersion(v string) ([]int, bool) {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return nil, false
+	}
+	nums := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		nums[i] = n
+	}
+	return nums, true
+}
@@ -0,0 +1,65 @@
+package project
+
+import (
+	"sort"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// GetChildren returns the tickets grouped under an epic, oldest first.
+func (g *GlobalTicketStore) GetChildren(ticketID board.TicketID) []*board.Ticket {
+	var children []*board.Ticket
+	for _, ticket := range g.allTickets {
+		if ticket.ParentID == ticketID {
+			children = append(children, ticket)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].CreatedAt.Before(children[j].CreatedAt)
+	})
+	return children
+}
+
+// IsAncestor reports whether ancestorID appears in the parent chain of ticketID.
+func (g *GlobalTicketStore) IsAncestor(ancestorID, ticketID board.TicketID) bool {
+	seen := make(map[board.TicketID]bool)
+	for t := g.allTickets[ticketID]; t != nil && t.ParentID != ""; t = g.allTickets[t.ParentID] {
+		if t.ParentID == ancestorID {
+			return true
+		}
+		if seen[t.ParentID] {
+			return false
+		}
+		seen[t.ParentID] = true
+	}
+	return false
+}
+
+// SetParent groups a ticket under an epic, or ungroups it when parentID is
+// empty. It refuses links that would make a ticket its own ancestor.
+func (g *GlobalTicketStore) SetParent(ticketID, parentID board.TicketID) error {
+	ticket, ok := g.allTickets[ticketID]
+	if !ok {
+		return board.ErrTicketNotFound
+	}
+	if parentID != "" {
+		if _, ok := g.allTickets[parentID]; !ok {
+			return board.ErrTicketNotFound
+		}
+		if parentID == ticketID || g.IsAncestor(ticketID, parentID) {
+			return board.ErrInvalidParent
+		}
+	}
+	ticket.ParentID = parentID
+	ticket.Touch()
+	return nil
+}
+
+// RemoveParentReferences ungroups the children of a deleted epic.
+func (g *GlobalTicketStore) RemoveParentReferences(ticketID board.TicketID) {
+	for _, ticket := range g.allTickets {
+		if ticket.ParentID == ticketID {
+			ticket.ParentID = ""
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+func newEpicStore(t *testing.T) (*GlobalTicketStore, *board.Ticket, *board.Ticket, *board.Ticket) {
+	t.Helper()
+	g := NewGlobalTicketStore(newRegistry())
+	g.AddProject(&Project{ID: "project-1", Name: "Test", RepoPath: t.TempDir()})
+
+	epic := board.NewTicket("Epic", "project-1")
+	story := board.NewTicket("Story", "project-1")
+	task := board.NewTicket("Task", "project-1")
+	for _, tk := range []*board.Ticket{epic, story, task} {
+		if err := g.Add(tk); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	return g, epic, story, task
+}
+
+func TestGlobalTicketStore_SetParent(t *testing.T) {
+	g, epic, story, task := newEpicStore(t)
+
+	if err := g.SetParent(story.ID, epic.ID); err != nil {
+		t.Fatalf("SetParent(story, epic): %v", err)
+	}
+	if err := g.SetParent(task.ID, story.ID); err != nil {
+		t.Fatalf("SetParent(task, story): %v", err)
+	}
+
+	if children := g.GetChildren(epic.ID); len(children) != 1 || children[0].ID != story.ID {
+		t.Errorf("GetChildren(epic) = %v; want [story]", children)
+	}
+	if !g.IsAncestor(epic.ID, task.ID) {
+		t.Error("epic should be an ancestor of task")
+	}
+
+	if err := g.SetParent(epic.ID, task.ID); err != board.ErrInvalidParent {
+		t.Errorf("cycle: err = %v; want ErrInvalidParent", err)
+	}
+	if err := g.SetParent(epic.ID, epic.ID); err != board.ErrInvalidParent {
+		t.Errorf("self: err = %v; want ErrInvalidParent", err)
+	}
+	if err := g.SetParent(task.ID, "missing"); err != board.ErrTicketNotFound {
+		t.Errorf("missing parent: err = %v; want ErrTicketNotFound", err)
+	}
+
+	if err := g.SetParent(task.ID, ""); err != nil || task.ParentID != "" {
+		t.Errorf("clearing parent: err = %v, ParentID = %q", err, task.ParentID)
+	}
+}
+
+func TestGlobalTicketStore_RemoveParentReferences(t *testing.T) {
+	g, epic, story, task := newEpicStore(t)
+	g.SetParent(story.ID, epic.ID)
+	g.SetParent(task.ID, epic.ID)
+
+	g.RemoveParentReferences(epic.ID)
+
+	if story.ParentID != "" || task.ParentID != "" {
+		t.Error("children should be ungrouped when their epic is removed")
+	}
+}
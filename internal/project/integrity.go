@@ -0,0 +1,98 @@
+package project
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// TicketSchemaVersion is the tickets file format this build writes. It only
+// goes up for changes an older build can't read safely, and files from a
+// newer version are not loaded. Additive changes keep the version: older
+// builds keep the fields they don't know and warn instead (see
+// checkCompatibility).
+const TicketSchemaVersion = 1
+
+// ModifiedError reports a save refused because the tickets file changed on
+// disk since it was loaded or last saved: another openkanban, a sync tool,
+// or a hand edit. Edited marks a file whose checksum didn't match when it
+// was loaded, which is only rewritten once the edit is accepted. ForceSave
+// overwrites it anyway.
+type ModifiedError struct {
+	ProjectID string
+	Path      string
+	Edited    bool
+}
+
+func (e *ModifiedError) Error() string {
+	if e.Edited {
+		return fmt.Sprintf("%s was edited outside openkanban (checksum mismatch)", e.Path)
+	}
+	return fmt.Sprintf("%s changed on disk since it was loaded", e.Path)
+}
+
+// ticketsChecksum fingerprints the tickets themselves rather than the file,
+// so reformatting the JSON doesn't count as a change.
+func ticketsChecksum(tickets map[board.TicketID]*board.Ticket) (string, error) {
+	data, err := json.Marshal(tickets)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// verifyLoaded checks a freshly read store's schema version and checksum,
+// and records the checksum of the tickets as read as the one on disk.
+func (s *TicketStore) verifyLoaded(path string) error {
+	if s.SchemaVersion > TicketSchemaVersion {
+		return fmt.Errorf("%s uses schema version %d, newer than this openkanban supports (%d); upgrade openkanban", path, s.SchemaVersion, TicketSchemaVersion)
+	}
+	sum, err := ticketsChecksum(s.Tickets)
+	if err != nil {
+		return err
+	}
+	if s.Checksum != "" && s.Checksum != sum {
+		s.integrityErr = fmt.Errorf("%s was edited outside openkanban (checksum mismatch)", path)
+	}
+	s.diskChecksum = sum
+	s.checkCompatibility(path)
+	return nil
+}
+
+// checkUnchanged returns a *ModifiedError if the file at path no longer
+// holds the tickets this store last read or wrote there.
+func (s *TicketStore) checkUnchanged(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var onDisk TicketStore
+	if err := json.Unmarshal(data, &onDisk); err != nil {
+		return &ModifiedError{ProjectID: s.ProjectID, Path: path}
+	}
+	if onDisk.Tickets == nil {
+		onDisk.Tickets = make(map[board.TicketID]*board.Ticket)
+	}
+	sum, err := ticketsChecksum(onDisk.Tickets)
+	if err != nil {
+		return err
+	}
+	if sum != s.diskChecksum {
+		return &ModifiedError{ProjectID: s.ProjectID, Path: path}
+	}
+	return nil
+}
+
+// IntegrityErr reports a checksum mismatch found when the store was loaded,
+// until a forced save accepts the edit.
+func (s *TicketStore) IntegrityErr() error {
+	return s.integrityErr
+}
@@ -0,0 +1,161 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// ReadTicketsFile reads a tickets file from outside the board, such as one
+// copied from another openkanban, without adopting it as a project's store.
+func ReadTicketsFile(path string) (*TicketStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var store TicketStore
+	if err := json.Unmarshal(data, &store); err != nil {
+		return nil, fmt.Errorf("%s is not a tickets file: %w", path, err)
+	}
+	if store.Tickets == nil {
+		store.Tickets = make(map[board.TicketID]*board.Ticket)
+	}
+	for id, t := range store.Tickets {
+		if t.ID == "" {
+			t.ID = id
+		}
+	}
+	return &store, nil
+}
+
+// MergeResult is what Merge did with another board's tickets.
+type MergeResult struct {
+	// Added are the tickets copied in, oldest first, as they now are.
+	Added []*board.Ticket
+	// Renamed maps added tickets whose ID was already taken on this board
+	// to the IDs they were given.
+	Renamed map[board.TicketID]board.TicketID
+	// Duplicates maps tickets left out to the ticket here with the same
+	// title and branch.
+	Duplicates map[board.TicketID]*board.Ticket
+}
+
+// Merge copies tickets from another board into a project. A ticket with the
+// same title and branch as one already in the project is taken to be that
+// ticket and skipped; the rest get a new ID if theirs is taken. Epics and
+// blockers follow the remapping, and references to tickets on neither board
+// are dropped. Merged tickets keep their history and branch with a merged
+// event added, but no agent state or worktree, and any open run is closed.
+// Nothing is saved.
+func (g *GlobalTicketStore) Merge(projectID string, tickets map[board.TicketID]*board.Ticket, source string) (MergeResult, error) {
+	result := MergeResult{
+		Renamed:    make(map[board.TicketID]board.TicketID),
+		Duplicates: make(map[board.TicketID]*board.Ticket),
+	}
+	store := g.ticketStores[projectID]
+	if store == nil {
+		return result, ErrProjectNotFound
+	}
+
+	existing := make(map[string]*board.Ticket)
+	for _, t := range store.Tickets {
+		existing[mergeKey(t)] = t
+	}
+
+	incoming := make([]*board.Ticket, 0, len(tickets))
+	for _, t := range tickets {
+		incoming = append(incoming, t)
+	}
+	sort.Slice(incoming, func(i, j int) bool {
+		if !incoming[i].CreatedAt.Equal(incoming[j].CreatedAt) {
+			return incoming[i].CreatedAt.Before(incoming[j].CreatedAt)
+		}
+		return incoming[i].ID < incoming[j].ID
+	})
+
+	// ids sends each incoming ticket's ID to its ID on this board, and
+	// assigned holds the IDs the merge gives out.
+	ids := make(map[board.TicketID]board.TicketID)
+	assigned := make(map[board.TicketID]bool)
+	var clashing []*board.Ticket
+	for _, t := range incoming {
+		key := mergeKey(t)
+		if dup, ok := existing[key]; ok {
+			result.Duplicates[t.ID] = dup
+			ids[t.ID] = dup.ID
+			continue
+		}
+		// A second copy on the incoming board is a duplicate of the first.
+		existing[key] = t
+		result.Added = append(result.Added, t)
+		if _, taken := g.allTickets[t.ID]; taken || t.ID == "" {
+			clashing = append(clashing, t)
+			continue
+		}
+		ids[t.ID] = t.ID
+		assigned[t.ID] = true
+		board.NoteTicketID(t.ID)
+	}
+	// New IDs are made once every kept ID has been noted, so a sequential
+	// one can't repeat an incoming ticket's.
+	taken := func(id board.TicketID) bool {
+		_, onBoard := g.allTickets[id]
+		return onBoard || assigned[id]
+	}
+	for _, t := range clashing {
+		id := board.NewTicketID()
+		for taken(id) {
+			id = board.NewTicketID()
+		}
+		assigned[id] = true
+		ids[t.ID] = id
+		result.Renamed[t.ID] = id
+	}
+
+	resolve := func(id board.TicketID) (board.TicketID, bool) {
+		if mapped, ok := ids[id]; ok {
+			return mapped, true
+		}
+		_, ok := g.allTickets[id]
+		return id, ok
+	}
+	for _, t := range result.Added {
+		t.ID = ids[t.ID]
+		t.ProjectID = projectID
+		if t.ParentID != "" {
+			if id, ok := resolve(t.ParentID); ok {
+				t.ParentID = id
+			} else {
+				t.ParentID = ""
+			}
+		}
+		var blockedBy []board.TicketID
+		for _, b := range t.BlockedBy {
+			if id, ok := resolve(b); ok {
+				blockedBy = append(blockedBy, id)
+			}
+		}
+		t.BlockedBy = blockedBy
+		// The other board's agents, worktrees, and sessions don't come along.
+		t.EndAgentRun(board.RunStopped, 0)
+		t.EndPipeline("stopped")
+		t.AgentStatus = board.AgentNone
+		t.AgentPort = 0
+		t.AgentSpawnedAt = nil
+		t.AgentSessionID = ""
+		t.WorktreePath = ""
+		t.Record(board.EventMerged, source)
+		g.Add(t)
+	}
+	return result, nil
+}
+
+// mergeKey identifies a ticket across boards: its title, ignoring case and
+// surrounding space, and its branch.
+func mergeKey(t *board.Ticket) string {
+	return strings.ToLower(strings.TrimSpace(t.Title)) + "\x00" + t.BranchName
+}
@@ -0,0 +1,161 @@
+package project
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+func TestGlobalTicketStore_Merge(t *testing.T) {
+	g := NewGlobalTicketStore(newRegistry())
+	g.AddProject(&Project{ID: "project-1", Name: "Test", RepoPath: t.TempDir()})
+
+	login := board.NewTicket("Fix login", "project-1")
+	login.BranchName = "task/fix-login"
+	clash := board.NewTicket("Write docs", "project-1")
+	for _, tk := range []*board.Ticket{login, clash} {
+		if err := g.Add(tk); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	// The other board forked from this one: the same login ticket, a ticket
+	// reusing the docs ticket's ID, and an epic with a blocked child.
+	dupLogin := *login
+	dupLogin.Title = "  fix LOGIN "
+	reused := board.NewTicket("Try a new parser", "other")
+	reused.ID = clash.ID
+	epic := board.NewTicket("Parser epic", "other")
+	child := board.NewTicket("Tokenizer", "other")
+	child.ParentID = epic.ID
+	child.BlockedBy = []board.TicketID{reused.ID, "gone"}
+	child.AgentStatus = board.AgentWorking
+	child.WorktreePath = "/elsewhere/tokenizer"
+	child.AgentSessionID = "tokenizer"
+	child.StartAgentRun("claude")
+	spawned := child.AgentRuns[0].StartedAt
+	child.AgentSpawnedAt = &spawned
+	incoming := map[board.TicketID]*board.Ticket{}
+	for _, tk := range []*board.Ticket{&dupLogin, reused, epic, child} {
+		incoming[tk.ID] = tk
+	}
+
+	result, err := g.Merge("project-1", incoming, "experiment.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Added) != 3 || len(result.Duplicates) != 1 || result.Duplicates[login.ID] != login {
+		t.Fatalf("Merge added %d, duplicates %v; want 3 added and the login ticket skipped", len(result.Added), result.Duplicates)
+	}
+	newID, ok := result.Renamed[clash.ID]
+	if !ok || newID == clash.ID || len(result.Renamed) != 1 {
+		t.Fatalf("Renamed = %v; want only the clashing ID renamed", result.Renamed)
+	}
+	if got, _ := g.Get(clash.ID); got != clash {
+		t.Error("the ticket already on the board should keep its ID")
+	}
+	if got, _ := g.Get(newID); got == nil || got.Title != "Try a new parser" || got.ProjectID != "project-1" {
+		t.Errorf("renamed ticket = %+v", got)
+	}
+
+	if child.ParentID != epic.ID {
+		t.Errorf("child.ParentID = %q; want %q", child.ParentID, epic.ID)
+	}
+	if len(child.BlockedBy) != 1 || child.BlockedBy[0] != newID {
+		t.Errorf("child.BlockedBy = %v; want [%s]", child.BlockedBy, newID)
+	}
+	if child.AgentStatus != board.AgentNone {
+		t.Errorf("child.AgentStatus = %q; want none", child.AgentStatus)
+	}
+	if child.WorktreePath != "" || child.AgentSessionID != "" || child.AgentSpawnedAt != nil {
+		t.Errorf("child kept agent state: worktree %q, session %q, spawned %v", child.WorktreePath, child.AgentSessionID, child.AgentSpawnedAt)
+	}
+	if child.CurrentAgentRun() != nil || child.AgentRuns[0].Outcome != board.RunStopped {
+		t.Errorf("child's open run = %+v; want it stopped", child.AgentRuns[0])
+	}
+	if last := child.History[len(child.History)-1]; last.Kind != board.EventMerged || last.Detail != "experiment.json" {
+		t.Errorf("last event = %+v; want merged from experiment.json", last)
+	}
+
+	if _, err := g.Merge("missing", incoming, ""); err != ErrProjectNotFound {
+		t.Errorf("Merge into missing project: err = %v; want ErrProjectNotFound", err)
+	}
+}
+
+func TestGlobalTicketStore_MergeSequentialIDs(t *testing.T) {
+	g := NewGlobalTicketStore(newRegistry())
+	g.AddProject(&Project{ID: "project-1", Name: "Test", RepoPath: t.TempDir()})
+	for _, id := range []board.TicketID{"1", "2"} {
+		tk := board.NewTicket("Here "+string(id), "project-1")
+		tk.ID = id
+		if err := g.Add(tk); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	// The other board numbered its tickets 1 to 3 too, and 1 is blocked by 3.
+	incoming := map[board.TicketID]*board.Ticket{}
+	for _, id := range []board.TicketID{"1", "2", "3"} {
+		tk := board.NewTicket("There "+string(id), "other")
+		tk.ID = id
+		incoming[id] = tk
+	}
+	incoming["1"].BlockedBy = []board.TicketID{"3"}
+
+	// Switched to only now, so that making the tickets above didn't count.
+	if err := board.SetIDScheme(board.IDSchemeSequential); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = board.SetIDScheme(board.IDSchemeUUID) })
+
+	result, err := g.Merge("project-1", incoming, "other.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Renamed) != 2 || result.Renamed["1"] == "3" || result.Renamed["2"] == "3" {
+		t.Fatalf("Renamed = %v; want 1 and 2 renamed past the incoming 3", result.Renamed)
+	}
+	if n := len(g.All()); n != 5 {
+		t.Errorf("store has %d tickets; want 5", n)
+	}
+	if got, _ := g.Get("3"); got == nil || got.Title != "There 3" {
+		t.Errorf("ticket 3 = %+v; want the incoming ticket 3", got)
+	}
+	blocked, _ := g.Get(result.Renamed["1"])
+	if blocked == nil || len(blocked.BlockedBy) != 1 || blocked.BlockedBy[0] != "3" {
+		t.Errorf("renamed ticket 1 = %+v; want it still blocked by 3", blocked)
+	}
+}
+
+func TestReadTicketsFile(t *testing.T) {
+	store := NewTicketStore("p", "")
+	ticket := board.NewTicket("From elsewhere", "p")
+	store.Add(ticket)
+	data, err := json.Marshal(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "other.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadTicketsFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Tickets[ticket.ID] == nil || got.Tickets[ticket.ID].Title != "From elsewhere" {
+		t.Errorf("ReadTicketsFile tickets = %v", got.Tickets)
+	}
+
+	if err := os.WriteFile(path, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadTicketsFile(path); err == nil {
+		t.Error("ReadTicketsFile of a JSON array: want an error")
+	}
+}
@@ -0,0 +1,177 @@
+package project
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+var (
+	ErrSprintNotFound  = errors.New("sprint not found")
+	ErrSprintAmbiguous = errors.New("sprint name matches more than one sprint")
+	ErrDuplicateSprint = errors.New("sprint with this name already exists")
+)
+
+// SprintStore holds the board's sprints. Sprints are shared across projects
+// so one timebox can cover work in several repositories.
+type SprintStore struct {
+	Sprints []*board.Sprint `json:"sprints"`
+}
+
+func sprintsPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sprints.json"), nil
+}
+
+func LoadSprints() (*SprintStore, error) {
+	path, err := sprintsPath()
+	if err != nil {
+		return &SprintStore{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &SprintStore{}, nil
+		}
+		return nil, err
+	}
+
+	var s SprintStore
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	s.sort()
+	return &s, nil
+}
+
+func (s *SprintStore) Save() error {
+	path, err := sprintsPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func (s *SprintStore) sort() {
+	sort.Slice(s.Sprints, func(i, j int) bool {
+		return s.Sprints[i].Start.Before(s.Sprints[j].Start)
+	})
+}
+
+// Add creates a sprint and saves the store.
+func (s *SprintStore) Add(name string, start, end time.Time) (*board.Sprint, error) {
+	for _, existing := range s.Sprints {
+		if strings.EqualFold(existing.Name, name) {
+			return nil, ErrDuplicateSprint
+		}
+	}
+	sprint := board.NewSprint(name, start, end)
+	s.Sprints = append(s.Sprints, sprint)
+	s.sort()
+	return sprint, s.Save()
+}
+
+func (s *SprintStore) Get(id string) *board.Sprint {
+	for _, sprint := range s.Sprints {
+		if sprint.ID == id {
+			return sprint
+		}
+	}
+	return nil
+}
+
+// Find resolves a sprint by ID, exact name, or unique name prefix, ignoring case.
+func (s *SprintStore) Find(nameOrID string) (*board.Sprint, error) {
+	query := strings.ToLower(nameOrID)
+	var matches []*board.Sprint
+	for _, sprint := range s.Sprints {
+		name := strings.ToLower(sprint.Name)
+		if sprint.ID == nameOrID || name == query {
+			return sprint, nil
+		}
+		if strings.HasPrefix(name, query) {
+			matches = append(matches, sprint)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, ErrSprintNotFound
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, ErrSprintAmbiguous
+	}
+}
+
+// Remove deletes a sprint and saves the store. Tickets keep their stale
+// SprintID; callers should clear it with ClearSprint.
+func (s *SprintStore) Remove(id string) error {
+	for i, sprint := range s.Sprints {
+		if sprint.ID == id {
+			s.Sprints = append(s.Sprints[:i], s.Sprints[i+1:]...)
+			return s.Save()
+		}
+	}
+	return ErrSprintNotFound
+}
+
+// Current returns the active sprint that ends soonest, or nil.
+func (s *SprintStore) Current(now time.Time) *board.Sprint {
+	var current *board.Sprint
+	for _, sprint := range s.Sprints {
+		if sprint.Active(now) && (current == nil || sprint.End.Before(current.End)) {
+			current = sprint
+		}
+	}
+	return current
+}
+
+// GetBySprint returns the non-archived tickets assigned to a sprint.
+func (g *GlobalTicketStore) GetBySprint(sprintID string) []*board.Ticket {
+	var tickets []*board.Ticket
+	for _, t := range g.allTickets {
+		if t.SprintID == sprintID && !t.Archived {
+			tickets = append(tickets, t)
+		}
+	}
+	return tickets
+}
+
+// ClearSprint unassigns every ticket from a deleted sprint and returns how
+// many were changed.
+func (g *GlobalTicketStore) ClearSprint(sprintID string) int {
+	count := 0
+	for _, t := range g.allTickets {
+		if t.SprintID == sprintID {
+			t.SprintID = ""
+			t.Touch()
+			count++
+		}
+	}
+	return count
+}
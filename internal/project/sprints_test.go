@@ -0,0 +1,78 @@
+package project
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSprintStore_AddFindCurrent(t *testing.T) {
+	t.Setenv("OPENKANBAN_CONFIG_DIR", t.TempDir())
+
+	store, err := LoadSprints()
+	if err != nil {
+		t.Fatalf("LoadSprints: %v", err)
+	}
+
+	now := time.Now()
+	past, err := store.Add("Sprint 1", now.AddDate(0, 0, -20), now.AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	current, _ := store.Add("Sprint 2", now.AddDate(0, 0, -3), now.AddDate(0, 0, 10))
+	store.Add("Hardening", now.AddDate(0, 0, 11), now.AddDate(0, 0, 20))
+
+	if _, err := store.Add("sprint 2", now, now); err != ErrDuplicateSprint {
+		t.Errorf("duplicate Add error = %v; want ErrDuplicateSprint", err)
+	}
+
+	if got := store.Current(now); got == nil || got.ID != current.ID {
+		t.Errorf("Current() = %v; want Sprint 2", got)
+	}
+
+	if got, err := store.Find("sprint 1"); err != nil || got.ID != past.ID {
+		t.Errorf("Find(exact) = %v, %v", got, err)
+	}
+	if got, err := store.Find("hard"); err != nil || got.Name != "Hardening" {
+		t.Errorf("Find(prefix) = %v, %v", got, err)
+	}
+	if _, err := store.Find("spr"); err != ErrSprintAmbiguous {
+		t.Errorf("Find(ambiguous) error = %v", err)
+	}
+	if _, err := store.Find("nope"); err != ErrSprintNotFound {
+		t.Errorf("Find(missing) error = %v", err)
+	}
+
+	reloaded, err := LoadSprints()
+	if err != nil || len(reloaded.Sprints) != 3 {
+		t.Fatalf("reloaded %d sprints, err %v; want 3", len(reloaded.Sprints), err)
+	}
+	if reloaded.Sprints[0].Name != "Sprint 1" {
+		t.Errorf("sprints should be sorted by start; first = %q", reloaded.Sprints[0].Name)
+	}
+
+	if err := store.Remove(past.ID); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if store.Get(past.ID) != nil {
+		t.Error("removed sprint should be gone")
+	}
+}
+
+func TestGlobalTicketStore_SprintTickets(t *testing.T) {
+	g, epic, story, task := newEpicStore(t)
+	epic.SprintID = "s1"
+	story.SprintID = "s1"
+	task.SprintID = "s1"
+	task.Archive()
+
+	if got := g.GetBySprint("s1"); len(got) != 2 {
+		t.Errorf("GetBySprint = %d tickets; want 2 (archived excluded)", len(got))
+	}
+
+	if n := g.ClearSprint("s1"); n != 3 {
+		t.Errorf("ClearSprint = %d; want 3", n)
+	}
+	if g.GetBySprint("s1") != nil {
+		t.Error("no tickets should remain in the sprint")
+	}
+}
@@ -2,6 +2,7 @@ package project
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -25,11 +26,19 @@ func ticketsDir() string {
 const ticketsFile = "tickets.json"
 
 type TicketStore struct {
-	ProjectID string                           `json:"project_id"`
-	Tickets   map[board.TicketID]*board.Ticket `json:"tickets"`
-	UpdatedAt time.Time                        `json:"updated_at"`
+	SchemaVersion int                              `json:"schema_version"`
+	WrittenBy     string                           `json:"written_by,omitempty"` // openkanban version that last saved
+	Features      []string                         `json:"features,omitempty"`   // Board features the tickets use
+	ProjectID     string                           `json:"project_id"`
+	Tickets       map[board.TicketID]*board.Ticket `json:"tickets"`
+	UpdatedAt     time.Time                        `json:"updated_at"`
+	Checksum      string                           `json:"checksum,omitempty"` // Of Tickets, as last saved
 
-	repoPath string
+	repoPath      string
+	diskChecksum  string                     // Checksum of the tickets last read from or written to disk
+	integrityErr  error                      // Checksum mismatch found on load
+	compatWarning error                      // File written by a newer openkanban
+	extra         map[string]json.RawMessage // Unknown top-level fields, written back on save
 }
 
 func NewTicketStore(projectID, repoPath string) *TicketStore {
@@ -83,6 +92,9 @@ func LoadTicketStore(project *Project) (*TicketStore, error) {
 		store.Tickets = make(map[board.TicketID]*board.Ticket)
 	}
 	store.repoPath = project.RepoPath
+	if err := store.verifyLoaded(newPath); err != nil {
+		return nil, err
+	}
 
 	return store, nil
 }
@@ -91,12 +103,42 @@ func (s *TicketStore) filePath() string {
 	return filepath.Join(ticketsDir(), s.ProjectID+".json")
 }
 
+// Save writes the store, refusing with a *ModifiedError if the file was
+// changed on disk since the store last read or wrote it, or was edited
+// outside openkanban before it was loaded.
 func (s *TicketStore) Save() error {
+	return s.save(true)
+}
+
+// ForceSave writes the store even if the file changed on disk.
+func (s *TicketStore) ForceSave() error {
+	return s.save(false)
+}
+
+func (s *TicketStore) save(checkUnchanged bool) error {
 	dir := ticketsDir()
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
+	path := s.filePath()
+	if checkUnchanged {
+		if s.integrityErr != nil {
+			return &ModifiedError{ProjectID: s.ProjectID, Path: path, Edited: true}
+		}
+		if err := s.checkUnchanged(path); err != nil {
+			return err
+		}
+	}
+
+	sum, err := ticketsChecksum(s.Tickets)
+	if err != nil {
+		return err
+	}
+	s.SchemaVersion = TicketSchemaVersion
+	s.WrittenBy = AppVersion
+	s.Features = s.usedFeatures()
+	s.Checksum = sum
 	s.UpdatedAt = time.Now()
 
 	data, err := json.MarshalIndent(s, "", "  ")
@@ -104,12 +146,16 @@ func (s *TicketStore) Save() error {
 		return err
 	}
 
-	path := s.filePath()
 	tmpPath := path + ".tmp"
 	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
 		return err
 	}
-	return os.Rename(tmpPath, path)
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	s.diskChecksum = sum
+	s.integrityErr = nil
+	return nil
 }
 
 func (s *TicketStore) Add(ticket *board.Ticket) {
@@ -205,6 +251,7 @@ func LoadGlobalTicketStore(registry *ProjectRegistry) (*GlobalTicketStore, error
 
 		for id, ticket := range store.Tickets {
 			g.allTickets[id] = ticket
+			board.NoteTicketID(id)
 		}
 	}
 
@@ -238,6 +285,7 @@ func (g *GlobalTicketStore) Add(ticket *board.Ticket) error {
 	}
 	store.Add(ticket)
 	g.allTickets[ticket.ID] = ticket
+	board.NoteTicketID(ticket.ID)
 	return nil
 }
 
@@ -276,13 +324,49 @@ func (g *GlobalTicketStore) Save(ticket *board.Ticket) error {
 	return store.Save()
 }
 
+// SaveAll saves every project's tickets. A project that can't be saved
+// doesn't stop the others; the errors are joined.
 func (g *GlobalTicketStore) SaveAll() error {
+	var errs []error
 	for _, store := range g.ticketStores {
 		if err := store.Save(); err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	return errors.Join(errs...)
+}
+
+// ForceSave saves a project's tickets even if the file changed on disk.
+func (g *GlobalTicketStore) ForceSave(projectID string) error {
+	store := g.ticketStores[projectID]
+	if store == nil {
+		return ErrProjectNotFound
+	}
+	return store.ForceSave()
+}
+
+// IntegrityErrors returns the checksum mismatches found loading each
+// project's tickets.
+func (g *GlobalTicketStore) IntegrityErrors() []error {
+	var errs []error
+	for _, p := range g.Projects() {
+		if store := g.ticketStores[p.ID]; store != nil && store.IntegrityErr() != nil {
+			errs = append(errs, store.IntegrityErr())
+		}
+	}
+	return errs
+}
+
+// CompatWarnings returns a warning for each project whose tickets were
+// saved by a newer openkanban.
+func (g *GlobalTicketStore) CompatWarnings() []error {
+	var errs []error
+	for _, p := range g.Projects() {
+		if store := g.ticketStores[p.ID]; store != nil && store.CompatWarning() != nil {
+			errs = append(errs, store.CompatWarning())
+		}
+	}
+	return errs
 }
 
 func (g *GlobalTicketStore) GetByStatus(status board.TicketStatus) []*board.Ticket {
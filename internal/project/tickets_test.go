@@ -1,8 +1,11 @@
 package project
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/techdufus/openkanban/internal/board"
@@ -347,3 +350,241 @@ func TestGlobalTicketStore_RemoveProjectArchive(t *testing.T) {
 		t.Error("original ticket file should not exist after archiving")
 	}
 }
+
+func TestTicketStore_SaveRefusesExternalChange(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("OPENKANBAN_CONFIG_DIR", configDir)
+	p := &Project{ID: "project-1", RepoPath: t.TempDir()}
+
+	store := NewTicketStore(p.ID, p.RepoPath)
+	store.Add(board.NewTicket("Ours", p.ID))
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatalf("second Save() error: %v", err)
+	}
+
+	// Another openkanban loads the same file and saves a change.
+	other, err := LoadTicketStore(p)
+	if err != nil {
+		t.Fatalf("LoadTicketStore() error: %v", err)
+	}
+	other.Add(board.NewTicket("Theirs", p.ID))
+	if err := other.Save(); err != nil {
+		t.Fatalf("other Save() error: %v", err)
+	}
+
+	var modified *ModifiedError
+	if err := store.Save(); !errors.As(err, &modified) {
+		t.Fatalf("Save() error = %v; want *ModifiedError", err)
+	}
+	if modified.ProjectID != p.ID {
+		t.Errorf("ModifiedError.ProjectID = %q; want %q", modified.ProjectID, p.ID)
+	}
+
+	if err := store.ForceSave(); err != nil {
+		t.Fatalf("ForceSave() error: %v", err)
+	}
+	if err := store.Save(); err != nil {
+		t.Errorf("Save() after ForceSave() error: %v", err)
+	}
+}
+
+func TestLoadTicketStore_ChecksumMismatch(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("OPENKANBAN_CONFIG_DIR", configDir)
+	p := &Project{ID: "project-1", RepoPath: t.TempDir()}
+
+	store := NewTicketStore(p.ID, p.RepoPath)
+	store.Add(board.NewTicket("Original title", p.ID))
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := LoadTicketStore(p)
+	if err != nil {
+		t.Fatalf("LoadTicketStore() error: %v", err)
+	}
+	if loaded.IntegrityErr() != nil {
+		t.Errorf("IntegrityErr() = %v for an untouched file", loaded.IntegrityErr())
+	}
+
+	path := filepath.Join(configDir, "tickets", "project-1.json")
+	data, _ := os.ReadFile(path)
+	os.WriteFile(path, []byte(strings.Replace(string(data), "Original title", "Edited title", 1)), 0644)
+
+	loaded, err = LoadTicketStore(p)
+	if err != nil {
+		t.Fatalf("LoadTicketStore() error: %v", err)
+	}
+	if loaded.IntegrityErr() == nil {
+		t.Error("IntegrityErr() = nil for a hand-edited file")
+	}
+	// The edit is only saved over once it has been accepted.
+	var modified *ModifiedError
+	if err := loaded.Save(); !errors.As(err, &modified) || !modified.Edited {
+		t.Fatalf("Save() of a hand-edited file = %v; want an edited *ModifiedError", err)
+	}
+	if err := loaded.ForceSave(); err != nil {
+		t.Fatalf("ForceSave() error: %v", err)
+	}
+	if loaded.IntegrityErr() != nil {
+		t.Errorf("IntegrityErr() = %v after the edit was accepted", loaded.IntegrityErr())
+	}
+	if err := loaded.Save(); err != nil {
+		t.Errorf("Save() after ForceSave() error: %v", err)
+	}
+}
+
+func TestLoadTicketStore_NewerSchema(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("OPENKANBAN_CONFIG_DIR", configDir)
+	p := &Project{ID: "project-1", RepoPath: t.TempDir()}
+
+	os.MkdirAll(filepath.Join(configDir, "tickets"), 0755)
+	data := fmt.Sprintf(`{"schema_version": %d, "project_id": "project-1", "tickets": {}}`, TicketSchemaVersion+1)
+	os.WriteFile(filepath.Join(configDir, "tickets", "project-1.json"), []byte(data), 0644)
+
+	if _, err := LoadTicketStore(p); err == nil {
+		t.Error("LoadTicketStore() should refuse a newer schema version")
+	}
+}
+
+func TestTicketStore_SaveStampsVersionAndFeatures(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("OPENKANBAN_CONFIG_DIR", configDir)
+	defer func(v string) { AppVersion = v }(AppVersion)
+	AppVersion = "1.4.0"
+	p := &Project{ID: "project-1", RepoPath: t.TempDir()}
+
+	store := NewTicketStore(p.ID, p.RepoPath)
+	epic := board.NewTicket("Epic", p.ID)
+	child := board.NewTicket("Child", p.ID)
+	child.ParentID = epic.ID
+	child.Status = "review"
+	store.Add(epic)
+	store.Add(child)
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := LoadTicketStore(p)
+	if err != nil {
+		t.Fatalf("LoadTicketStore() error: %v", err)
+	}
+	if loaded.WrittenBy != "1.4.0" {
+		t.Errorf("WrittenBy = %q, want 1.4.0", loaded.WrittenBy)
+	}
+	want := []string{FeatureCustomColumns, FeatureEpics}
+	if fmt.Sprint(loaded.Features) != fmt.Sprint(want) {
+		t.Errorf("Features = %v, want %v", loaded.Features, want)
+	}
+	if loaded.CompatWarning() != nil {
+		t.Errorf("CompatWarning() = %v for a file this version wrote", loaded.CompatWarning())
+	}
+}
+
+func TestLoadTicketStore_NewerBoard(t *testing.T) {
+	defer func(v string) { AppVersion = v }(AppVersion)
+	AppVersion = "1.4.0"
+
+	tests := []struct {
+		name     string
+		data     string
+		wantWarn string
+	}{
+		{
+			name: "same version",
+			data: `{"schema_version": 1, "written_by": "1.4.0", "features": ["comments"], "project_id": "project-1", "tickets": {}}`,
+		},
+		{
+			name: "dev build",
+			data: `{"schema_version": 1, "written_by": "dev", "project_id": "project-1", "tickets": {}}`,
+		},
+		{
+			name:     "newer version",
+			data:     `{"schema_version": 1, "written_by": "v1.10.0", "project_id": "project-1", "tickets": {}}`,
+			wantWarn: "openkanban v1.10.0",
+		},
+		{
+			name:     "unknown feature",
+			data:     `{"schema_version": 1, "features": ["swimlanes"], "project_id": "project-1", "tickets": {}}`,
+			wantWarn: "(swimlanes)",
+		},
+		{
+			name:     "unknown ticket field",
+			data:     `{"schema_version": 1, "project_id": "project-1", "tickets": {"t1": {"id": "t1", "title": "A", "status": "backlog", "lane": "infra"}}}`,
+			wantWarn: "newer openkanban",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configDir := t.TempDir()
+			t.Setenv("OPENKANBAN_CONFIG_DIR", configDir)
+			p := &Project{ID: "project-1", RepoPath: t.TempDir()}
+			os.MkdirAll(filepath.Join(configDir, "tickets"), 0755)
+			os.WriteFile(filepath.Join(configDir, "tickets", "project-1.json"), []byte(tt.data), 0644)
+
+			store, err := LoadTicketStore(p)
+			if err != nil {
+				t.Fatalf("LoadTicketStore() error: %v", err)
+			}
+			warn := store.CompatWarning()
+			switch {
+			case tt.wantWarn == "" && warn != nil:
+				t.Errorf("CompatWarning() = %v, want nil", warn)
+			case tt.wantWarn != "" && (warn == nil || !strings.Contains(warn.Error(), tt.wantWarn)):
+				t.Errorf("CompatWarning() = %v, want it to mention %q", warn, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestTicketStore_SaveKeepsUnknownData(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("OPENKANBAN_CONFIG_DIR", configDir)
+	p := &Project{ID: "project-1", RepoPath: t.TempDir()}
+	path := filepath.Join(configDir, "tickets", "project-1.json")
+	os.MkdirAll(filepath.Dir(path), 0755)
+	data := `{"schema_version": 1, "features": ["swimlanes"], "project_id": "project-1", "lanes": ["infra"],
+		"tickets": {"t1": {"id": "t1", "title": "A", "status": "backlog", "lane": "infra"}}}`
+	os.WriteFile(path, []byte(data), 0644)
+
+	store, err := LoadTicketStore(p)
+	if err != nil {
+		t.Fatalf("LoadTicketStore() error: %v", err)
+	}
+	store.Tickets["t1"].Title = "Renamed"
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	saved, _ := os.ReadFile(path)
+	for _, want := range []string{`"lanes"`, `"lane": "infra"`, `"swimlanes"`, `"Renamed"`} {
+		if !strings.Contains(string(saved), want) {
+			t.Errorf("saved file lacks %s:\n%s", want, saved)
+		}
+	}
+}
+
+func TestVersionNewer(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"1.2.0", "1.1.9", true},
+		{"v1.10.0", "1.9.0", true},
+		{"1.2.0", "1.2.0", false},
+		{"1.2.0-rc1", "1.1.0", true},
+		{"1.1.0", "1.2.0", false},
+		{"dev", "1.0.0", false},
+		{"1.0.0", "dev", false},
+		{"", "1.0.0", false},
+	}
+	for _, tt := range tests {
+		if got := versionNewer(tt.a, tt.b); got != tt.expected {
+			t.Errorf("versionNewer(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package share
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// Changelog is the work finished since a release, for release notes.
+type Changelog struct {
+	// Since names where the changelog starts, such as a tag or a date.
+	Since     string
+	Generated time.Time
+	Groups    []ChangelogGroup
+}
+
+// ChangelogGroup is the finished tickets sharing a label.
+type ChangelogGroup struct {
+	// Label is empty for tickets without one.
+	Label   string
+	Entries []ChangelogEntry
+}
+
+// ChangelogEntry is one finished ticket.
+type ChangelogEntry struct {
+	Title    string
+	Project  string
+	PRURL    string
+	IssueURL string
+	// Commits are the short hashes of commits linked to the ticket.
+	Commits     []string
+	CompletedAt time.Time
+}
+
+// ChangelogGroups collects the tickets in Done that were completed after
+// since, leaving out abandoned ones, grouped by their first label: labels
+// in order, then unlabeled tickets. Entries are oldest first. project names
+// a ticket's board, or is nil for a single board.
+func ChangelogGroups(tickets []*board.Ticket, project func(*board.Ticket) string, since time.Time) []ChangelogGroup {
+	byLabel := make(map[string][]ChangelogEntry)
+	for _, t := range tickets {
+		if t.Status != board.StatusDone || t.CompletedAt == nil || t.CompletedAt.Before(since) {
+			continue
+		}
+		if t.Outcome == board.OutcomeAbandoned {
+			continue
+		}
+		entry := ChangelogEntry{
+			Title:       t.Title,
+			PRURL:       t.PRURL,
+			IssueURL:    t.IssueURL,
+			Commits:     linkedCommits(t),
+			CompletedAt: *t.CompletedAt,
+		}
+		if project != nil {
+			entry.Project = project(t)
+		}
+		label := ""
+		if len(t.Labels) > 0 {
+			label = t.Labels[0]
+		}
+		byLabel[label] = append(byLabel[label], entry)
+	}
+
+	groups := make([]ChangelogGroup, 0, len(byLabel))
+	for label, entries := range byLabel {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].CompletedAt.Before(entries[j].CompletedAt)
+		})
+		groups = append(groups, ChangelogGroup{Label: label, Entries: entries})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if (groups[i].Label == "") != (groups[j].Label == "") {
+			return groups[j].Label == ""
+		}
+		return groups[i].Label < groups[j].Label
+	})
+	return groups
+}
+
+// linkedCommits lists the short hashes of the commits in the ticket's
+// history, oldest first.
+func linkedCommits(t *board.Ticket) []string {
+	var commits []string
+	for _, e := range t.History {
+		if e.Kind != board.EventCommit {
+			continue
+		}
+		if hash, _, _ := strings.Cut(e.Detail, " "); hash != "" {
+			commits = append(commits, hash)
+		}
+	}
+	return commits
+}
+
+var pullNumber = regexp.MustCompile(`/(?:pull|merge_requests)/(\d+)`)
+
+// ChangelogMarkdown renders the changelog as a CHANGELOG-style section: a
+// heading, then a list of tickets per label linking their pull requests,
+// issues and commits.
+func ChangelogMarkdown(c Changelog) []byte {
+	var sb strings.Builder
+	sb.WriteString("## Changes")
+	if c.Since != "" {
+		sb.WriteString(" since " + escapeMarkdown(c.Since))
+	}
+	total := 0
+	for _, g := range c.Groups {
+		total += len(g.Entries)
+	}
+	fmt.Fprintf(&sb, "\n\n_Generated %s, %d tickets_\n", c.Generated.Format("2006-01-02"), total)
+
+	for _, g := range c.Groups {
+		label := g.Label
+		if label == "" {
+			label = "Other"
+		}
+		sb.WriteString("\n### " + escapeMarkdown(label) + "\n\n")
+		for _, e := range g.Entries {
+			sb.WriteString("- " + escapeMarkdown(e.Title))
+			var links []string
+			if e.PRURL != "" {
+				text := "PR"
+				if m := pullNumber.FindStringSubmatch(e.PRURL); m != nil {
+					text = "#" + m[1]
+				}
+				links = append(links, "["+text+"]("+e.PRURL+")")
+			}
+			if e.IssueURL != "" {
+				links = append(links, "[issue]("+e.IssueURL+")")
+			}
+			for _, hash := range e.Commits {
+				links = append(links, "`"+hash+"`")
+			}
+			if len(links) > 0 {
+				sb.WriteString(" (" + strings.Join(links, ", ") + ")")
+			}
+			if e.Project != "" {
+				sb.WriteString(" — " + escapeMarkdown(e.Project))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	if total == 0 {
+		sb.WriteString("\nNo tickets were finished.\n")
+	}
+	return []byte(sb.String())
+}
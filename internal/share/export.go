@@ -0,0 +1,124 @@
+package share
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// Export is a selection of tickets pulled from the board by a query, to hand
+// over without the rest of the board.
+type Export struct {
+	Query     string           `json:"query,omitempty"`
+	Generated time.Time        `json:"generated"`
+	Tickets   []ExportedTicket `json:"tickets"`
+}
+
+// ExportedTicket is a ticket's content as exported: unlike a snapshot card it
+// keeps the description and comments, but leaves out local details such as
+// worktree paths, agent sessions and the audit history.
+type ExportedTicket struct {
+	ID          board.TicketID  `json:"id"`
+	Project     string          `json:"project,omitempty"`
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	Status      string          `json:"status"`
+	Priority    int             `json:"priority,omitempty"`
+	Labels      []string        `json:"labels,omitempty"`
+	Assignee    string          `json:"assignee,omitempty"`
+	Branch      string          `json:"branch,omitempty"`
+	PRURL       string          `json:"pr_url,omitempty"`
+	IssueURL    string          `json:"issue_url,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	Comments    []board.Comment `json:"comments,omitempty"`
+}
+
+// NewExportedTicket exports ticket, which belongs to the named project and
+// sits in the named column.
+func NewExportedTicket(ticket *board.Ticket, project, status string) ExportedTicket {
+	return ExportedTicket{
+		ID:          ticket.ID,
+		Project:     project,
+		Title:       ticket.Title,
+		Description: ticket.Description,
+		Status:      status,
+		Priority:    ticket.Priority,
+		Labels:      ticket.Labels,
+		Assignee:    ticket.Assignee,
+		Branch:      ticket.BranchName,
+		PRURL:       ticket.PRURL,
+		IssueURL:    ticket.IssueURL,
+		CreatedAt:   ticket.CreatedAt,
+		UpdatedAt:   ticket.UpdatedAt,
+		CompletedAt: ticket.CompletedAt,
+		Comments:    ticket.Comments,
+	}
+}
+
+// ExportJSON renders the export as indented JSON.
+func ExportJSON(e Export) ([]byte, error) {
+	if e.Tickets == nil {
+		e.Tickets = []ExportedTicket{}
+	}
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// ExportMarkdown renders the export as a markdown document, one section per
+// ticket.
+func ExportMarkdown(e Export) []byte {
+	var sb strings.Builder
+	sb.WriteString("# Tickets")
+	if e.Query != "" {
+		sb.WriteString(" matching `" + strings.ReplaceAll(e.Query, "`", "'") + "`")
+	}
+	fmt.Fprintf(&sb, "\n\n_Exported %s, %d tickets_\n", e.Generated.Format("2006-01-02 15:04 MST"), len(e.Tickets))
+
+	for _, t := range e.Tickets {
+		sb.WriteString("\n## ")
+		if mark := (Card{Priority: t.Priority}).PriorityMark(); mark != "" {
+			sb.WriteString("**" + mark + "** ")
+		}
+		sb.WriteString(escapeMarkdown(t.Title) + "\n\n")
+
+		details := []string{"Status: " + escapeMarkdown(t.Status)}
+		if t.Project != "" {
+			details = append(details, "Project: "+escapeMarkdown(t.Project))
+		}
+		if t.Assignee != "" {
+			details = append(details, "Assignee: @"+escapeMarkdown(t.Assignee))
+		}
+		if len(t.Labels) > 0 {
+			details = append(details, "Labels: `"+strings.Join(t.Labels, "` `")+"`")
+		}
+		if t.Branch != "" {
+			details = append(details, "Branch: `"+t.Branch+"`")
+		}
+		if t.CompletedAt != nil {
+			details = append(details, "Completed: "+t.CompletedAt.Format("2006-01-02"))
+		}
+		for _, d := range details {
+			sb.WriteString("- " + d + "\n")
+		}
+
+		if desc := strings.TrimSpace(t.Description); desc != "" {
+			sb.WriteString("\n" + desc + "\n")
+		}
+		if len(t.Comments) > 0 {
+			sb.WriteString("\n### Comments\n\n")
+			for _, c := range t.Comments {
+				fmt.Fprintf(&sb, "- **%s** (%s): %s\n", escapeMarkdown(c.Author), c.CreatedAt.Format("2006-01-02 15:04"),
+					strings.ReplaceAll(strings.TrimSpace(c.Text), "\n", "\n  "))
+			}
+		}
+	}
+	return []byte(sb.String())
+}
@@ -0,0 +1,162 @@
+package share
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// FeedLimit is how many events the Atom feed keeps, newest first.
+const FeedLimit = 50
+
+// Feed event kinds.
+const (
+	FeedStarted    = "started"
+	FeedCompleted  = "completed"
+	FeedAgentError = "agent_error"
+)
+
+// FeedEntry is one board event in the feed.
+type FeedEntry struct {
+	TicketID board.TicketID
+	Kind     string
+	Title    string
+	Summary  string
+	Project  string
+	// Link is the ticket's pull request or issue, if it has one.
+	Link string
+	At   time.Time
+}
+
+// FeedEvents collects the events worth following from the tickets' history:
+// tickets started and completed, and agent runs that failed. The newest
+// limit of them are returned, newest first. project names a ticket's board,
+// or is nil for a single board.
+func FeedEvents(tickets []*board.Ticket, project func(*board.Ticket) string, limit int) []FeedEntry {
+	var entries []FeedEntry
+	for _, t := range tickets {
+		add := func(kind, title, summary string, at time.Time) {
+			e := FeedEntry{TicketID: t.ID, Kind: kind, Title: title, Summary: summary, At: at, Link: t.PRURL}
+			if e.Link == "" {
+				e.Link = t.IssueURL
+			}
+			if project != nil {
+				e.Project = project(t)
+			}
+			entries = append(entries, e)
+		}
+
+		for _, ev := range t.History {
+			if ev.Kind != board.EventMoved {
+				continue
+			}
+			switch _, to, _ := strings.Cut(ev.Detail, " → "); board.TicketStatus(to) {
+			case board.StatusInProgress:
+				add(FeedStarted, "Started: "+t.Title, "", ev.At)
+			case board.StatusDone:
+				summary := ""
+				if t.Outcome != board.OutcomeNone {
+					summary = "Outcome: " + string(t.Outcome)
+				}
+				add(FeedCompleted, "Completed: "+t.Title, summary, ev.At)
+			}
+		}
+		for _, run := range t.AgentRuns {
+			if run.Outcome != board.RunError || run.EndedAt == nil {
+				continue
+			}
+			summary := run.Agent + " stopped with an error"
+			if run.StartupError != "" {
+				summary = run.Agent + " failed to start: " + run.StartupError
+			}
+			add(FeedAgentError, "Agent failed: "+t.Title, summary, *run.EndedAt)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].At.After(entries[j].At)
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
+
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Updated   string      `xml:"updated"`
+	Author    atomAuthor  `xml:"author"`
+	Generator string      `xml:"generator"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title    string       `xml:"title"`
+	ID       string       `xml:"id"`
+	Updated  string       `xml:"updated"`
+	Link     *atomLink    `xml:"link,omitempty"`
+	Category atomCategory `xml:"category"`
+	Summary  string       `xml:"summary,omitempty"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// Atom renders the events as an Atom feed titled after the board. Entry IDs
+// come from the ticket and the time of the event, so a reader sees each
+// event once however often the feed is regenerated.
+func Atom(b Board, entries []FeedEntry) ([]byte, error) {
+	updated := b.Generated
+	if len(entries) > 0 {
+		updated = entries[0].At
+	}
+	feed := atomFeed{
+		Title:     b.Title,
+		ID:        "urn:openkanban:feed:" + board.Slugify(b.Title, 40),
+		Updated:   updated.UTC().Format(time.RFC3339),
+		Author:    atomAuthor{Name: "openkanban"},
+		Generator: "openkanban",
+	}
+	for _, e := range entries {
+		entry := atomEntry{
+			Title:    e.Title,
+			ID:       fmt.Sprintf("urn:openkanban:%s:%s:%d", e.TicketID, e.Kind, e.At.UnixNano()),
+			Updated:  e.At.UTC().Format(time.RFC3339),
+			Category: atomCategory{Term: e.Kind},
+			Summary:  e.Summary,
+		}
+		if e.Project != "" {
+			entry.Title += " (" + e.Project + ")"
+		}
+		if e.Link != "" {
+			entry.Link = &atomLink{Href: e.Link}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
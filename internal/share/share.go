@@ -0,0 +1,194 @@
+// Package share renders read-only board snapshots and publishes them for
+// people who follow the work without running openkanban.
+package share
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// Board is a snapshot of the board as shown, column by column.
+type Board struct {
+	Title     string
+	Generated time.Time
+	Columns   []Column
+}
+
+// Column is one board column and its cards in display order.
+type Column struct {
+	Name  string
+	Limit int
+	Cards []Card
+}
+
+// Card is the shareable part of a ticket; descriptions, comments and agent
+// transcripts are left out.
+type Card struct {
+	Title       string
+	Project     string
+	Labels      []string
+	Assignee    string
+	Priority    int
+	AgentStatus board.AgentStatus
+	Branch      string
+}
+
+// NewCard snapshots ticket, which belongs to the named project.
+func NewCard(ticket *board.Ticket, project string) Card {
+	return Card{
+		Title:       ticket.Title,
+		Project:     project,
+		Labels:      ticket.Labels,
+		Assignee:    ticket.Assignee,
+		Priority:    ticket.Priority,
+		AgentStatus: ticket.AgentStatus,
+		Branch:      ticket.BranchName,
+	}
+}
+
+// PriorityMark flags critical and high priority cards as on the board.
+func (c Card) PriorityMark() string {
+	switch c.Priority {
+	case 1:
+		return "!!"
+	case 2:
+		return "!"
+	}
+	return ""
+}
+
+// Agent is the card's agent status, or empty when no agent has run.
+func (c Card) Agent() string {
+	if c.AgentStatus == board.AgentNone || c.AgentStatus == "" {
+		return ""
+	}
+	return string(c.AgentStatus)
+}
+
+// Count is the column header's card count, against its limit if it has one.
+func (c Column) Count() string {
+	if c.Limit > 0 {
+		return fmt.Sprintf("%d/%d", len(c.Cards), c.Limit)
+	}
+	return fmt.Sprintf("%d", len(c.Cards))
+}
+
+// Markdown renders the snapshot as a markdown document, one section per
+// column.
+func Markdown(b Board) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", b.Title)
+	fmt.Fprintf(&sb, "_Snapshot taken %s_\n", b.Generated.Format("2006-01-02 15:04 MST"))
+
+	for _, col := range b.Columns {
+		fmt.Fprintf(&sb, "\n## %s (%s)\n\n", col.Name, col.Count())
+		if len(col.Cards) == 0 {
+			sb.WriteString("_Empty_\n")
+			continue
+		}
+		for _, c := range col.Cards {
+			sb.WriteString("- ")
+			if mark := c.PriorityMark(); mark != "" {
+				sb.WriteString("**" + mark + "** ")
+			}
+			sb.WriteString(escapeMarkdown(c.Title))
+
+			var details []string
+			if c.Project != "" {
+				details = append(details, escapeMarkdown(c.Project))
+			}
+			if c.Assignee != "" {
+				details = append(details, "@"+escapeMarkdown(c.Assignee))
+			}
+			for _, l := range c.Labels {
+				details = append(details, "`"+l+"`")
+			}
+			if agent := c.Agent(); agent != "" {
+				details = append(details, "agent "+agent)
+			}
+			if c.Branch != "" {
+				details = append(details, "branch `"+c.Branch+"`")
+			}
+			if len(details) > 0 {
+				sb.WriteString(" — " + strings.Join(details, " · "))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return []byte(sb.String())
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, "#", `\#`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+var htmlPage = template.Must(template.New("board").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { margin: 0; padding: 24px; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; background: #1e1e2e; color: #cdd6f4; }
+h1 { margin: 0 0 4px; font-size: 22px; }
+.generated { color: #7f849c; font-size: 13px; margin-bottom: 24px; }
+.board { display: flex; gap: 16px; align-items: flex-start; overflow-x: auto; }
+.column { flex: 1 0 260px; max-width: 360px; background: #181825; border-radius: 8px; padding: 12px; }
+.column h2 { margin: 0 0 12px; font-size: 15px; }
+.count { color: #7f849c; font-weight: normal; }
+.card { background: #313244; border-radius: 6px; padding: 10px 12px; margin-bottom: 8px; }
+.card .title { font-weight: 600; }
+.priority { color: #f38ba8; margin-right: 4px; }
+.meta { margin-top: 6px; font-size: 12px; color: #a6adc8; }
+.label { display: inline-block; background: #45475a; border-radius: 4px; padding: 0 6px; margin: 2px 4px 0 0; }
+.agent-working { color: #a6e3a1; } .agent-waiting { color: #f9e2af; } .agent-error { color: #f38ba8; }
+code { font-size: 11px; color: #89b4fa; }
+.empty { color: #6c7086; font-style: italic; font-size: 13px; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="generated">Snapshot taken {{.Generated.Format "2006-01-02 15:04 MST"}}</div>
+<div class="board">
+{{- range .Columns}}
+<section class="column">
+<h2>{{.Name}} <span class="count">{{.Count}}</span></h2>
+{{- range .Cards}}
+<div class="card">
+<div class="title">{{with .PriorityMark}}<span class="priority">{{.}}</span>{{end}}{{.Title}}</div>
+<div class="meta">
+{{- with .Project}}<span>{{.}}</span> {{end}}
+{{- with .Assignee}}<span>@{{.}}</span> {{end}}
+{{- with .Agent}}<span class="agent-{{.}}">● {{.}}</span>{{end}}
+{{- with .Labels}}<div>{{range .}}<span class="label">{{.}}</span>{{end}}</div>{{end}}
+{{- with .Branch}}<div><code>{{.}}</code></div>{{end}}
+</div>
+</div>
+{{- else}}
+<div class="empty">Empty</div>
+{{- end}}
+</section>
+{{- end}}
+</div>
+</body>
+</html>
+`))
+
+// HTML renders the snapshot as a self-contained page with the columns side
+// by side.
+func HTML(b Board) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlPage.Execute(&buf, b); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
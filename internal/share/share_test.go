@@ -0,0 +1,226 @@
+package share
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+func testBoard() Board {
+	return Board{
+		Title:     "Openkanban board",
+		Generated: time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+		Columns: []Column{
+			{Name: "Backlog", Cards: []Card{{Title: "Write *docs*", Project: "app", Labels: []string{"docs"}}}},
+			{Name: "In Progress", Limit: 3, Cards: []Card{{
+				Title:       "Fix <login>",
+				Project:     "app",
+				Assignee:    "sam",
+				Priority:    1,
+				AgentStatus: board.AgentWorking,
+				Branch:      "task/fix-login",
+			}}},
+			{Name: "Done"},
+		},
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	out := string(Markdown(testBoard()))
+
+	for _, want := range []string{
+		"# Openkanban board",
+		"_Snapshot taken 2026-10-18 09:30 UTC_",
+		"## Backlog (1)",
+		`- Write \*docs\* — app · ` + "`docs`",
+		"## In Progress (1/3)",
+		`- **!!** Fix \<login> — app · @sam · agent working · branch ` + "`task/fix-login`",
+		"## Done (0)\n\n_Empty_",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	out, err := HTML(testBoard())
+	if err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+	page := string(out)
+
+	for _, want := range []string{
+		"<title>Openkanban board</title>",
+		`<span class="count">1/3</span>`,
+		`<span class="priority">!!</span>Fix &lt;login&gt;`,
+		`<span class="agent-working">● working</span>`,
+		`<span class="label">docs</span>`,
+		`<div class="empty">Empty</div>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("html missing %q", want)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	at := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	e := Export{
+		Query:     "label:client-x",
+		Generated: at,
+		Tickets: []ExportedTicket{{
+			ID:          "t1",
+			Project:     "app",
+			Title:       "Fix *login*",
+			Description: "Redirect loops after SSO.",
+			Status:      "In Progress",
+			Priority:    1,
+			Labels:      []string{"client-x"},
+			Comments:    []board.Comment{{Author: "sam", CreatedAt: at, Text: "Reproduced"}},
+		}},
+	}
+
+	md := string(ExportMarkdown(e))
+	for _, want := range []string{
+		"# Tickets matching `label:client-x`",
+		"_Exported 2026-10-18 09:30 UTC, 1 tickets_",
+		`## **!!** Fix \*login\*`,
+		"- Status: In Progress\n- Project: app\n- Labels: `client-x`",
+		"Redirect loops after SSO.",
+		"- **sam** (2026-10-18 09:30): Reproduced",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	data, err := ExportJSON(e)
+	if err != nil {
+		t.Fatalf("ExportJSON() error = %v", err)
+	}
+	for _, want := range []string{`"query": "label:client-x"`, `"title": "Fix *login*"`, `"text": "Reproduced"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("json missing %q:\n%s", want, data)
+		}
+	}
+
+	empty, _ := ExportJSON(Export{Generated: at})
+	if !strings.Contains(string(empty), `"tickets": []`) {
+		t.Errorf("empty export should list no tickets:\n%s", empty)
+	}
+}
+
+func TestUpload_Command(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.md")
+	if err := os.WriteFile(path, []byte("# board"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	url, err := Upload(config.ShareSettings{
+		Target:  "command",
+		Command: `echo uploading >&2; echo "https://example.com/$(basename "$OPENKANBAN_SHARE_FILE")"; echo`,
+	}, path, "board")
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if url != "https://example.com/board.md" {
+		t.Errorf("Upload() = %q; want https://example.com/board.md", url)
+	}
+
+	_, err = Upload(config.ShareSettings{Target: "command", Command: "echo denied >&2; exit 1"}, path, "board")
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("Upload() error = %v; want the command's stderr", err)
+	}
+}
+
+func TestFeedEventsAndAtom(t *testing.T) {
+	at := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	login := board.NewTicket("Fix <login>", "p")
+	login.PRURL = "https://github.com/acme/app/pull/7"
+	login.Outcome = board.OutcomeShipped
+	login.History = []board.TicketEvent{
+		{Kind: board.EventCreated, At: at},
+		{Kind: board.EventMoved, At: at.Add(time.Hour), Detail: "backlog → in_progress"},
+		{Kind: board.EventMoved, At: at.Add(3 * time.Hour), Detail: "in_progress → done"},
+	}
+	ended := at.Add(2 * time.Hour)
+	docs := board.NewTicket("Write docs", "p")
+	docs.AgentRuns = []board.AgentRun{
+		{Agent: "claude", StartedAt: at, EndedAt: &ended, Outcome: board.RunError, StartupError: "not logged in"},
+		{Agent: "claude", StartedAt: at, EndedAt: &ended, Outcome: board.RunCompleted},
+	}
+
+	entries := FeedEvents([]*board.Ticket{docs, login}, func(*board.Ticket) string { return "app" }, 2)
+	if len(entries) != 2 || entries[0].Kind != FeedCompleted || entries[1].Kind != FeedAgentError {
+		t.Fatalf("FeedEvents = %+v; want the completion, then the failed run", entries)
+	}
+
+	out, err := Atom(Board{Title: "App board", Generated: at.Add(5 * time.Hour)}, entries)
+	if err != nil {
+		t.Fatalf("Atom() error = %v", err)
+	}
+	feed := string(out)
+	for _, want := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		"<id>urn:openkanban:feed:app-board</id>",
+		"<updated>2026-10-18T12:00:00Z</updated>",
+		"<title>Completed: Fix &lt;login&gt; (app)</title>",
+		`<link href="https://github.com/acme/app/pull/7"></link>`,
+		"<summary>Outcome: shipped</summary>",
+		"<summary>claude failed to start: not logged in</summary>",
+	} {
+		if !strings.Contains(feed, want) {
+			t.Errorf("feed missing %q:\n%s", want, feed)
+		}
+	}
+	if again, _ := Atom(Board{Title: "App board", Generated: at.Add(9 * time.Hour)}, entries); string(again) != feed {
+		t.Error("regenerating the feed should give the same entries and updated time")
+	}
+}
+
+func TestChangelog(t *testing.T) {
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	done := func(title string, completed time.Time, labels ...string) *board.Ticket {
+		tk := board.NewTicket(title, "p")
+		tk.Status = board.StatusDone
+		tk.CompletedAt = &completed
+		tk.Labels = labels
+		return tk
+	}
+	login := done("Fix login", since.Add(48*time.Hour), "bug", "auth")
+	login.PRURL = "https://github.com/acme/app/pull/7"
+	login.Record(board.EventCommit, "abc1234 Fix login OK-1 (Jo)")
+	crash := done("Fix crash", since.Add(24*time.Hour), "bug")
+	docs := done("Write docs", since.Add(time.Hour))
+	old := done("Old work", since.Add(-time.Hour), "bug")
+	dropped := done("Dropped", since.Add(time.Hour), "bug")
+	dropped.Outcome = board.OutcomeAbandoned
+	open := board.NewTicket("Still going", "p")
+
+	groups := ChangelogGroups([]*board.Ticket{login, crash, docs, old, dropped, open}, nil, since)
+	if len(groups) != 2 || groups[0].Label != "bug" || groups[1].Label != "" {
+		t.Fatalf("ChangelogGroups = %+v; want bug, then unlabeled", groups)
+	}
+	if bugs := groups[0].Entries; len(bugs) != 2 || bugs[0].Title != "Fix crash" || bugs[1].Title != "Fix login" {
+		t.Errorf("bug entries = %+v; want the crash, then the login fix", bugs)
+	}
+
+	md := string(ChangelogMarkdown(Changelog{Since: "v1.2.0", Generated: since.Add(72 * time.Hour), Groups: groups}))
+	for _, want := range []string{
+		"## Changes since v1.2.0",
+		"_Generated 2026-10-04, 3 tickets_",
+		"### bug",
+		"- Fix login ([#7](https://github.com/acme/app/pull/7), `abc1234`)",
+		"### Other\n\n- Write docs\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("ChangelogMarkdown missing %q in:\n%s", want, md)
+		}
+	}
+}
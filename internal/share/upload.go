@@ -0,0 +1,95 @@
+package share
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+// uploadTimeout bounds a single upload.
+const uploadTimeout = 2 * time.Minute
+
+// presignExpiry is how long a presigned S3 link stays valid, the most S3
+// allows.
+const presignExpiry = 7 * 24 * time.Hour
+
+// Upload publishes the snapshot at path to the configured target and returns
+// the URL to share. Gists go through the gh CLI and S3 through the aws CLI,
+// so both use whatever credentials those tools already have; a custom
+// command gets the file in OPENKANBAN_SHARE_FILE and prints the URL.
+func Upload(s config.ShareSettings, path, description string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+	defer cancel()
+
+	switch s.Target {
+	case "", "gist":
+		args := []string{"gist", "create", "--desc", description}
+		if s.Public {
+			args = append(args, "--public")
+		}
+		return run(ctx, exec.CommandContext(ctx, "gh", append(args, path)...))
+	case "s3":
+		name := filepath.Base(path)
+		dest := strings.TrimSuffix(s.S3URI, "/") + "/" + name
+		cp := exec.CommandContext(ctx, "aws", "s3", "cp", path, dest, "--content-type", contentType(path))
+		if _, err := run(ctx, cp); err != nil {
+			return "", err
+		}
+		if s.BaseURL != "" {
+			return strings.TrimSuffix(s.BaseURL, "/") + "/" + name, nil
+		}
+		expires := fmt.Sprintf("%d", int(presignExpiry.Seconds()))
+		return run(ctx, exec.CommandContext(ctx, "aws", "s3", "presign", dest, "--expires-in", expires))
+	case "command":
+		cmd := exec.CommandContext(ctx, "sh", "-c", s.Command)
+		cmd.Env = append(os.Environ(), "OPENKANBAN_SHARE_FILE="+path)
+		return run(ctx, cmd)
+	}
+	return "", fmt.Errorf("unknown share target %q", s.Target)
+}
+
+// run runs an upload step and returns the last line it printed, which is
+// where gh, aws and custom commands put the URL.
+func run(ctx context.Context, cmd *exec.Cmd) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("%s timed out after %s", cmd.Args[0], uploadTimeout)
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return "", fmt.Errorf("%s not found in PATH", cmd.Args[0])
+	}
+	if err != nil {
+		if reason := strings.TrimSpace(stderr.String()); reason != "" {
+			return "", fmt.Errorf("%s failed: %s", cmd.Args[0], reason)
+		}
+		return "", fmt.Errorf("%s failed: %w", cmd.Args[0], err)
+	}
+	return lastLine(stdout.String()), nil
+}
+
+func lastLine(out string) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+func contentType(path string) string {
+	switch filepath.Ext(path) {
+	case ".html":
+		return "text/html; charset=utf-8"
+	case ".atom":
+		return "application/atom+xml; charset=utf-8"
+	}
+	return "text/markdown; charset=utf-8"
+}
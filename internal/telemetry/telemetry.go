@@ -0,0 +1,184 @@
+// Package telemetry counts which openkanban features get used — commands,
+// agent types, board sizes — and, only for users who opted in, sends the
+// counts to the configured endpoint when openkanban exits. Counts are all it
+// records: never ticket titles, paths, prompts, or anything else typed into
+// the board. Count is a no-op until Start is called.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sendTimeout bounds how long exiting waits for the report.
+const sendTimeout = 3 * time.Second
+
+// QueueFile is the file, in the config directory, that CLI commands queue
+// their counts in for the board to send.
+const QueueFile = "telemetry-queue"
+
+// maxQueueBytes caps the queue, so counts from a CLI used without the board
+// don't pile up.
+const maxQueueBytes = 1 << 20
+
+// Options says where the report goes and which build sends it.
+type Options struct {
+	Endpoint string
+	Version  string
+}
+
+// Report is the body posted to the endpoint.
+type Report struct {
+	Version string         `json:"version"`
+	OS      string         `json:"os"`
+	Arch    string         `json:"arch"`
+	Counts  map[string]int `json:"counts"`
+}
+
+type recorder struct {
+	opts   Options
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// active is the recorder counts go to, nil unless the user opted in.
+var active atomic.Pointer[recorder]
+
+// Start begins counting for a report to opts.Endpoint.
+func Start(opts Options) error {
+	if opts.Endpoint == "" {
+		return fmt.Errorf("telemetry endpoint is not set")
+	}
+	active.Store(&recorder{opts: opts, counts: make(map[string]int)})
+	return nil
+}
+
+// Enabled reports whether usage is being counted.
+func Enabled() bool {
+	return active.Load() != nil
+}
+
+// Count adds one use of a feature, named like "command.grep" or
+// "agent.claude". Names must come from a fixed set, never from user input.
+func Count(name string) {
+	r := active.Load()
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.counts[name]++
+	r.mu.Unlock()
+}
+
+// Queue records one use of a feature in the queue file at path, for the
+// next report that loads it with LoadQueued. Short-lived commands, such as
+// the ones agent hooks run, queue their counts this way rather than each
+// waiting on the network to send a report.
+func Queue(path, name string) error {
+	if info, err := os.Stat(path); err == nil && info.Size() >= maxQueueBytes {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(name + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadQueued adds the counts queued at path to the report and empties the
+// queue. It is a no-op while counting is off, leaving the queue for later.
+func LoadQueued(path string) error {
+	if !Enabled() {
+		return nil
+	}
+	// Counts queued while this one is read start a new queue.
+	taken := path + ".loading"
+	if err := os.Rename(path, taken); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	data, err := os.ReadFile(taken)
+	os.Remove(taken)
+	if err != nil {
+		return err
+	}
+	for _, name := range strings.Split(string(data), "\n") {
+		if name = strings.TrimSpace(name); name != "" {
+			Count(name)
+		}
+	}
+	return nil
+}
+
+// Stop sends what was counted and turns counting off. A report that can't
+// be sent is dropped.
+func Stop() error {
+	r := active.Swap(nil)
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	report := Report{Version: r.opts.Version, OS: runtime.GOOS, Arch: runtime.GOARCH, Counts: r.counts}
+	r.mu.Unlock()
+	if len(report.Counts) == 0 {
+		return nil
+	}
+	return send(r.opts.Endpoint, report)
+}
+
+func send(endpoint string, report Report) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send usage report: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to send usage report: %s", resp.Status)
+	}
+	return nil
+}
+
+// SizeBucket coarsens a count, such as a board's tickets, so reports say
+// how big a board is without saying exactly.
+func SizeBucket(n int) string {
+	switch {
+	case n == 0:
+		return "0"
+	case n <= 10:
+		return "1-10"
+	case n <= 50:
+		return "11-50"
+	case n <= 200:
+		return "51-200"
+	case n <= 1000:
+		return "201-1000"
+	default:
+		return "1000+"
+	}
+}
@@ -0,0 +1,130 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCountWhileOff(t *testing.T) {
+	Stop()
+	Count("command.grep")
+	if Enabled() {
+		t.Error("Enabled() = true, want false")
+	}
+	if err := Stop(); err != nil {
+		t.Errorf("Stop() while off = %v", err)
+	}
+}
+
+func TestReport(t *testing.T) {
+	var got Report
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("bad payload: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	if err := Start(Options{Endpoint: srv.URL, Version: "1.2.3"}); err != nil {
+		t.Fatal(err)
+	}
+	Count("command.grep")
+	Count("command.grep")
+	Count("agent.claude")
+	if err := Stop(); err != nil {
+		t.Fatalf("Stop() error: %v", err)
+	}
+
+	if requests != 1 {
+		t.Fatalf("got %d requests, want 1", requests)
+	}
+	if got.Version != "1.2.3" || got.OS == "" || got.Arch == "" {
+		t.Errorf("report = %+v", got)
+	}
+	if got.Counts["command.grep"] != 2 || got.Counts["agent.claude"] != 1 || len(got.Counts) != 2 {
+		t.Errorf("counts = %v", got.Counts)
+	}
+}
+
+func TestReportSkippedWhenEmpty(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("empty report was sent")
+	}))
+	defer srv.Close()
+
+	if err := Start(Options{Endpoint: srv.URL}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Stop(); err != nil {
+		t.Errorf("Stop() error: %v", err)
+	}
+}
+
+func TestSizeBucket(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{0, "0"},
+		{1, "1-10"},
+		{10, "1-10"},
+		{11, "11-50"},
+		{200, "51-200"},
+		{201, "201-1000"},
+		{5000, "1000+"},
+	}
+	for _, tt := range tests {
+		if got := SizeBucket(tt.n); got != tt.expected {
+			t.Errorf("SizeBucket(%d) = %q, want %q", tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), QueueFile)
+	for _, name := range []string{"cli.emit-status", "cli.emit-status", "cli.share"} {
+		if err := Queue(path, name); err != nil {
+			t.Fatalf("Queue() error: %v", err)
+		}
+	}
+
+	// Nothing is taken from the queue while counting is off.
+	Stop()
+	if err := LoadQueued(path); err != nil {
+		t.Fatalf("LoadQueued() while off = %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("queue emptied while off: %v", err)
+	}
+
+	var got Report
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &got)
+	}))
+	defer srv.Close()
+
+	if err := Start(Options{Endpoint: srv.URL}); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadQueued(path); err != nil {
+		t.Fatalf("LoadQueued() error: %v", err)
+	}
+	if err := Stop(); err != nil {
+		t.Fatalf("Stop() error: %v", err)
+	}
+	if got.Counts["cli.emit-status"] != 2 || got.Counts["cli.share"] != 1 {
+		t.Errorf("counts = %v", got.Counts)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("queue not emptied: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package terminal
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -13,6 +14,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/creack/pty"
 	"github.com/hinshun/vt10x"
+
+	"github.com/techdufus/openkanban/internal/tracing"
 )
 
 const (
@@ -39,6 +42,7 @@ type Pane struct {
 	renderScheduled bool
 
 	mouseEnabled bool // tracks if child process has enabled mouse tracking
+	bracketedPaste bool // tracks if child process has enabled bracketed paste
 
 	// Scrollback and viewport state (Issue #95)
 	scrollback      *ScrollbackBuffer
@@ -47,6 +51,10 @@ type Pane struct {
 	lastTopRow      []vt10x.Glyph // snapshot of row 0 before write for scroll detection
 	scrollbackSize  int      // configured scrollback buffer size
 	selection       *SelectionState // mouse text selection state
+
+	log io.WriteCloser // receives raw output as it is read; closed when the PTY closes
+
+	headless bool // runs without a terminal, its output piped rather than on a PTY
 }
 
 func New(id string, width, height int, scrollbackSize int) *Pane {
@@ -61,6 +69,28 @@ func New(id string, width, height int, scrollbackSize int) *Pane {
 	}
 }
 
+// SetLog sets where the pane's raw output is copied as it is read, like
+// tmux pipe-pane. It must be called before Start; the pane closes it once
+// the process's output ends. A log with a Resize(width, height int) method
+// is also told when the pane is resized.
+func (p *Pane) SetLog(w io.WriteCloser) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.log = w
+}
+
+// SetHeadless runs the pane's command without a terminal: it gets no input,
+// and its stdout and stderr are piped to the log and the pane's screen. It
+// must be called before Start.
+func (p *Pane) SetHeadless() {
+	p.headless = true
+}
+
+// Headless reports whether the pane runs its command without a terminal.
+func (p *Pane) Headless() bool {
+	return p.headless
+}
+
 // ID returns the pane's identifier
 func (p *Pane) ID() string {
 	return p.id
@@ -115,11 +145,14 @@ func (p *Pane) SetSize(width, height int) {
 		p.vt.Resize(width, height)
 	}
 
-	if p.pty != nil && p.running {
+	if p.pty != nil && p.running && !p.headless {
 		pty.Setsize(p.pty, &pty.Winsize{
 			Rows: uint16(height),
 			Cols: uint16(width),
 		})
+		if r, ok := p.log.(interface{ Resize(width, height int) }); ok {
+			r.Resize(width, height)
+		}
 	}
 }
 
@@ -162,9 +195,11 @@ type OutputMsg struct {
 	Data   []byte
 }
 
-// ExitMsg indicates the process has exited
+// ExitMsg indicates the process has exited. Pane tells it apart from a
+// newer pane started under the same ID.
 type ExitMsg struct {
 	PaneID string
+	Pane   *Pane
 	Err    error
 }
 
@@ -194,10 +229,26 @@ func (p *Pane) Start(command string, args ...string) tea.Cmd {
 		}
 
 		// Start PTY first so we can use it as vt10x writer
+		span := tracing.Begin("pane.start",
+			tracing.String("pane.id", p.id),
+			tracing.String("pane.command", command),
+			tracing.String("pane.dir", p.workdir))
+		if p.headless {
+			err := p.startHeadlessUnlocked()
+			span.End(err)
+			if err != nil {
+				return ExitMsg{PaneID: p.id, Pane: p, Err: err}
+			}
+			return p.readOutputUnlocked()()
+		}
 		ptmx, err := pty.Start(p.cmd)
+		span.End(err)
 		if err != nil {
 			p.exitErr = err
-			return ExitMsg{PaneID: p.id, Err: err}
+			if p.log != nil {
+				p.log.Close()
+			}
+			return ExitMsg{PaneID: p.id, Pane: p, Err: err}
 		}
 		p.pty = ptmx
 		p.running = true
@@ -220,6 +271,42 @@ func (p *Pane) Start(command string, args ...string) tea.Cmd {
 	}
 }
 
+// startHeadlessUnlocked starts the command with its output on a pipe, which
+// takes the PTY's place for reading. Must be called with mu held.
+func (p *Pane) startHeadlessUnlocked() error {
+	r, w, err := os.Pipe()
+	if err != nil {
+		p.exitErr = err
+		if p.log != nil {
+			p.log.Close()
+		}
+		return err
+	}
+	p.cmd.Env = append(p.cmd.Env, "TERM=dumb")
+	p.cmd.Stdout = w
+	p.cmd.Stderr = w
+	err = p.cmd.Start()
+	// The child holds its own copy of the write end; once it exits, reads
+	// see EOF.
+	w.Close()
+	if err != nil {
+		r.Close()
+		p.exitErr = err
+		if p.log != nil {
+			p.log.Close()
+		}
+		return err
+	}
+	p.pty = r
+	p.running = true
+	p.exitErr = nil
+
+	p.vt = vt10x.New(vt10x.WithSize(p.width, p.height))
+	p.scrollback = NewScrollbackBuffer(p.scrollbackSize)
+	p.selection = NewSelectionState()
+	return nil
+}
+
 func (p *Pane) Stop() error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -273,6 +360,9 @@ func (p *Pane) StopGraceful(timeout time.Duration) error {
 
 var ErrPaneNotRunning = fmt.Errorf("pane is not running")
 
+// ErrPaneHeadless is returned for input to a pane without a terminal.
+var ErrPaneHeadless = fmt.Errorf("pane has no terminal to type into")
+
 func (p *Pane) WriteInput(data []byte) (int, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -280,9 +370,27 @@ func (p *Pane) WriteInput(data []byte) (int, error) {
 	if !p.running || p.pty == nil {
 		return 0, ErrPaneNotRunning
 	}
+	if p.headless {
+		return 0, ErrPaneHeadless
+	}
 	return p.pty.Write(data)
 }
 
+// Paste types text into the program as a single paste. It is bracketed when
+// the program has asked for bracketed paste, so a line break in text doesn't
+// submit it early.
+func (p *Pane) Paste(text string) error {
+	p.mu.Lock()
+	bracketed := p.bracketedPaste
+	p.mu.Unlock()
+
+	if bracketed {
+		text = "\x1b[200~" + text + "\x1b[201~"
+	}
+	_, err := p.WriteInput([]byte(text))
+	return err
+}
+
 // readOutput returns a Cmd that reads from the PTY
 func (p *Pane) readOutput() tea.Cmd {
 	p.mu.Lock()
@@ -298,12 +406,26 @@ func (p *Pane) readOutputUnlocked() tea.Cmd {
 
 	ptyFile := p.pty
 	paneID := p.id
+	log := p.log
+	cmd := p.cmd
+	headless := p.headless
 
 	return func() tea.Msg {
 		buf := make([]byte, readBufferSize)
 		n, err := ptyFile.Read(buf)
+		if n > 0 && log != nil {
+			log.Write(buf[:n])
+		}
 		if err != nil {
-			return ExitMsg{PaneID: paneID, Err: err}
+			if log != nil {
+				log.Close()
+			}
+			if headless {
+				// The pipe closes as the process exits, which tells how it
+				// ended.
+				err = cmd.Wait()
+			}
+			return ExitMsg{PaneID: paneID, Pane: p, Err: err}
 		}
 		return OutputMsg{PaneID: paneID, Data: buf[:n]}
 	}
@@ -331,7 +453,7 @@ func (p *Pane) Update(msg tea.Msg) tea.Cmd {
 		return nil
 
 	case ExitMsg:
-		if msg.PaneID != p.id {
+		if msg.PaneID != p.id || (msg.Pane != nil && msg.Pane != p) {
 			return nil
 		}
 		p.mu.Lock()
@@ -357,6 +479,12 @@ func (p *Pane) handleOutput(data []byte) {
 
 	p.detectMouseModeChanges(data)
 	p.detectAltScreenChanges(data)
+	p.detectBracketedPasteChanges(data)
+
+	if p.headless {
+		// No terminal turns line feeds into new lines here, as a PTY would
+		data = bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))
+	}
 
 	// Capture scrollback: snapshot before, compare after
 	p.captureScrollbackBeforeWrite()
@@ -402,6 +530,17 @@ func (p *Pane) detectMouseModeChanges(data []byte) {
 	}
 }
 
+// detectBracketedPasteChanges scans output for the program turning
+// bracketed paste on or off; the last switch in data wins.
+// Called with mutex held.
+func (p *Pane) detectBracketedPasteChanges(data []byte) {
+	on := bytes.LastIndex(data, []byte("\x1b[?2004h"))
+	off := bytes.LastIndex(data, []byte("\x1b[?2004l"))
+	if on != off {
+		p.bracketedPaste = on > off
+	}
+}
+
 // detectAltScreenChanges scans output for alternate screen mode escape sequences.
 // Called with mutex held.
 func (p *Pane) detectAltScreenChanges(data []byte) {
@@ -876,6 +1015,53 @@ func (p *Pane) GetContent() string {
 	return result.String()
 }
 
+// Transcript returns up to maxLines of plain-text output, combining the
+// scrollback history with the current screen. Trailing blank lines are dropped.
+func (p *Pane) Transcript(maxLines int) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var lines []string
+	if p.scrollback != nil {
+		for _, line := range p.scrollback.GetRange(0, p.scrollback.Len()) {
+			lines = append(lines, glyphsToText(line))
+		}
+	}
+
+	if p.vt != nil {
+		p.vt.Lock()
+		cols, rows := p.vt.Size()
+		for row := 0; row < rows; row++ {
+			line := make([]vt10x.Glyph, cols)
+			for col := 0; col < cols; col++ {
+				line[col] = p.vt.Cell(col, row)
+			}
+			lines = append(lines, glyphsToText(line))
+		}
+		p.vt.Unlock()
+	}
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if maxLines > 0 && len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func glyphsToText(line []vt10x.Glyph) string {
+	var b strings.Builder
+	for _, g := range line {
+		ch := g.Char
+		if ch == 0 {
+			ch = ' '
+		}
+		b.WriteRune(ch)
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
 // --- Rendering (Issue #14) ---
 
 // View returns the rendered terminal content
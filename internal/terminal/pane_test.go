@@ -1,7 +1,11 @@
 package terminal
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"os/exec"
+	"strings"
 	"testing"
 )
 
@@ -154,6 +158,32 @@ func TestDetectAltScreenChanges(t *testing.T) {
 	}
 }
 
+func TestDetectBracketedPasteChanges(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          []byte
+		initialState  bool
+		expectedState bool
+	}{
+		{"Enable", []byte("\x1b[?2004h"), false, true},
+		{"Disable", []byte("\x1b[?2004l"), true, false},
+		{"Last switch wins", []byte("\x1b[?2004h prompt \x1b[?2004l"), false, false},
+		{"Re-enabled", []byte("\x1b[?2004l\x1b[?2004h"), true, true},
+		{"No sequence - state unchanged", []byte("Hello World"), true, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pane := New("test", 80, 24, 1000)
+			pane.bracketedPaste = tc.initialState
+			pane.detectBracketedPasteChanges(tc.data)
+			if pane.bracketedPaste != tc.expectedState {
+				t.Errorf("expected bracketedPaste=%v, got %v", tc.expectedState, pane.bracketedPaste)
+			}
+		})
+	}
+}
+
 func TestViewportScrolling(t *testing.T) {
 	pane := New("test", 80, 24, 100)
 	pane.scrollback = NewScrollbackBuffer(100)
@@ -187,3 +217,47 @@ func TestViewportScrolling(t *testing.T) {
 		t.Errorf("scrollDown beyond 0 should cap at 0, got %d", pane.viewportOffset)
 	}
 }
+
+type testLog struct{ bytes.Buffer }
+
+func (l *testLog) Close() error { return nil }
+
+func TestHeadlessPane(t *testing.T) {
+	pane := New("test", 80, 24, 100)
+	pane.SetHeadless()
+	log := &testLog{}
+	pane.SetLog(log)
+
+	msg := pane.Start("sh", "-c", "printf 'one\\ntwo\\n'; exit 3")()
+	if _, err := pane.WriteInput([]byte("x")); err != ErrPaneHeadless {
+		t.Errorf("WriteInput() error = %v, want ErrPaneHeadless", err)
+	}
+	for {
+		out, ok := msg.(OutputMsg)
+		if !ok {
+			break
+		}
+		pane.handleOutput(out.Data)
+		msg = pane.readOutput()()
+	}
+
+	exit, ok := msg.(ExitMsg)
+	if !ok {
+		t.Fatalf("got %T, want ExitMsg", msg)
+	}
+	if exit.Pane != pane {
+		t.Errorf("ExitMsg.Pane = %p, want the pane that exited", exit.Pane)
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(exit.Err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Errorf("ExitMsg.Err = %v, want exit status 3", exit.Err)
+	}
+	if got := log.String(); got != "one\ntwo\n" {
+		t.Errorf("log = %q, want %q", got, "one\ntwo\n")
+	}
+	// Each line starts at the left edge, as on a terminal
+	lines := strings.Split(pane.GetContent(), "\n")
+	if strings.TrimSpace(lines[0]) != "one" || strings.TrimSpace(lines[1]) != "two" {
+		t.Errorf("GetContent() lines = %q, want one, two", lines[:2])
+	}
+}
@@ -0,0 +1,254 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	exportInterval = 5 * time.Second
+	exportTimeout  = 10 * time.Second
+	// stopTimeout bounds how long quitting waits for the last export.
+	stopTimeout = 3 * time.Second
+	maxBatch    = 512
+	// queueSize caps the spans waiting for export; more are dropped rather
+	// than slowing down the operations being traced.
+	queueSize = 4096
+)
+
+// Options says where spans go and what produced them.
+type Options struct {
+	Endpoint       string // OTLP/HTTP collector; "/v1/traces" is added unless present
+	Headers        map[string]string
+	ServiceName    string
+	ServiceVersion string
+}
+
+// record is a finished span waiting for export.
+type record struct {
+	traceID  string
+	spanID   string
+	parentID string
+	name     string
+	start    time.Time
+	end      time.Time
+	attrs    []Attr
+	err      string
+}
+
+type exporter struct {
+	url     string
+	opts    Options
+	client  *http.Client
+	queue   chan record
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// Start begins recording spans and exporting them in batches to
+// opts.Endpoint. Exports that fail are dropped: tracing never gets in the
+// way of the board.
+func Start(opts Options) error {
+	if opts.Endpoint == "" {
+		return fmt.Errorf("tracing endpoint is not set")
+	}
+	if opts.ServiceName == "" {
+		opts.ServiceName = "openkanban"
+	}
+	e := &exporter{
+		url:     tracesURL(opts.Endpoint),
+		opts:    opts,
+		client:  &http.Client{Timeout: exportTimeout},
+		queue:   make(chan record, queueSize),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if prev := active.Swap(e); prev != nil {
+		prev.shutdown()
+	}
+	go e.run()
+	return nil
+}
+
+// Stop exports the spans still queued and turns tracing off.
+func Stop() {
+	if e := active.Swap(nil); e != nil {
+		e.shutdown()
+	}
+}
+
+// tracesURL is the collector's trace endpoint, following the
+// OTEL_EXPORTER_OTLP_ENDPOINT convention of a base URL.
+func tracesURL(endpoint string) string {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if strings.HasSuffix(endpoint, "/v1/traces") {
+		return endpoint
+	}
+	return endpoint + "/v1/traces"
+}
+
+func (e *exporter) enqueue(r record) {
+	select {
+	case e.queue <- r:
+	default:
+	}
+}
+
+func (e *exporter) shutdown() {
+	close(e.stop)
+	select {
+	case <-e.stopped:
+	case <-time.After(stopTimeout):
+	}
+}
+
+func (e *exporter) run() {
+	defer close(e.stopped)
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+
+	var batch []record
+	flush := func() {
+		if len(batch) > 0 {
+			_ = e.export(batch)
+			batch = nil
+		}
+	}
+	for {
+		select {
+		case r := <-e.queue:
+			batch = append(batch, r)
+			if len(batch) >= maxBatch {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.stop:
+			for {
+				select {
+				case r := <-e.queue:
+					batch = append(batch, r)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// export sends a batch as an OTLP/HTTP JSON request.
+func (e *exporter) export(batch []record) error {
+	body, err := json.Marshal(e.payload(batch))
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.opts.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export spans: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to export spans: %s", resp.Status)
+	}
+	return nil
+}
+
+// The OTLP JSON encoding of an ExportTraceServiceRequest, as much of it as
+// openkanban fills in.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttr `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+	otlpSpan struct {
+		TraceID           string     `json:"traceId"`
+		SpanID            string     `json:"spanId"`
+		ParentSpanID      string     `json:"parentSpanId,omitempty"`
+		Name              string     `json:"name"`
+		Kind              int        `json:"kind"`
+		StartTimeUnixNano string     `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+		Attributes        []otlpAttr `json:"attributes,omitempty"`
+		Status            otlpStatus `json:"status"`
+	}
+	otlpAttr struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue string `json:"stringValue"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	}
+)
+
+const (
+	spanKindInternal = 1
+	statusCodeError  = 2
+)
+
+func (e *exporter) payload(batch []record) otlpRequest {
+	resource := []otlpAttr{{Key: "service.name", Value: otlpValue{e.opts.ServiceName}}}
+	if e.opts.ServiceVersion != "" {
+		resource = append(resource, otlpAttr{Key: "service.version", Value: otlpValue{e.opts.ServiceVersion}})
+	}
+	spans := make([]otlpSpan, 0, len(batch))
+	for _, r := range batch {
+		s := otlpSpan{
+			TraceID:           r.traceID,
+			SpanID:            r.spanID,
+			ParentSpanID:      r.parentID,
+			Name:              r.name,
+			Kind:              spanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(r.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(r.end.UnixNano(), 10),
+		}
+		for _, a := range r.attrs {
+			s.Attributes = append(s.Attributes, otlpAttr{Key: a.Key, Value: otlpValue{a.Value}})
+		}
+		if r.err != "" {
+			s.Status = otlpStatus{Code: statusCodeError, Message: r.err}
+		}
+		spans = append(spans, s)
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: resource},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/techdufus/openkanban", Version: e.opts.ServiceVersion},
+			Spans: spans,
+		}},
+	}}}
+}
@@ -0,0 +1,103 @@
+// Package tracing records spans for the slow things openkanban does — git,
+// agent, and terminal pane operations — and exports them to an
+// OpenTelemetry collector over OTLP/HTTP. Nothing is recorded until Start
+// is called with an endpoint, so instrumented code costs a nil check when
+// tracing is off.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Attr is a span attribute.
+type Attr struct {
+	Key   string
+	Value string
+}
+
+// String makes a span attribute.
+func String(key, value string) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+// Int makes a span attribute from a number.
+func Int(key string, value int) Attr {
+	return Attr{Key: key, Value: strconv.Itoa(value)}
+}
+
+// Span is an operation being timed. A nil Span, which Begin returns while
+// tracing is off, ignores every call.
+type Span struct {
+	exporter *exporter
+	traceID  string
+	spanID   string
+	parentID string
+	name     string
+	start    time.Time
+	attrs    []Attr
+	ended    atomic.Bool
+}
+
+// active is the exporter spans go to, nil while tracing is off.
+var active atomic.Pointer[exporter]
+
+// Enabled reports whether spans are being recorded.
+func Enabled() bool {
+	return active.Load() != nil
+}
+
+// Begin starts a span for an operation with its own trace.
+func Begin(name string, attrs ...Attr) *Span {
+	e := active.Load()
+	if e == nil {
+		return nil
+	}
+	return &Span{exporter: e, traceID: newID(16), spanID: newID(8), name: name, start: time.Now(), attrs: attrs}
+}
+
+// Child starts a span for an operation that is part of s.
+func (s *Span) Child(name string, attrs ...Attr) *Span {
+	if s == nil {
+		return nil
+	}
+	return &Span{exporter: s.exporter, traceID: s.traceID, spanID: newID(8), parentID: s.spanID, name: name, start: time.Now(), attrs: attrs}
+}
+
+// SetAttrs adds attributes learned while the operation ran.
+func (s *Span) SetAttrs(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.attrs = append(s.attrs, attrs...)
+}
+
+// End finishes the span, marking it failed when err is non-nil, and queues
+// it for export. Only the first call counts.
+func (s *Span) End(err error) {
+	if s == nil || s.ended.Swap(true) {
+		return
+	}
+	rec := record{
+		traceID:  s.traceID,
+		spanID:   s.spanID,
+		parentID: s.parentID,
+		name:     s.name,
+		start:    s.start,
+		end:      time.Now(),
+		attrs:    s.attrs,
+	}
+	if err != nil {
+		rec.err = err.Error()
+	}
+	s.exporter.enqueue(rec)
+}
+
+func newID(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
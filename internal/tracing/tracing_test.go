@@ -0,0 +1,100 @@
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBeginWhileOff(t *testing.T) {
+	Stop()
+	span := Begin("git status")
+	if span != nil {
+		t.Fatalf("Begin while off = %v, want nil", span)
+	}
+	// A nil span ignores everything.
+	span.SetAttrs(String("k", "v"))
+	span.Child("child").End(nil)
+	span.End(errors.New("boom"))
+	if Enabled() {
+		t.Error("Enabled() = true, want false")
+	}
+}
+
+func TestExport(t *testing.T) {
+	var got otlpRequest
+	var gotPath, gotHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotHeader = r.URL.Path, r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("bad payload: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	if err := Start(Options{Endpoint: srv.URL, Headers: map[string]string{"Authorization": "Bearer x"}, ServiceVersion: "1.2.3"}); err != nil {
+		t.Fatal(err)
+	}
+	parent := Begin("agent.spawn", String("ticket.id", "t1"))
+	child := parent.Child("git worktree", Int("exit", 0))
+	child.End(errors.New("worktree exists"))
+	parent.End(nil)
+	parent.End(nil)
+	Stop()
+
+	if gotPath != "/v1/traces" {
+		t.Errorf("path = %q, want /v1/traces", gotPath)
+	}
+	if gotHeader != "Bearer x" {
+		t.Errorf("Authorization = %q, want %q", gotHeader, "Bearer x")
+	}
+	if len(got.ResourceSpans) != 1 || len(got.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("payload = %+v, want one resource and scope", got)
+	}
+	if attr := got.ResourceSpans[0].Resource.Attributes[0]; attr.Key != "service.name" || attr.Value.StringValue != "openkanban" {
+		t.Errorf("resource attribute = %+v, want service.name openkanban", attr)
+	}
+	spans := got.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	c, p := spans[0], spans[1]
+	if c.Name != "git worktree" || p.Name != "agent.spawn" {
+		t.Errorf("span names = %q, %q", c.Name, p.Name)
+	}
+	if c.TraceID != p.TraceID || c.ParentSpanID != p.SpanID || p.ParentSpanID != "" {
+		t.Errorf("child %+v is not under parent %+v", c, p)
+	}
+	if len(p.TraceID) != 32 || len(p.SpanID) != 16 {
+		t.Errorf("ids = %q, %q, want 32 and 16 hex digits", p.TraceID, p.SpanID)
+	}
+	if c.Status.Code != statusCodeError || c.Status.Message != "worktree exists" {
+		t.Errorf("child status = %+v, want error", c.Status)
+	}
+	if p.Status.Code != 0 {
+		t.Errorf("parent status = %+v, want unset", p.Status)
+	}
+	if len(p.Attributes) != 1 || p.Attributes[0].Value.StringValue != "t1" {
+		t.Errorf("parent attributes = %+v", p.Attributes)
+	}
+}
+
+func TestTracesURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{"http://localhost:4318", "http://localhost:4318/v1/traces"},
+		{"http://localhost:4318/", "http://localhost:4318/v1/traces"},
+		{"https://otel.example.com/v1/traces", "https://otel.example.com/v1/traces"},
+	}
+	for _, tt := range tests {
+		if got := tracesURL(tt.endpoint); got != tt.expected {
+			t.Errorf("tracesURL(%q) = %q, want %q", tt.endpoint, got, tt.expected)
+		}
+	}
+}
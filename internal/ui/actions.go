@@ -0,0 +1,247 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/git"
+)
+
+// ticketAction is an entry in the ticket actions menu. Entries for board
+// actions name the binding, so the menu shows its key and the key runs it.
+type ticketAction struct {
+	label  string
+	action string
+	run    func() (tea.Model, tea.Cmd)
+}
+
+// execDoneMsg reports that a program the board handed the terminal to,
+// such as a shell or git's pager, has exited.
+type execDoneMsg struct {
+	what string
+	err  error
+}
+
+// openActions lists what can be done with the selected ticket.
+func (m *Model) openActions() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+	m.actions = m.ticketActions(ticket)
+	m.actionIndex = 0
+	m.mode = ModeActions
+	return m, nil
+}
+
+// ticketActions are the actions that apply to ticket right now.
+func (m *Model) ticketActions(ticket *board.Ticket) []ticketAction {
+	actions := []ticketAction{
+		{"Edit", "edit_ticket", m.editTicket},
+		{"Edit in $EDITOR", "edit_in_editor", m.editInEditor},
+		{"Details and comments", "ticket_details", m.openTicketDetail},
+		{"Move to…", "move_to", m.openMovePicker},
+	}
+
+	if pane, ok := m.panes[ticket.ID]; ok && pane.Running() {
+		actions = append(actions,
+			ticketAction{"Attach to agent", "attach_agent", m.attachToAgent},
+			ticketAction{"Stop agent", "stop_agent", m.stopAgent})
+	} else {
+		actions = append(actions, ticketAction{"Spawn agent", "spawn_agent", m.spawnAgent})
+	}
+	if ticket.AgentType != "" {
+		label := "Mute notifications"
+		if ticket.Muted {
+			label = "Unmute notifications"
+		}
+		actions = append(actions, ticketAction{label, "mute", m.toggleMute})
+	}
+	if ticket.UseWorktree && ticket.WorktreePath != "" && ticket.BranchName != "" {
+		actions = append(actions, ticketAction{"Retry in a clean worktree", "retry", m.confirmRetryTicket})
+	}
+	if len(ticket.Attempts()) > 1 {
+		actions = append(actions, ticketAction{"Compare attempts", "attempts", m.openAttempts})
+	}
+
+	if ticket.PRURL != "" {
+		actions = append(actions, ticketAction{"Open pull request", "", func() (tea.Model, tea.Cmd) {
+			m.openTicketURL("pull request", ticket.PRURL)
+			return m, nil
+		}})
+	}
+	if ticket.IssueURL != "" {
+		actions = append(actions, ticketAction{"Open issue", "", func() (tea.Model, tea.Cmd) {
+			m.openTicketURL("issue", ticket.IssueURL)
+			return m, nil
+		}})
+	}
+	if ticket.WorktreePath != "" {
+		if _, err := os.Stat(ticket.WorktreePath); err == nil {
+			actions = append(actions,
+				ticketAction{"Open shell in worktree", "", func() (tea.Model, tea.Cmd) {
+					return m, m.openWorktreeShell(ticket)
+				}},
+				ticketAction{"Open worktree folder", "", func() (tea.Model, tea.Cmd) {
+					m.openWorktreeDir(ticket)
+					return m, nil
+				}})
+		}
+	}
+	if ticket.BranchName != "" {
+		actions = append(actions,
+			ticketAction{"Copy branch name", "", func() (tea.Model, tea.Cmd) {
+				if err := clipboard.WriteAll(ticket.BranchName); err != nil {
+					m.notifyError("Copy failed: " + err.Error())
+				} else {
+					m.notifySuccess("Copied " + ticket.BranchName)
+				}
+				return m, nil
+			}},
+			ticketAction{"View diff", "", func() (tea.Model, tea.Cmd) {
+				return m, m.viewDiff(ticket)
+			}})
+	}
+
+	actions = append(actions, ticketAction{"Set epic", "set_epic", m.openParentPicker})
+	if ticket.Status == board.StatusDone {
+		actions = append(actions, ticketAction{"Archive", "archive", m.archiveTicket})
+	}
+	return append(actions, ticketAction{"Delete", "delete_ticket", m.confirmDeleteTicket})
+}
+
+func (m *Model) handleActionsMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", ".":
+		m.mode = ModeNormal
+	case "j", "down":
+		m.actionIndex = min(m.actionIndex+1, len(m.actions)-1)
+	case "k", "up":
+		m.actionIndex = max(m.actionIndex-1, 0)
+	case "enter":
+		return m.runAction(m.actionIndex)
+	default:
+		// An action's own key runs it from the menu too.
+		key := m.keymap.resolve(msg.String())
+		for i, a := range m.actions {
+			if a.action != "" && defaultKey(a.action) == key {
+				return m.runAction(i)
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) runAction(i int) (tea.Model, tea.Cmd) {
+	m.mode = ModeNormal
+	if i < 0 || i >= len(m.actions) {
+		return m, nil
+	}
+	return m.actions[i].run()
+}
+
+// defaultKey is the key handleNormalMode knows action by.
+func defaultKey(action string) string {
+	for _, b := range boardKeyBindings {
+		if b.action == action && len(b.keys) > 0 {
+			return b.keys[0]
+		}
+	}
+	return ""
+}
+
+// openWorktreeShell suspends the board and starts the user's shell in the
+// ticket's worktree; exiting the shell returns to the board.
+func (m *Model) openWorktreeShell(ticket *board.Ticket) tea.Cmd {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+	cmd := exec.Command(shell)
+	cmd.Dir = ticket.WorktreePath
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return execDoneMsg{what: "Shell", err: err}
+	})
+}
+
+// viewDiff suspends the board to show the ticket's changes against its base
+// branch in git's pager.
+func (m *Model) viewDiff(ticket *board.Ticket) tea.Cmd {
+	proj := m.globalStore.GetProjectForTicket(ticket)
+	if proj == nil {
+		m.notifyError("Project not found for this ticket")
+		return nil
+	}
+	base := ticket.BaseBranch
+	if base == "" {
+		if mgr := m.worktreeMgrs[proj.ID]; mgr != nil {
+			base, _ = mgr.GetDefaultBranch()
+		}
+	}
+	if base == "" {
+		m.notifyError("No base branch to diff against")
+		return nil
+	}
+
+	workdir := ""
+	if ticket.WorktreePath != "" {
+		if _, err := os.Stat(ticket.WorktreePath); err == nil {
+			workdir = ticket.WorktreePath
+		}
+	}
+	cmd := git.DiffCmd(proj.RepoPath, workdir, base, ticket.BranchName)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return execDoneMsg{what: "git diff", err: err}
+	})
+}
+
+func (m *Model) renderActions() string {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		return ""
+	}
+
+	width := min(50, m.width-4)
+	width = max(width, 36)
+	innerWidth := width - 4
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.colors.text).Background(m.colors.surface).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(m.colors.muted)
+
+	lines := []string{
+		titleStyle.Render(truncateString(ticket.Title, innerWidth)),
+		"",
+	}
+	for i, a := range m.actions {
+		key := ""
+		if a.action != "" {
+			key = m.keymap.label(a.action)
+		}
+		label := truncateString(a.label, innerWidth-lipgloss.Width(key)-4)
+		gap := strings.Repeat(" ", max(innerWidth-2-lipgloss.Width(label)-lipgloss.Width(key), 1))
+		if i == m.actionIndex {
+			lines = append(lines, selectedStyle.Render("▸ "+label+gap+key))
+		} else {
+			lines = append(lines, rowStyle.Render("  "+label+gap)+keyStyle.Render(key))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, m.dimStyle().Render("[j/k] Navigate  [Enter] Run  [Esc] Close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/git"
+)
+
+// adoptBranch links the selected ticket to a branch or worktree created
+// outside openkanban, so work already in flight comes under the board.
+func (m *Model) adoptBranch(ref string) (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+	if ref == "" {
+		m.notify("Usage: :adopt <branch or worktree path>")
+		return m, nil
+	}
+	if _, running := m.panes[ticket.ID]; running {
+		m.notify("Stop the ticket's agent before adopting a branch")
+		return m, nil
+	}
+
+	proj := m.globalStore.GetProjectForTicket(ticket)
+	if proj == nil {
+		m.notifyError("Project not found for this ticket")
+		return m, nil
+	}
+	mgr := m.worktreeMgrs[proj.ID]
+	if mgr == nil {
+		m.notifyError("Worktree manager not found")
+		return m, nil
+	}
+
+	if strings.HasPrefix(ref, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			ref = filepath.Join(home, ref[2:])
+		}
+	}
+	adoption, err := mgr.Inspect(ref)
+	if err != nil {
+		m.notifyError("Can't adopt: " + err.Error())
+		return m, nil
+	}
+	if adoption.Branch == ticket.BranchName && adoption.WorktreePath == ticket.WorktreePath {
+		m.notify("Already on " + adoption.Branch)
+		return m, nil
+	}
+	for _, other := range m.globalStore.All() {
+		if other.ID != ticket.ID && other.ProjectID == ticket.ProjectID && other.BranchName == adoption.Branch {
+			m.notify(adoption.Branch + " already belongs to " + other.Title)
+			return m, nil
+		}
+	}
+
+	// The ticket's own worktree goes away; its branch stays as an attempt.
+	if ticket.UseWorktree && ticket.WorktreePath != "" && ticket.WorktreePath != adoption.WorktreePath {
+		msg := "Adopt " + adoption.Branch + "? The current worktree is removed; " + ticket.BranchName + " is kept as an attempt."
+		return m.confirmOrRun(m.config.Behavior.Confirm.RemoveWorktree, msg, func() tea.Cmd {
+			if m.releaseWorktree(ticket) {
+				m.adopt(ticket, adoption)
+			}
+			return nil
+		})
+	}
+
+	m.adopt(ticket, adoption)
+	return m, nil
+}
+
+// adopt points the ticket at the adopted branch; the worktree, if any, is
+// used when an agent is next spawned.
+func (m *Model) adopt(ticket *board.Ticket, adoption git.Adoption) {
+	adoption.Apply(ticket)
+	delete(m.agentMessages, ticket.ID)
+	m.saveTicket(ticket)
+	m.refreshColumnTickets()
+	m.selectTicketByID(ticket.ID)
+
+	where := "not checked out"
+	if adoption.WorktreePath != "" {
+		where = adoption.WorktreePath
+	}
+	m.notifySuccess("Adopted " + adoption.Branch + " (" + where + ") — press s to spawn an agent")
+}
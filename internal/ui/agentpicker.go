@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+// agentChoice is one configured agent in the spawn picker.
+type agentChoice struct {
+	name      string
+	command   string
+	available bool
+}
+
+// offerAgentPicker reports whether spawning the ticket should ask which
+// agent to run: there's more than one to choose from, the column doesn't
+// fix one, and the spawn would go ahead. Otherwise spawnAgentFor runs
+// straight away and explains itself.
+func (m *Model) offerAgentPicker(ticket *board.Ticket) bool {
+	if len(m.config.Agents) < 2 || m.columnAgent(ticket.Status) != "" {
+		return false
+	}
+	if ticket.Status != board.StatusInProgress {
+		return false
+	}
+	if _, ok := m.starting[ticket.ID]; ok {
+		return false
+	}
+	if _, ok := m.preparing[ticket.ID]; ok {
+		return false
+	}
+	_, running := m.panes[ticket.ID]
+	return !running
+}
+
+// openAgentPicker lists the configured agents, in the order they're
+// preferred when auto-detecting, with the ticket's last agent selected.
+func (m *Model) openAgentPicker(ticket *board.Ticket) (tea.Model, tea.Cmd) {
+	m.agentChoices = agentChoices(m.config.Agents)
+	m.agentPickTicketID = ticket.ID
+	m.agentPickIndex = 0
+
+	last := ticket.AgentType
+	if last == "" {
+		last = m.config.Defaults.DefaultAgent
+	}
+	if i := slices.IndexFunc(m.agentChoices, func(c agentChoice) bool { return c.name == last }); i >= 0 {
+		m.agentPickIndex = i
+	} else if i := slices.IndexFunc(m.agentChoices, func(c agentChoice) bool { return c.available }); i >= 0 {
+		m.agentPickIndex = i
+	}
+	m.mode = ModeAgentPicker
+	return m, nil
+}
+
+func agentChoices(agents map[string]config.AgentConfig) []agentChoice {
+	rank := func(name string) int {
+		if i := slices.Index(config.AgentPriority, name); i >= 0 {
+			return i
+		}
+		return len(config.AgentPriority)
+	}
+	names := make([]string, 0, len(agents))
+	for name := range agents {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if ri, rj := rank(names[i]), rank(names[j]); ri != rj {
+			return ri < rj
+		}
+		return names[i] < names[j]
+	})
+
+	choices := make([]agentChoice, 0, len(names))
+	for _, name := range names {
+		command := agents[name].Command
+		_, err := exec.LookPath(command)
+		choices = append(choices, agentChoice{name: name, command: command, available: err == nil})
+	}
+	return choices
+}
+
+func (m *Model) handleAgentPickerMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	ticket, _ := m.globalStore.Get(m.agentPickTicketID)
+	if ticket == nil || len(m.agentChoices) == 0 {
+		m.mode = ModeNormal
+		return m, nil
+	}
+
+	key := msg.String()
+	switch key {
+	case "esc", "q":
+		m.mode = ModeNormal
+	case "j", "down":
+		m.agentPickIndex = min(m.agentPickIndex+1, len(m.agentChoices)-1)
+	case "k", "up":
+		m.agentPickIndex = max(m.agentPickIndex-1, 0)
+	case "enter", "s":
+		// "s" twice spawns the ticket's last agent.
+		return m.pickAgent(ticket, m.agentChoices[m.agentPickIndex])
+	default:
+		// Number keys pick an agent directly.
+		if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= len(m.agentChoices) {
+			m.agentPickIndex = n - 1
+			return m.pickAgent(ticket, m.agentChoices[n-1])
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) pickAgent(ticket *board.Ticket, choice agentChoice) (tea.Model, tea.Cmd) {
+	if !choice.available {
+		m.notifyError(fmt.Sprintf("%s isn't installed: %s not found in PATH", choice.name, choice.command))
+		return m, nil
+	}
+	m.mode = ModeNormal
+	return m.spawnWithAgent(ticket, choice.name)
+}
+
+// spawnWithAgent spawns the named agent on the ticket and remembers it as
+// the ticket's agent for next time.
+func (m *Model) spawnWithAgent(ticket *board.Ticket, name string) (tea.Model, tea.Cmd) {
+	if agent := m.columnAgent(ticket.Status); agent != "" && agent != name {
+		m.notify(fmt.Sprintf("This column always runs %s", agent))
+		return m, nil
+	}
+	if name != ticket.AgentType {
+		// Another agent can't resume this one's session.
+		ticket.AgentType = name
+		ticket.AgentSpawnedAt = nil
+		m.saveTicket(ticket)
+	}
+	return m.spawnAgentFor(ticket)
+}
+
+func (m *Model) renderAgentPicker() string {
+	ticket, _ := m.globalStore.Get(m.agentPickTicketID)
+	if ticket == nil {
+		return ""
+	}
+
+	width := min(50, m.width-4)
+	width = max(width, 36)
+	innerWidth := width - 4
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.colors.text).Background(m.colors.surface).Bold(true)
+
+	lines := []string{
+		titleStyle.Render("Spawn: " + truncateString(ticket.Title, innerWidth-7)),
+		"",
+	}
+	for i, choice := range m.agentChoices {
+		last := "  "
+		if choice.name == ticket.AgentType {
+			last = "● "
+		}
+		name := choice.name
+		if !choice.available {
+			name += " (not installed)"
+		}
+		row := fmt.Sprintf("%d %s%s", i+1, last, truncateString(name, innerWidth-8))
+		switch {
+		case i == m.agentPickIndex:
+			lines = append(lines, selectedStyle.Render("▸ "+row))
+		case !choice.available:
+			lines = append(lines, m.dimStyle().Render("  "+row))
+		default:
+			lines = append(lines, rowStyle.Render("  "+row))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, m.dimStyle().Render("[j/k] Navigate  [Enter] Spawn  [Esc] Cancel"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
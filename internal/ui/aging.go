@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// agingColor is the border color of a card that hasn't been updated since
+// ticket.UpdatedAt: the theme's surface color while it is fresh, then along
+// the configured gradient as it ages. Done tickets don't age.
+func (m *Model) agingColor(ticket *board.Ticket, now time.Time) lipgloss.Color {
+	a := m.config.UI.Aging
+	if !a.Enabled || ticket.Status == board.StatusDone || a.FullDays <= a.StartDays {
+		return m.colors.surface
+	}
+
+	var gradient []string
+	for _, name := range a.Colors {
+		if hex, ok := m.theme.Colors.Lookup(name); ok {
+			gradient = append(gradient, hex)
+		}
+	}
+	if len(gradient) < 2 {
+		return m.colors.surface
+	}
+
+	days := now.Sub(ticket.UpdatedAt).Hours() / 24
+	frac := (days - float64(a.StartDays)) / float64(a.FullDays-a.StartDays)
+	if frac <= 0 {
+		return m.colors.surface
+	}
+	if frac >= 1 {
+		return lipgloss.Color(gradient[len(gradient)-1])
+	}
+	pos := frac * float64(len(gradient)-1)
+	i := int(pos)
+	return lipgloss.Color(blendHex(gradient[i], gradient[i+1], pos-float64(i)))
+}
+
+// blendHex mixes two #rgb or #rrggbb colors, t of the way from a to b.
+func blendHex(a, b string, t float64) string {
+	ra, ga, ba := parseHex(a)
+	rb, gb, bb := parseHex(b)
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*t + 0.5)
+	}
+	return fmt.Sprintf("#%02x%02x%02x", mix(ra, rb), mix(ga, gb), mix(ba, bb))
+}
+
+func parseHex(s string) (r, g, b uint8) {
+	s = s[1:]
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	v, _ := strconv.ParseUint(s, 16, 32)
+	return uint8(v >> 16), uint8(v >> 8), uint8(v)
+}
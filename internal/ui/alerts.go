@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+// flashDuration is how long the header flashes for an alert.
+const flashDuration = 400 * time.Millisecond
+
+// flashDoneMsg redraws the header once a flash is over.
+type flashDoneMsg struct{}
+
+// alert rings the bell and flashes the header for an event, as far as
+// ui.alerts allows. Every bell and flash goes through here, so the policy
+// and quiet hours apply to all of them alike. They happen at the end of
+// the update.
+func (m *Model) alert(event int) {
+	a := m.config.UI.Alerts
+	if !a.Allows(event, time.Now()) {
+		return
+	}
+	m.pendingBell = m.pendingBell || a.Bell
+	if a.Flash {
+		// Alerts close together flash in the most urgent one's color.
+		if _, flashing := m.flashColor(); !flashing || event < m.flashEvent {
+			m.flashEvent = event
+		}
+		m.flashUntil = time.Now().Add(flashDuration)
+	}
+}
+
+// runPendingAlerts rings the bell and schedules the end of the flash for
+// the alerts raised during the update.
+func (m *Model) runPendingAlerts() tea.Cmd {
+	var cmds []tea.Cmd
+	if m.pendingBell {
+		m.pendingBell = false
+		cmds = append(cmds, func() tea.Msg {
+			os.Stdout.Write([]byte("\a"))
+			return nil
+		})
+	}
+	if !m.flashUntil.IsZero() && !m.flashScheduled {
+		m.flashScheduled = true
+		cmds = append(cmds, tea.Tick(flashDuration, func(time.Time) tea.Msg { return flashDoneMsg{} }))
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleFlashDone ends the flash, or waits out one raised since it began.
+func (m *Model) handleFlashDone() tea.Cmd {
+	if remaining := time.Until(m.flashUntil); remaining > 0 {
+		return tea.Tick(remaining, func(time.Time) tea.Msg { return flashDoneMsg{} })
+	}
+	m.flashUntil = time.Time{}
+	m.flashScheduled = false
+	return nil
+}
+
+// flashColor is the color the header flashes in, if it's flashing.
+func (m *Model) flashColor() (lipgloss.Color, bool) {
+	if m.flashUntil.IsZero() || time.Now().After(m.flashUntil) {
+		return "", false
+	}
+	switch m.flashEvent {
+	case config.AlertError:
+		return m.colors.err, true
+	case config.AlertWaiting:
+		return m.colors.warning, true
+	}
+	return m.colors.success, true
+}
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// archiveTicket moves the selected Done ticket off the board.
+func (m *Model) archiveTicket() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		return m, nil
+	}
+	if ticket.Status != board.StatusDone {
+		m.notify("Only Done tickets can be archived")
+		return m, nil
+	}
+	if pane, ok := m.panes[ticket.ID]; ok && pane.Running() {
+		m.notify("Stop the agent before archiving")
+		return m, nil
+	}
+
+	ticket.Archive()
+	m.saveTicket(ticket)
+	m.refreshColumnTickets()
+	m.clampActiveTicket()
+	m.notifySuccess("Archived: " + ticket.Title)
+	return m, nil
+}
+
+// archiveDone archives every visible Done ticket without a running agent.
+func (m *Model) archiveDone() (tea.Model, tea.Cmd) {
+	count := 0
+	for _, ticket := range m.globalStore.GetByStatus(board.StatusDone) {
+		if ticket.Archived || !m.ticketMatchesFilter(ticket) {
+			continue
+		}
+		if pane, ok := m.panes[ticket.ID]; ok && pane.Running() {
+			continue
+		}
+		ticket.Archive()
+		count++
+	}
+	if count == 0 {
+		m.notify("No Done tickets to archive")
+		return m, nil
+	}
+
+	m.saveAll()
+	m.refreshColumnTickets()
+	m.clampActiveTicket()
+	m.notifySuccess(fmt.Sprintf("Archived %d tickets", count))
+	return m, nil
+}
+
+func (m *Model) clampActiveTicket() {
+	if m.activeColumn < len(m.columnTickets) {
+		m.activeTicket = min(m.activeTicket, max(len(m.columnTickets[m.activeColumn])-1, 0))
+	}
+	m.ensureTicketVisible()
+}
+
+// archivedTickets returns archived tickets matching the project filter,
+// most recently archived first.
+func (m *Model) archivedTickets() []*board.Ticket {
+	var tickets []*board.Ticket
+	for _, t := range m.globalStore.All() {
+		if !t.Archived {
+			continue
+		}
+		if len(m.filterProjectIDs) > 0 && !m.filterProjectIDs[t.ProjectID] {
+			continue
+		}
+		tickets = append(tickets, t)
+	}
+	sort.Slice(tickets, func(i, j int) bool {
+		a, b := tickets[i].ArchivedAt, tickets[j].ArchivedAt
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.After(*b)
+	})
+	return tickets
+}
+
+func (m *Model) openArchive() (tea.Model, tea.Cmd) {
+	m.mode = ModeArchive
+	m.archiveIndex = 0
+	return m, nil
+}
+
+func (m *Model) handleArchiveMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tickets := m.archivedTickets()
+	m.archiveIndex = min(m.archiveIndex, max(len(tickets)-1, 0))
+
+	switch msg.String() {
+	case "esc", "q", "A":
+		m.mode = ModeNormal
+		return m, nil
+	case "j", "down":
+		if m.archiveIndex < len(tickets)-1 {
+			m.archiveIndex++
+		}
+		return m, nil
+	case "k", "up":
+		if m.archiveIndex > 0 {
+			m.archiveIndex--
+		}
+		return m, nil
+	}
+
+	if len(tickets) == 0 {
+		return m, nil
+	}
+	ticket := tickets[m.archiveIndex]
+
+	switch msg.String() {
+	case "u", "enter":
+		ticket.Unarchive()
+		m.saveTicket(ticket)
+		m.refreshColumnTickets()
+		m.notifySuccess("Restored: " + ticket.Title)
+	case "d":
+		return m.confirmOrRun(m.config.Behavior.Confirm.DeleteTicket, "Permanently delete: "+ticket.Title+"?", func() tea.Cmd {
+			m.performTicketCleanup(ticket)
+			return nil
+		})
+	}
+	return m, nil
+}
+
+func (m *Model) renderArchive() string {
+	tickets := m.archivedTickets()
+
+	width := min(90, m.width-4)
+	width = max(width, 40)
+	innerWidth := width - 4
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.colors.text).Background(m.colors.surface).Bold(true)
+
+	lines := []string{
+		titleStyle.Render("Archive") + m.dimStyle().Render(fmt.Sprintf("  %d tickets", len(tickets))),
+		"",
+	}
+	if len(tickets) == 0 {
+		lines = append(lines, m.dimStyle().Italic(true).Render("Nothing archived yet. Press a on a Done ticket or run :archive-done."))
+	}
+
+	viewport := max(m.height-12, 3)
+	start := 0
+	if m.archiveIndex >= viewport {
+		start = m.archiveIndex - viewport + 1
+	}
+	end := min(start+viewport, len(tickets))
+
+	for i := start; i < end; i++ {
+		t := tickets[i]
+		date := "          "
+		if t.ArchivedAt != nil {
+			date = t.ArchivedAt.Format("2006-01-02")
+		}
+		project := ""
+		if proj := m.globalStore.GetProjectForTicket(t); proj != nil {
+			project = proj.Name
+		}
+		meta := fmt.Sprintf("%s  %-12s ", date, truncateString(project, 12))
+		row := meta + truncateString(t.Title, max(innerWidth-len(meta)-2, 10))
+		if i == m.archiveIndex {
+			lines = append(lines, selectedStyle.Render("▸ "+row))
+		} else {
+			lines = append(lines, rowStyle.Render("  "+row))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, m.dimStyle().Render("[j/k] Navigate  [u/Enter] Restore  [d] Delete  [Esc] Close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+// asciiGlyphs swaps the non-ASCII symbols and box drawing the UI uses for
+// plain characters of the same width, so layouts and mouse regions line up
+// either way. Wide emoji become two characters.
+var asciiGlyphs = strings.NewReplacer(
+	// Borders and rules
+	"─", "-", "━", "-", "═", "-", "╌", "-",
+	"│", "|", "┃", "|", "║", "|",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+",
+	"┏", "+", "┓", "+", "┗", "+", "┛", "+",
+	"╔", "+", "╗", "+", "╚", "+", "╝", "+",
+	"╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"├", "+", "┤", "+", "┬", "+", "┴", "+", "┼", "+",
+
+	// Blocks and bars
+	"█", "#", "▓", "#", "▒", ":", "░", ".", "▀", "-",
+	"▌", "|", "▏", "|", "▰", "#", "▱", "-",
+	"■", "#", "▣", "#", "▤", "=", "▦", "#",
+
+	// Arrows and indicators
+	"←", "<", "→", ">", "↑", "^", "↓", "v", "↳", ">",
+	"⇄", "~", "⇅", "^", "‹", "<", "›", ">",
+	"◀", "<", "▶", ">", "▸", ">", "▲", "^", "▼", "v", "▾", "v",
+
+	// Status marks
+	"●", "*", "○", "o", "◆", "*", "◇", "o", "◈", "*", "◉", "@", "◐", "~",
+	"✓", "+", "✗", "x", "✧", "*", "⚠", "!", "⚙", "*", "✎", "/",
+	"⛓", "&", "⑂", "Y", "⏱", "@", "⏎", "<", "⌕", "?", "⌁", "~",
+	"☰", "=", "≡", "=", "❨", "(", "❩", ")",
+
+	// Punctuation
+	"…", "~", "·", ".", "•", "*", "×", "x", "–", "-", "—", "-",
+
+	// Emoji
+	"⚡", ">>", "✅", "ok", "📋", "[]", "📁", "[]", "📂", "[]",
+	"📝", "##", "🤖", "@@", "🧭", "<>", "💡", "!!", "👁", "o",
+)
+
+// asciify applies ui.ascii to text about to be drawn.
+func (m *Model) asciify(s string) string {
+	if !m.config.UI.ASCII {
+		return s
+	}
+	return asciiGlyphs.Replace(s)
+}
+
+// spinnerFor is the agent spinner for the UI settings: braille dots, or a
+// plain line with ui.ascii on, never ticking faster than the configured
+// animation rate.
+func spinnerFor(ui config.UIConfig) spinner.Spinner {
+	sp := spinner.Dot
+	if ui.ASCII {
+		sp = spinner.Line
+	}
+	if fps := ui.AnimationFPS; fps > 0 {
+		sp.FPS = max(sp.FPS, time.Second/time.Duration(fps))
+	}
+	return sp
+}
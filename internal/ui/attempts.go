@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/git"
+)
+
+// minAttemptWidth is the narrowest an attempt's column gets before the
+// comparison shows fewer attempts at a time.
+const minAttemptWidth = 36
+
+// attemptSummary is one of a ticket's branches as shown in the comparison.
+type attemptSummary struct {
+	branch  string
+	current bool
+	commits []string
+	stat    string
+	err     error
+}
+
+// openAttempts compares the selected ticket's attempts side by side, from
+// the branch it started on to the one it is on now.
+func (m *Model) openAttempts() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		return m, nil
+	}
+	branches := ticket.Attempts()
+	if len(branches) < 2 {
+		m.notify("Only one attempt so far — press R to retry in a clean worktree")
+		return m, nil
+	}
+	proj := m.globalStore.GetProjectForTicket(ticket)
+	if proj == nil {
+		m.notifyError("Project not found for this ticket")
+		return m, nil
+	}
+
+	mgr := m.worktreeMgrs[proj.ID]
+	if mgr == nil {
+		m.notifyError("Worktree manager not found")
+		return m, nil
+	}
+	base := ticket.BaseBranch
+	if base == "" {
+		base, _ = mgr.GetDefaultBranch()
+	}
+
+	_, width := m.attemptColumns(len(branches))
+	m.attempts = m.attempts[:0]
+	for _, branch := range branches {
+		a := attemptSummary{branch: branch, current: branch == ticket.BranchName}
+		if !mgr.BranchExists(branch) {
+			a.err = errors.New("branch not found")
+		} else if a.commits, a.err = git.Commits(proj.RepoPath, base, branch); a.err == nil {
+			a.stat, a.err = git.DiffStat(proj.RepoPath, base, branch, width-4)
+		}
+		m.attempts = append(m.attempts, a)
+	}
+
+	m.attemptsTicketID = ticket.ID
+	m.attemptsBase = base
+	m.attemptIndex = len(m.attempts) - 1
+	m.mode = ModeAttempts
+	return m, nil
+}
+
+func (m *Model) handleAttemptsMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "b":
+		m.mode = ModeNormal
+	case "h", "left":
+		m.attemptIndex = max(m.attemptIndex-1, 0)
+	case "l", "right":
+		m.attemptIndex = min(m.attemptIndex+1, len(m.attempts)-1)
+	case "enter":
+		return m.confirmKeepAttempt()
+	}
+	return m, nil
+}
+
+// confirmKeepAttempt offers to switch the ticket to the selected attempt.
+func (m *Model) confirmKeepAttempt() (tea.Model, tea.Cmd) {
+	ticket, _ := m.globalStore.Get(m.attemptsTicketID)
+	if ticket == nil || m.attemptIndex >= len(m.attempts) {
+		m.mode = ModeNormal
+		return m, nil
+	}
+	attempt := m.attempts[m.attemptIndex]
+	if attempt.current {
+		m.notify("Already on " + attempt.branch)
+		return m, nil
+	}
+	if attempt.err != nil {
+		m.notifyError("Can't keep " + attempt.branch + ": " + attempt.err.Error())
+		return m, nil
+	}
+
+	msg := "Keep " + attempt.branch + "? The current worktree is removed; " + ticket.BranchName + " is kept as an attempt."
+	if proj := m.globalStore.GetProjectForTicket(ticket); proj != nil && ticket.WorktreePath != "" {
+		if mgr := m.worktreeMgrs[proj.ID]; mgr != nil {
+			if dirty, err := mgr.HasUncommittedChanges(ticket.WorktreePath); err == nil && dirty {
+				msg += " Its uncommitted changes will be lost."
+			}
+		}
+	}
+	return m.confirmOrRun(m.config.Behavior.Confirm.RemoveWorktree, msg, func() tea.Cmd {
+		m.keepAttempt(ticket, attempt.branch)
+		return nil
+	})
+}
+
+// keepAttempt moves the ticket back onto an earlier attempt's branch. The
+// worktree is recreated when an agent is next spawned.
+func (m *Model) keepAttempt(ticket *board.Ticket, branchName string) {
+	if !m.releaseWorktree(ticket) {
+		return
+	}
+	ticket.KeepAttempt(branchName)
+	delete(m.agentMessages, ticket.ID)
+	m.saveTicket(ticket)
+	m.mode = ModeNormal
+	m.notifySuccess("Switched to " + branchName + " — press s to spawn an agent")
+}
+
+// attemptColumns is how many attempts fit side by side, and how wide each
+// one's column is.
+func (m *Model) attemptColumns(count int) (shown, width int) {
+	inner := max(min(m.width-8, 180), minAttemptWidth)
+	shown = max(min(count, inner/minAttemptWidth), 1)
+	return shown, inner / shown
+}
+
+func (m *Model) renderAttempts() string {
+	ticket, _ := m.globalStore.Get(m.attemptsTicketID)
+	if ticket == nil {
+		return ""
+	}
+
+	shown, width := m.attemptColumns(len(m.attempts))
+	start := min(max(m.attemptIndex-shown+1, 0), len(m.attempts)-shown)
+	end := start + shown
+	maxLines := max(m.height-14, 6)
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	header := titleStyle.Render("Attempts") + m.dimStyle().Render(fmt.Sprintf("  %s · against %s", truncateString(ticket.Title, 40), m.attemptsBase))
+
+	var columns []string
+	for i := start; i < end; i++ {
+		columns = append(columns, m.renderAttempt(m.attempts[i], i == m.attemptIndex, width, maxLines))
+	}
+
+	scroll := ""
+	if shown < len(m.attempts) {
+		scroll = m.dimStyle().Render(fmt.Sprintf("  %d–%d of %d", start+1, end, len(m.attempts)))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		header+scroll,
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, columns...),
+		"",
+		m.dimStyle().Render("[h/l] Select  [Enter] Keep this attempt  [Esc] Close"),
+	)
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Render(content)
+}
+
+func (m *Model) renderAttempt(a attemptSummary, selected bool, width, maxLines int) string {
+	inner := width - 4
+
+	branchStyle := lipgloss.NewStyle().Foreground(m.colors.text).Bold(true)
+	if selected {
+		branchStyle = branchStyle.Foreground(m.colors.primary)
+	}
+	lines := []string{branchStyle.Render(truncateString(a.branch, inner))}
+	if a.current {
+		lines = append(lines, lipgloss.NewStyle().Foreground(m.colors.success).Render("● current"))
+	} else {
+		lines = append(lines, m.dimStyle().Render("○ previous"))
+	}
+	lines = append(lines, "")
+
+	if a.err != nil {
+		lines = append(lines, lipgloss.NewStyle().Foreground(m.colors.err).Width(inner).Render(a.err.Error()))
+	} else {
+		sectionStyle := lipgloss.NewStyle().Foreground(m.colors.secondary).Bold(true)
+		lines = append(lines, sectionStyle.Render(fmt.Sprintf("Commits (%d)", len(a.commits))))
+		if len(a.commits) == 0 {
+			lines = append(lines, m.dimStyle().Italic(true).Render("none"))
+		}
+		for _, c := range a.commits {
+			lines = append(lines, truncateString(c, inner))
+		}
+		lines = append(lines, "", sectionStyle.Render("Changes"))
+		if a.stat == "" {
+			lines = append(lines, m.dimStyle().Italic(true).Render("none"))
+		} else {
+			for _, l := range strings.Split(a.stat, "\n") {
+				lines = append(lines, m.dimStyle().Render(truncateString(strings.TrimSpace(l), inner)))
+			}
+		}
+	}
+
+	if len(lines) > maxLines {
+		hidden := len(lines) - maxLines + 1
+		lines = append(lines[:maxLines-1], m.dimStyle().Render(fmt.Sprintf("… %d more lines", hidden)))
+	}
+
+	borderColor := m.colors.surface
+	if selected {
+		borderColor = m.colors.primary
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(0, 1).
+		Width(width - 2).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// moveOnAgentDone moves a ticket whose agent has just completed into the
+// column its column's on_agent_done names, through that column's gate. A
+// protected column is left for the user to move into, as is everything
+// while automation is paused. The selection stays where it is, and a move
+// into Done doesn't ask for the outcome.
+func (m *Model) moveOnAgentDone(ticket *board.Ticket) tea.Cmd {
+	_, l := m.columnFor(ticket.Status)
+	if l.OnAgentDone == "" || m.automationPaused {
+		return nil
+	}
+	target, ok := m.columnByID(l.OnAgentDone)
+	if !ok || target.Status == ticket.Status {
+		return nil
+	}
+	if _, tl := m.columnFor(target.Status); tl.Protected {
+		m.notify("Agent done: " + ticket.Title + " — " + target.Name + " is protected, move it yourself")
+		return nil
+	}
+
+	from := ticket.Status
+	_, cmd := m.guardMove(ticket, target.Status, func() (tea.Model, tea.Cmd) {
+		if ticket.Status != from {
+			return m, nil
+		}
+		selected := m.selectedTicket()
+		m.globalStore.Move(ticket.ID, target.Status)
+		m.refreshColumnTickets()
+		if selected != nil {
+			m.selectTicketByID(selected.ID)
+		}
+		m.saveTicket(ticket)
+		m.notifySuccess("Agent done: " + ticket.Title + " moved to " + target.Name)
+		return m, m.animateMove(ticket.ID)
+	})
+	return cmd
+}
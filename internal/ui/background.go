@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+// themeChoices are the themes offered by the settings panel and :theme,
+// starting with "auto".
+func themeChoices() []string {
+	return append([]string{config.AutoTheme}, config.ThemeNames()...)
+}
+
+// startupDarkBackground reports whether the terminal background is dark.
+// The terminal is only asked (OSC 11, then COLORFGBG) when the theme is
+// auto: the query can stall on terminals that don't answer, and has to
+// happen before the program starts reading input.
+func startupDarkBackground(cfg *config.Config) bool {
+	if cfg.UI.Theme == config.AutoTheme {
+		return lipgloss.HasDarkBackground()
+	}
+	return colorFGBGDark()
+}
+
+// colorFGBGDark reads the background from COLORFGBG ("fg;bg" as ANSI color
+// numbers), which some terminals set. It is what the auto theme goes by
+// when picked mid-session; without it the background is taken to be dark.
+func colorFGBGDark() bool {
+	_, bg, ok := strings.Cut(os.Getenv("COLORFGBG"), ";")
+	if i := strings.LastIndex(bg, ";"); i >= 0 {
+		bg = bg[i+1:] // "fg;default;bg" in rxvt
+	}
+	n, err := strconv.Atoi(bg)
+	if !ok || err != nil {
+		return true
+	}
+	return n < 7 || n == 8
+}
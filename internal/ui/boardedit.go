@@ -0,0 +1,393 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+// boardEditField is the value being typed in the board editor.
+type boardEditField int
+
+const (
+	boardEditNone boardEditField = iota
+	boardEditName
+	boardEditColor
+	boardEditNew
+	boardEditGate
+)
+
+func (m *Model) openBoardEditor() (tea.Model, tea.Cmd) {
+	// The editor works on the saved board, so the session's arrangement
+	// gives way to it.
+	if m.hiddenColumns != nil || m.sessionColumnOrder != nil {
+		m.hiddenColumns = nil
+		m.sessionColumnOrder = nil
+		m.applyColumnSettings()
+	}
+	m.mode = ModeBoardEditor
+	// Row 0 is the board title; columns follow.
+	m.boardIndex = m.activeColumn + 1
+	m.boardEditing = boardEditNone
+	return m, nil
+}
+
+func (m *Model) handleBoardEditorMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.boardEditing != boardEditNone {
+		return m.handleBoardEditorInput(msg)
+	}
+
+	column := m.boardIndex - 1
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = ModeNormal
+	case "j", "down":
+		m.boardIndex = min(m.boardIndex+1, len(m.columns))
+	case "k", "up":
+		m.boardIndex = max(m.boardIndex-1, 0)
+	case "J", "L":
+		if column >= 0 && m.swapColumns(column, column+1) {
+			m.boardIndex++
+		}
+	case "K", "H":
+		if column >= 0 && m.swapColumns(column, column-1) {
+			m.boardIndex--
+		}
+	case "a":
+		m.startBoardEdit(boardEditNew, "")
+		return m, textinput.Blink
+	case "d":
+		if column >= 0 {
+			m.deleteColumn(column)
+			m.boardIndex = min(m.boardIndex, len(m.columns))
+		}
+	case "enter", "r":
+		if column < 0 {
+			m.startBoardEdit(boardEditName, m.config.Defaults.Title)
+		} else {
+			m.startBoardEdit(boardEditName, m.columns[column].Name)
+		}
+		return m, textinput.Blink
+	case "c":
+		if column >= 0 {
+			m.startBoardEdit(boardEditColor, m.config.ColumnLayout(m.columns[column].ID).Color)
+			return m, textinput.Blink
+		}
+	case "p":
+		if column >= 0 {
+			m.toggleColumnProtected(column)
+		}
+	case "g":
+		if column >= 0 {
+			m.startBoardEdit(boardEditGate, m.config.ColumnLayout(m.columns[column].ID).Gate)
+			return m, textinput.Blink
+		}
+	case "o":
+		if column >= 0 {
+			m.cycleOnAgentDone(column)
+		}
+	case "x":
+		if column < 0 {
+			m.setBoardTitle("")
+		} else {
+			id := m.columns[column].ID
+			l := m.config.ColumnLayout(id)
+			l.Name, l.Color = "", ""
+			m.config.SetColumnLayout(id, l)
+			m.saveBoardSettings("Reset " + id)
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) startBoardEdit(field boardEditField, value string) {
+	m.boardEditing = field
+	m.boardInput.Placeholder = "name"
+	m.boardInput.CharLimit = 40
+	switch field {
+	case boardEditColor:
+		m.boardInput.Placeholder = "#89b4fa (empty for theme color)"
+	case boardEditNew:
+		m.boardInput.Placeholder = "new column name"
+	case boardEditGate:
+		m.boardInput.Placeholder = "command that must pass (empty for none)"
+		m.boardInput.CharLimit = 500
+	}
+	m.boardInput.SetValue(value)
+	m.boardInput.CursorEnd()
+	m.boardInput.Focus()
+}
+
+func (m *Model) handleBoardEditorInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.boardEditing = boardEditNone
+		m.boardInput.Blur()
+		return m, nil
+	case "enter":
+		field := m.boardEditing
+		value := strings.TrimSpace(m.boardInput.Value())
+		m.boardEditing = boardEditNone
+		m.boardInput.Blur()
+
+		column := m.boardIndex - 1
+		switch {
+		case field == boardEditNew:
+			m.addColumn(value)
+			m.boardIndex = m.activeColumn + 1
+		case column < 0:
+			m.setBoardTitle(value)
+		case field == boardEditColor:
+			m.setColumnColor(column, value)
+		case field == boardEditGate:
+			m.setColumnGate(column, value)
+		default:
+			m.renameColumn(column, value)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.boardInput, cmd = m.boardInput.Update(msg)
+	return m, cmd
+}
+
+// setBoardTitle handles ":title <name>"; an empty name restores the default.
+func (m *Model) setBoardTitle(title string) (tea.Model, tea.Cmd) {
+	m.config.Defaults.Title = title
+	m.saveBoardSettings("Board renamed to " + m.boardTitle())
+	return m, nil
+}
+
+// renameColumn handles ":rename <name>" for the active column; an empty name
+// restores the built-in one.
+func (m *Model) renameColumn(column int, name string) (tea.Model, tea.Cmd) {
+	if column < 0 || column >= len(m.columns) {
+		return m, nil
+	}
+	id := m.columns[column].ID
+	l := m.config.ColumnLayout(id)
+	l.Name = name
+	m.config.SetColumnLayout(id, l)
+	m.saveBoardSettings("Column renamed")
+	return m, nil
+}
+
+func (m *Model) setColumnColor(column int, color string) {
+	if color != "" && !config.IsHexColor(color) {
+		m.notifyError("Invalid color: " + color)
+		return
+	}
+	id := m.columns[column].ID
+	l := m.config.ColumnLayout(id)
+	l.Color = color
+	m.config.SetColumnLayout(id, l)
+	m.saveBoardSettings("Column color updated")
+}
+
+// toggleColumnProtected makes moves into a column ask for confirmation, or
+// stops it asking.
+func (m *Model) toggleColumnProtected(column int) {
+	col := m.columns[column]
+	l := m.config.ColumnLayout(col.ID)
+	l.Protected = !l.Protected
+	m.config.SetColumnLayout(col.ID, l)
+	if l.Protected {
+		m.saveBoardSettings(col.Name + " protected")
+	} else {
+		m.saveBoardSettings(col.Name + " unprotected")
+	}
+}
+
+// cycleOnAgentDone steps the column tickets move to when their agent
+// completes through the other columns, then back to none.
+func (m *Model) cycleOnAgentDone(column int) {
+	col := m.columns[column]
+	l := m.config.ColumnLayout(col.ID)
+	var targets []board.Column
+	for _, other := range m.columns {
+		if other.ID != col.ID {
+			targets = append(targets, other)
+		}
+	}
+	i := slices.IndexFunc(targets, func(t board.Column) bool { return t.ID == l.OnAgentDone })
+	if i+1 < len(targets) {
+		l.OnAgentDone = targets[i+1].ID
+	} else {
+		l.OnAgentDone = ""
+	}
+	m.config.SetColumnLayout(col.ID, l)
+	if l.OnAgentDone == "" {
+		m.saveBoardSettings(col.Name + ": tickets stay when their agent is done")
+	} else {
+		m.saveBoardSettings(col.Name + ": tickets move to " + targets[i+1].Name + " when their agent is done")
+	}
+}
+
+func (m *Model) setColumnGate(column int, command string) {
+	id := m.columns[column].ID
+	l := m.config.ColumnLayout(id)
+	l.Gate = command
+	m.config.SetColumnLayout(id, l)
+	m.saveBoardSettings("Column gate updated")
+}
+
+// addColumn handles ":column-add <name>", adding an extra column after the
+// others and making it active.
+func (m *Model) addColumn(name string) (tea.Model, tea.Cmd) {
+	if name == "" {
+		m.notify("Usage: column-add <name>")
+		return m, nil
+	}
+	id := config.ColumnID(name)
+	if err := m.config.AddColumn(id); err != nil {
+		m.notifyError("Can't add column: " + err.Error())
+		return m, nil
+	}
+	if name != id {
+		m.config.SetColumnLayout(id, config.ColumnLayout{Name: name})
+	}
+	m.saveBoardSettings("Added column " + name)
+	for i, col := range m.columns {
+		if col.ID == id {
+			m.activeColumn = i
+			m.clampActiveTicket()
+			m.ensureColumnVisible()
+		}
+	}
+	return m, nil
+}
+
+// deleteColumn handles ":column-delete" for the active column. Only empty
+// extra columns can go; tickets would otherwise be left without a column.
+func (m *Model) deleteColumn(column int) (tea.Model, tea.Cmd) {
+	if column < 0 || column >= len(m.columns) {
+		return m, nil
+	}
+	col := m.columns[column]
+	count := 0
+	for _, ticket := range m.globalStore.All() {
+		if ticket.Status == col.Status {
+			count++
+		}
+	}
+	if count > 0 {
+		m.notify(fmt.Sprintf("Move %d ticket(s) out of %s first", count, col.Name))
+		return m, nil
+	}
+	if !m.config.RemoveColumn(col.ID) {
+		m.notify("Built-in columns can't be deleted")
+		return m, nil
+	}
+	m.saveBoardSettings("Deleted column " + col.Name)
+	return m, nil
+}
+
+// swapColumns exchanges two columns and saves the new order.
+func (m *Model) swapColumns(i, j int) bool {
+	if i < 0 || j < 0 || i >= len(m.columns) || j >= len(m.columns) {
+		return false
+	}
+	order := make([]string, len(m.columns))
+	for k, col := range m.columns {
+		order[k] = col.ID
+	}
+	order[i], order[j] = order[j], order[i]
+	m.config.Defaults.ColumnOrder = order
+	m.saveBoardSettings("Columns reordered")
+	return true
+}
+
+func (m *Model) saveBoardSettings(message string) {
+	m.applyColumnSettings()
+	if err := m.config.Save(""); err != nil {
+		m.notifyError("Failed to save config: " + err.Error())
+		return
+	}
+	m.notifySuccess(message)
+}
+
+func (m *Model) renderBoardEditor() string {
+	width := min(66, m.width-4)
+	width = max(width, 40)
+	innerWidth := width - 4
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.colors.text).Background(m.colors.surface).Bold(true)
+
+	rows := []string{"Title: " + m.boardTitle()}
+	for _, col := range m.columns {
+		swatch := lipgloss.NewStyle().Foreground(m.columnColor(col)).Render("■")
+		row := fmt.Sprintf("%s %s", swatch, truncateString(col.Name, innerWidth-20))
+		if l := m.config.ColumnLayout(col.ID); l.Color != "" {
+			row += m.dimStyle().Render("  " + l.Color)
+		}
+		if col.Limit > 0 {
+			row += m.dimStyle().Render(fmt.Sprintf("  WIP %d", col.Limit))
+		}
+		if agent := m.config.ColumnLayout(col.ID).Agent; agent != "" {
+			row += m.dimStyle().Render("  agent " + agent)
+		}
+		if reviewer := m.config.ColumnLayout(col.ID).Reviewer; reviewer != "" {
+			row += m.dimStyle().Render("  reviewer " + reviewer)
+		}
+		if l := m.config.ColumnLayout(col.ID); l.Protected {
+			row += m.dimStyle().Render("  protected")
+		}
+		if l := m.config.ColumnLayout(col.ID); l.Gate != "" {
+			row += m.dimStyle().Render("  gated")
+		}
+		if l := m.config.ColumnLayout(col.ID); l.OnAgentDone != "" {
+			target, _ := m.columnByID(l.OnAgentDone)
+			row += m.dimStyle().Render("  done → " + target.Name)
+		}
+		rows = append(rows, row)
+	}
+
+	lines := []string{titleStyle.Render("Board"), ""}
+	for i, row := range rows {
+		if i == m.boardIndex {
+			lines = append(lines, selectedStyle.Render("▸ "+row))
+		} else {
+			lines = append(lines, rowStyle.Render("  "+row))
+		}
+		if i == 0 {
+			lines = append(lines, "")
+		}
+	}
+
+	lines = append(lines, "")
+	if m.boardEditing != boardEditNone {
+		label := "Name: "
+		switch m.boardEditing {
+		case boardEditColor:
+			label = "Color: "
+		case boardEditNew:
+			label = "New column: "
+		case boardEditGate:
+			label = "Gate: "
+		}
+		lines = append(lines, rowStyle.Render(label)+m.boardInput.View())
+		lines = append(lines, "")
+		lines = append(lines, m.dimStyle().Render("[Enter] Save  [Esc] Cancel"))
+	} else {
+		lines = append(lines, m.dimStyle().Render("[r] Rename  [c] Color  [H/L] Move  [x] Reset"))
+		lines = append(lines, m.dimStyle().Render("[p] Protect  [g] Gate command  [o] Agent done"))
+		lines = append(lines, m.dimStyle().Render("[a] Add column  [d] Delete column  [Esc] Close"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,346 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+// cardContext is what a card's elements are rendered from.
+type cardContext struct {
+	ticket   *board.Ticket
+	status   board.AgentStatus
+	hasPane  bool
+	selected bool
+	focus    bool
+	width    int
+	// busy is what is being done for the ticket in the background, such
+	// as starting its agent, or empty.
+	busy string
+}
+
+// agentStatusLabels name the agent states on cards.
+var agentStatusLabels = map[board.AgentStatus]string{
+	board.AgentIdle:      "idle",
+	board.AgentWorking:   "working",
+	board.AgentWaiting:   "waiting",
+	board.AgentCompleted: "done",
+	board.AgentError:     "error",
+}
+
+// agentMark is the glyph and color an agent status is drawn with. Each
+// state has its own glyph, so telling them apart never depends on color
+// alone; ok is false for no agent.
+func (m *Model) agentMark(status board.AgentStatus) (glyph string, color lipgloss.Color, ok bool) {
+	switch status {
+	case board.AgentIdle:
+		return "◆", m.colors.primary, true
+	case board.AgentWorking:
+		return m.spinner.View(), m.colors.warning, true
+	case board.AgentWaiting:
+		return "◐", m.colors.secondary, true
+	case board.AgentCompleted:
+		return "✓", m.colors.success, true
+	case board.AgentError:
+		return "✗", m.colors.err, true
+	}
+	return "", "", false
+}
+
+// busyLabel says what is being done for a ticket in the background, if
+// anything.
+func (m *Model) busyLabel(ticketID board.TicketID) string {
+	if agentName, ok := m.starting[ticketID]; ok {
+		return "starting " + agentName
+	}
+	if _, ok := m.preparing[ticketID]; ok {
+		return "creating worktree"
+	}
+	return ""
+}
+
+// focusDescriptionLines caps the wrapped description on focus mode cards.
+const focusDescriptionLines = 6
+
+// cardLines is the card layout to render. Focus mode cards always show the
+// description, adding a line for it if the layout leaves it out.
+func (m *Model) cardLines() []config.CardLine {
+	if !m.focusMode {
+		return m.cardLayout
+	}
+	for _, line := range m.cardLayout {
+		for _, e := range line.Elements {
+			if e.Name == "description" {
+				return m.cardLayout
+			}
+		}
+	}
+	desc, _ := config.ParseCardLine("{description}")
+	return append(slices.Clone(m.cardLayout), desc)
+}
+
+// renderCompactTicket draws a ticket on one row for compact mode: a bar in
+// the card's accent color, then its priority and title, with its session
+// marker at the right. The selected row takes the column's color instead of
+// a border.
+func (m *Model) renderCompactTicket(c cardContext, accent lipgloss.Color, isHovered bool, width int, columnColor lipgloss.Color) string {
+	barColor := accent
+	switch {
+	case c.ticket.ID == m.movedTicketID:
+		barColor = m.colors.warning
+	case c.selected:
+		barColor = columnColor
+	case m.isVisualSelected(c.ticket):
+		barColor = m.colors.secondary
+	case isHovered:
+		barColor = m.colors.overlay
+	}
+
+	marker := m.cardElement("session", c)
+	if c.busy != "" {
+		marker = lipgloss.NewStyle().Foreground(m.colors.warning).Render(m.spinner.View())
+	} else if c.status == board.AgentWorking {
+		glyph, color, _ := m.agentMark(c.status)
+		marker = lipgloss.NewStyle().Foreground(color).Render(glyph)
+	}
+	right := ""
+	if marker != "" {
+		right = " " + marker
+	}
+
+	left := ""
+	if priority := m.cardElement("priority", c); priority != "" {
+		left = priority + " "
+	}
+	titleWidth := max(width-2-lipgloss.Width(left)-lipgloss.Width(right)-1, 1)
+	titleColor := m.colors.text
+	if c.selected {
+		titleColor = columnColor
+	}
+	left += lipgloss.NewStyle().Foreground(titleColor).Bold(c.selected).
+		Render(ansi.Truncate(c.ticket.Title, titleWidth, "…"))
+
+	gap := max(width-2-lipgloss.Width(left)-lipgloss.Width(right), 0)
+	return lipgloss.NewStyle().Foreground(barColor).Render("▌") + " " + left + strings.Repeat(" ", gap) + right
+}
+
+// renderCardLine fills in a card layout line. Elements with nothing to show
+// are left out with the separator before them, and a line with nothing to
+// show at all is empty. A title sharing its line is cut to fit on it.
+func (m *Model) renderCardLine(line config.CardLine, c cardContext) string {
+	if len(line.Elements) == 1 && line.Elements[0].Name == "title" && line.Lead == "" && line.Trail == "" {
+		return lipgloss.NewStyle().
+			Foreground(m.colors.text).
+			Bold(c.selected).
+			Width(c.width).
+			Render(c.ticket.Title)
+	}
+
+	type piece struct {
+		sep, text string
+		title     bool
+	}
+	var shown []piece
+	for _, e := range line.Elements {
+		if e.Name == "title" {
+			shown = append(shown, piece{sep: e.Sep, title: true})
+		} else if s := m.cardElement(e.Name, c); s != "" {
+			shown = append(shown, piece{sep: e.Sep, text: s})
+		}
+	}
+	if len(shown) == 0 {
+		return ""
+	}
+	shown[0].sep = ""
+
+	render := func() string {
+		var b strings.Builder
+		b.WriteString(line.Lead)
+		for _, p := range shown {
+			b.WriteString(p.sep + p.text)
+		}
+		b.WriteString(line.Trail)
+		return b.String()
+	}
+	for i := range shown {
+		if shown[i].title {
+			room := max(c.width-2-lipgloss.Width(render()), 8)
+			shown[i].text = lipgloss.NewStyle().
+				Foreground(m.colors.text).
+				Bold(c.selected).
+				Render(ansi.Truncate(c.ticket.Title, room, "…"))
+		}
+	}
+	return render()
+}
+
+// cardElement renders one card layout element, or "" if the ticket has
+// nothing to show for it.
+func (m *Model) cardElement(name string, c cardContext) string {
+	ticket := c.ticket
+	switch name {
+	case "priority":
+		label := map[int]string{1: "!!", 2: "!"}[ticket.Priority]
+		if label == "" {
+			return ""
+		}
+		return lipgloss.NewStyle().Foreground(m.colors.priority(ticket.Priority)).Bold(true).Render(label)
+
+	case "project":
+		proj := m.globalStore.GetProjectForTicket(ticket)
+		if proj == nil {
+			return ""
+		}
+		shortName := proj.Name
+		if len(shortName) > 12 {
+			shortName = shortName[:10] + ".."
+		}
+		bracketStyle := lipgloss.NewStyle().Foreground(m.colors.info)
+		textStyle := lipgloss.NewStyle().Foreground(m.colors.info).Bold(true)
+		return bracketStyle.Render("❨") + textStyle.Render(shortName) + bracketStyle.Render("❩")
+
+	case "deps":
+		blockedByCount := len(m.globalStore.GetBlockedBy(ticket.ID))
+		blocksCount := len(m.globalStore.GetBlocks(ticket.ID))
+		depStyle := lipgloss.NewStyle().Foreground(m.colors.muted)
+		switch {
+		case blockedByCount > 0 && blocksCount > 0:
+			return depStyle.Render(fmt.Sprintf("⛓%d↑%d↓", blockedByCount, blocksCount))
+		case blockedByCount > 0:
+			return depStyle.Render(fmt.Sprintf("⛓%d↑", blockedByCount))
+		case blocksCount > 0:
+			return depStyle.Render(fmt.Sprintf("⛓%d↓", blocksCount))
+		}
+
+	case "session":
+		// Working shows as the spinner on the status element.
+		if c.status == board.AgentWorking || (c.status == board.AgentIdle && !c.hasPane) {
+			return ""
+		}
+		if glyph, color, ok := m.agentMark(c.status); ok {
+			return lipgloss.NewStyle().Foreground(color).Render(glyph)
+		}
+
+	case "epic":
+		return m.epicCardLine(ticket, c.width)
+
+	case "description":
+		if ticket.Description == "" {
+			return ""
+		}
+		style := lipgloss.NewStyle().Foreground(m.colors.muted).Italic(true)
+		if c.focus {
+			wrapped := strings.Split(lipgloss.NewStyle().Width(max(c.width-2, 10)).Render(strings.TrimSpace(ticket.Description)), "\n")
+			if len(wrapped) > focusDescriptionLines {
+				wrapped = append(wrapped[:focusDescriptionLines-1], "…")
+			}
+			for i, l := range wrapped {
+				wrapped[i] = style.Render(strings.TrimRight(l, " "))
+			}
+			return strings.Join(wrapped, "\n")
+		}
+		desc := ticket.Description
+		if len(desc) > 60 {
+			desc = desc[:57] + "..."
+		}
+		desc = strings.ReplaceAll(desc, "\n", " ")
+		return style.Render(desc)
+
+	case "agent":
+		if ticket.AgentType == "" {
+			return ""
+		}
+		return lipgloss.NewStyle().
+			Foreground(m.colors.base).
+			Background(m.colors.primary).
+			Padding(0, 1).
+			Render(ticket.AgentType)
+
+	case "stage":
+		stages := m.config.Pipelines[ticket.Pipeline]
+		if ticket.Pipeline == "" || len(stages) < 2 {
+			return ""
+		}
+		return lipgloss.NewStyle().Foreground(m.colors.subtext).
+			Render(fmt.Sprintf("%d/%d", ticket.PipelineStage+1, len(stages)))
+
+	case "status":
+		if c.busy != "" {
+			return lipgloss.NewStyle().Foreground(m.colors.warning).Render(m.spinner.View() + " " + c.busy)
+		}
+		glyph, color, ok := m.agentMark(c.status)
+		if !ok {
+			return ""
+		}
+		status := lipgloss.NewStyle().Foreground(color).Render(glyph + " " + agentStatusLabels[c.status])
+		if ticket.Muted {
+			status += lipgloss.NewStyle().Foreground(m.colors.muted).Render(" · muted")
+		}
+		return status
+
+	case "assignee":
+		if ticket.Assignee == "" {
+			return ""
+		}
+		return lipgloss.NewStyle().Foreground(m.colors.subtext).Render("~" + ticket.Assignee)
+
+	case "message":
+		message := m.agentMessages[ticket.ID]
+		if message == "" || c.status == board.AgentNone {
+			return ""
+		}
+		return lipgloss.NewStyle().Foreground(m.colors.subtext).Render(ansi.Truncate(message, c.width-2, "…"))
+
+	case "labels":
+		var labelParts []string
+		for _, label := range ticket.Labels {
+			labelParts = append(labelParts, lipgloss.NewStyle().
+				Foreground(m.colors.subtext).
+				Background(m.colors.overlay).
+				Padding(0, 1).
+				Render(label))
+		}
+		return strings.Join(labelParts, " ")
+
+	case "fields":
+		return m.cardFieldsLine(ticket, c.width)
+
+	case "cost":
+		var cost float64
+		for _, run := range ticket.AgentRuns {
+			cost += run.CostUSD
+		}
+		if cost == 0 {
+			return ""
+		}
+		return lipgloss.NewStyle().Foreground(m.colors.subtext).Render(fmt.Sprintf("$%.2f", cost))
+
+	case "branch":
+		if ticket.BranchName == "" {
+			return ""
+		}
+		return m.dimStyle().Render("⑂ " + ticket.BranchName)
+
+	case "age":
+		return m.dimStyle().Render(cardAge(time.Since(ticket.UpdatedAt)))
+	}
+	return ""
+}
+
+// cardAge is a compact time since the last update, like "5m", "3h" or "2d".
+func cardAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// clickRegion is a span of the header or status bar that acts like pressing
+// key when clicked. start and end are screen columns, end exclusive.
+type clickRegion struct {
+	start, end int
+	key        tea.KeyMsg
+}
+
+// hintKeys maps the named keys shown in hints to the keys they stand for.
+// Single-character hints map to themselves; combined ones like "j/k" are
+// not clickable.
+var hintKeys = map[string]tea.KeyMsg{
+	"Enter":  {Type: tea.KeyEnter},
+	"Esc":    {Type: tea.KeyEsc},
+	"Tab":    {Type: tea.KeyTab},
+	"Space":  {Type: tea.KeySpace, Runes: []rune{' '}},
+	"Ctrl+S": {Type: tea.KeyCtrlS},
+	"Ctrl+G": {Type: tea.KeyCtrlG},
+}
+
+// hintKey is the key a hint label stands for.
+func hintKey(label string) (tea.KeyMsg, bool) {
+	if key, ok := hintKeys[label]; ok {
+		return key, true
+	}
+	if utf8.RuneCountInString(label) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(label)}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// hintRegions splits rendered "key action │ key action" hints starting at
+// screen column x into one click region per hint with a known key.
+func hintRegions(hints string, x int) []clickRegion {
+	var regions []clickRegion
+	for i, hint := range strings.Split(ansi.Strip(hints), " │ ") {
+		if i > 0 {
+			x += 3
+		}
+		width := ansi.StringWidth(hint)
+		label, _, _ := strings.Cut(hint, " ")
+		if key, ok := hintKey(label); ok {
+			regions = append(regions, clickRegion{start: x, end: x + width, key: key})
+		}
+		x += width
+	}
+	return regions
+}
+
+// regionAt finds the click region under screen column x.
+func regionAt(regions []clickRegion, x int) (tea.KeyMsg, bool) {
+	for _, r := range regions {
+		if x >= r.start && x < r.end {
+			return r.key, true
+		}
+	}
+	return tea.KeyMsg{}, false
+}
@@ -0,0 +1,462 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// layoutMode is the board arrangement, chosen from the terminal width.
+type layoutMode int
+
+const (
+	layoutWide layoutMode = iota
+	layoutMedium
+	layoutNarrow
+)
+
+const (
+	// Below mediumBreakpoint at most two columns share the screen; below
+	// narrowBreakpoint the board becomes a single list with a status
+	// switcher and the sidebar is hidden.
+	mediumBreakpoint = 110
+	narrowBreakpoint = 60
+
+	statusSwitcherSep = " │ "
+)
+
+func (m *Model) layoutMode() layoutMode {
+	switch {
+	case m.width > 0 && m.width < narrowBreakpoint:
+		return layoutNarrow
+	case m.width > 0 && m.width < mediumBreakpoint:
+		return layoutMedium
+	default:
+		return layoutWide
+	}
+}
+
+// maxVisibleColumns caps how many columns the current layout shows at once.
+func (m *Model) maxVisibleColumns() int {
+	switch m.layoutMode() {
+	case layoutNarrow:
+		return 1
+	case layoutMedium:
+		return 2
+	default:
+		return len(m.columns)
+	}
+}
+
+// showSidebar reports whether the sidebar is on screen; narrow layouts hide
+// it regardless of the user's toggle.
+func (m *Model) showSidebar() bool {
+	return m.sidebarVisible && m.layoutMode() != layoutNarrow
+}
+
+// showSwitcher reports whether the status switcher stands above the board:
+// whenever a single column is shown, in the narrow layout or focus mode.
+func (m *Model) showSwitcher() bool {
+	return m.layoutMode() == layoutNarrow || m.focusMode
+}
+
+// switcherHeight is the number of rows the status switcher takes above the
+// column.
+func (m *Model) switcherHeight() int {
+	if m.showSwitcher() {
+		return 1
+	}
+	return 0
+}
+
+// applyColumnSettings rebuilds the columns after the board settings change,
+// keeping the same column active.
+func (m *Model) applyColumnSettings() {
+	var activeID string
+	if m.activeColumn < len(m.columns) {
+		activeID = m.columns[m.activeColumn].ID
+	}
+
+	m.columns = m.sessionColumns(m.config.BoardColumns())
+	m.columnOffsets = nil
+	m.activeColumn = min(m.activeColumn, len(m.columns)-1)
+	for i, col := range m.columns {
+		if col.ID == activeID {
+			m.activeColumn = i
+		}
+	}
+	m.refreshColumnTickets()
+	m.clampActiveTicket()
+	m.ensureColumnVisible()
+}
+
+// sessionColumns applies the session's column order and hidden columns to
+// the configured ones. Neither is saved, so the board comes back as
+// configured on the next start.
+func (m *Model) sessionColumns(columns []board.Column) []board.Column {
+	columns = m.sessionOrder(columns)
+	visible := slices.DeleteFunc(slices.Clone(columns), func(col board.Column) bool {
+		return slices.Contains(m.hiddenColumns, col.ID)
+	})
+	if len(visible) == 0 {
+		return columns
+	}
+	return visible
+}
+
+// sessionOrder sorts columns into the session's order, if one is set;
+// columns it doesn't list keep their place after the rest.
+func (m *Model) sessionOrder(columns []board.Column) []board.Column {
+	if m.sessionColumnOrder == nil {
+		return columns
+	}
+	rank := func(col board.Column) int {
+		if i := slices.Index(m.sessionColumnOrder, col.ID); i >= 0 {
+			return i
+		}
+		return len(m.sessionColumnOrder)
+	}
+	slices.SortStableFunc(columns, func(a, b board.Column) int { return rank(a) - rank(b) })
+	return columns
+}
+
+// hideColumn hides a column for the rest of the session; at least one
+// column stays on the board.
+func (m *Model) hideColumn(column int) {
+	if column < 0 || column >= len(m.columns) {
+		return
+	}
+	if len(m.columns) == 1 {
+		m.notify("Can't hide the last column")
+		return
+	}
+	col := m.columns[column]
+	m.hiddenColumns = append(m.hiddenColumns, col.ID)
+	m.applyColumnSettings()
+	m.notify("Hid " + col.Name + " (:show to bring it back)")
+}
+
+// hideCommand handles ":hide [column]", hiding the active column when no
+// name is given.
+func (m *Model) hideCommand(name string) (tea.Model, tea.Cmd) {
+	if name == "" {
+		m.hideColumn(m.activeColumn)
+		return m, nil
+	}
+	for i, col := range m.columns {
+		if strings.EqualFold(col.ID, name) || strings.EqualFold(col.Name, name) {
+			m.hideColumn(i)
+			return m, nil
+		}
+	}
+	m.notify("No visible column named " + name)
+	return m, nil
+}
+
+// showColumns handles ":show [column]", bringing back a hidden column, or
+// with no name every hidden column and the saved order.
+func (m *Model) showColumns(name string) (tea.Model, tea.Cmd) {
+	if name == "" {
+		if m.hiddenColumns == nil && m.sessionColumnOrder == nil {
+			m.notify("All columns are shown")
+			return m, nil
+		}
+		m.hiddenColumns = nil
+		m.sessionColumnOrder = nil
+		m.applyColumnSettings()
+		m.notify("Showing all columns")
+		return m, nil
+	}
+	for _, col := range m.config.BoardColumns() {
+		if !strings.EqualFold(col.ID, name) && !strings.EqualFold(col.Name, name) {
+			continue
+		}
+		if !slices.Contains(m.hiddenColumns, col.ID) {
+			m.notify(col.Name + " isn't hidden")
+			return m, nil
+		}
+		m.hiddenColumns = slices.DeleteFunc(m.hiddenColumns, func(id string) bool { return id == col.ID })
+		m.applyColumnSettings()
+		m.notify("Showing " + col.Name)
+		return m, nil
+	}
+	m.notify("No column named " + name)
+	return m, nil
+}
+
+// hiddenColumnNames lists the hidden columns, for completion.
+func (m *Model) hiddenColumnNames() []string {
+	var names []string
+	for _, col := range m.config.BoardColumns() {
+		if slices.Contains(m.hiddenColumns, col.ID) {
+			names = append(names, col.ID)
+		}
+	}
+	return names
+}
+
+// shiftColumn moves the active column past its visible neighbour (delta -1
+// for left, 1 for right) for this session only; the board editor saves
+// the order instead.
+func (m *Model) shiftColumn(delta int) {
+	target := m.activeColumn + delta
+	if target < 0 || target >= len(m.columns) {
+		return
+	}
+	var order []string
+	for _, col := range m.sessionOrder(m.config.BoardColumns()) {
+		order = append(order, col.ID)
+	}
+	i := slices.Index(order, m.columns[m.activeColumn].ID)
+	j := slices.Index(order, m.columns[target].ID)
+	order[i], order[j] = order[j], order[i]
+	m.sessionColumnOrder = order
+	m.applyColumnSettings()
+}
+
+// columnAgent is the agent configured for the column holding status, or
+// failing that its reviewer, if any.
+func (m *Model) columnAgent(status board.TicketStatus) string {
+	_, l := m.columnFor(status)
+	if l.Agent != "" {
+		return l.Agent
+	}
+	return l.Reviewer
+}
+
+// spawnAgentType picks the agent to spawn for a ticket: the column's agent,
+// then the ticket's own choice, then the board default.
+func (m *Model) spawnAgentType(ticket *board.Ticket) string {
+	if agent := m.columnAgent(ticket.Status); agent != "" {
+		return agent
+	}
+	if ticket.AgentType != "" {
+		return ticket.AgentType
+	}
+	return m.config.Defaults.DefaultAgent
+}
+
+// boardTitle is the name shown at the left of the header.
+func (m *Model) boardTitle() string {
+	if m.config.Defaults.Title != "" {
+		return m.config.Defaults.Title
+	}
+	return "OpenKanban"
+}
+
+// boardSlot is one column drawn on the board and its content width.
+type boardSlot struct {
+	column int
+	width  int
+}
+
+// boardLayout is the horizontal arrangement of the board: the columns on
+// screen, in board order, plus how many scrollable columns are hidden on
+// either side.
+type boardLayout struct {
+	slots       []boardSlot
+	hiddenLeft  int
+	hiddenRight int
+}
+
+// scrollableColumns splits column indexes into pinned ones, which are always
+// on screen, and the rest, which scroll.
+func (m *Model) scrollableColumns() (pinned, free []int) {
+	for i, col := range m.columns {
+		if m.config.ColumnLayout(col.ID).Pinned {
+			pinned = append(pinned, i)
+		} else {
+			free = append(free, i)
+		}
+	}
+	return pinned, free
+}
+
+// scrollSlots is how many unpinned columns fit beside the pinned ones.
+func (m *Model) scrollSlots(pinned int) int {
+	capacity := min(m.visibleColumnCount(m.calcColumnWidth()), m.maxVisibleColumns())
+	return max(capacity-pinned, 1)
+}
+
+func (m *Model) boardLayout() boardLayout {
+	if m.focusMode && m.activeColumn < len(m.columns) {
+		visible := []int{m.activeColumn}
+		return boardLayout{slots: []boardSlot{{column: m.activeColumn, width: m.columnWidths(visible)[0]}}}
+	}
+
+	pinned, free := m.scrollableColumns()
+	slots := min(m.scrollSlots(len(pinned)), len(free))
+	offset := min(max(m.scrollOffset, 0), len(free)-slots)
+
+	visible := slices.Concat(pinned, free[offset:offset+slots])
+	slices.Sort(visible)
+
+	widths := m.columnWidths(visible)
+	layout := boardLayout{
+		hiddenLeft:  offset,
+		hiddenRight: len(free) - offset - slots,
+	}
+	for i, col := range visible {
+		layout.slots = append(layout.slots, boardSlot{column: col, width: widths[i]})
+	}
+	return layout
+}
+
+// toggleFocusMode shows only the active column across the board's width,
+// or brings the other columns back.
+func (m *Model) toggleFocusMode() {
+	m.focusMode = !m.focusMode
+	clear(m.ticketHeights)
+	if m.focusMode && m.activeColumn < len(m.columns) {
+		m.notify("Focus: " + m.columns[m.activeColumn].Name)
+	} else {
+		m.ensureColumnVisible()
+	}
+}
+
+// toggleCompactCards switches between bordered cards and one line per
+// ticket, keeping the selection in view at its new height.
+func (m *Model) toggleCompactCards() {
+	m.compactCards = !m.compactCards
+	clear(m.ticketHeights)
+	m.ensureTicketVisible()
+}
+
+// columnWidths sizes the visible columns. Fixed widths are honoured first and
+// the remaining space is split by weight. If that would squeeze a flexible
+// column below minColumnWidth, every column falls back to an equal share.
+func (m *Model) columnWidths(visible []int) []int {
+	widths := make([]int, len(visible))
+	baseWidth, remainder := m.distributeWidth(len(visible))
+	uniform := func() []int {
+		for i := range widths {
+			widths[i] = baseWidth
+			if i < remainder {
+				widths[i]++
+			}
+		}
+		return widths
+	}
+
+	boardW := m.boardWidth()
+	if boardW == 0 || len(visible) == 0 {
+		return uniform()
+	}
+
+	available := boardW - len(visible)*2 - (len(visible) - 1)
+	totalWeight := 0
+	for _, col := range visible {
+		l := m.config.ColumnLayout(m.columns[col].ID)
+		if l.Width > 0 {
+			available -= l.Width
+		} else {
+			totalWeight += max(l.Weight, 1)
+		}
+	}
+	if available < 0 {
+		return uniform()
+	}
+	if totalWeight == 0 {
+		// All columns are fixed; give any slack to the last one.
+		for i, col := range visible {
+			widths[i] = m.config.ColumnLayout(m.columns[col].ID).Width
+		}
+		widths[len(widths)-1] += max(available, 0)
+		return widths
+	}
+	if available < minColumnWidth {
+		return uniform()
+	}
+
+	flexible := available
+	lastFlex := -1
+	for i, col := range visible {
+		l := m.config.ColumnLayout(m.columns[col].ID)
+		if l.Width > 0 {
+			widths[i] = l.Width
+			continue
+		}
+		widths[i] = available * max(l.Weight, 1) / totalWeight
+		if widths[i] < minColumnWidth {
+			return uniform()
+		}
+		flexible -= widths[i]
+		lastFlex = i
+	}
+	widths[lastFlex] += flexible
+	return widths
+}
+
+// ensureColumnVisible scrolls the unpinned columns so the active column is on
+// screen. Pinned columns never need scrolling.
+func (m *Model) ensureColumnVisible() {
+	pinned, free := m.scrollableColumns()
+	pos := slices.Index(free, m.activeColumn)
+	slots := m.scrollSlots(len(pinned))
+
+	if pos >= 0 {
+		if pos < m.scrollOffset {
+			m.scrollOffset = pos
+		} else if pos >= m.scrollOffset+slots {
+			m.scrollOffset = pos - slots + 1
+		}
+	}
+
+	maxOffset := max(len(free)-slots, 0)
+	m.scrollOffset = min(max(m.scrollOffset, 0), maxOffset)
+}
+
+// statusSwitcherSegments renders one tab per column for the narrow layout.
+// When the tabs don't fit, only the active column is shown between arrows.
+func (m *Model) statusSwitcherSegments() []string {
+	var segments []string
+	for i, col := range m.columns {
+		label := fmt.Sprintf("%s %d", col.Name, m.columnCount(i))
+		if i == m.activeColumn {
+			segments = append(segments, lipgloss.NewStyle().
+				Foreground(m.columnColor(col)).
+				Bold(true).
+				Underline(true).
+				Render(label))
+		} else {
+			segments = append(segments, m.dimStyle().Render(label))
+		}
+	}
+
+	sepWidth := lipgloss.Width(statusSwitcherSep)
+	total := (len(segments) - 1) * sepWidth
+	for _, seg := range segments {
+		total += lipgloss.Width(seg)
+	}
+	if total <= m.boardWidth() {
+		return segments
+	}
+	return []string{m.dimStyle().Render("‹ ") + segments[m.activeColumn] +
+		m.dimStyle().Render(fmt.Sprintf(" %d/%d ›", m.activeColumn+1, len(m.columns)))}
+}
+
+func (m *Model) renderStatusSwitcher() string {
+	return strings.Join(m.statusSwitcherSegments(), m.dimStyle().Render(statusSwitcherSep))
+}
+
+// hitTestSwitcher maps an x position on the status switcher to a column.
+func (m *Model) hitTestSwitcher(x int) int {
+	segments := m.statusSwitcherSegments()
+	if len(segments) != len(m.columns) {
+		return m.activeColumn
+	}
+	start := 0
+	for i, seg := range segments {
+		end := start + lipgloss.Width(seg)
+		if x >= start && x < end {
+			return i
+		}
+		start = end + lipgloss.Width(statusSwitcherSep)
+	}
+	return -1
+}
@@ -0,0 +1,309 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+// maxCommandHistory bounds the ":" history kept for the session.
+const maxCommandHistory = 100
+
+// commandNames lists the ":" commands, for completion.
+var commandNames = []string{
+	"adopt", "agent", "archive", "archive-done", "board", "column-add",
+	"column-delete", "grep", "group", "hide", "hygiene", "issue", "label", "link", "log",
+	"move", "open", "pause", "pipeline", "pr", "q", "rename", "resume", "show", "sprint",
+	"sprint-new", "stats", "theme", "title", "w", "w!", "wq",
+}
+
+// rememberCommand adds line to the history, skipping immediate repeats.
+func (m *Model) rememberCommand(line string) {
+	if line != "" && (len(m.commandHistory) == 0 || m.commandHistory[len(m.commandHistory)-1] != line) {
+		m.commandHistory = append(m.commandHistory, line)
+		if len(m.commandHistory) > maxCommandHistory {
+			m.commandHistory = m.commandHistory[1:]
+		}
+	}
+	m.commandHistoryIndex = len(m.commandHistory)
+}
+
+// browseHistory steps through earlier command lines (step -1) or back
+// towards the line being typed (step 1).
+func (m *Model) browseHistory(step int) {
+	i := m.commandHistoryIndex + step
+	if i < 0 || i > len(m.commandHistory) {
+		return
+	}
+	if m.commandHistoryIndex == len(m.commandHistory) {
+		m.commandDraft = m.commandInput.Value()
+	}
+	m.commandHistoryIndex = i
+	if i == len(m.commandHistory) {
+		m.commandInput.SetValue(m.commandDraft)
+	} else {
+		m.commandInput.SetValue(m.commandHistory[i])
+	}
+	m.commandInput.CursorEnd()
+	m.completions = nil
+}
+
+// completeCommand completes the word before the cursor, cycling through the
+// candidates on repeated presses (step 1 forwards, -1 backwards).
+func (m *Model) completeCommand(step int) {
+	if m.completions == nil {
+		line := m.commandInput.Value()
+		cut := strings.LastIndex(line, " ") + 1
+		word := line[cut:]
+		for _, c := range m.commandCandidates(strings.Fields(line[:cut])) {
+			if strings.HasPrefix(strings.ToLower(c), strings.ToLower(word)) {
+				m.completions = append(m.completions, c)
+			}
+		}
+		if len(m.completions) == 0 {
+			m.completions = nil
+			return
+		}
+		m.completionPrefix = line[:cut]
+		m.completionIndex = -1
+		if step < 0 {
+			m.completionIndex = 0
+		}
+	}
+
+	n := len(m.completions)
+	m.completionIndex = (m.completionIndex + step + n) % n
+	value := m.completionPrefix + m.completions[m.completionIndex]
+	if n == 1 {
+		value += " "
+	}
+	m.commandInput.SetValue(value)
+	m.commandInput.CursorEnd()
+}
+
+// commandCandidates is what may follow the words already typed.
+func (m *Model) commandCandidates(words []string) []string {
+	if len(words) == 0 {
+		return commandNames
+	}
+	switch strings.Join(words, " ") {
+	case "move":
+		var statuses []string
+		for _, col := range m.config.BoardColumns() {
+			statuses = append(statuses, string(col.Status))
+		}
+		return statuses
+	case "hide":
+		var ids []string
+		for _, col := range m.columns {
+			ids = append(ids, col.ID)
+		}
+		return ids
+	case "show":
+		return m.hiddenColumnNames()
+	case "group":
+		return []string{config.GroupByLabel, "off"}
+	case "pipeline":
+		return append(m.config.PipelineNames(), "next", "off")
+	case "label":
+		return []string{"add", "rm"}
+	case "label add":
+		var labels []string
+		ticket := m.selectedTicket()
+		for _, t := range m.globalStore.All() {
+			for _, l := range t.Labels {
+				if !slices.Contains(labels, l) && (ticket == nil || !slices.Contains(ticket.Labels, l)) {
+					labels = append(labels, l)
+				}
+			}
+		}
+		sort.Strings(labels)
+		return labels
+	case "label rm":
+		if ticket := m.selectedTicket(); ticket != nil {
+			return ticket.Labels
+		}
+	case "agent":
+		return []string{"refresh", "spawn", "stop"}
+	case "agent spawn":
+		var names []string
+		for name := range m.config.Agents {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	case "open":
+		return ticketLinkTargets
+	case "theme":
+		return themeChoices()
+	case "sprint":
+		if m.sprints != nil {
+			var names []string
+			for _, s := range m.sprints.Sprints {
+				names = append(names, s.Name)
+			}
+			return names
+		}
+	}
+	return nil
+}
+
+// renderCompletions lists the completion candidates for the status bar,
+// highlighting the one in the command line.
+func (m *Model) renderCompletions(hintStyle lipgloss.Style) string {
+	var parts []string
+	for i, c := range m.completions {
+		if i == m.completionIndex {
+			parts = append(parts, hintStyle.Render(c))
+		} else {
+			parts = append(parts, m.dimStyle().Render(c))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// moveCommand handles ":move <column>", matching the column's status or
+// name.
+func (m *Model) moveCommand(name string) (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+	if name == "" {
+		m.notify("Usage: :move <column>")
+		return m, nil
+	}
+	for _, col := range m.config.BoardColumns() {
+		if strings.EqualFold(string(col.Status), name) || strings.EqualFold(col.Name, name) {
+			model, cmd := m.moveTicketTo(ticket, col.Status)
+			m.ensureColumnVisible()
+			return model, cmd
+		}
+	}
+	m.notify("No column named " + name)
+	return m, nil
+}
+
+// labelCommand handles ":label add <labels>" and ":label rm <labels>" on the
+// selected ticket.
+func (m *Model) labelCommand(args string) (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+	sub, rest, _ := strings.Cut(strings.TrimSpace(args), " ")
+	names := strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(names) == 0 || (sub != "add" && sub != "rm") {
+		m.notify("Usage: :label add|rm <label>")
+		return m, nil
+	}
+
+	labels := slices.Clone(ticket.Labels)
+	if sub == "add" {
+		for _, name := range names {
+			if !slices.Contains(labels, name) {
+				labels = append(labels, name)
+			}
+		}
+	} else {
+		labels = slices.DeleteFunc(labels, func(l string) bool { return slices.Contains(names, l) })
+	}
+	if slices.Equal(labels, ticket.Labels) {
+		m.notify("Labels unchanged")
+		return m, nil
+	}
+
+	ticket.Labels = labels
+	ticket.Record(board.EventEdited, "labels")
+	ticket.Touch()
+	m.saveTicket(ticket)
+	m.refreshColumnTickets()
+	if len(labels) == 0 {
+		m.notifySuccess("Labels cleared")
+	} else {
+		m.notifySuccess("Labels: " + strings.Join(labels, ", "))
+	}
+	return m, nil
+}
+
+// agentCommand handles ":agent spawn [agent]" and ":agent stop".
+func (m *Model) agentCommand(args string) (tea.Model, tea.Cmd) {
+	sub, name, _ := strings.Cut(strings.TrimSpace(args), " ")
+	name = strings.TrimSpace(name)
+	switch sub {
+	case "stop":
+		return m.stopAgent()
+	case "refresh":
+		return m.refreshAgentContext()
+	case "spawn":
+	default:
+		m.notify("Usage: :agent spawn [agent] | :agent stop | :agent refresh")
+		return m, nil
+	}
+
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+	if name == "" {
+		return m.spawnAgentFor(ticket)
+	}
+	if _, ok := m.config.Agents[name]; !ok {
+		m.notifyError("Agent '" + name + "' not configured")
+		return m, nil
+	}
+	return m.spawnWithAgent(ticket, name)
+}
+
+// themeCommand handles ":theme <name>", saving it like the settings panel.
+func (m *Model) themeCommand(name string) (tea.Model, tea.Cmd) {
+	if name == "" {
+		if m.config.UI.Theme == config.AutoTheme {
+			m.notify("Theme: auto (" + m.theme.Name + ")")
+		} else {
+			m.notify("Theme: " + m.config.UI.Theme)
+		}
+		return m, nil
+	}
+	themes := themeChoices()
+	i := slices.IndexFunc(themes, func(t string) bool { return strings.EqualFold(t, name) })
+	if i < 0 {
+		m.notifyError("Unknown theme: " + name)
+		return m, nil
+	}
+	m.applySettingsValue("theme", themes[i])
+	m.notify("Theme: " + themes[i])
+	return m, nil
+}
+
+// writeTickets handles ":w", saving every project's tickets.
+func (m *Model) writeTickets() bool {
+	if err := m.globalStore.SaveAll(); err != nil {
+		m.handleSaveError(err)
+		return false
+	}
+	m.notifySuccess(fmt.Sprintf("Saved %d ticket(s)", m.globalStore.Count()))
+	return true
+}
+
+// forceWriteTickets handles ":w!", saving every project's tickets even over
+// changes made on disk since they were loaded.
+func (m *Model) forceWriteTickets() {
+	for _, p := range m.globalStore.Projects() {
+		if err := m.globalStore.ForceSave(p.ID); err != nil {
+			m.notifyError("Save failed: " + err.Error())
+			return
+		}
+	}
+	m.notifySuccess(fmt.Sprintf("Saved %d ticket(s)", m.globalStore.Count()))
+}
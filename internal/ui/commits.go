@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/git"
+)
+
+// commitScanInterval is how often the status poll reads the repos for new
+// commits mentioning ticket keys.
+const commitScanInterval = 30 * time.Second
+
+// commitScanLookback is how far back the first read of a repo goes. Later
+// reads overlap the previous one a little, since commit times come from
+// the committer's clock; commits already linked are skipped.
+const (
+	commitScanLookback = 7 * 24 * time.Hour
+	commitScanOverlap  = time.Minute
+)
+
+// commitsScannedMsg delivers the commits read from a project's repo.
+type commitsScannedMsg struct {
+	projectID string
+	scannedAt time.Time
+	commits   []git.Commit
+	err       error
+}
+
+// scanCommitsIfDue reads each project's repo for commits made since the
+// last read, when the board has a ticket key and automation isn't paused.
+func (m *Model) scanCommitsIfDue() tea.Cmd {
+	if m.config.Defaults.TicketKey == "" || m.automationPaused || time.Since(m.commitScanAt) < commitScanInterval {
+		return nil
+	}
+	m.commitScanAt = time.Now()
+
+	var cmds []tea.Cmd
+	for _, proj := range m.globalStore.Projects() {
+		since := time.Now().Add(-commitScanLookback)
+		if last, ok := m.commitScans[proj.ID]; ok {
+			since = last.Add(-commitScanOverlap)
+		}
+		projectID, repoPath := proj.ID, proj.RepoPath
+		cmds = append(cmds, func() tea.Msg {
+			scannedAt := time.Now()
+			commits, err := git.CommitsSince(repoPath, since)
+			return commitsScannedMsg{projectID: projectID, scannedAt: scannedAt, commits: commits, err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// linkCommits adds the scanned commits to the history of the tickets their
+// messages mention by key, wherever the commits were made. A repo that
+// can't be read, such as one with no commits yet, is tried again next time.
+func (m *Model) linkCommits(msg commitsScannedMsg) {
+	if msg.err != nil {
+		return
+	}
+	m.commitScans[msg.projectID] = msg.scannedAt
+
+	exists := func(id board.TicketID) bool {
+		ticket, _ := m.globalStore.Get(id)
+		return ticket != nil
+	}
+	linked := 0
+	// Oldest first, so the history reads in the order they were made.
+	for i := len(msg.commits) - 1; i >= 0; i-- {
+		c := msg.commits[i]
+		for _, id := range board.KeyRefs(c.Message, m.config.Defaults.TicketKey, exists) {
+			ticket, _ := m.globalStore.Get(id)
+			if ticket.LinkCommit(c.Hash, commitSubject(c.Message), c.Author) {
+				m.saveTicket(ticket)
+				linked++
+			}
+		}
+	}
+	if linked > 0 {
+		m.notify(fmt.Sprintf("Linked %d commit(s) to tickets", linked))
+	}
+}
+
+// commitSubject is the first line of a commit message.
+func commitSubject(message string) string {
+	subject, _, _ := strings.Cut(message, "\n")
+	return subject
+}
@@ -0,0 +1,22 @@
+package ui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// confirming reports whether an action whose behavior.confirm setting is ask
+// should prompt: not when the policy, or --yes, says never to ask.
+func (m *Model) confirming(ask bool) bool {
+	policy := m.config.Behavior.Confirm
+	return ask && !policy.Never && !policy.AssumeYes
+}
+
+// confirmOrRun asks msg before running fn if confirming(ask), and runs fn
+// straight away otherwise.
+func (m *Model) confirmOrRun(ask bool, msg string, fn func() tea.Cmd) (tea.Model, tea.Cmd) {
+	if !m.confirming(ask) {
+		return m, fn()
+	}
+	m.showConfirm = true
+	m.confirmMsg = msg
+	m.confirmFn = fn
+	return m, nil
+}
@@ -0,0 +1,353 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/git"
+)
+
+// detailTab selects which body the ticket detail view shows.
+type detailTab int
+
+const (
+	detailTabInfo detailTab = iota
+	detailTabHistory
+	detailTabPrompt
+
+	detailTabCount = int(detailTabPrompt) + 1
+)
+
+func (m *Model) openTicketDetail() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+
+	m.mode = ModeTicketDetail
+	m.detailTicketID = ticket.ID
+	m.detailScroll = 0
+	m.detailTab = detailTabInfo
+	m.composingComment = false
+	m.commentInput.Reset()
+	m.commentInput.Blur()
+	return m, nil
+}
+
+func (m *Model) closeTicketDetail() {
+	m.mode = ModeNormal
+	m.detailTicketID = ""
+	m.composingComment = false
+	m.commentInput.Blur()
+}
+
+func (m *Model) handleTicketDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	ticket, _ := m.globalStore.Get(m.detailTicketID)
+	if ticket == nil {
+		m.closeTicketDetail()
+		return m, nil
+	}
+
+	if m.composingComment {
+		switch msg.String() {
+		case "esc":
+			m.composingComment = false
+			m.commentInput.Blur()
+			return m, nil
+		case "ctrl+s":
+			m.saveComment(ticket)
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.commentInput, cmd = m.commentInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "q", "i":
+		m.closeTicketDetail()
+	case "c":
+		m.composingComment = true
+		m.commentInput.Reset()
+		m.commentInput.Focus()
+		return m, textarea.Blink
+	case "e":
+		m.closeTicketDetail()
+		return m.editTicket()
+	case "f":
+		return m.openCustomFields(ticket)
+	case "tab":
+		m.detailTab = detailTab((int(m.detailTab) + 1) % detailTabCount)
+		m.detailScroll = 0
+	case "j", "down":
+		m.detailScroll++
+	case "k", "up":
+		m.detailScroll = max(m.detailScroll-1, 0)
+	case "g":
+		m.detailScroll = 0
+	}
+	return m, nil
+}
+
+func (m *Model) handleTicketDetailMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.detailScroll = max(m.detailScroll-3, 0)
+	case tea.MouseButtonWheelDown:
+		m.detailScroll += 3
+	}
+	return m, nil
+}
+
+func (m *Model) saveComment(ticket *board.Ticket) {
+	text := strings.TrimSpace(m.commentInput.Value())
+	if text == "" {
+		m.notifyError("Comment cannot be empty")
+		return
+	}
+
+	author := ""
+	if proj := m.globalStore.GetProjectForTicket(ticket); proj != nil {
+		author = git.UserName(proj.RepoPath)
+	}
+	ticket.AddComment(author, text)
+	m.saveTicket(ticket)
+
+	m.composingComment = false
+	m.commentInput.Reset()
+	m.commentInput.Blur()
+	m.notifySuccess("Comment added")
+}
+
+func (m *Model) renderTicketDetail() string {
+	ticket, _ := m.globalStore.Get(m.detailTicketID)
+	if ticket == nil {
+		return ""
+	}
+
+	width := min(80, m.width-4)
+	width = max(width, 40)
+	innerWidth := width - 4
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true).Width(innerWidth)
+	sectionStyle := lipgloss.NewStyle().Foreground(m.colors.secondary).Bold(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("◈ "+ticket.Title))
+	lines = append(lines, m.renderDetailTabs())
+	lines = append(lines, "")
+	switch m.detailTab {
+	case detailTabHistory:
+		lines = append(lines, m.ticketHistoryLines(ticket)...)
+	case detailTabPrompt:
+		lines = append(lines, m.ticketPromptLines(ticket, innerWidth)...)
+	default:
+		lines = append(lines, m.ticketInfoLines(ticket, innerWidth)...)
+	}
+
+	// Keep the composer pinned below the scrollable body.
+	var footer []string
+	if m.composingComment {
+		m.commentInput.SetWidth(innerWidth)
+		footer = append(footer, sectionStyle.Render("New comment"))
+		footer = append(footer, m.commentInput.View())
+		footer = append(footer, "")
+		footer = append(footer, m.dimStyle().Render("[Ctrl+S] Save  [Esc] Cancel"))
+	} else {
+		keyStyle := lipgloss.NewStyle().Foreground(m.colors.info)
+		footer = append(footer, keyStyle.Render("[c]")+m.dimStyle().Render(" Comment  ")+
+			keyStyle.Render("[e]")+m.dimStyle().Render(" Edit  ")+
+			keyStyle.Render("[f]")+m.dimStyle().Render(" Fields  ")+
+			keyStyle.Render("[Tab]")+m.dimStyle().Render(" Next tab  ")+
+			keyStyle.Render("[j/k]")+m.dimStyle().Render(" Scroll  ")+
+			keyStyle.Render("[Esc]")+m.dimStyle().Render(" Close"))
+	}
+
+	viewport := m.height - 6 - len(footer) - 1
+	viewport = max(viewport, 5)
+	maxScroll := max(len(lines)-viewport, 0)
+	m.detailScroll = min(m.detailScroll, maxScroll)
+	visible := lines[m.detailScroll:min(m.detailScroll+viewport, len(lines))]
+
+	content := strings.Join(visible, "\n") + "\n\n" + strings.Join(footer, "\n")
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Width(width).
+		Render(content)
+}
+
+func (m *Model) ticketInfoLines(ticket *board.Ticket, innerWidth int) []string {
+	sectionStyle := lipgloss.NewStyle().Foreground(m.colors.secondary).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+	valueStyle := lipgloss.NewStyle().Foreground(m.colors.text)
+
+	field := func(label, value string) string {
+		return labelStyle.Render(fmt.Sprintf("%-10s", label)) + " " + valueStyle.Render(value)
+	}
+
+	var lines []string
+	if proj := m.globalStore.GetProjectForTicket(ticket); proj != nil {
+		lines = append(lines, field("Project", proj.Name))
+	}
+	if key := m.config.Defaults.TicketKey; key != "" {
+		lines = append(lines, field("Key", board.TicketKey(key, ticket.ID)))
+	}
+	lines = append(lines, field("Status", string(ticket.Status)))
+	if ticket.Outcome != board.OutcomeNone {
+		lines = append(lines, field("Outcome", string(ticket.Outcome)))
+	}
+	lines = append(lines, field("Priority", fmt.Sprintf("%d", ticket.Priority)))
+	if ticket.Assignee != "" {
+		lines = append(lines, field("Assignee", ticket.Assignee))
+	}
+	if len(ticket.Labels) > 0 {
+		lines = append(lines, field("Labels", strings.Join(ticket.Labels, ", ")))
+	}
+	if sprint := m.sprintFor(ticket); sprint != nil {
+		lines = append(lines, field("Sprint", sprint.Name))
+	}
+	if parent, _ := m.globalStore.Get(ticket.ParentID); parent != nil {
+		lines = append(lines, field("Epic", parent.Title))
+	}
+	if children := m.globalStore.GetChildren(ticket.ID); len(children) > 0 {
+		p := board.SummarizeProgress(children)
+		lines = append(lines, field("Children", fmt.Sprintf("%d/%d done, %d in progress", p.Done, p.Total, p.InProgress)))
+	}
+	if ticket.BranchName != "" {
+		lines = append(lines, field("Branch", ticket.BranchName))
+	}
+	if ticket.PRURL != "" {
+		lines = append(lines, field("PR", ticket.PRURL))
+	}
+	if ticket.IssueURL != "" {
+		lines = append(lines, field("Issue", ticket.IssueURL))
+	}
+	if ticket.AgentType != "" {
+		agentLine := fmt.Sprintf("%s (%s)", ticket.AgentType, ticket.AgentStatus)
+		if ticket.Muted {
+			agentLine += ", notifications muted"
+		}
+		lines = append(lines, field("Agent", agentLine))
+		if message := m.agentMessages[ticket.ID]; message != "" {
+			lines = append(lines, field("Message", message))
+		} else if n := len(ticket.AgentRuns); n > 0 && ticket.AgentRuns[n-1].StartupError != "" {
+			lines = append(lines, field("Failed", ticket.AgentRuns[n-1].StartupError))
+		}
+	}
+	for _, f := range m.config.Defaults.CustomFields {
+		if v := ticket.Meta[f.Name]; v != "" {
+			lines = append(lines, field(f.Name, v))
+		}
+	}
+	lines = append(lines, field("Updated", ticket.UpdatedAt.Format("2006-01-02 15:04")))
+	for i := len(ticket.AgentRuns) - 1; i >= 0; i-- {
+		if dir := ticket.AgentRuns[i].ArtifactsDir; dir != "" {
+			lines = append(lines, field("Artifacts", dir))
+			break
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, sectionStyle.Render("Description"))
+	if ticket.Description != "" {
+		lines = append(lines, m.markdownLines(ticket.Description, innerWidth)...)
+	} else {
+		lines = append(lines, m.dimStyle().Italic(true).Render("No description"))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, sectionStyle.Render(fmt.Sprintf("Comments (%d)", len(ticket.Comments))))
+	if len(ticket.Comments) == 0 {
+		lines = append(lines, m.dimStyle().Italic(true).Render("No comments yet"))
+	}
+	authorStyle := lipgloss.NewStyle().Foreground(m.colors.info).Bold(true)
+	for _, c := range ticket.Comments {
+		author := c.Author
+		if author == "" {
+			author = "unknown"
+		}
+		lines = append(lines, authorStyle.Render(author)+" "+m.dimStyle().Render(c.CreatedAt.Format("2006-01-02 15:04")))
+		lines = append(lines, m.markdownLines(c.Text, innerWidth)...)
+		lines = append(lines, "")
+	}
+
+	return lines
+}
+
+func (m *Model) renderDetailTabs() string {
+	activeStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true).Underline(true)
+	tabs := []string{"Details", "History", "Prompt"}
+	for i, name := range tabs {
+		if detailTab(i) == m.detailTab {
+			tabs[i] = activeStyle.Render(name)
+		} else {
+			tabs[i] = m.dimStyle().Render(name)
+		}
+	}
+	return strings.Join(tabs, m.dimStyle().Render("  │  "))
+}
+
+// ticketPromptLines previews the init prompt the ticket's agent would be
+// spawned with, so template errors show up before a session is started.
+func (m *Model) ticketPromptLines(ticket *board.Ticket, innerWidth int) []string {
+	labelStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+	valueStyle := lipgloss.NewStyle().Foreground(m.colors.text)
+	errStyle := lipgloss.NewStyle().Foreground(m.colors.err).Width(innerWidth)
+	noteStyle := m.dimStyle().Italic(true).Width(innerWidth)
+
+	agentType := m.spawnAgentType(ticket)
+	lines := []string{labelStyle.Render("Agent: ") + valueStyle.Render(agentType)}
+	if m.columnAgent(ticket.Status) != "" {
+		lines[0] += m.dimStyle().Render(" (set by column)")
+	}
+	lines = append(lines, "")
+
+	promptTemplate := m.initPromptFor(ticket, agentType)
+	if promptTemplate == "" {
+		return append(lines, noteStyle.Render("No init prompt configured for this agent"))
+	}
+	if !agent.ShouldInjectContext(ticket) && agentType == ticket.AgentType {
+		lines = append(lines, noteStyle.Render("The agent has run before and will resume its session; this prompt is only sent on the first spawn"), "")
+	}
+
+	ctx := m.promptContext(ticket, m.globalStore.GetProjectForTicket(ticket))
+	prompt, err := agent.RenderContextPrompt(promptTemplate, ctx)
+	if err != nil {
+		lines = append(lines, strings.Split(errStyle.Render("✗ Template error: "+err.Error()), "\n")...)
+		lines = append(lines, "", noteStyle.Render("The agent would get this fallback instead:"), "")
+		prompt = agent.BuildContextPrompt(promptTemplate, ctx)
+	}
+	return append(lines, strings.Split(valueStyle.Width(innerWidth).Render(prompt), "\n")...)
+}
+
+// ticketHistoryLines renders the audit log newest first.
+func (m *Model) ticketHistoryLines(ticket *board.Ticket) []string {
+	if len(ticket.History) == 0 {
+		return []string{m.dimStyle().Italic(true).Render("No history recorded")}
+	}
+
+	kindStyle := lipgloss.NewStyle().Foreground(m.colors.info).Bold(true)
+	valueStyle := lipgloss.NewStyle().Foreground(m.colors.text)
+
+	var lines []string
+	for i := len(ticket.History) - 1; i >= 0; i-- {
+		e := ticket.History[i]
+		line := m.dimStyle().Render(e.At.Format("2006-01-02 15:04:05")) + "  " +
+			kindStyle.Render(fmt.Sprintf("%-13s", e.Kind))
+		if e.Detail != "" {
+			line += " " + valueStyle.Render(e.Detail)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// editorDoneMsg reports that the editor opened on a ticket has exited.
+type editorDoneMsg struct {
+	ticketID board.TicketID
+	path     string
+	original string
+	err      error
+}
+
+// editInEditor opens the selected ticket in $VISUAL or $EDITOR as a
+// markdown file, the way git opens a commit message; saving and quitting
+// updates the ticket.
+func (m *Model) editInEditor() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+
+	f, err := os.CreateTemp("", "openkanban-ticket-*.md")
+	if err != nil {
+		m.notifyError("Can't open the editor: " + err.Error())
+		return m, nil
+	}
+	text := board.FormatText(ticket)
+	_, err = f.WriteString(text)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		m.notifyError("Can't open the editor: " + err.Error())
+		return m, nil
+	}
+
+	args := strings.Fields(editorCommand())
+	cmd := exec.Command(args[0], append(args[1:], f.Name())...)
+	id, path := ticket.ID, f.Name()
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorDoneMsg{ticketID: id, path: path, original: text, err: err}
+	})
+}
+
+// editorCommand is the user's editor, which may include arguments such as
+// "code --wait".
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// applyEditorEdit updates the ticket from the file the editor saved. An
+// unchanged file or a cleared title leaves the ticket as it was. A file
+// that doesn't parse is kept, and its path shown, so the edit isn't lost.
+func (m *Model) applyEditorEdit(msg editorDoneMsg) {
+	keep := false
+	defer func() {
+		if !keep {
+			os.Remove(msg.path)
+		}
+	}()
+	if msg.err != nil {
+		m.notifyError("Editor failed: " + msg.err.Error())
+		return
+	}
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.notifyError("Ticket not updated: " + err.Error())
+		return
+	}
+	if string(data) == msg.original {
+		m.notify("No changes")
+		return
+	}
+	text, err := board.ParseText(string(data))
+	if err != nil {
+		keep = true
+		m.notifyError("Ticket not updated: " + err.Error() + " — your edit is saved in " + msg.path)
+		return
+	}
+	if text.Title == "" {
+		m.notify("Edit cancelled: empty title")
+		return
+	}
+
+	ticket, err := m.globalStore.Get(msg.ticketID)
+	if err != nil || ticket == nil {
+		m.notifyError("Ticket no longer exists")
+		return
+	}
+	before := *ticket
+	ticket.Title = text.Title
+	ticket.Description = text.Description
+	ticket.Labels = text.Labels
+	changed := board.ChangedFields(&before, ticket)
+	if len(changed) == 0 {
+		m.notify("No changes")
+		return
+	}
+	ticket.Record(board.EventEdited, strings.Join(changed, ", "))
+	ticket.Touch()
+	m.saveTicket(ticket)
+	m.refreshColumnTickets()
+	m.notifySuccess("Updated: " + ticket.Title)
+}
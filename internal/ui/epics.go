@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// maxEpicDepth bounds ancestor walks in case a hand-edited file has a cycle.
+const maxEpicDepth = 16
+
+// parentCandidates lists the tickets the selected ticket can be grouped
+// under: same project, not archived, and not the ticket or its descendants.
+func (m *Model) parentCandidates(ticket *board.Ticket) []*board.Ticket {
+	var candidates []*board.Ticket
+	for _, t := range m.globalStore.All() {
+		if t.ID == ticket.ID || t.Archived || t.ProjectID != ticket.ProjectID {
+			continue
+		}
+		if m.globalStore.IsAncestor(ticket.ID, t.ID) {
+			continue
+		}
+		candidates = append(candidates, t)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
+	})
+	return candidates
+}
+
+func (m *Model) openParentPicker() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+
+	m.mode = ModeParentPicker
+	m.parentTicketID = ticket.ID
+	m.parentIndex = 0
+	// Start on the current parent so enter is a no-op.
+	for i, c := range m.parentCandidates(ticket) {
+		if c.ID == ticket.ParentID {
+			m.parentIndex = i + 1
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) handleParentPickerMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	ticket, _ := m.globalStore.Get(m.parentTicketID)
+	if ticket == nil {
+		m.mode = ModeNormal
+		return m, nil
+	}
+	// Index 0 is "no epic"; candidates follow.
+	candidates := m.parentCandidates(ticket)
+
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = ModeNormal
+	case "j", "down":
+		m.parentIndex = min(m.parentIndex+1, len(candidates))
+	case "k", "up":
+		m.parentIndex = max(m.parentIndex-1, 0)
+	case "x":
+		m.setParent(ticket, nil)
+	case "enter":
+		var parent *board.Ticket
+		if m.parentIndex > 0 && m.parentIndex <= len(candidates) {
+			parent = candidates[m.parentIndex-1]
+		}
+		m.setParent(ticket, parent)
+	}
+	return m, nil
+}
+
+func (m *Model) setParent(ticket, parent *board.Ticket) {
+	m.mode = ModeNormal
+
+	var parentID board.TicketID
+	if parent != nil {
+		parentID = parent.ID
+	}
+	if parentID == ticket.ParentID {
+		return
+	}
+	if err := m.globalStore.SetParent(ticket.ID, parentID); err != nil {
+		m.notifyError("Cannot set epic: " + err.Error())
+		return
+	}
+
+	ticket.Record(board.EventEdited, "parent")
+	m.saveTicket(ticket)
+	m.refreshColumnTickets()
+	m.selectTicketByID(ticket.ID)
+	if parent == nil {
+		m.notifySuccess("Removed from epic: " + ticket.Title)
+	} else {
+		m.notifySuccess("Added to epic: " + parent.Title)
+	}
+}
+
+// toggleEpic collapses or expands the children of the selected epic. When
+// the selection is a child, its epic is toggled instead.
+func (m *Model) toggleEpic() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		return m, nil
+	}
+	epic := ticket
+	if len(m.globalStore.GetChildren(epic.ID)) == 0 {
+		parent, _ := m.globalStore.Get(ticket.ParentID)
+		if parent == nil {
+			m.notify("Not an epic")
+			return m, nil
+		}
+		epic = parent
+	}
+
+	if m.collapsedEpics[epic.ID] {
+		delete(m.collapsedEpics, epic.ID)
+		m.notify("Expanded: " + epic.Title)
+	} else {
+		m.collapsedEpics[epic.ID] = true
+		m.notify("Collapsed: " + epic.Title)
+	}
+	m.refreshColumnTickets()
+	m.selectTicketByID(epic.ID)
+	return m, nil
+}
+
+// hiddenByCollapsedEpic reports whether any ancestor of t is collapsed.
+func (m *Model) hiddenByCollapsedEpic(t *board.Ticket) bool {
+	if len(m.collapsedEpics) == 0 {
+		return false
+	}
+	for id, depth := t.ParentID, 0; id != "" && depth < maxEpicDepth; depth++ {
+		if m.collapsedEpics[id] {
+			return true
+		}
+		parent, _ := m.globalStore.Get(id)
+		if parent == nil {
+			break
+		}
+		id = parent.ParentID
+	}
+	return false
+}
+
+// epicCardLine renders the roll-up progress shown on an epic's card, or
+// the parent link shown on a child's card.
+func (m *Model) epicCardLine(ticket *board.Ticket, width int) string {
+	children := m.globalStore.GetChildren(ticket.ID)
+	if len(children) > 0 {
+		p := board.SummarizeProgress(children)
+		const barWidth = 8
+		filled := int(p.Fraction() * barWidth)
+		bar := lipgloss.NewStyle().Foreground(m.colors.success).Render(strings.Repeat("▰", filled)) +
+			lipgloss.NewStyle().Foreground(m.colors.muted).Render(strings.Repeat("▱", barWidth-filled))
+		marker := "▾"
+		if m.collapsedEpics[ticket.ID] {
+			marker = "▸"
+		}
+		label := lipgloss.NewStyle().Foreground(m.colors.secondary).Bold(true).Render(marker + " Epic")
+		return label + " " + bar + m.dimStyle().Render(fmt.Sprintf(" %d/%d", p.Done, p.Total))
+	}
+
+	if parent, _ := m.globalStore.Get(ticket.ParentID); parent != nil {
+		return m.dimStyle().Render("↳ " + truncateString(parent.Title, max(width-2, 8)))
+	}
+	return ""
+}
+
+func (m *Model) renderParentPicker() string {
+	ticket, _ := m.globalStore.Get(m.parentTicketID)
+	if ticket == nil {
+		return ""
+	}
+	candidates := m.parentCandidates(ticket)
+
+	width := min(70, m.width-4)
+	width = max(width, 40)
+	innerWidth := width - 4
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.colors.text).Background(m.colors.surface).Bold(true)
+
+	rows := []string{"(no epic)"}
+	for _, c := range candidates {
+		row := truncateString(c.Title, innerWidth-6)
+		if n := len(m.globalStore.GetChildren(c.ID)); n > 0 {
+			row += fmt.Sprintf(" (%d)", n)
+		}
+		rows = append(rows, row)
+	}
+
+	lines := []string{
+		titleStyle.Render("Epic for: " + truncateString(ticket.Title, innerWidth-10)),
+		"",
+	}
+
+	viewport := max(m.height-12, 3)
+	start := 0
+	if m.parentIndex >= viewport {
+		start = m.parentIndex - viewport + 1
+	}
+	end := min(start+viewport, len(rows))
+	for i := start; i < end; i++ {
+		current := "  "
+		if i > 0 && candidates[i-1].ID == ticket.ParentID {
+			current = "● "
+		}
+		if i == m.parentIndex {
+			lines = append(lines, selectedStyle.Render("▸ "+current+rows[i]))
+		} else {
+			lines = append(lines, rowStyle.Render("  "+current+rows[i]))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, m.dimStyle().Render("[j/k] Navigate  [Enter] Set  [x] Clear  [Esc] Cancel"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
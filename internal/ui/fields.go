@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+func (m *Model) openCustomFields(ticket *board.Ticket) (tea.Model, tea.Cmd) {
+	if len(m.config.Defaults.CustomFields) == 0 {
+		m.notify("No custom fields defined (defaults.custom_fields)")
+		return m, nil
+	}
+
+	m.mode = ModeCustomFields
+	m.fieldsTicketID = ticket.ID
+	m.fieldsIndex = 0
+	m.fieldsEditing = false
+	m.fieldInput.Blur()
+	return m, nil
+}
+
+// closeCustomFields returns to the ticket detail view the editor was opened from.
+func (m *Model) closeCustomFields() {
+	m.mode = ModeTicketDetail
+	m.fieldsEditing = false
+	m.fieldInput.Blur()
+}
+
+func (m *Model) handleCustomFieldsMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	ticket, _ := m.globalStore.Get(m.fieldsTicketID)
+	fields := m.config.Defaults.CustomFields
+	if ticket == nil || len(fields) == 0 {
+		m.closeCustomFields()
+		return m, nil
+	}
+	m.fieldsIndex = min(m.fieldsIndex, len(fields)-1)
+	field := fields[m.fieldsIndex]
+
+	if m.fieldsEditing {
+		switch msg.String() {
+		case "esc":
+			m.fieldsEditing = false
+			m.fieldInput.Blur()
+			return m, nil
+		case "enter":
+			value := strings.TrimSpace(m.fieldInput.Value())
+			if err := field.CheckValue(value); err != nil {
+				m.notifyError(err.Error())
+				return m, nil
+			}
+			m.setCustomField(ticket, field.Name, value)
+			m.fieldsEditing = false
+			m.fieldInput.Blur()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.fieldInput, cmd = m.fieldInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.closeCustomFields()
+	case "j", "down":
+		m.fieldsIndex = (m.fieldsIndex + 1) % len(fields)
+	case "k", "up":
+		m.fieldsIndex = (m.fieldsIndex - 1 + len(fields)) % len(fields)
+	case "x", "backspace":
+		m.setCustomField(ticket, field.Name, "")
+	case "h", "left":
+		if field.Type == config.FieldEnum {
+			m.setCustomField(ticket, field.Name, cycleOption(field.Options, ticket.Meta[field.Name], -1))
+		}
+	case "l", "right", "enter":
+		if field.Type == config.FieldEnum {
+			m.setCustomField(ticket, field.Name, cycleOption(field.Options, ticket.Meta[field.Name], 1))
+			return m, nil
+		}
+		if msg.String() == "enter" {
+			m.fieldsEditing = true
+			m.fieldInput.SetValue(ticket.Meta[field.Name])
+			m.fieldInput.CursorEnd()
+			m.fieldInput.Focus()
+			return m, textinput.Blink
+		}
+	}
+	return m, nil
+}
+
+// cycleOption steps through options, passing through "unset" between the
+// last and first values.
+func cycleOption(options []string, current string, delta int) string {
+	choices := append([]string{""}, options...)
+	i := max(slices.Index(choices, current), 0)
+	return choices[(i+delta+len(choices))%len(choices)]
+}
+
+func (m *Model) setCustomField(ticket *board.Ticket, name, value string) {
+	if value == "" {
+		delete(ticket.Meta, name)
+	} else {
+		if ticket.Meta == nil {
+			ticket.Meta = make(map[string]string)
+		}
+		ticket.Meta[name] = value
+	}
+	ticket.Record(board.EventEdited, name)
+	ticket.Touch()
+	m.saveTicket(ticket)
+}
+
+func (m *Model) renderCustomFields() string {
+	ticket, _ := m.globalStore.Get(m.fieldsTicketID)
+	if ticket == nil {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+	activeStyle := lipgloss.NewStyle().Foreground(m.colors.info).Bold(true)
+	valueStyle := lipgloss.NewStyle().Foreground(m.colors.text)
+	typeStyle := lipgloss.NewStyle().Foreground(m.colors.muted).Italic(true)
+
+	lines := []string{titleStyle.Render("Fields: " + ticket.Title), ""}
+
+	for i, f := range m.config.Defaults.CustomFields {
+		prefix := "  "
+		label := nameStyle.Render(fmt.Sprintf("%-14s", f.Name))
+		if i == m.fieldsIndex {
+			prefix = activeStyle.Render("▸ ")
+			label = activeStyle.Render(fmt.Sprintf("%-14s", f.Name))
+		}
+
+		value := valueStyle.Render(ticket.Meta[f.Name])
+		if ticket.Meta[f.Name] == "" {
+			value = m.dimStyle().Render("-")
+		}
+		if i == m.fieldsIndex && m.fieldsEditing {
+			value = m.fieldInput.View()
+		}
+
+		hint := f.Type
+		if f.Type == config.FieldEnum {
+			hint = strings.Join(f.Options, "|")
+		}
+		lines = append(lines, prefix+label+" "+value+"  "+typeStyle.Render(hint))
+	}
+
+	lines = append(lines, "")
+	if m.fieldsEditing {
+		lines = append(lines, m.dimStyle().Render("[Enter] Save  [Esc] Cancel"))
+	} else {
+		lines = append(lines, m.dimStyle().Render("[Enter] Edit  [h/l] Cycle enum  [x] Clear  [Esc] Back"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+// cardFieldsLine renders custom fields flagged show_on_card that have a value.
+func (m *Model) cardFieldsLine(ticket *board.Ticket, width int) string {
+	var parts []string
+	for _, f := range m.config.Defaults.CustomFields {
+		if !f.ShowOnCard || ticket.Meta[f.Name] == "" {
+			continue
+		}
+		parts = append(parts, f.Name+": "+ticket.Meta[f.Name])
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Foreground(m.colors.subtext).
+		Width(width).
+		Render(strings.Join(parts, " · "))
+}
@@ -0,0 +1,237 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+// labelGroup is a run of tickets in a label-grouped column that share their
+// first label.
+type labelGroup struct {
+	label     string // "" for tickets without labels
+	count     int    // tickets in the group, collapsed ones included
+	collapsed bool
+	// start is the index in the column's tickets of the group's first
+	// ticket, or for a collapsed group, of the ticket after it.
+	start int
+}
+
+// groupKey names a label group in a column, for remembering it collapsed.
+type groupKey struct {
+	column string
+	label  string
+}
+
+// groupLabel is the label a ticket is grouped under: its first.
+func groupLabel(t *board.Ticket) string {
+	if len(t.Labels) == 0 {
+		return ""
+	}
+	return t.Labels[0]
+}
+
+// groupByLabel orders a grouped column's tickets by their first label,
+// alphabetically with unlabeled tickets last, keeping the column's sort
+// within each group. Tickets in collapsed groups are left out.
+func (m *Model) groupByLabel(col board.Column, tickets []*board.Ticket) ([]*board.Ticket, []labelGroup) {
+	byLabel := make(map[string][]*board.Ticket)
+	var labels []string
+	for _, t := range tickets {
+		label := groupLabel(t)
+		if _, ok := byLabel[label]; !ok {
+			labels = append(labels, label)
+		}
+		byLabel[label] = append(byLabel[label], t)
+	}
+	slices.SortFunc(labels, func(a, b string) int {
+		if (a == "") != (b == "") {
+			if a == "" {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Or(cmp.Compare(strings.ToLower(a), strings.ToLower(b)), cmp.Compare(a, b))
+	})
+
+	visible := make([]*board.Ticket, 0, len(tickets))
+	groups := make([]labelGroup, 0, len(labels))
+	for _, label := range labels {
+		g := labelGroup{
+			label:     label,
+			count:     len(byLabel[label]),
+			collapsed: m.collapsedGroups[groupKey{col.ID, label}],
+			start:     len(visible),
+		}
+		if !g.collapsed {
+			visible = append(visible, byLabel[label]...)
+		}
+		groups = append(groups, g)
+	}
+	return visible, groups
+}
+
+// columnCount is how many tickets a column holds, counting those in
+// collapsed groups.
+func (m *Model) columnCount(column int) int {
+	n := len(m.columnTickets[column])
+	if column < len(m.columnGroups) {
+		for _, g := range m.columnGroups[column] {
+			if g.collapsed {
+				n += g.count
+			}
+		}
+	}
+	return n
+}
+
+// groupedColumn returns the active column if it is grouped by label, and
+// otherwise says how to group it.
+func (m *Model) groupedColumn() (board.Column, bool) {
+	if m.activeColumn >= len(m.columns) {
+		return board.Column{}, false
+	}
+	col := m.columns[m.activeColumn]
+	if m.config.ColumnGroup(col.ID) != config.GroupByLabel {
+		m.notify(col.Name + " isn't grouped — :group label groups it by label")
+		return col, false
+	}
+	return col, true
+}
+
+// collapseLabelGroup folds the selected ticket's label group down to its
+// header.
+func (m *Model) collapseLabelGroup() (tea.Model, tea.Cmd) {
+	col, ok := m.groupedColumn()
+	if !ok {
+		return m, nil
+	}
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+	label := groupLabel(ticket)
+	m.collapsedGroups[groupKey{col.ID, label}] = true
+	m.refreshColumnTickets()
+	m.activeTicket = min(m.activeTicket, max(len(m.columnTickets[m.activeColumn])-1, 0))
+	m.ensureTicketVisible()
+	m.notify("Collapsed: " + groupName(label))
+	return m, nil
+}
+
+// foldLabelGroups expands every group in the active column if any is
+// collapsed, and otherwise collapses all but the selected ticket's.
+func (m *Model) foldLabelGroups() (tea.Model, tea.Cmd) {
+	col, ok := m.groupedColumn()
+	if !ok {
+		return m, nil
+	}
+	groups := m.columnGroups[m.activeColumn]
+	selected := m.selectedTicket()
+
+	if slices.ContainsFunc(groups, func(g labelGroup) bool { return g.collapsed }) {
+		for _, g := range groups {
+			delete(m.collapsedGroups, groupKey{col.ID, g.label})
+		}
+		m.notify("Expanded all groups in " + col.Name)
+	} else {
+		for _, g := range groups {
+			if selected == nil || g.label != groupLabel(selected) {
+				m.collapsedGroups[groupKey{col.ID, g.label}] = true
+			}
+		}
+		m.notify("Collapsed the other groups in " + col.Name)
+	}
+	m.refreshColumnTickets()
+	if selected != nil {
+		m.selectTicketByID(selected.ID)
+	}
+	return m, nil
+}
+
+// groupCommand handles ":group [label|off]", grouping the active column by
+// label, ungrouping it, or toggling between the two, and saves it to the
+// board settings.
+func (m *Model) groupCommand(arg string) (tea.Model, tea.Cmd) {
+	if m.activeColumn >= len(m.columns) {
+		return m, nil
+	}
+	col := m.columns[m.activeColumn]
+	l := m.config.ColumnLayout(col.ID)
+	switch arg {
+	case "":
+		if l.Group == "" {
+			l.Group = config.GroupByLabel
+		} else {
+			l.Group = ""
+		}
+	case config.GroupByLabel:
+		l.Group = config.GroupByLabel
+	case "off":
+		l.Group = ""
+	default:
+		m.notifyError("Usage: :group [label|off]")
+		return m, nil
+	}
+	selected := m.selectedTicket()
+	m.config.SetColumnLayout(col.ID, l)
+
+	m.refreshColumnTickets()
+	if selected != nil {
+		m.selectTicketByID(selected.ID)
+	}
+	clear(m.ticketHeights)
+	if err := m.config.Save(""); err != nil {
+		m.notifyError("Failed to save config: " + err.Error())
+		return m, nil
+	}
+	if l.Group == "" {
+		m.notify(col.Name + " ungrouped")
+	} else {
+		m.notify(col.Name + " grouped by label")
+	}
+	return m, nil
+}
+
+// groupName is how a group's label is shown.
+func groupName(label string) string {
+	if label == "" {
+		return "No label"
+	}
+	return label
+}
+
+// groupHeaders renders the subheaders that come before the ticket at index
+// i of a grouped column: those of any collapsed groups, then the header of
+// the group starting there.
+func (m *Model) groupHeaders(groups []labelGroup, i, width int) []string {
+	var headers []string
+	for _, g := range groups {
+		if g.start == i {
+			headers = append(headers, m.renderGroupHeader(g, width))
+		}
+	}
+	return headers
+}
+
+func (m *Model) renderGroupHeader(g labelGroup, width int) string {
+	marker := "▾"
+	if g.collapsed {
+		marker = "▸"
+	}
+	count := fmt.Sprintf(" (%d)", g.count)
+	style := lipgloss.NewStyle().Foreground(m.colors.secondary).Bold(true)
+	if g.label == "" {
+		style = m.dimStyle().Italic(true)
+	}
+	name := truncateString(marker+" "+groupName(g.label), max(width-len(count), 4))
+	return style.Render(name) + m.dimStyle().Render(count)
+}
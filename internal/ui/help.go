@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	helpKeyWidth  = 13
+	helpDescWidth = 28
+)
+
+func (m *Model) openHelp() {
+	m.showHelp = true
+	m.helpQuery = ""
+	m.helpScroll = 0
+}
+
+// handleHelpKey narrows the help as the user types; ↑/↓ scroll when it is
+// taller than the screen.
+func (m *Model) handleHelpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.showHelp = false
+		if m.mode == ModeNormal {
+			return m.handleQuit()
+		}
+	case tea.KeyEsc, tea.KeyEnter:
+		m.showHelp = false
+	case tea.KeyBackspace:
+		if r := []rune(m.helpQuery); len(r) > 0 {
+			m.helpQuery = string(r[:len(r)-1])
+			m.helpScroll = 0
+		}
+	case tea.KeyCtrlU:
+		m.helpQuery = ""
+		m.helpScroll = 0
+	case tea.KeyUp:
+		m.helpScroll--
+	case tea.KeyDown:
+		m.helpScroll++
+	case tea.KeyPgUp:
+		m.helpScroll -= 10
+	case tea.KeyPgDown:
+		m.helpScroll += 10
+	case tea.KeyRunes, tea.KeySpace:
+		if m.helpQuery == "" && m.keymap.resolve(msg.String()) == "?" {
+			m.showHelp = false
+			return m, nil
+		}
+		m.helpQuery += string(msg.Runes)
+		m.helpScroll = 0
+	}
+	return m, nil
+}
+
+// renderHelp lists the board's key bindings, as configured, by category,
+// keeping those that match the search.
+func (m *Model) renderHelp() string {
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	sectionStyle := lipgloss.NewStyle().Foreground(m.colors.secondary).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(m.colors.info).Bold(true).Width(helpKeyWidth)
+	descStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+
+	var blocks [][]string
+	for _, category := range keyCategories {
+		lines := []string{sectionStyle.Render(category.icon + " " + category.name)}
+		for _, b := range m.keymap.bindings {
+			if b.category != category.name || len(b.keys) == 0 || !b.matches(m.helpQuery) {
+				continue
+			}
+			lines = append(lines, keyStyle.Render(ansi.Truncate(b.keysLabel(), helpKeyWidth-1, "…"))+
+				descStyle.Render(ansi.Truncate(b.help, helpDescWidth, "…")))
+		}
+		if len(lines) > 1 {
+			blocks = append(blocks, lines)
+		}
+	}
+
+	// Two columns when they fit, each block going to the shorter one.
+	colWidth := helpKeyWidth + helpDescWidth
+	columns := make([][]string, 1)
+	if m.width >= 2*colWidth+3+8 {
+		columns = make([][]string, 2)
+	}
+	for _, block := range blocks {
+		shortest := 0
+		for i, col := range columns {
+			if len(col) < len(columns[shortest]) {
+				shortest = i
+			}
+		}
+		if len(columns[shortest]) > 0 {
+			columns[shortest] = append(columns[shortest], "")
+		}
+		columns[shortest] = append(columns[shortest], block...)
+	}
+	rendered := make([]string, 0, 2*len(columns))
+	for i, col := range columns {
+		if i > 0 {
+			rendered = append(rendered, "   ")
+		}
+		rendered = append(rendered, lipgloss.NewStyle().Width(colWidth).Render(strings.Join(col, "\n")))
+	}
+	body := strings.Split(lipgloss.JoinHorizontal(lipgloss.Top, rendered...), "\n")
+	if len(blocks) == 0 {
+		body = []string{m.dimStyle().Render("No keys match")}
+	}
+
+	visible := max(m.height-14, 5)
+	m.helpScroll = min(max(m.helpScroll, 0), max(len(body)-visible, 0))
+	footer := "Type to search · Esc to close"
+	if len(body) > visible {
+		body = body[m.helpScroll : m.helpScroll+visible]
+		footer = "↑/↓ scroll · " + footer
+	}
+
+	sepWidth := colWidth
+	if len(columns) > 1 {
+		sepWidth = 2*colWidth + 3
+	}
+	sep := lipgloss.NewStyle().Foreground(m.colors.surface).Render(strings.Repeat("─", sepWidth))
+	search := m.dimStyle().Render("Search: ")
+	if m.helpQuery != "" {
+		search += lipgloss.NewStyle().Foreground(m.colors.text).Render(m.helpQuery)
+	}
+	search += lipgloss.NewStyle().Foreground(m.colors.primary).Render("▏")
+
+	help := titleStyle.Render("◈ Keyboard Shortcuts") + "\n\n" +
+		search + "\n" +
+		sep + "\n" +
+		strings.Join(body, "\n") + "\n" +
+		sep + "\n" +
+		lipgloss.NewStyle().Foreground(m.colors.warning).Render("💡") + m.dimStyle().Render(" Tip: Hold Shift to select text in agent view") + "\n\n" +
+		m.dimStyle().Render(footer)
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Render(help)
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/hooks"
+)
+
+// hookRun is a hook command waiting to run for an event.
+type hookRun struct {
+	command string
+	event   hooks.Event
+	dir     string
+}
+
+// hookDoneMsg reports a hook command that has finished.
+type hookDoneMsg struct {
+	event string
+	title string
+	err   error
+}
+
+// queueHooks queues the hooks for the events in the ticket's history since
+// it was last saved: its creation, moves, and agent runs starting or
+// failing. Every way of changing a ticket saves it, so this is where they
+// are all seen.
+func (m *Model) queueHooks(ticket *board.Ticket) {
+	if len(m.config.Hooks) == 0 {
+		return
+	}
+	since, ok := m.hookCursor[ticket.ID]
+	if !ok {
+		since = m.hooksFrom
+	}
+	for _, ev := range ticket.History {
+		if !ev.At.After(since) {
+			continue
+		}
+		since = ev.At
+		switch ev.Kind {
+		case board.EventCreated:
+			m.queueHook(ticket, config.HookTicketCreated, hooks.Event{})
+		case board.EventMoved:
+			from, to, _ := strings.Cut(ev.Detail, " → ")
+			m.queueHook(ticket, config.HookTicketMoved, hooks.Event{From: from, To: to})
+			if board.TicketStatus(to) == board.StatusDone {
+				m.queueHook(ticket, config.HookMovedToDone, hooks.Event{From: from, To: to})
+			}
+		case board.EventAgentSpawned:
+			m.queueHook(ticket, config.HookAgentSpawned, hooks.Event{Agent: ev.Detail})
+		case board.EventAgentStopped:
+			if agent, ok := strings.CutSuffix(ev.Detail, " ("+string(board.RunError)+")"); ok {
+				m.queueHook(ticket, config.HookAgentFailed, hooks.Event{Agent: agent})
+			}
+		}
+	}
+	m.hookCursor[ticket.ID] = since
+}
+
+// queueHook queues the hook for event, if one is configured, to run in the
+// ticket's worktree or its project's repo. Hooks are skipped, not held
+// back, while automation is paused.
+func (m *Model) queueHook(ticket *board.Ticket, event string, e hooks.Event) {
+	command := m.config.Hooks[event]
+	if command == "" || m.automationPaused {
+		return
+	}
+	proj := m.globalStore.GetProjectForTicket(ticket)
+	e.PromptContext = m.promptContext(ticket, proj)
+	e.Event = event
+
+	dir := ticket.WorktreePath
+	if _, err := os.Stat(dir); dir == "" || err != nil {
+		dir = ""
+		if proj != nil {
+			dir = proj.RepoPath
+		}
+	}
+	m.pendingHooks = append(m.pendingHooks, hookRun{command: command, event: e, dir: dir})
+}
+
+// runPendingHooks starts the hooks queued during the update, each in the
+// background.
+func (m *Model) runPendingHooks() tea.Cmd {
+	if len(m.pendingHooks) == 0 {
+		return nil
+	}
+	cmds := make([]tea.Cmd, 0, len(m.pendingHooks))
+	for _, run := range m.pendingHooks {
+		cmds = append(cmds, func() tea.Msg {
+			err := hooks.Run(run.command, run.event, run.dir)
+			return hookDoneMsg{event: run.event.Event, title: run.event.Title, err: err}
+		})
+	}
+	m.pendingHooks = nil
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) handleHookDone(msg hookDoneMsg) {
+	if msg.err != nil {
+		m.notifyError(fmt.Sprintf("Hook %s failed for %s: %v", msg.event, msg.title, msg.err))
+	}
+}
@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/git"
+)
+
+// hygieneFix is the one-key fix offered for a hygiene issue.
+type hygieneFix int
+
+const (
+	fixNone hygieneFix = iota
+	fixToBacklog
+	fixLabels
+)
+
+// hygieneIssue is one anti-pattern found on the board, about a ticket or,
+// when ticketID is empty, a whole column.
+type hygieneIssue struct {
+	ticketID board.TicketID
+	column   int
+	problem  string
+	fix      hygieneFix
+}
+
+var hygieneFixHints = map[hygieneFix]string{
+	fixToBacklog: "move back to Backlog",
+	fixLabels:    "add labels",
+}
+
+// openHygiene checks the board as shown for anti-patterns and lists them.
+func (m *Model) openHygiene() (tea.Model, tea.Cmd) {
+	m.hygieneIssues = m.checkHygiene(time.Now())
+	m.hygieneIndex = 0
+	m.mode = ModeHygiene
+	return m, nil
+}
+
+// checkHygiene flags over-limit columns, In Progress tickets left without an
+// agent or commits, Done tickets whose branch isn't merged, and unlabeled
+// backlog items, in board order.
+func (m *Model) checkHygiene(now time.Time) []hygieneIssue {
+	staleAfter := time.Duration(m.config.Behavior.StaleAfterDays) * 24 * time.Hour
+
+	var issues []hygieneIssue
+	for i, col := range m.columns {
+		if i >= len(m.columnTickets) {
+			break
+		}
+		tickets := m.columnTickets[i]
+		if n := m.columnCount(i); col.Limit > 0 && n > col.Limit {
+			issues = append(issues, hygieneIssue{
+				column:  i,
+				problem: fmt.Sprintf("%d tickets, over the limit of %d", n, col.Limit),
+			})
+		}
+
+		for _, ticket := range tickets {
+			issue := hygieneIssue{ticketID: ticket.ID, column: i}
+			switch ticket.Status {
+			case board.StatusInProgress:
+				if staleAfter == 0 || ticket.StartedAt == nil || m.panes[ticket.ID] != nil {
+					continue
+				}
+				last := *ticket.StartedAt
+				if at, ok := m.lastCommit(ticket); ok && at.After(last) {
+					last = at
+				}
+				if now.Sub(last) < staleAfter {
+					continue
+				}
+				issue.problem = fmt.Sprintf("No agent and no commits for %d days", int(now.Sub(last).Hours()/24))
+				issue.fix = fixToBacklog
+			case board.StatusDone:
+				base, unmerged := m.unmergedBranch(ticket)
+				if !unmerged {
+					continue
+				}
+				issue.problem = fmt.Sprintf("Done, but %s isn't merged into %s", ticket.BranchName, base)
+			case board.StatusBacklog:
+				if len(ticket.Labels) > 0 {
+					continue
+				}
+				issue.problem = "Unlabeled"
+				issue.fix = fixLabels
+			default:
+				continue
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// lastCommit is when the ticket's branch was last committed to.
+func (m *Model) lastCommit(ticket *board.Ticket) (time.Time, bool) {
+	proj := m.globalStore.GetProjectForTicket(ticket)
+	if proj == nil || ticket.BranchName == "" {
+		return time.Time{}, false
+	}
+	at, err := git.LastCommitTime(proj.RepoPath, ticket.BranchName)
+	return at, err == nil
+}
+
+// unmergedBranch reports whether the ticket's branch still has commits that
+// are on neither its base branch nor the remote copy of it.
+func (m *Model) unmergedBranch(ticket *board.Ticket) (string, bool) {
+	proj := m.globalStore.GetProjectForTicket(ticket)
+	if proj == nil || ticket.BranchName == "" {
+		return "", false
+	}
+	mgr := m.worktreeMgrs[proj.ID]
+	if mgr == nil || !mgr.BranchExists(ticket.BranchName) {
+		return "", false
+	}
+	base := ticket.BaseBranch
+	if base == "" {
+		base, _ = mgr.GetDefaultBranch()
+	}
+	for _, ref := range []string{base, "origin/" + base} {
+		if merged, err := git.IsMerged(proj.RepoPath, ref, ticket.BranchName); err == nil && merged {
+			return base, false
+		}
+	}
+	return base, true
+}
+
+func (m *Model) handleHygieneMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = ModeNormal
+	case "j", "down":
+		if m.hygieneIndex < len(m.hygieneIssues)-1 {
+			m.hygieneIndex++
+		}
+	case "k", "up":
+		if m.hygieneIndex > 0 {
+			m.hygieneIndex--
+		}
+	case "enter":
+		if m.hygieneIndex < len(m.hygieneIssues) {
+			m.mode = ModeNormal
+			m.goToHygieneIssue(m.hygieneIssues[m.hygieneIndex])
+		}
+	case "f":
+		if m.hygieneIndex < len(m.hygieneIssues) {
+			return m.fixHygieneIssue(m.hygieneIssues[m.hygieneIndex])
+		}
+	}
+	return m, nil
+}
+
+// goToHygieneIssue selects the issue's ticket, or its column.
+func (m *Model) goToHygieneIssue(issue hygieneIssue) {
+	if issue.ticketID == "" {
+		m.activeColumn = issue.column
+		m.activeTicket = 0
+		m.ensureColumnVisible()
+		m.ensureTicketVisible()
+		return
+	}
+	m.selectTicketByID(issue.ticketID)
+	m.ensureColumnVisible()
+}
+
+func (m *Model) fixHygieneIssue(issue hygieneIssue) (tea.Model, tea.Cmd) {
+	ticket, _ := m.globalStore.Get(issue.ticketID)
+	if ticket == nil || issue.fix == fixNone {
+		m.notify("No quick fix — press Enter to go there")
+		return m, nil
+	}
+
+	switch issue.fix {
+	case fixToBacklog:
+		model, cmd := m.moveTicketTo(ticket, board.StatusBacklog)
+		m.hygieneIssues = m.checkHygiene(time.Now())
+		m.hygieneIndex = min(m.hygieneIndex, max(len(m.hygieneIssues)-1, 0))
+		return model, cmd
+	case fixLabels:
+		m.mode = ModeNormal
+		m.selectTicketByID(ticket.ID)
+		m.ensureColumnVisible()
+		model, cmd := m.editTicket()
+		m.blurAllFormFields()
+		m.ticketFormField = formFieldLabels
+		m.focusCurrentField()
+		return model, cmd
+	}
+	return m, nil
+}
+
+func (m *Model) renderHygiene() string {
+	width := min(90, m.width-4)
+	width = max(width, 40)
+	innerWidth := width - 4
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	subjectStyle := lipgloss.NewStyle().Foreground(m.colors.info).Bold(true)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.colors.text).Background(m.colors.surface)
+	fixStyle := lipgloss.NewStyle().Foreground(m.colors.success)
+
+	lines := []string{
+		titleStyle.Render("Hygiene") + m.dimStyle().Render(fmt.Sprintf("  %d issue(s)", len(m.hygieneIssues))),
+		"",
+	}
+	if len(m.hygieneIssues) == 0 {
+		lines = append(lines, m.dimStyle().Italic(true).Render("Nothing to tidy up"))
+	}
+
+	viewport := max(m.height-10, 4) / 2
+	start := 0
+	if m.hygieneIndex >= viewport {
+		start = m.hygieneIndex - viewport + 1
+	}
+	end := min(start+viewport, len(m.hygieneIssues))
+
+	for i := start; i < end; i++ {
+		issue := m.hygieneIssues[i]
+		column := m.columns[issue.column].Name
+		header := subjectStyle.Render(truncateString(column+" column", innerWidth-24))
+		if ticket, _ := m.globalStore.Get(issue.ticketID); ticket != nil {
+			header = subjectStyle.Render(truncateString(ticket.Title, innerWidth-24)) + m.dimStyle().Render("  "+column)
+		}
+		if hint := hygieneFixHints[issue.fix]; hint != "" {
+			header += fixStyle.Render("  [f] " + hint)
+		}
+		text := "  " + truncateString(issue.problem, innerWidth-2)
+		if i == m.hygieneIndex {
+			text = selectedStyle.Render(text)
+		}
+		lines = append(lines, header, text)
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, m.dimStyle().Render("[j/k] Navigate  [Enter] Go there  [f] Fix  [Esc] Close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// keyCategory is a group of bindings in the help overlay.
+type keyCategory struct {
+	name string
+	icon string
+}
+
+var keyCategories = []keyCategory{
+	{"Navigation", "🧭"},
+	{"Tickets", "📝"},
+	{"Agent", "🤖"},
+	{"View", "👁"},
+	{"Sidebar", "📂"},
+}
+
+// keyBinding is a key on the board and what it does. Keys are written as
+// tea.KeyMsg.String() reports them. Bindings with an action can be rebound
+// under "keys" in the config; the others are fixed.
+type keyBinding struct {
+	action   string
+	keys     []string
+	help     string
+	category string
+}
+
+// boardKeyBindings are the board's keys, in the order the help lists them.
+// handleNormalMode switches on the first key of each action.
+var boardKeyBindings = []keyBinding{
+	{"column_left", []string{"h", "left"}, "Previous column", "Navigation"},
+	{"column_right", []string{"l", "right"}, "Next column", "Navigation"},
+	{"ticket_down", []string{"j", "down"}, "Next ticket", "Navigation"},
+	{"ticket_up", []string{"k", "up"}, "Previous ticket", "Navigation"},
+	{"first_ticket", []string{"g"}, "Go to first ticket", "Navigation"},
+	{"last_ticket", []string{"G"}, "Go to last ticket", "Navigation"},
+	{"visual", []string{"v"}, "Visual select", "Navigation"},
+	{"filter", []string{"/"}, "Search/filter", "Navigation"},
+	{"", []string{"esc"}, "Close the top layer, then the filter", "Navigation"},
+	{"command", []string{":"}, "Command line", "Navigation"},
+
+	{"new_ticket", []string{"n"}, "New ticket", "Tickets"},
+	{"new_backlog_ticket", []string{"N"}, "New ticket in Backlog", "Tickets"},
+	{"edit_ticket", []string{"e"}, "Edit ticket", "Tickets"},
+	{"edit_in_editor", []string{"E"}, "Edit in $EDITOR", "Tickets"},
+	{"ticket_details", []string{"i"}, "Ticket details", "Tickets"},
+	{"actions", []string{"."}, "Ticket actions menu", "Tickets"},
+	{"delete_ticket", []string{"d"}, "Delete ticket", "Tickets"},
+	{"move_forward", []string{" "}, "Move forward", "Tickets"},
+	{"move_backward", []string{"backspace"}, "Move backward", "Tickets"},
+	{"move_to", []string{"m"}, "Move to column", "Tickets"},
+	{"nudge_down", []string{"J"}, "Move ticket down", "Tickets"},
+	{"nudge_up", []string{"K"}, "Move ticket up", "Tickets"},
+	{"archive", []string{"a"}, "Archive Done ticket", "Tickets"},
+	{"browse_archive", []string{"A"}, "Browse archive", "Tickets"},
+	{"set_epic", []string{"p"}, "Set epic", "Tickets"},
+	{"toggle_epic", []string{"z"}, "Collapse/expand epic", "Tickets"},
+	{"collapse_group", []string{"c"}, "Collapse label group", "Tickets"},
+	{"fold_groups", []string{"C"}, "Expand all / collapse other groups", "Tickets"},
+
+	{"spawn_agent", []string{"s"}, "Spawn agent", "Agent"},
+	{"stop_agent", []string{"S"}, "Stop agent", "Agent"},
+	{"attach_agent", []string{"enter"}, "Attach to agent", "Agent"},
+	{"", []string{"ctrl+g"}, "Exit agent view", "Agent"},
+	{"retry", []string{"R"}, "Retry in clean worktree", "Agent"},
+	{"attempts", []string{"b"}, "Compare attempts", "Agent"},
+	{"preview", []string{"P"}, "Preview agent output", "Agent"},
+	{"mute", []string{"M"}, "Mute agent notifications", "Agent"},
+
+	{"toggle_sidebar", []string{"["}, "Toggle sidebar", "View"},
+	{"focus_sidebar", []string{"tab"}, "Focus sidebar", "View"},
+	{"split_view", []string{"]"}, "Detail beside board", "View"},
+	{"focus_mode", []string{"Z"}, "Focus on one column", "View"},
+	{"compact_cards", []string{"-"}, "Compact cards", "View"},
+	{"cycle_sort", []string{"o"}, "Cycle column sort", "View"},
+	{"narrow_column", []string{"<"}, "Narrow column", "View"},
+	{"widen_column", []string{">"}, "Widen column", "View"},
+	{"shift_column_left", []string{"{"}, "Shift column left", "View"},
+	{"shift_column_right", []string{"}"}, "Shift column right", "View"},
+	{"hide_column", []string{"X"}, "Hide column", "View"},
+	{"pause_automation", []string{"!"}, "Pause/resume automation", "View"},
+	{"settings", []string{"O"}, "Settings", "View"},
+	{"help", []string{"?"}, "Toggle help", "View"},
+	{"quit", []string{"q"}, "Quit", "View"},
+
+	{"", []string{"j", "k"}, "Navigate projects", "Sidebar"},
+	{"", []string{"enter", " "}, "Toggle project", "Sidebar"},
+	{"", []string{"a"}, "Add project", "Sidebar"},
+	{"", []string{"d"}, "Delete project", "Sidebar"},
+	{"", []string{"l", "esc"}, "Back to board", "Sidebar"},
+}
+
+// keymap is the board's bindings with the user's overrides applied.
+type keymap struct {
+	bindings []keyBinding
+	// remap sends a pressed key to the key handleNormalMode knows the action
+	// by; an empty value means the key has been unbound.
+	remap map[string]string
+}
+
+// newKeymap applies overrides, from action to space-separated keys
+// ("space" for the space bar), to the board's bindings. Keys given to an
+// action replace its defaults and are taken from any action that had them.
+func newKeymap(overrides map[string]string) (keymap, []error) {
+	km := keymap{bindings: slices.Clone(boardKeyBindings), remap: make(map[string]string)}
+	var errs []error
+
+	actions := make([]string, 0, len(overrides))
+	for action := range overrides {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	claimed := make(map[string]string)
+	for _, action := range actions {
+		i := slices.IndexFunc(km.bindings, func(b keyBinding) bool { return b.action != "" && b.action == action })
+		if i < 0 {
+			errs = append(errs, fmt.Errorf("keys: unknown action %q", action))
+			continue
+		}
+		keys := parseKeys(overrides[action])
+		if len(keys) == 0 {
+			errs = append(errs, fmt.Errorf("keys.%s: no keys given", action))
+			continue
+		}
+		if k := slices.IndexFunc(keys, func(k string) bool { return claimed[k] != "" }); k >= 0 {
+			errs = append(errs, fmt.Errorf("keys: %q is bound to both %s and %s", keySpec(keys[k]), claimed[keys[k]], action))
+			continue
+		}
+
+		b := &km.bindings[i]
+		for _, k := range b.keys {
+			if _, ok := km.remap[k]; !ok {
+				km.remap[k] = ""
+			}
+		}
+		for _, k := range keys {
+			claimed[k] = action
+			km.remap[k] = b.keys[0]
+		}
+		b.keys = keys
+	}
+
+	// Keys claimed by an override no longer trigger the action they came from.
+	for i, b := range km.bindings {
+		if b.action == "" || slices.Contains(actions, b.action) {
+			continue
+		}
+		km.bindings[i].keys = slices.DeleteFunc(slices.Clone(b.keys), func(k string) bool { return claimed[k] != "" })
+	}
+	return km, errs
+}
+
+// ValidateKeys reports what's wrong with a keys config, such as unknown
+// actions or a key bound to two of them, without building the keymap.
+func ValidateKeys(overrides map[string]string) []error {
+	_, errs := newKeymap(overrides)
+	return errs
+}
+
+// parseKeys splits a space-separated key list from the config.
+func parseKeys(s string) []string {
+	var keys []string
+	for _, k := range strings.Fields(s) {
+		if strings.EqualFold(k, "space") {
+			k = " "
+		}
+		if !slices.Contains(keys, k) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// keySpec writes a key the way the config spells it.
+func keySpec(k string) string {
+	if k == " " {
+		return "space"
+	}
+	return k
+}
+
+// resolve turns a key pressed on the board into the key handleNormalMode
+// switches on.
+func (km keymap) resolve(key string) string {
+	if to, ok := km.remap[key]; ok {
+		return to
+	}
+	return key
+}
+
+// label is how the help and hints show the keys bound to action, or ""
+// when it has none.
+func (km keymap) label(action string) string {
+	for _, b := range km.bindings {
+		if b.action == action && len(b.keys) > 0 {
+			return keyLabel(b.keys[0])
+		}
+	}
+	return ""
+}
+
+// keysLabel shows every key of a binding, such as "h/←".
+func (b keyBinding) keysLabel() string {
+	labels := make([]string, len(b.keys))
+	for i, k := range b.keys {
+		labels[i] = keyLabel(k)
+	}
+	return strings.Join(labels, "/")
+}
+
+// matches reports whether a binding should show for a help search.
+func (b keyBinding) matches(query string) bool {
+	if query == "" {
+		return true
+	}
+	query = strings.ToLower(query)
+	for _, s := range []string{b.help, b.action, b.category, b.keysLabel()} {
+		if strings.Contains(strings.ToLower(s), query) {
+			return true
+		}
+	}
+	return slices.Contains(b.keys, query)
+}
+
+var keyLabels = map[string]string{
+	" ":     "Space",
+	"left":  "←",
+	"right": "→",
+	"up":    "↑",
+	"down":  "↓",
+}
+
+// keyLabel shows a key as hints do: "Space", "Enter", "Ctrl+G".
+func keyLabel(k string) string {
+	if label, ok := keyLabels[k]; ok {
+		return label
+	}
+	if len([]rune(k)) == 1 {
+		return k
+	}
+	parts := strings.Split(k, "+")
+	for i, p := range parts {
+		if p != "" {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "+")
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// layers names what's open on top of the board, outermost first: an active
+// filter or the focused sidebar, the mode and any mode it was opened from,
+// an input open inside the mode, and a confirmation. The status bar shows
+// them as a breadcrumb, and Esc closes the last one.
+func (m *Model) layers() []string {
+	var layers []string
+	if (m.filterQuery != "" || len(m.filterProjectIDs) > 0) && m.mode != ModeFilter {
+		layers = append(layers, "FILTERED")
+	}
+
+	switch m.mode {
+	case ModeNormal:
+		if m.sidebarFocused {
+			layers = append(layers, "SIDEBAR")
+		}
+	case ModeCustomFields:
+		layers = append(layers, string(ModeTicketDetail), string(m.mode))
+	case ModeFilter:
+		if m.filterParent != ModeNormal {
+			layers = append(layers, string(m.filterParent))
+		}
+		layers = append(layers, string(m.mode))
+	default:
+		layers = append(layers, string(m.mode))
+	}
+	if input := m.modeInput(); input != "" {
+		layers = append(layers, input)
+	}
+
+	if m.showConfirm {
+		layers = append(layers, string(ModeConfirm))
+	}
+	return layers
+}
+
+// modeInput names the input open inside the current mode, which Esc closes
+// before the mode itself.
+func (m *Model) modeInput() string {
+	switch {
+	case m.mode == ModeTicketDetail && m.composingComment:
+		return "COMMENT"
+	case m.mode == ModeCustomFields && m.fieldsEditing,
+		m.mode == ModeSettings && m.settingsEditing,
+		m.mode == ModeBoardEditor && m.boardEditing != boardEditNone:
+		return "EDITING"
+	case m.mode == ModeVisual && m.visualLabeling:
+		return "LABEL"
+	case (m.mode == ModeCreateTicket || m.mode == ModeEditTicket) && m.showAddProjectForm:
+		return string(ModeCreateProject)
+	}
+	return ""
+}
+
+// escapeBoard handles Esc on the board: it leaves the sidebar, then clears
+// the filter.
+func (m *Model) escapeBoard() (tea.Model, tea.Cmd) {
+	switch {
+	case m.sidebarFocused:
+		m.sidebarFocused = false
+	case m.filterQuery != "" || len(m.filterProjectIDs) > 0:
+		m.clearFilter()
+		m.notify("Filter cleared")
+	}
+	return m, nil
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// openTicketLink shows the selected ticket's link with a QR code to scan
+// it from a phone.
+func (m *Model) openTicketLink() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+
+	var projectName string
+	if proj := m.globalStore.GetProjectForTicket(ticket); proj != nil {
+		projectName = proj.Name
+	}
+	link := m.config.TicketLink(string(ticket.ID), projectName)
+	qr, err := renderQR(link)
+	if err != nil {
+		m.notifyError("Can't make a QR code: " + err.Error())
+		return m, nil
+	}
+
+	m.linkTicketID = ticket.ID
+	m.linkURL = link
+	m.linkQR = qr
+	m.mode = ModeLink
+	return m, nil
+}
+
+func (m *Model) handleLinkMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "enter":
+		m.mode = ModeNormal
+	case "y", "c":
+		if err := clipboard.WriteAll(m.linkURL); err != nil {
+			m.notifyError("Copy failed: " + err.Error())
+		} else {
+			m.notifySuccess("Copied " + m.linkURL)
+		}
+		m.mode = ModeNormal
+	}
+	return m, nil
+}
+
+func (m *Model) renderTicketLink() string {
+	ticket, _ := m.globalStore.Get(m.linkTicketID)
+	if ticket == nil {
+		return ""
+	}
+	width := max(lipgloss.Width(m.linkQR), 40)
+
+	lines := []string{
+		lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true).Render("Ticket link"),
+		m.dimStyle().Render(truncateString(ticket.Title, width)),
+		"",
+		lipgloss.PlaceHorizontal(width, lipgloss.Center, m.linkQR),
+		"",
+		lipgloss.NewStyle().Foreground(m.colors.info).Width(width).Render(m.linkURL),
+		"",
+		m.dimStyle().Render("[y] Copy link  [Esc] Close"),
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderQR draws a QR code two modules to a character cell with half
+// blocks, always dark on light so phone cameras read it whatever the theme.
+// Without colors the light modules are drawn as blocks instead, which reads
+// on a dark terminal.
+func renderQR(content string) (string, error) {
+	qr, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return "", err
+	}
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		return strings.TrimSuffix(qr.ToSmallString(false), "\n"), nil
+	}
+	bits := qr.Bitmap()
+
+	color := func(set bool) lipgloss.Color {
+		if set {
+			return lipgloss.Color("#000000")
+		}
+		return lipgloss.Color("#ffffff")
+	}
+	var rows []string
+	for y := 0; y < len(bits); y += 2 {
+		var row strings.Builder
+		for x := range bits[y] {
+			bottom := false
+			if y+1 < len(bits) {
+				bottom = bits[y+1][x]
+			}
+			row.WriteString(lipgloss.NewStyle().
+				Foreground(color(bits[y][x])).
+				Background(color(bottom)).
+				Render("▀"))
+		}
+		rows = append(rows, row.String())
+	}
+	return strings.Join(rows, "\n"), nil
+}
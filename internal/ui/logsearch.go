@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/agent"
+)
+
+const logSearchLimit = 200
+
+// openLogSearch searches archived transcripts and session logs plus the
+// output of any running agent panes, and shows the results in an overlay.
+func (m *Model) openLogSearch(term string) (tea.Model, tea.Cmd) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		m.notify("Usage: :grep <term>")
+		return m, nil
+	}
+
+	idx, err := agent.BuildLogIndex(agent.ArtifactsDir())
+	if err != nil {
+		m.notifyError("Search failed: " + err.Error())
+		return m, nil
+	}
+	if err := idx.AddSessionLogs(agent.SessionLogsDir()); err != nil {
+		m.notifyError("Search failed: " + err.Error())
+		return m, nil
+	}
+	for ticketID, pane := range m.panes {
+		idx.Add(ticketID, "live", pane.Transcript(agent.TranscriptTailLines))
+	}
+
+	m.logTerm = term
+	m.logMatches = idx.Search(term, logSearchLimit)
+	m.logIndex = 0
+	m.mode = ModeLogSearch
+	return m, nil
+}
+
+func (m *Model) handleLogSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = ModeNormal
+	case "j", "down":
+		if m.logIndex < len(m.logMatches)-1 {
+			m.logIndex++
+		}
+	case "k", "up":
+		if m.logIndex > 0 {
+			m.logIndex--
+		}
+	case "enter":
+		if m.logIndex >= len(m.logMatches) {
+			return m, nil
+		}
+		ticketID := m.logMatches[m.logIndex].TicketID
+		ticket, _ := m.globalStore.Get(ticketID)
+		if ticket == nil {
+			m.notify("Ticket no longer exists")
+			return m, nil
+		}
+		m.mode = ModeNormal
+		if !m.ticketMatchesFilter(ticket) {
+			m.clearFilter()
+		}
+		m.refreshColumnTickets()
+		m.selectTicketByID(ticketID)
+	}
+	return m, nil
+}
+
+func (m *Model) renderLogSearch() string {
+	width := min(100, m.width-4)
+	width = max(width, 40)
+	innerWidth := width - 4
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	ticketStyle := lipgloss.NewStyle().Foreground(m.colors.info).Bold(true)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.colors.text).Background(m.colors.surface)
+
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("grep %q", m.logTerm)) + m.dimStyle().Render(fmt.Sprintf("  %d matches", len(m.logMatches))),
+		"",
+	}
+
+	if len(m.logMatches) == 0 {
+		lines = append(lines, m.dimStyle().Italic(true).Render("No matches in archived or live transcripts"))
+	}
+
+	viewport := max(m.height-10, 4) / 2
+	start := 0
+	if m.logIndex >= viewport {
+		start = m.logIndex - viewport + 1
+	}
+	end := min(start+viewport, len(m.logMatches))
+
+	for i := start; i < end; i++ {
+		match := m.logMatches[i]
+		title := string(match.TicketID)
+		if ticket, _ := m.globalStore.Get(match.TicketID); ticket != nil {
+			title = ticket.Title
+		}
+		header := ticketStyle.Render(truncateString(title, innerWidth/2)) + " " +
+			m.dimStyle().Render(fmt.Sprintf("%s:%d", match.Source, match.Line))
+		text := "  " + truncateString(match.Text, innerWidth-2)
+		if i == m.logIndex {
+			text = selectedStyle.Render(text)
+		}
+		lines = append(lines, header, text)
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, m.dimStyle().Render("[j/k] Navigate  [Enter] Go to ticket  [Esc] Close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
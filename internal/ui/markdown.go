@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxMarkdownCache bounds the rendered texts kept between redraws.
+const maxMarkdownCache = 256
+
+// markdownRenderer renders ticket descriptions and comments for the detail
+// view. Rendering is slow next to a frame, so output is kept by text.
+type markdownRenderer struct {
+	width    int
+	renderer *glamour.TermRenderer
+	cache    map[string][]string
+}
+
+// markdownLines renders text as markdown wrapped to width, falling back to
+// plain wrapped text if it can't be rendered.
+func (m *Model) markdownLines(text string, width int) []string {
+	if m.markdown == nil || m.markdown.width != width {
+		renderer, err := glamour.NewTermRenderer(
+			glamour.WithStyles(m.markdownStyle()),
+			glamour.WithWordWrap(width),
+			glamour.WithPreservedNewLines(),
+			glamour.WithColorProfile(lipgloss.ColorProfile()),
+		)
+		if err != nil {
+			return strings.Split(lipgloss.NewStyle().Foreground(m.colors.text).Width(width).Render(text), "\n")
+		}
+		m.markdown = &markdownRenderer{width: width, renderer: renderer, cache: map[string][]string{}}
+	}
+
+	if lines, ok := m.markdown.cache[text]; ok {
+		return lines
+	}
+	out, err := m.markdown.renderer.Render(text)
+	if err != nil {
+		return strings.Split(lipgloss.NewStyle().Foreground(m.colors.text).Width(width).Render(text), "\n")
+	}
+	lines := strings.Split(strings.Trim(out, "\n"), "\n")
+	if len(m.markdown.cache) >= maxMarkdownCache {
+		clear(m.markdown.cache)
+	}
+	m.markdown.cache[text] = lines
+	return lines
+}
+
+// markdownStyle is glamour's dark or light style, whichever suits the
+// theme's background, recolored with the theme and without the document
+// margins the detail view already has.
+func (m *Model) markdownStyle() ansi.StyleConfig {
+	c := m.theme.Colors
+	style := styles.DarkStyleConfig
+	if r, g, b := parseHex(c.Base); 299*int(r)+587*int(g)+114*int(b) > 128*1000 {
+		style = styles.LightStyleConfig
+	}
+
+	style.Document.BlockPrefix = ""
+	style.Document.BlockSuffix = ""
+	style.Document.Color = &c.Text
+	style.Document.Margin = new(uint)
+	style.BlockQuote.Color = &c.Subtext
+	style.Heading.Color = &c.Primary
+	style.H1.Color = &c.Base
+	style.H1.BackgroundColor = &c.Primary
+	style.H6.Color = &c.Muted
+	style.HorizontalRule.Color = &c.Muted
+	style.Link.Color = &c.Info
+	style.LinkText.Color = &c.Secondary
+	style.Code.Color = &c.Warning
+	style.Code.BackgroundColor = &c.Surface
+	style.CodeBlock.Color = &c.Subtext
+	style.CodeBlock.Margin = new(uint)
+	if style.CodeBlock.Chroma != nil {
+		chroma := *style.CodeBlock.Chroma
+		chroma.Background.BackgroundColor = &c.Surface
+		style.CodeBlock.Chroma = &chroma
+	}
+	return style
+}
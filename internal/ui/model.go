@@ -1,9 +1,11 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -17,8 +19,11 @@ import (
 	"github.com/techdufus/openkanban/internal/board"
 	"github.com/techdufus/openkanban/internal/config"
 	"github.com/techdufus/openkanban/internal/git"
+	"github.com/techdufus/openkanban/internal/hooks"
 	"github.com/techdufus/openkanban/internal/project"
+	"github.com/techdufus/openkanban/internal/telemetry"
 	"github.com/techdufus/openkanban/internal/terminal"
+	"github.com/techdufus/openkanban/internal/tracing"
 	"github.com/techdufus/openkanban/internal/update"
 )
 
@@ -37,15 +42,34 @@ const (
 	ModeAgentView     Mode = "AGENT"
 	ModeSettings      Mode = "SETTINGS"
 	ModeShuttingDown  Mode = "SHUTTING_DOWN"
-	ModeSpawning      Mode = "SPAWNING"
 	ModeFilter        Mode = "FILTER"
 	ModeCreateProject Mode = "NEW_PROJECT"
+	ModeTicketDetail  Mode = "DETAIL"
+	ModeOutcome       Mode = "OUTCOME"
+	ModeCustomFields  Mode = "FIELDS"
+	ModeLogSearch     Mode = "LOGS"
+	ModeArchive       Mode = "ARCHIVE"
+	ModeParentPicker  Mode = "EPIC"
+	ModeBoardEditor   Mode = "BOARD"
+	ModeVisual        Mode = "VISUAL"
+	ModeMovePicker    Mode = "MOVE"
+	ModeAttempts      Mode = "ATTEMPTS"
+	ModeHygiene       Mode = "HYGIENE"
+	ModeStats         Mode = "STATS"
+	ModeLink          Mode = "LINK"
+	ModeSessionLog    Mode = "LOG"
+	ModeActions       Mode = "ACTIONS"
+	ModeAgentPicker   Mode = "SPAWN"
 )
 
 const (
 	minColumnWidth = 20
 	columnOverhead = 5
 
+	// columnResizeStep is how many cells < and > change a fixed-width
+	// column by.
+	columnResizeStep = 4
+
 	ticketHeight       = 6
 	columnHeaderHeight = 3
 
@@ -53,11 +77,13 @@ const (
 	formFieldDescription = 1
 	formFieldBranch      = 2
 	formFieldLabels      = 3
-	formFieldPriority    = 4
-	formFieldWorktree    = 5
-	formFieldAgent       = 6
-	formFieldBlockedBy   = 7
-	formFieldProject     = 8
+	formFieldAssignee    = 4
+	formFieldPriority    = 5
+	formFieldStatus      = 6
+	formFieldWorktree    = 7
+	formFieldAgent       = 8
+	formFieldBlockedBy   = 9
+	formFieldProject     = 10
 )
 
 type Model struct {
@@ -65,8 +91,20 @@ type Model struct {
 	theme  config.Theme
 	colors uiColors
 
+	// darkBackground is whether the terminal background is dark, which
+	// picks the variant of the auto theme.
+	darkBackground bool
+
+	// cardLayout is the parsed card face, from ui.card.layout.
+	cardLayout []config.CardLine
+
+	// markdown renders ticket text in the detail view; nil until first
+	// used and whenever the theme changes.
+	markdown *markdownRenderer
+
 	globalStore      *project.GlobalTicketStore
 	projectRegistry  *project.ProjectRegistry
+	sprints          *project.SprintStore
 	columns          []board.Column
 	filterProjectIDs map[string]bool
 
@@ -83,11 +121,29 @@ type Model struct {
 	scrollOffset  int
 	columnOffsets []int
 
+	// spinnerRunning is set while a spinner tick is pending, so it is
+	// only ever driven by one tick chain.
+	spinnerRunning bool
+
 	dragging         bool
 	dragSourceColumn int
 	dragSourceTicket int
 	dragTargetColumn int
 
+	// cardHeights holds the rendered height of each visible card per column,
+	// from the column's scroll offset down, for mouse hit-testing.
+	cardHeights map[board.TicketStatus][]int
+
+	// ticketHeights remembers each card's height as last rendered, so
+	// scrolling can tell how many fit before they are drawn again.
+	ticketHeights map[board.TicketID]int
+
+	// headerRegions and statusRegions are the clickable spans of the header
+	// and status bar as last rendered. statusRegions is empty while an
+	// overlay reaches down over the status bar.
+	headerRegions []clickRegion
+	statusRegions []clickRegion
+
 	hoverColumn int
 	hoverTicket int
 
@@ -96,17 +152,35 @@ type Model struct {
 	lastClickTicket int
 
 	columnTickets [][]*board.Ticket
+	// columnGroups holds the label groups of each column grouped by label
+	columnGroups    [][]labelGroup
+	collapsedGroups map[groupKey]bool
 
 	showHelp    bool
+	helpQuery   string
+	helpScroll  int
 	showConfirm bool
 	confirmMsg  string
 	confirmFn   func() tea.Cmd
 
+	// pendingGate is the move waiting on a protected column's gate command.
+	pendingGate *gatedMove
+
+	// automationPaused holds off what the board does on its own, for this
+	// session only.
+	automationPaused bool
+
+	// showPreview shows the selected ticket's agent output under the board.
+	showPreview bool
+	preview     agentPreview
+
 	titleInput         textinput.Model
 	descInput          textarea.Model
 	branchInput        textinput.Model
 	labelsInput        textinput.Model
+	assigneeInput      textinput.Model
 	ticketPriority     int
+	ticketStatus       board.TicketStatus
 	ticketUseWorktree  bool
 	ticketAgent        string
 	agentListIndex     int
@@ -128,15 +202,67 @@ type Model struct {
 	formScrollOffset int
 	formFieldLines   map[int]int
 
-	notification string
-	notifyTime   time.Time
+	// keymap holds the board's key bindings, with the config's overrides.
+	keymap keymap
+
+	// toasts are the notifications on screen, newest last.
+	toasts []toast
+
+	outcomeTicketID board.TicketID
+	outcomeIndex    int
+
+	fieldsTicketID board.TicketID
+	fieldsIndex    int
+	fieldsEditing  bool
+	fieldInput     textinput.Model
+
+	detailTicketID   board.TicketID
+	detailScroll     int
+	detailTab        detailTab
+	commentInput     textarea.Model
+	composingComment bool
 
 	panes          map[board.TicketID]*terminal.Pane
 	focusedPane    board.TicketID
 	statusDetector *agent.StatusDetector
-
-	spawningTicketID board.TicketID
-	spawningAgent    string
+	agentMessages  map[board.TicketID]string
+	gitSummaries   map[board.TicketID]gitSummary
+	agentTraces    map[board.TicketID]agentTrace
+
+	// commitScans maps projects to when their repo was last read for
+	// commits mentioning ticket keys, and commitScanAt is when the last
+	// round of reads started.
+	commitScans  map[string]time.Time
+	commitScanAt time.Time
+
+	// hookCursor maps tickets to the time of the last history event hooks
+	// were queued for; events before hooksFrom, when the board opened, are
+	// never run. pendingHooks wait for the end of the current update.
+	hookCursor   map[board.TicketID]time.Time
+	hooksFrom    time.Time
+	pendingHooks []hookRun
+
+	// reviewCursor does the same for moves into columns with a reviewer,
+	// whose reviews are spawned at the end of the update. heldReviews are
+	// those pending until the ticket's agent finishes.
+	reviewCursor   map[board.TicketID]time.Time
+	pendingReviews []board.TicketID
+	heldReviews    map[board.TicketID]bool
+
+	// pendingBell rings the bell at the end of the update; flashUntil is
+	// when the header stops flashing in flashEvent's color.
+	pendingBell    bool
+	flashUntil     time.Time
+	flashEvent     int
+	flashScheduled bool
+
+	// starting maps tickets whose agent is starting, from the spawn until
+	// its first output, to the agent's name; preparing maps tickets whose
+	// worktree is being created on their way into a column to that column.
+	// Both run in the background and show on the ticket's card.
+	starting      map[board.TicketID]string
+	preparing     map[board.TicketID]board.TicketStatus
+	attachOnStart board.TicketID
 
 	settingsIndex   int
 	settingsEditing bool
@@ -145,8 +271,88 @@ type Model struct {
 
 	filterInput textinput.Model
 	filterQuery string
+	// filterBefore is the query Esc restores, and filterParent the mode it
+	// returns to.
+	filterBefore string
+	filterParent Mode
+
+	archiveIndex int
+
+	attemptsTicketID board.TicketID
+	attemptsBase     string
+	attempts         []attemptSummary
+	attemptIndex     int
+
+	hygieneIssues []hygieneIssue
+	hygieneIndex  int
+
+	statsMetric heatmapMetric
+
+	linkTicketID board.TicketID
+	linkURL      string
+	linkQR       string
+
+	sessionLog sessionLogView
+
+	parentTicketID board.TicketID
+	parentIndex    int
+	collapsedEpics map[board.TicketID]bool
+
+	// Board editor
+	boardIndex   int
+	boardEditing boardEditField
+	boardInput   textinput.Model
+
+	// Column picker for "m"
+	moveTicketID board.TicketID
+	moveIndex    int
+
+	// Agent picker for "s"
+	agentPickTicketID board.TicketID
+	agentChoices      []agentChoice
+	agentPickIndex    int
+
+	// Ticket actions menu
+	actions     []ticketAction
+	actionIndex int
+
+	// Visual mode and bulk spawning
+	visualAnchor   int
+	visualLabeling bool
+	visualInput    textinput.Model
+	spawnQueue     []board.TicketID
+	batchSpawning  bool
+	batchSpawned   int
+	batchTicketID  board.TicketID
+
+	movedTicketID board.TicketID
+	moveFrame     int
+	moveAnimGen   int
+	renderBudget  renderBudget
+
+	commandInput textinput.Model
+	logTerm      string
+	logMatches   []agent.LogMatch
+	logIndex     int
+
+	// Command line history and tab completion; completions is nil until
+	// tab is pressed and again once the line is edited.
+	commandHistory      []string
+	commandHistoryIndex int
+	commandDraft        string
+	completions         []string
+	completionIndex     int
+	completionPrefix    string
+
+	// hiddenColumns and sessionColumnOrder rearrange the board for this
+	// session only; neither is saved.
+	hiddenColumns      []string
+	sessionColumnOrder []string
 
 	sidebarVisible bool
+	splitView      bool
+	focusMode      bool
+	compactCards   bool
 	sidebarFocused bool
 	sidebarIndex   int
 	sidebarWidth   int
@@ -154,7 +360,7 @@ type Model struct {
 	updateChecker *update.Checker
 }
 
-func NewModel(cfg *config.Config, globalStore *project.GlobalTicketStore, projectRegistry *project.ProjectRegistry, agentMgr *agent.Manager, opencodeServer *agent.OpencodeServer, filterProjectID string, updateChecker *update.Checker) *Model {
+func NewModel(cfg *config.Config, globalStore *project.GlobalTicketStore, projectRegistry *project.ProjectRegistry, sprints *project.SprintStore, agentMgr *agent.Manager, opencodeServer *agent.OpencodeServer, filterProjectID string, updateChecker *update.Checker) *Model {
 	ti := textinput.New()
 	ti.Placeholder = "Enter ticket title..."
 	ti.CharLimit = 100
@@ -177,6 +383,11 @@ func NewModel(cfg *config.Config, globalStore *project.GlobalTicketStore, projec
 	li.CharLimit = 200
 	li.Width = 40
 
+	ai := textinput.New()
+	ai.Placeholder = "Unassigned"
+	ai.CharLimit = 100
+	ai.Width = 40
+
 	pi := textinput.New()
 	pi.Placeholder = "Select project..."
 	pi.CharLimit = 100
@@ -201,8 +412,33 @@ func NewModel(cfg *config.Config, globalStore *project.GlobalTicketStore, projec
 	bf.CharLimit = 100
 	bf.Width = 30
 
+	cmi := textinput.New()
+	cmi.Placeholder = "tab to complete"
+	cmi.CharLimit = 200
+	cmi.Width = 30
+
+	fv := textinput.New()
+	fv.CharLimit = 200
+	fv.Width = 30
+
+	bdi := textinput.New()
+	bdi.CharLimit = 40
+	bdi.Width = 30
+
+	vi := textinput.New()
+	vi.Placeholder = "label, -removed"
+	vi.CharLimit = 100
+	vi.Width = 30
+
+	ci := textarea.New()
+	ci.Placeholder = "Add a comment..."
+	ci.CharLimit = 0
+	ci.SetWidth(50)
+	ci.SetHeight(3)
+	ci.ShowLineNumbers = false
+
 	sp := spinner.New()
-	sp.Spinner = spinner.Dot
+	sp.Spinner = spinnerFor(cfg.UI)
 
 	worktreeMgrs := make(map[string]*git.WorktreeManager)
 	for _, p := range globalStore.Projects() {
@@ -220,14 +456,17 @@ func NewModel(cfg *config.Config, globalStore *project.GlobalTicketStore, projec
 		}
 	}
 
-	theme := cfg.GetTheme()
+	darkBackground := startupDarkBackground(cfg)
+	theme := cfg.GetTheme(darkBackground)
 	m := &Model{
 		config:             cfg,
 		theme:              theme,
 		colors:             newUIColors(theme),
+		darkBackground:     darkBackground,
+		cardLayout:         cfg.UI.Card.Lines(),
 		globalStore:        globalStore,
 		projectRegistry:    projectRegistry,
-		columns:            board.DefaultColumns(),
+		sprints:            sprints,
 		filterProjectIDs:   make(map[string]bool),
 		worktreeMgrs:       worktreeMgrs,
 		agentMgr:           agentMgr,
@@ -237,24 +476,51 @@ func NewModel(cfg *config.Config, globalStore *project.GlobalTicketStore, projec
 		descInput:          di,
 		branchInput:        bi,
 		labelsInput:        li,
+		assigneeInput:      ai,
+		fieldInput:         fv,
+		commandInput:       cmi,
+		boardInput:         bdi,
+		visualInput:        vi,
 		ticketPriority:     3,
 		projectInput:       pi,
 		settingsInput:      si,
 		filterInput:        fi,
 		addProjectPath:     ap,
 		blockerFilterInput: bf,
+		commentInput:       ci,
 		selectedBlockers:   make(map[board.TicketID]bool),
+		collapsedEpics:     make(map[board.TicketID]bool),
+		collapsedGroups:    make(map[groupKey]bool),
 		formFieldLines:     make(map[int]int),
+		cardHeights:        make(map[board.TicketStatus][]int),
+		ticketHeights:      make(map[board.TicketID]int),
 		spinner:            sp,
 		panes:              make(map[board.TicketID]*terminal.Pane),
 		statusDetector:     agent.NewStatusDetector(),
+		agentMessages:      make(map[board.TicketID]string),
+		gitSummaries:       make(map[board.TicketID]gitSummary),
+		commitScans:        make(map[string]time.Time),
+		hookCursor:         make(map[board.TicketID]time.Time),
+		reviewCursor:       make(map[board.TicketID]time.Time),
+		heldReviews:        make(map[board.TicketID]bool),
+		hooksFrom:          time.Now(),
+		agentTraces:        make(map[board.TicketID]agentTrace),
+		starting:           make(map[board.TicketID]string),
+		preparing:          make(map[board.TicketID]board.TicketStatus),
 		selectedProject:    selectedProject,
 		sidebarVisible:     cfg.UI.SidebarVisible,
+		splitView:          cfg.UI.SplitView,
+		compactCards:       cfg.UI.CompactCards,
 		sidebarWidth:       24,
 		hoverColumn:        -1,
 		hoverTicket:        -1,
 		updateChecker:      updateChecker,
 	}
+	m.columns = m.config.BoardColumns()
+	km, keyErrs := newKeymap(cfg.Keys)
+	m.keymap = km
+	m.statusDetector.SetStatusFileTTL(time.Duration(cfg.Behavior.StatusFileTTL) * time.Second)
+	m.statusDetector.SetOpencodeAPI(cfg.Opencode.ServerEnabled)
 	if filterProjectID != "" {
 		m.filterProjectIDs[filterProjectID] = true
 	}
@@ -267,6 +533,16 @@ func NewModel(cfg *config.Config, globalStore *project.GlobalTicketStore, projec
 			globalStore.Save(ticket)
 		}
 	}
+	m.recoverLostSessions()
+	if errs := globalStore.IntegrityErrors(); len(errs) > 0 {
+		m.notifyError("Warning: " + errs[0].Error())
+	}
+	if errs := globalStore.CompatWarnings(); len(errs) > 0 {
+		m.notifyError("Warning: " + errs[0].Error())
+	}
+	if len(keyErrs) > 0 {
+		m.notifyError("Warning: " + keyErrs[0].Error())
+	}
 
 	m.refreshColumnTickets()
 	return m
@@ -275,7 +551,7 @@ func NewModel(cfg *config.Config, globalStore *project.GlobalTicketStore, projec
 func (m *Model) Init() tea.Cmd {
 	return tea.Batch(
 		tickAgentStatus(m.agentMgr.StatusPollInterval()),
-		m.spinner.Tick,
+		m.spinnerTick(),
 		m.checkForUpdates(),
 	)
 }
@@ -290,114 +566,85 @@ func (m *Model) checkForUpdates() tea.Cmd {
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	if ticks := m.toastTicks(); ticks != nil {
+		cmd = tea.Batch(cmd, ticks)
+	}
+	if summary := m.refreshGitSummary(); summary != nil {
+		cmd = tea.Batch(cmd, summary)
+	}
+	if run := m.runPendingHooks(); run != nil {
+		cmd = tea.Batch(cmd, run)
+	}
+	if review := m.runPendingReviews(); review != nil {
+		cmd = tea.Batch(cmd, review)
+	}
+	if alerts := m.runPendingAlerts(); alerts != nil {
+		cmd = tea.Batch(cmd, alerts)
+	}
+	return model, cmd
+}
+
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.mode == ModeShuttingDown {
 		switch msg := msg.(type) {
 		case shutdownCompleteMsg:
 			return m, tea.Quit
 		case spinner.TickMsg:
-			var cmd tea.Cmd
-			m.spinner, cmd = m.spinner.Update(msg)
-			return m, cmd
-		}
-		return m, nil
-	}
-
-	if m.mode == ModeSpawning {
-		switch msg := msg.(type) {
-		case agentStatusMsg:
-			return m, tea.Batch(
-				m.pollAgentStatusesAsync(),
-				tickAgentStatus(m.agentMgr.StatusPollInterval()),
-			)
-		case spawnReadyMsg:
-			if msg.ticketID != m.spawningTicketID {
-				return m, nil
-			}
-
-			ticket, _ := m.globalStore.Get(msg.ticketID)
-			if ticket != nil {
-				ticket.AgentType = m.spawningAgent
-				ticket.AgentStatus = board.AgentNone
-				if ticket.AgentSpawnedAt == nil {
-					now := time.Now()
-					ticket.AgentSpawnedAt = &now
-				}
-				if msg.worktreePath != "" && ticket.WorktreePath == "" {
-					ticket.WorktreePath = msg.worktreePath
-					ticket.BranchName = msg.branchName
-					ticket.BaseBranch = msg.baseBranch
-				}
-				m.saveTicket(ticket)
-			}
-
-			m.panes[msg.ticketID] = msg.pane
-			m.focusedPane = msg.ticketID
-			return m, msg.pane.Start(msg.command, msg.args...)
-
-		case spawnErrorMsg:
-			if msg.ticketID == m.spawningTicketID {
-				m.mode = ModeNormal
-				m.spawningTicketID = ""
-				m.spawningAgent = ""
-				m.notify(msg.err)
-			}
-			return m, nil
-
-		case terminal.OutputMsg:
-			if board.TicketID(msg.PaneID) == m.spawningTicketID {
-				m.mode = ModeAgentView
-				m.spawningTicketID = ""
-				m.spawningAgent = ""
-			}
-			return m.handleTerminalMsg(msg)
-
-		case terminal.ExitMsg:
-			if board.TicketID(msg.PaneID) == m.spawningTicketID {
-				m.resetSpawnState(board.TicketID(msg.PaneID))
-				if msg.Err != nil {
-					m.notify("Agent failed: " + msg.Err.Error())
-				} else {
-					m.notify("Agent exited unexpectedly")
-				}
-			}
-			return m, nil
-
-		case spinner.TickMsg:
-			var cmd tea.Cmd
-			m.spinner, cmd = m.spinner.Update(msg)
-			return m, cmd
-
-		case tea.KeyMsg:
-			if msg.String() == "esc" {
-				if pane, ok := m.panes[m.spawningTicketID]; ok {
-					pane.Stop()
-					delete(m.panes, m.spawningTicketID)
-				}
-				m.mode = ModeNormal
-				m.spawningTicketID = ""
-				m.spawningAgent = ""
-				m.notify("Spawn cancelled")
-				return m, nil
-			}
+			return m, m.updateSpinner(msg)
 		}
 		return m, nil
 	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		return m.handleKey(msg)
+		model, cmd := m.handleKey(msg)
+		m.refreshPreview()
+		return model, cmd
 
 	case tea.WindowSizeMsg:
+		sized := m.width > 0
 		m.width = msg.Width
 		m.height = msg.Height
+		if !m.showSidebar() {
+			m.sidebarFocused = false
+		}
+		m.ensureColumnVisible()
 		if m.focusedPane != "" {
 			if pane, ok := m.panes[m.focusedPane]; ok {
 				pane.SetSize(m.width, m.height-2)
 			}
 		}
+		if !sized && len(m.spawnQueue) > 0 && !m.batchSpawning {
+			// Lost sessions respawn once there's a size for their panes.
+			m.batchSpawning = true
+			m.batchSpawned = 0
+			return m.spawnQueued()
+		}
 		return m, nil
 
 	case tea.MouseMsg:
+		if m.showHelp {
+			if msg.Action == tea.MouseActionPress {
+				switch msg.Button {
+				case tea.MouseButtonLeft:
+					m.showHelp = false
+				case tea.MouseButtonWheelUp:
+					m.helpScroll--
+				case tea.MouseButtonWheelDown:
+					m.helpScroll++
+				}
+			}
+			return m, nil
+		}
+		if m.showConfirm {
+			return m.handleConfirmMouse(msg)
+		}
+		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft && msg.Y == m.height-1 {
+			if key, ok := regionAt(m.statusRegions, msg.X); ok {
+				return m.handleKey(key)
+			}
+		}
 		if m.mode == ModeNormal {
 			return m.handleMouse(msg)
 		}
@@ -413,31 +660,107 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.mode == ModeSettings {
 			return m.handleSettingsMouse(msg)
 		}
-		if m.showHelp {
-			if msg.Action == tea.MouseActionPress {
-				m.showHelp = false
-			}
-			return m, nil
-		}
-		if m.showConfirm {
-			return m.handleConfirmMouse(msg)
+		if m.mode == ModeTicketDetail {
+			return m.handleTicketDetailMouse(msg)
 		}
 		return m, nil
 
-	case terminal.OutputMsg, terminal.RenderTickMsg:
+	case spawnReadyMsg:
+		return m.handleSpawnReady(msg)
+
+	case spawnErrorMsg:
+		return m.handleSpawnError(msg)
+
+	case branchReadyMsg:
+		return m.handleBranchReady(msg)
+
+	case firstOutputTimeoutMsg:
+		return m.handleFirstOutputTimeout(msg)
+
+	case terminal.OutputMsg:
+		_, cmd := m.handleTerminalMsg(msg)
+		if _, starting := m.starting[board.TicketID(msg.PaneID)]; starting {
+			return m, tea.Batch(cmd, m.agentStarted(board.TicketID(msg.PaneID)))
+		}
+		return m, cmd
+
+	case terminal.RenderTickMsg:
+		m.followSessionLog(board.TicketID(msg.PaneID))
 		return m.handleTerminalMsg(msg)
 
 	case terminal.ExitMsg:
 		ticketID := board.TicketID(msg.PaneID)
-		delete(m.panes, ticketID)
-		if ticket, _ := m.globalStore.Get(ticketID); ticket != nil {
+		// A pane stopped for a restart or review can report its exit after
+		// its replacement is registered under the same ID.
+		if pane, tracked := m.panes[ticketID]; tracked && msg.Pane != pane {
+			return m, nil
+		}
+		if _, starting := m.starting[ticketID]; starting {
+			// Exits before the new pane is registered come from a pane
+			// stopped just before this spawn.
+			if _, started := m.panes[ticketID]; started {
+				return m.failAgentStart(ticketID, "exited without output")
+			}
+			return m, nil
+		}
+		ticket, _ := m.globalStore.Get(ticketID)
+		if _, tracked := m.panes[ticketID]; !tracked && ticket != nil && ticket.CurrentAgentRun() == nil {
+			// Already recorded, e.g. stopped by failAgentStart.
+			return m, nil
+		}
+		// A headless run may well be a quick one-shot prompt.
+		if pane, tracked := m.panes[ticketID]; tracked && !pane.Headless() && ticket != nil && m.exitedDuringStartup(ticket) {
+			return m.failAgentStart(ticketID, "exited during startup")
+		}
+		// An agent that exits having completed moves its pipeline on, as
+		// one that reports completing does.
+		advance := false
+		if ticket != nil {
+			outcome := board.RunCompleted
+			if msg.Err != nil || ticket.AgentStatus == board.AgentError {
+				outcome = board.RunError
+			}
+			// An agent that reported completing has already alerted.
+			alerted := ticket.AgentStatus == board.AgentError || ticket.AgentStatus == board.AgentCompleted
+			advance = outcome == board.RunCompleted && ticket.AgentStatus != board.AgentCompleted
+			pane := m.panes[ticketID]
+			m.finishAgentRun(ticket, pane, outcome)
 			ticket.AgentStatus = board.AgentNone
+			if outcome == board.RunError {
+				// The card shows the failure until the agent is respawned.
+				ticket.AgentStatus = board.AgentError
+				if reason := exitReason(pane, msg.Err); reason != "" {
+					m.agentMessages[ticketID] = reason
+				}
+			}
 			m.saveTicket(ticket)
+			if outcome == board.RunError {
+				if !ticket.Muted {
+					m.notifyError("Agent failed: " + ticket.Title + " — " + m.keymap.label("spawn_agent") + " respawns")
+				}
+			} else {
+				m.notifySuccess("Agent finished: " + ticket.Title)
+			}
+			if !ticket.Muted && !alerted {
+				if outcome == board.RunError {
+					m.alert(config.AlertError)
+				} else {
+					m.alert(config.AlertDone)
+				}
+			}
 		}
+		m.followSessionLog(ticketID)
+		delete(m.panes, ticketID)
 		if m.focusedPane == ticketID {
 			m.mode = ModeNormal
 			m.focusedPane = ""
-			m.notify("Agent exited")
+			if ticket == nil {
+				m.notify("Agent exited")
+			}
+		}
+		if advance {
+			cmd, _ := m.advancePipeline(ticket)
+			return m, cmd
 		}
 		return m, nil
 
@@ -447,26 +770,79 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case agentStatusMsg:
+		m.refreshPreview()
 		return m, tea.Batch(
-			m.pollAgentStatusesAsync(),
+			m.pollAgentStatusesIfActive(),
+			m.scanCommitsIfDue(),
 			tickAgentStatus(m.agentMgr.StatusPollInterval()),
+			m.checkRenderBudget(),
 		)
 
 	case agentStatusResultMsg:
-		for ticketID, status := range msg {
+		cmds := []tea.Cmd{m.spinnerTick()}
+		for ticketID, result := range msg {
 			if ticket, _ := m.globalStore.Get(ticketID); ticket != nil {
-				ticket.AgentStatus = status
+				previous := ticket.AgentStatus
+				ticket.AgentStatus = result.status
+				m.notifyAgentStatus(ticket, previous)
+				if result.status == board.AgentCompleted && previous != board.AgentCompleted {
+					if !ticket.Muted {
+						m.alert(config.AlertDone)
+					}
+					m.queueHook(ticket, config.HookAgentCompleted, hooks.Event{Agent: ticket.AgentType})
+					if cmd, staged := m.advancePipeline(ticket); staged {
+						cmds = append(cmds, cmd)
+					} else {
+						cmds = append(cmds, m.moveOnAgentDone(ticket))
+					}
+				}
+			}
+			if result.message != "" {
+				m.agentMessages[ticketID] = result.message
+			} else {
+				delete(m.agentMessages, ticketID)
 			}
 		}
+		return m, tea.Batch(cmds...)
+
+	case opencodeSessionMsg:
+		return m.handleOpencodeSession(msg)
+
+	case gateResultMsg:
+		return m.handleGateResult(msg)
+
+	case gitSummaryMsg:
+		m.gitSummaries[msg.ticketID] = msg.summary
+		return m, nil
+
+	case commitsScannedMsg:
+		m.linkCommits(msg)
+		return m, nil
+
+	case hookDoneMsg:
+		m.handleHookDone(msg)
+		return m, nil
+
+	case flashDoneMsg:
+		return m, m.handleFlashDone()
 
 	case spinner.TickMsg:
-		var cmd tea.Cmd
-		m.spinner, cmd = m.spinner.Update(msg)
-		return m, cmd
+		return m, m.updateSpinner(msg)
+
+	case moveAnimMsg:
+		return m, m.handleMoveAnim(msg)
 
 	case notificationMsg:
-		if time.Since(m.notifyTime) > 3*time.Second {
-			m.notification = ""
+		m.toasts = m.liveToasts(time.Time(msg))
+		return m, nil
+
+	case editorDoneMsg:
+		m.applyEditorEdit(msg)
+		return m, nil
+
+	case execDoneMsg:
+		if msg.err != nil {
+			m.notifyError(fmt.Sprintf("%s failed: %v", msg.what, msg.err))
 		}
 		return m, nil
 
@@ -482,37 +858,33 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
+	if m.showHelp {
+		return m.handleHelpKey(msg)
+	}
+
+	key := msg.String()
+	if m.mode == ModeNormal {
+		key = m.keymap.resolve(key)
+	}
+	switch key {
 	case "ctrl+c", "q":
 		if m.mode == ModeNormal {
 			return m.handleQuit()
 		}
 	case "esc":
-		if m.mode == ModeAgentView {
-			break
-		}
-		if m.mode == ModeNormal && (m.filterQuery != "" || len(m.filterProjectIDs) > 0) {
-			m.clearFilter()
-			m.notify("Filter cleared")
-			return m, nil
+		// Esc closes one layer at a time, the innermost first. Modes close
+		// their own inputs and themselves; on the board it unfocuses the
+		// sidebar, then clears the filter.
+		if m.mode == ModeNormal && !m.showConfirm {
+			return m.escapeBoard()
 		}
-		m.mode = ModeNormal
-		m.showHelp = false
-		m.showConfirm = false
-		m.titleInput.Blur()
-		return m, nil
 	case "?":
 		if m.mode == ModeNormal || m.mode == ModeHelp {
-			m.showHelp = !m.showHelp
+			m.openHelp()
 			return m, nil
 		}
 	}
 
-	if m.showHelp {
-		m.showHelp = false
-		return m, nil
-	}
-
 	if m.showConfirm {
 		return m.handleConfirm(msg)
 	}
@@ -534,15 +906,48 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleFilterMode(msg)
 	case ModeCreateProject:
 		return m.handleCreateProjectMode(msg)
+	case ModeTicketDetail:
+		return m.handleTicketDetailMode(msg)
+	case ModeOutcome:
+		return m.handleOutcomeMode(msg)
+	case ModeCustomFields:
+		return m.handleCustomFieldsMode(msg)
+	case ModeLogSearch:
+		return m.handleLogSearchMode(msg)
+	case ModeArchive:
+		return m.handleArchiveMode(msg)
+	case ModeParentPicker:
+		return m.handleParentPickerMode(msg)
+	case ModeBoardEditor:
+		return m.handleBoardEditorMode(msg)
+	case ModeVisual:
+		return m.handleVisualMode(msg)
+	case ModeMovePicker:
+		return m.handleMovePickerMode(msg)
+	case ModeAgentPicker:
+		return m.handleAgentPickerMode(msg)
+	case ModeActions:
+		return m.handleActionsMode(msg)
+	case ModeHygiene:
+		return m.handleHygieneMode(msg)
+	case ModeStats:
+		return m.handleStatsMode(msg)
+	case ModeLink:
+		return m.handleLinkMode(msg)
+	case ModeSessionLog:
+		return m.handleSessionLogMode(msg)
+	case ModeAttempts:
+		return m.handleAttemptsMode(msg)
 	}
 
 	return m, nil
 }
 
 func (m *Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
+	key := m.keymap.resolve(msg.String())
+	switch key {
 	case "tab":
-		if m.sidebarVisible {
+		if m.showSidebar() {
 			m.sidebarFocused = !m.sidebarFocused
 			return m, nil
 		}
@@ -552,15 +957,21 @@ func (m *Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.sidebarFocused = false
 		}
 		return m, nil
+	case "]":
+		m.toggleSplitView()
+		return m, nil
+	case "Z":
+		m.toggleFocusMode()
+		return m, nil
 	}
 
 	if m.sidebarFocused {
 		return m.handleSidebarNav(msg)
 	}
 
-	switch msg.String() {
+	switch key {
 	case "h", "left":
-		if m.activeColumn == 0 && m.sidebarVisible {
+		if m.activeColumn == 0 && m.showSidebar() {
 			m.sidebarFocused = true
 			return m, nil
 		}
@@ -580,30 +991,99 @@ func (m *Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.ensureTicketVisible()
 
+	case "v":
+		return m.enterVisualMode()
+
 	case "n":
 		return m.createNewTicket()
+	case "N":
+		return m.createBacklogTicket()
 	case "e":
 		return m.editTicket()
 	case "enter":
 		return m.attachToAgent()
+	case "i":
+		return m.openTicketDetail()
 	case "d":
 		return m.confirmDeleteTicket()
 	case " ":
 		return m.quickMoveTicket()
-	case "-", "backspace":
+	case "backspace":
 		return m.quickMoveTicketBackward()
+	case "-":
+		m.toggleCompactCards()
+		return m, nil
+	case "m":
+		return m.openMovePicker()
 	case "s":
 		return m.spawnAgent()
 	case "S":
 		return m.stopAgent()
+	case "R":
+		return m.confirmRetryTicket()
+	case "b":
+		return m.openAttempts()
+	case "a":
+		return m.archiveTicket()
+	case "A":
+		return m.openArchive()
+
+	case "p":
+		return m.openParentPicker()
+
+	case "P":
+		m.togglePreview()
+		return m, nil
+
+	case "M":
+		return m.toggleMute()
+
+	case ".":
+		return m.openActions()
+
+	case "E":
+		return m.editInEditor()
+
+	case "!":
+		return m, m.setAutomationPaused(!m.automationPaused)
+
+	case "z":
+		return m.toggleEpic()
+	case "c":
+		return m.collapseLabelGroup()
+	case "C":
+		return m.foldLabelGroups()
+
+	case "o":
+		return m.cycleColumnSort()
+	case "<":
+		return m.resizeColumn(-1)
+	case ">":
+		return m.resizeColumn(1)
+	case "{":
+		m.shiftColumn(-1)
+		return m, nil
+	case "}":
+		m.shiftColumn(1)
+		return m, nil
+	case "X":
+		m.hideColumn(m.activeColumn)
+		return m, nil
+	case "J":
+		return m.nudgeTicket(1)
+	case "K":
+		return m.nudgeTicket(-1)
 
 	case ":":
+		m.commandInput.Reset()
+		m.commandInput.Focus()
+		m.commandHistoryIndex = len(m.commandHistory)
+		m.completions = nil
 		m.mode = ModeCommand
+		return m, textinput.Blink
 
 	case "/":
-		m.filterInput.SetValue(m.filterQuery)
-		m.filterInput.Focus()
-		m.mode = ModeFilter
+		return m.openFilter(ModeNormal)
 
 	case "O":
 		m.mode = ModeSettings
@@ -618,7 +1098,7 @@ func (m *Model) openAddProjectForm() (tea.Model, tea.Cmd) {
 	m.addProjectPath.SetValue("")
 	m.addProjectPath.Focus()
 	m.mode = ModeCreateProject
-	m.notification = ""
+	m.clearToasts()
 	return m, textinput.Blink
 }
 
@@ -703,16 +1183,44 @@ func (m *Model) handleSidebarNav(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	switch msg.Action {
 	case tea.MouseActionPress:
+		// Wheel events arrive as presses.
+		if msg.Button == tea.MouseButtonWheelUp || msg.Button == tea.MouseButtonWheelDown {
+			if m.showSidebar() && msg.X < m.sidebarWidth {
+				return m, nil
+			}
+			delta := 1
+			if msg.Button == tea.MouseButtonWheelUp {
+				delta = -1
+			}
+			col, _ := m.hitTest(msg.X, msg.Y)
+			m.scrollColumn(col, delta)
+			return m, nil
+		}
 		if msg.Button == tea.MouseButtonLeft {
-			if m.hitTestHeader(msg.X, msg.Y) {
+			if msg.Y < m.headerHeight() {
+				if key, ok := regionAt(m.headerRegions, msg.X); ok {
+					return m.handleKey(key)
+				}
 				return m, nil
 			}
-			if m.sidebarVisible && msg.X < m.sidebarWidth {
+			if m.showSidebar() && msg.X < m.sidebarWidth {
 				return m.handleSidebarMouse(msg)
 			}
 			col, ticket := m.hitTest(msg.X, msg.Y)
+			if col >= 0 && ticket < 0 && m.onColumnHeader(msg.Y) {
+				// A column header jumps to the top of that column.
+				m.sidebarFocused = false
+				m.activeColumn = col
+				m.activeTicket = 0
+				m.ensureColumnVisible()
+				m.ensureTicketVisible()
+				return m, nil
+			}
 			if col >= 0 {
 				m.sidebarFocused = false
+				if col != m.activeColumn && ticket < 0 && col < len(m.columnOffsets) {
+					m.activeTicket = m.columnOffsets[col]
+				}
 				m.activeColumn = col
 				if ticket >= 0 {
 					now := time.Now()
@@ -743,12 +1251,14 @@ func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 
 	case tea.MouseActionMotion:
 		if m.dragging && msg.Button == tea.MouseButtonLeft {
+			// Dragging off the columns cancels the drop.
 			col, _ := m.hitTest(msg.X, msg.Y)
-			if col >= 0 {
-				m.dragTargetColumn = col
+			if col < 0 {
+				col = m.dragSourceColumn
 			}
+			m.dragTargetColumn = col
 		} else {
-			if m.sidebarVisible && msg.X < m.sidebarWidth {
+			if m.showSidebar() && msg.X < m.sidebarWidth {
 				m.hoverColumn = -1
 				m.hoverTicket = -1
 			} else {
@@ -770,80 +1280,45 @@ func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		m.hoverColumn = col
 		m.hoverTicket = ticket
 
-	default:
-		switch msg.Button {
-		case tea.MouseButtonWheelUp:
-			m.moveTicket(-1)
-		case tea.MouseButtonWheelDown:
-			m.moveTicket(1)
-		}
 	}
 
 	return m, nil
 }
 
-func (m *Model) hitTestHeader(x, y int) bool {
-	if y > 2 {
-		return false
-	}
-
-	if m.filterQuery != "" || len(m.filterProjectIDs) > 0 {
-		clearStart := 20 + len(m.filterQuery) + 15
-		if x >= clearStart && x <= clearStart+10 {
-			m.clearFilter()
-			return true
-		}
-	}
-
-	if x >= 15 && x <= 30 {
-		m.filterInput.SetValue(m.filterQuery)
-		m.filterInput.Focus()
-		m.mode = ModeFilter
-		return true
-	}
-
-	return false
-}
-
 func (m *Model) hitTest(x, y int) (column, ticket int) {
 	if m.width == 0 || len(m.columns) == 0 {
 		return -1, -1
 	}
 
-	if m.sidebarVisible {
+	if m.showSidebar() {
 		x = x - m.sidebarWidth - 1
 	}
 
-	headerHeight := 2
+	headerHeight := m.headerHeight()
 	if y < headerHeight {
 		return -1, -1
 	}
 
-	columnWidth := m.calcColumnWidth()
-	visibleCols := m.visibleColumnCount(columnWidth)
-	numVisible := visibleCols
-	if m.scrollOffset+visibleCols > len(m.columns) {
-		numVisible = len(m.columns) - m.scrollOffset
+	if m.showSwitcher() {
+		if y == headerHeight {
+			return m.hitTestSwitcher(x), -1
+		}
+		headerHeight += m.switcherHeight()
 	}
 
-	baseWidth, remainder := m.distributeWidth(numVisible)
+	layout := m.boardLayout()
 
-	hasLeftIndicator := m.scrollOffset > 0
 	startX := 0
-	if hasLeftIndicator {
+	if layout.hiddenLeft > 0 && m.layoutMode() != layoutNarrow {
 		startX = 2
 	}
 
-	for i := 0; i < numVisible; i++ {
-		colWidth := baseWidth + 3
-		if i < remainder {
-			colWidth++
-		}
+	for _, slot := range layout.slots {
+		colWidth := slot.width + 3
 
 		if x >= startX && x < startX+colWidth {
-			actualCol := m.scrollOffset + i
-			ticketIdx := m.hitTestTicket(y-headerHeight, actualCol)
-			return actualCol, ticketIdx
+			ticketIdx := m.hitTestTicket(y-headerHeight, slot.column)
+			return slot.column, ticketIdx
 		}
 		startX += colWidth
 	}
@@ -851,6 +1326,13 @@ func (m *Model) hitTest(x, y int) (column, ticket int) {
 	return -1, -1
 }
 
+// onColumnHeader reports whether screen row y is on the column headers,
+// above the tickets.
+func (m *Model) onColumnHeader(y int) bool {
+	top := m.headerHeight() + m.switcherHeight()
+	return y >= top && y < top+columnHeaderHeight
+}
+
 func (m *Model) hitTestTicket(relativeY, column int) int {
 	if column < 0 || column >= len(m.columnTickets) {
 		return -1
@@ -871,69 +1353,162 @@ func (m *Model) hitTestTicket(relativeY, column int) int {
 		offset = m.columnOffsets[column]
 	}
 
-	ticketIdx := offset + (ticketY / ticketHeight)
-	if ticketIdx >= len(tickets) {
-		return -1
+	heights, measured := m.cardHeights[m.columns[column].Status]
+	if !measured {
+		// Not rendered yet; assume the nominal card height.
+		ticketIdx := offset + (ticketY / ticketHeight)
+		if ticketIdx >= len(tickets) {
+			return -1
+		}
+		return ticketIdx
 	}
 
-	return ticketIdx
+	for i, h := range heights {
+		if ticketY < h {
+			return offset + i
+		}
+		ticketY -= h
+	}
+	return -1
 }
 
 func (m *Model) dropTicket() (tea.Model, tea.Cmd) {
-	if len(m.columnTickets) <= m.dragSourceColumn {
-		m.dragging = false
-		return m, nil
-	}
-
-	tickets := m.columnTickets[m.dragSourceColumn]
-	if len(tickets) <= m.dragSourceTicket {
-		m.dragging = false
+	ticket := m.draggedTicket()
+	target := m.dragTargetColumn
+	m.dragging = false
+	m.dragTargetColumn = 0
+	if ticket == nil || target < 0 || target >= len(m.columns) {
 		return m, nil
 	}
 
-	ticket := tickets[m.dragSourceTicket]
-	targetStatus := m.columns[m.dragTargetColumn].Status
-
-	if targetStatus == board.StatusInProgress && ticket.WorktreePath == "" {
-		if ticket.UseWorktree {
-			if err := m.setupWorktree(ticket); err != nil {
-				m.notify("Worktree failed: " + err.Error())
-				m.dragging = false
-				return m, nil
-			}
-		} else {
-			if err := m.setupMainRepoBranch(ticket); err != nil {
-				m.notify("Branch setup failed: " + err.Error())
-				m.dragging = false
-				return m, nil
-			}
-		}
-	}
-
-	m.globalStore.Move(ticket.ID, targetStatus)
-	m.refreshColumnTickets()
-	m.saveTicket(ticket)
-
-	m.activeColumn = m.dragTargetColumn
-	m.activeTicket = 0
+	model, cmd := m.moveTicketTo(ticket, m.columns[target].Status)
 	m.ensureColumnVisible()
-	m.ensureTicketVisible()
-
-	m.notify("Moved to " + string(targetStatus))
-	m.dragging = false
-	m.dragTargetColumn = 0
+	return model, cmd
+}
 
-	return m, nil
+// draggedTicket is the ticket picked up by the current drag, if any.
+func (m *Model) draggedTicket() *board.Ticket {
+	if !m.dragging || m.dragSourceColumn < 0 || m.dragSourceColumn >= len(m.columnTickets) {
+		return nil
+	}
+	tickets := m.columnTickets[m.dragSourceColumn]
+	if m.dragSourceTicket < 0 || m.dragSourceTicket >= len(tickets) {
+		return nil
+	}
+	return tickets[m.dragSourceTicket]
 }
 
 func (m *Model) handleCommandMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
+		line := strings.TrimSpace(m.commandInput.Value())
+		m.commandInput.Blur()
 		m.mode = ModeNormal
+		m.rememberCommand(line)
+		return m.runCommand(line)
 	case "esc":
+		m.commandInput.Blur()
 		m.mode = ModeNormal
+		return m, nil
+	case "up":
+		m.browseHistory(-1)
+		return m, nil
+	case "down":
+		m.browseHistory(1)
+		return m, nil
+	case "tab":
+		m.completeCommand(1)
+		return m, nil
+	case "shift+tab":
+		m.completeCommand(-1)
+		return m, nil
+	}
+	m.completions = nil
+	var cmd tea.Cmd
+	m.commandInput, cmd = m.commandInput.Update(msg)
+	return m, cmd
+}
+
+// runCommand executes a ":" command line.
+func (m *Model) runCommand(line string) (tea.Model, tea.Cmd) {
+	name, args, _ := strings.Cut(line, " ")
+	if slices.Contains(commandNames, name) {
+		telemetry.Count("command." + name)
+	}
+	switch name {
+	case "":
+		return m, nil
+	case "grep":
+		return m.openLogSearch(args)
+	case "archive":
+		return m.openArchive()
+	case "archive-done":
+		return m.archiveDone()
+	case "sprint":
+		return m.assignSprint(strings.TrimSpace(args))
+	case "sprint-new":
+		return m.createSprint(strings.TrimSpace(args))
+	case "board":
+		return m.openBoardEditor()
+	case "title":
+		return m.setBoardTitle(strings.TrimSpace(args))
+	case "rename":
+		return m.renameColumn(m.activeColumn, strings.TrimSpace(args))
+	case "column-add":
+		return m.addColumn(strings.TrimSpace(args))
+	case "column-delete":
+		return m.deleteColumn(m.activeColumn)
+	case "hide":
+		return m.hideCommand(strings.TrimSpace(args))
+	case "show":
+		return m.showColumns(strings.TrimSpace(args))
+	case "adopt":
+		return m.adoptBranch(strings.TrimSpace(args))
+	case "hygiene":
+		return m.openHygiene()
+	case "stats":
+		return m.openStats()
+	case "link":
+		return m.openTicketLink()
+	case "log":
+		return m.openSessionLog()
+	case "move":
+		return m.moveCommand(strings.TrimSpace(args))
+	case "label":
+		return m.labelCommand(args)
+	case "group":
+		return m.groupCommand(strings.TrimSpace(args))
+	case "pipeline":
+		return m.pipelineCommand(strings.TrimSpace(args))
+	case "agent":
+		return m.agentCommand(args)
+	case "theme":
+		return m.themeCommand(strings.TrimSpace(args))
+	case "pr", "issue":
+		return m.linkCommand(name, strings.TrimSpace(args))
+	case "open":
+		return m.openCommand(strings.TrimSpace(args))
+	case "pause":
+		return m, m.setAutomationPaused(true)
+	case "resume":
+		return m, m.setAutomationPaused(false)
+	case "w":
+		m.writeTickets()
+		return m, nil
+	case "w!":
+		m.forceWriteTickets()
+		return m, nil
+	case "q":
+		return m.handleQuit()
+	case "wq":
+		if !m.writeTickets() {
+			return m, nil
+		}
+		return m.handleQuit()
+	default:
+		m.notifyError("Unknown command: " + name)
+		return m, nil
 	}
-	return m, nil
 }
 
 func (m *Model) handleConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -955,9 +1530,9 @@ func (m *Model) handleQuit() (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	}
 
-	if !m.config.Behavior.ConfirmQuitWithAgents {
+	if !m.confirming(m.config.Behavior.ConfirmQuitWithAgents) {
 		m.mode = ModeShuttingDown
-		return m, tea.Batch(m.spinner.Tick, m.cleanupAsync())
+		return m, tea.Batch(m.spinnerTick(), m.cleanupAsync())
 	}
 
 	m.showConfirm = true
@@ -965,7 +1540,7 @@ func (m *Model) handleQuit() (tea.Model, tea.Cmd) {
 	m.confirmFn = func() tea.Cmd {
 		m.mode = ModeShuttingDown
 		m.showConfirm = false
-		return tea.Batch(m.spinner.Tick, m.cleanupAsync())
+		return tea.Batch(m.spinnerTick(), m.cleanupAsync())
 	}
 	return m, nil
 }
@@ -1047,8 +1622,10 @@ func (m *Model) handleTicketFormMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	case relY >= 15 && relY <= 17:
 		clickedField = formFieldLabels
 	case relY >= 19 && relY <= 21:
+		clickedField = formFieldAssignee
+	case relY >= 23 && relY <= 25:
 		clickedField = formFieldPriority
-	case relY >= 23:
+	case relY >= 27:
 		clickedField = formFieldProject
 	}
 
@@ -1059,7 +1636,7 @@ func (m *Model) handleTicketFormMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 
 		if clickedField == formFieldProject && !m.showAddProjectForm {
 			projects := m.globalStore.Projects()
-			projectRelY := relY - 24
+			projectRelY := relY - 28
 			if projectRelY >= 0 && projectRelY <= len(projects) {
 				m.projectListIndex = projectRelY
 				if projectRelY == len(projects) {
@@ -1087,6 +1664,8 @@ func (m *Model) handleTicketFormMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		}
 	case formFieldLabels:
 		m.labelsInput, cmd = m.labelsInput.Update(msg)
+	case formFieldAssignee:
+		m.assigneeInput, cmd = m.assigneeInput.Update(msg)
 	}
 
 	return m, cmd
@@ -1101,6 +1680,12 @@ func (m *Model) handleEditTicketMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleTicketForm(msg tea.KeyMsg, isEdit bool) (tea.Model, tea.Cmd) {
+	if msg.Paste && !isEdit && m.ticketFormField == formFieldTitle {
+		if titles := pastedTitles(string(msg.Runes)); len(titles) > 1 {
+			return m.offerPastedTickets(titles)
+		}
+	}
+
 	switch msg.String() {
 	case "ctrl+c":
 		m.mode = ModeNormal
@@ -1162,8 +1747,12 @@ func (m *Model) handleTicketForm(msg tea.KeyMsg, isEdit bool) (tea.Model, tea.Cm
 		}
 	case formFieldLabels:
 		m.labelsInput, cmd = m.labelsInput.Update(msg)
+	case formFieldAssignee:
+		m.assigneeInput, cmd = m.assigneeInput.Update(msg)
 	case formFieldPriority:
 		cmd = m.handlePriorityNav(msg)
+	case formFieldStatus:
+		cmd = m.handleStatusNav(msg)
 	case formFieldWorktree:
 		cmd = m.handleWorktreeToggle(msg)
 	case formFieldAgent:
@@ -1200,6 +1789,28 @@ func (m *Model) handlePriorityNav(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
+// handleStatusNav cycles the column a new ticket is filed into.
+func (m *Model) handleStatusNav(msg tea.KeyMsg) tea.Cmd {
+	if len(m.columns) == 0 {
+		return nil
+	}
+	idx := 0
+	for i, col := range m.columns {
+		if col.Status == m.ticketStatus {
+			idx = i
+		}
+	}
+
+	switch msg.String() {
+	case "j", "down", "l", "right", " ":
+		idx = (idx + 1) % len(m.columns)
+	case "k", "up", "h", "left":
+		idx = (idx - 1 + len(m.columns)) % len(m.columns)
+	}
+	m.ticketStatus = m.columns[idx].Status
+	return nil
+}
+
 func (m *Model) handleWorktreeToggle(msg tea.KeyMsg) tea.Cmd {
 	switch msg.String() {
 	case " ", "enter", "h", "l", "left", "right":
@@ -1329,7 +1940,7 @@ func (m *Model) initBlockerCandidates(excludeTicketID board.TicketID) {
 		if ticket.ID == excludeTicketID {
 			continue
 		}
-		if ticket.Status == board.StatusArchived {
+		if ticket.Status == board.StatusArchived || ticket.Archived {
 			continue
 		}
 		m.blockerCandidates = append(m.blockerCandidates, ticket)
@@ -1367,7 +1978,7 @@ func (m *Model) confirmDeleteProject(p *project.Project) {
 	m.showConfirm = true
 	m.confirmFn = func() tea.Cmd {
 		if err := m.projectRegistry.Delete(p.ID); err != nil {
-			m.notify("Failed to delete: " + err.Error())
+			m.notifyError("Failed to delete: " + err.Error())
 			return nil
 		}
 
@@ -1386,7 +1997,7 @@ func (m *Model) confirmDeleteProject(p *project.Project) {
 
 		delete(m.filterProjectIDs, p.ID)
 
-		m.notify("Deleted: " + p.Name)
+		m.notifySuccess("Deleted: " + p.Name)
 		return nil
 	}
 }
@@ -1412,7 +2023,7 @@ func (m *Model) handleProjectSelection() (tea.Model, tea.Cmd) {
 func (m *Model) createProjectFromPath() (tea.Model, tea.Cmd) {
 	path := strings.TrimSpace(m.addProjectPath.Value())
 	if path == "" {
-		m.notify("Path cannot be empty")
+		m.notifyError("Path cannot be empty")
 		return m, nil
 	}
 
@@ -1425,13 +2036,13 @@ func (m *Model) createProjectFromPath() (tea.Model, tea.Cmd) {
 
 	absPath, err := filepath.Abs(path)
 	if err != nil {
-		m.notify("Invalid path: " + err.Error())
+		m.notifyError("Invalid path: " + err.Error())
 		return m, nil
 	}
 
 	gitDir := filepath.Join(absPath, ".git")
 	if _, err := os.Stat(gitDir); err != nil {
-		m.notify("Not a git repository")
+		m.notifyError("Not a git repository")
 		return m, nil
 	}
 
@@ -1442,7 +2053,7 @@ func (m *Model) createProjectFromPath() (tea.Model, tea.Cmd) {
 	// Empty values cascade to global config via getDefaultAgent() and GetBranchPrefix().
 
 	if err := m.projectRegistry.Add(newProject); err != nil {
-		m.notify("Failed to save: " + err.Error())
+		m.notifyError("Failed to save: " + err.Error())
 		return m, nil
 	}
 
@@ -1457,7 +2068,7 @@ func (m *Model) createProjectFromPath() (tea.Model, tea.Cmd) {
 		m.mode = ModeNormal
 	}
 
-	m.notify("Added project: " + name)
+	m.notifySuccess("Added project: " + name)
 	return m, nil
 }
 
@@ -1482,6 +2093,10 @@ func (m *Model) nextFormField(isEdit bool) *Model {
 			m.ticketFormField++
 			continue
 		}
+		if m.ticketFormField == formFieldStatus && isEdit {
+			m.ticketFormField++
+			continue
+		}
 		break
 	}
 	m.focusCurrentField()
@@ -1509,6 +2124,10 @@ func (m *Model) prevFormField(isEdit bool) *Model {
 			m.ticketFormField--
 			continue
 		}
+		if m.ticketFormField == formFieldStatus && isEdit {
+			m.ticketFormField--
+			continue
+		}
 		break
 	}
 	m.focusCurrentField()
@@ -1520,6 +2139,7 @@ func (m *Model) blurAllFormFields() {
 	m.descInput.Blur()
 	m.branchInput.Blur()
 	m.labelsInput.Blur()
+	m.assigneeInput.Blur()
 	m.blockerFilterInput.Blur()
 	m.projectInput.Blur()
 }
@@ -1534,6 +2154,8 @@ func (m *Model) focusCurrentField() {
 		m.branchInput.Focus()
 	case formFieldLabels:
 		m.labelsInput.Focus()
+	case formFieldAssignee:
+		m.assigneeInput.Focus()
 	case formFieldPriority:
 		break
 	case formFieldWorktree:
@@ -1548,7 +2170,7 @@ func (m *Model) focusCurrentField() {
 func (m *Model) saveTicketForm(isEdit bool) (tea.Model, tea.Cmd) {
 	title := strings.TrimSpace(m.titleInput.Value())
 	if title == "" {
-		m.notify("Title cannot be empty")
+		m.notifyError("Title cannot be empty")
 		return m, nil
 	}
 
@@ -1564,44 +2186,45 @@ func (m *Model) saveTicketForm(isEdit bool) (tea.Model, tea.Cmd) {
 	}
 
 	labels := m.parseLabels(m.labelsInput.Value())
+	assignee := strings.TrimSpace(m.assigneeInput.Value())
 
 	blockedBy := m.collectSelectedBlockers()
 
 	if isEdit && m.editingTicketID != "" {
 		ticket, _ := m.globalStore.Get(m.editingTicketID)
 		if ticket != nil {
+			before := *ticket
 			ticket.Title = title
 			ticket.Description = desc
 			if !m.branchLocked {
 				ticket.BranchName = branchName
 			}
 			ticket.Labels = labels
+			ticket.Assignee = assignee
 			ticket.Priority = m.ticketPriority
 			ticket.UseWorktree = m.ticketUseWorktree
 			if !m.agentLocked {
 				ticket.AgentType = m.ticketAgent
 			}
 			ticket.BlockedBy = blockedBy
+			if changed := board.ChangedFields(&before, ticket); len(changed) > 0 {
+				ticket.Record(board.EventEdited, strings.Join(changed, ", "))
+			}
 			ticket.Touch()
 			m.saveTicket(ticket)
 			m.refreshColumnTickets()
-			m.notify("Updated: " + title)
+			m.notifySuccess("Updated: " + title)
 		}
 	} else {
-		ticket := board.NewTicket(title, m.selectedProject.ID)
+		ticket := m.newTicketFromForm(title)
 		ticket.Description = desc
 		ticket.BranchName = branchName
-		ticket.Labels = labels
-		ticket.Priority = m.ticketPriority
-		ticket.UseWorktree = m.ticketUseWorktree
-		ticket.AgentType = m.ticketAgent
-		ticket.BlockedBy = blockedBy
-		ticket.Status = m.columns[m.activeColumn].Status
 		m.globalStore.Add(ticket)
 		m.refreshColumnTickets()
 		m.selectTicketByID(ticket.ID)
+		m.ensureColumnVisible()
 		m.saveTicket(ticket)
-		m.notify("Created: " + title)
+		m.notifySuccess("Created: " + title)
 	}
 
 	m.mode = ModeNormal
@@ -1611,6 +2234,20 @@ func (m *Model) saveTicketForm(isEdit bool) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// newTicketFromForm builds a ticket from the create form's fields other than
+// the description and branch.
+func (m *Model) newTicketFromForm(title string) *board.Ticket {
+	ticket := board.NewTicket(title, m.selectedProject.ID)
+	ticket.Labels = m.parseLabels(m.labelsInput.Value())
+	ticket.Assignee = strings.TrimSpace(m.assigneeInput.Value())
+	ticket.Priority = m.ticketPriority
+	ticket.UseWorktree = m.ticketUseWorktree
+	ticket.AgentType = m.ticketAgent
+	ticket.BlockedBy = m.collectSelectedBlockers()
+	ticket.Status = m.ticketStatus
+	return ticket
+}
+
 func (m *Model) parseLabels(input string) []string {
 	if strings.TrimSpace(input) == "" {
 		return []string{}
@@ -1637,11 +2274,26 @@ var settingsFields = []settingsField{
 	{"theme", "Theme", "theme", "Color theme for the UI"},
 	{"default_agent", "Default Agent", "agent", "Agent to spawn for new tickets (claude, codex, rovodev, opencode, gemini, aider)"},
 	{"confirm_quit", "Confirm Quit", "toggle", "Prompt before quitting with running agents"},
+	{"confirm_delete", "Confirm Delete", "toggle", "Prompt before deleting tickets"},
+	{"confirm_stop", "Confirm Stop", "toggle", "Prompt before stopping a running agent"},
+	{"confirm_worktree", "Confirm Worktree", "toggle", "Prompt before removing a worktree: retry, adopt, keep attempt, or delete with uncommitted changes"},
+	{"confirm_never", "Never Confirm", "toggle", "Skip every confirmation prompt"},
+	{"capture_artifacts", "Capture Artifacts", "toggle", "Archive prompt, transcript, and diff when an agent run ends"},
+	{"session_logs", "Session Logs", "toggle", "Log each agent run's full output for review with :log"},
+	{"record_sessions", "Record Sessions", "toggle", "Record agent runs for playback with openkanban replay"},
+	{"ticket_file", "Ticket File", "toggle", "Write TICKET.md with the ticket's context into its worktree"},
+	{"respawn_lost", "Respawn Lost Agents", "toggle", "On startup, respawn agents that were running when openkanban last crashed or the machine rebooted"},
 	{"branch_prefix", "Branch Prefix", "text", "Prefix for auto-generated branch names (e.g. task/, feature/)"},
 	{"delete_worktree", "Delete Worktree", "toggle", "Remove git worktree when deleting tickets"},
 	{"delete_branch", "Delete Branch", "toggle", "Delete git branch when deleting tickets"},
 	{"force_cleanup", "Force Cleanup", "toggle", "Force worktree removal even with uncommitted changes"},
 	{"sidebar_visible", "Show Sidebar", "toggle", "Toggle the project sidebar visibility"},
+	{"split_view", "Split View", "toggle", "Show the selected ticket's detail beside the board"},
+	{"compact_cards", "Compact Cards", "toggle", "Show each ticket on one line instead of a bordered card"},
+	{"reduce_motion", "Reduce Motion", "toggle", "Disable card animations and the spinner to save CPU"},
+	{"ascii", "ASCII Only", "toggle", "Draw with plain ASCII for fonts that lack box drawing and symbols"},
+	{"card_aging", "Card Aging", "toggle", "Tint the borders of cards that haven't been updated in a while"},
+	{"alerts", "Alerts", "alerts", "Ring the bell or flash the header for: never, errors, waiting agents and errors, or all agent events"},
 	{"filter_project", "Filter Project", "project", "Show only tickets from a specific project"},
 }
 
@@ -1671,10 +2323,7 @@ func (m *Model) handleSettingsEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	field := settingsFields[m.settingsIndex]
 
 	if field.kind == "project" {
-		m.filterInput.SetValue(m.filterQuery)
-		m.filterInput.Focus()
-		m.mode = ModeFilter
-		return m, textinput.Blink
+		return m.openFilter(ModeSettings)
 	}
 
 	if field.kind == "theme" {
@@ -1686,7 +2335,7 @@ func (m *Model) handleSettingsEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.applySettingsValue(field.key, m.settingsInput.Value())
 		m.settingsEditing = false
 		m.settingsInput.Blur()
-		m.notify("Settings saved")
+		m.notifySuccess("Settings saved")
 		return m, nil
 	case "esc":
 		m.settingsEditing = false
@@ -1700,7 +2349,7 @@ func (m *Model) handleSettingsEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleThemeNav(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	themes := config.ThemeNames()
+	themes := themeChoices()
 	if len(themes) == 0 {
 		return m, nil
 	}
@@ -1775,19 +2424,19 @@ func (m *Model) enterSettingsEdit() (tea.Model, tea.Cmd) {
 
 	switch field.kind {
 	case "project":
-		m.filterInput.SetValue(m.filterQuery)
-		m.filterInput.Focus()
-		m.mode = ModeFilter
-		return m, textinput.Blink
+		return m.openFilter(ModeSettings)
 
 	case "toggle":
 		m.applySettingsValue(field.key, "")
 		status := m.getSettingsValue(field.key)
 		m.notify(field.label + ": " + status)
+		if field.key == "reduce_motion" {
+			return m, m.spinnerTick()
+		}
 		return m, nil
 
 	case "theme":
-		themes := config.ThemeNames()
+		themes := themeChoices()
 		current := m.config.UI.Theme
 		m.themeListIndex = 0
 		for i, t := range themes {
@@ -1815,6 +2464,13 @@ func (m *Model) enterSettingsEdit() (tea.Model, tea.Cmd) {
 		m.notify("Default agent: " + nextAgent)
 		return m, nil
 
+	case "alerts":
+		i := slices.Index(config.AlertPolicies, m.config.UI.Alerts.Policy)
+		next := config.AlertPolicies[(i+1)%len(config.AlertPolicies)]
+		m.applySettingsValue(field.key, next)
+		m.notify("Alerts: " + next)
+		return m, nil
+
 	case "text":
 		m.settingsEditing = true
 		m.settingsInput.SetValue(m.getSettingsValue(field.key))
@@ -1840,6 +2496,51 @@ func (m *Model) getSettingsValue(key string) string {
 			return "On"
 		}
 		return "Off"
+	case "confirm_delete":
+		if m.config.Behavior.Confirm.DeleteTicket {
+			return "On"
+		}
+		return "Off"
+	case "confirm_stop":
+		if m.config.Behavior.Confirm.StopAgent {
+			return "On"
+		}
+		return "Off"
+	case "confirm_worktree":
+		if m.config.Behavior.Confirm.RemoveWorktree {
+			return "On"
+		}
+		return "Off"
+	case "confirm_never":
+		if m.config.Behavior.Confirm.Never {
+			return "On"
+		}
+		return "Off"
+	case "capture_artifacts":
+		if m.config.Behavior.CaptureArtifacts {
+			return "On"
+		}
+		return "Off"
+	case "session_logs":
+		if m.config.Behavior.SessionLogs {
+			return "On"
+		}
+		return "Off"
+	case "record_sessions":
+		if m.config.Behavior.RecordSessions {
+			return "On"
+		}
+		return "Off"
+	case "respawn_lost":
+		if m.config.Behavior.RespawnLostAgents {
+			return "On"
+		}
+		return "Off"
+	case "ticket_file":
+		if m.config.Behavior.TicketFile {
+			return "On"
+		}
+		return "Off"
 	case "branch_prefix":
 		return m.config.Defaults.BranchPrefix
 	case "delete_worktree":
@@ -1868,6 +2569,33 @@ func (m *Model) getSettingsValue(key string) string {
 			return "On"
 		}
 		return "Off"
+	case "split_view":
+		if m.splitView {
+			return "On"
+		}
+		return "Off"
+	case "compact_cards":
+		if m.compactCards {
+			return "On"
+		}
+		return "Off"
+	case "reduce_motion":
+		if m.config.UI.ReduceMotion {
+			return "On"
+		}
+		return "Off"
+	case "ascii":
+		if m.config.UI.ASCII {
+			return "On"
+		}
+		return "Off"
+	case "card_aging":
+		if m.config.UI.Aging.Enabled {
+			return "On"
+		}
+		return "Off"
+	case "alerts":
+		return m.config.UI.Alerts.Policy
 	}
 	return ""
 }
@@ -1876,8 +2604,9 @@ func (m *Model) applySettingsValue(key, value string) {
 	switch key {
 	case "theme":
 		m.config.UI.Theme = value
-		m.theme = m.config.GetTheme()
+		m.theme = m.config.GetTheme(m.darkBackground)
 		m.colors = newUIColors(m.theme)
+		m.markdown = nil
 		m.config.Save("")
 	case "default_agent":
 		m.config.Defaults.DefaultAgent = value
@@ -1885,6 +2614,36 @@ func (m *Model) applySettingsValue(key, value string) {
 	case "confirm_quit":
 		m.config.Behavior.ConfirmQuitWithAgents = !m.config.Behavior.ConfirmQuitWithAgents
 		m.config.Save("")
+	case "confirm_delete":
+		m.config.Behavior.Confirm.DeleteTicket = !m.config.Behavior.Confirm.DeleteTicket
+		m.config.Save("")
+	case "confirm_stop":
+		m.config.Behavior.Confirm.StopAgent = !m.config.Behavior.Confirm.StopAgent
+		m.config.Save("")
+	case "confirm_worktree":
+		m.config.Behavior.Confirm.RemoveWorktree = !m.config.Behavior.Confirm.RemoveWorktree
+		m.config.Save("")
+	case "confirm_never":
+		m.config.Behavior.Confirm.Never = !m.config.Behavior.Confirm.Never
+		m.config.Save("")
+	case "capture_artifacts":
+		m.config.Behavior.CaptureArtifacts = !m.config.Behavior.CaptureArtifacts
+		m.config.Save("")
+	case "session_logs":
+		m.config.Behavior.SessionLogs = !m.config.Behavior.SessionLogs
+		m.config.Save("")
+	case "record_sessions":
+		m.config.Behavior.RecordSessions = !m.config.Behavior.RecordSessions
+		m.config.Save("")
+	case "respawn_lost":
+		m.config.Behavior.RespawnLostAgents = !m.config.Behavior.RespawnLostAgents
+		m.config.Save("")
+	case "ticket_file":
+		m.config.Behavior.TicketFile = !m.config.Behavior.TicketFile
+		m.config.Save("")
+		for _, ticket := range m.globalStore.All() {
+			m.syncTicketFile(ticket)
+		}
 	case "branch_prefix":
 		m.config.Defaults.BranchPrefix = value
 		m.config.Save("")
@@ -1904,20 +2663,52 @@ func (m *Model) applySettingsValue(key, value string) {
 			m.sidebarFocused = false
 		}
 		m.config.Save("")
+	case "split_view":
+		m.toggleSplitView()
+		m.config.UI.SplitView = m.splitView
+		m.config.Save("")
+	case "compact_cards":
+		m.toggleCompactCards()
+		m.config.UI.CompactCards = m.compactCards
+		m.config.Save("")
+	case "reduce_motion":
+		m.config.UI.ReduceMotion = !m.config.UI.ReduceMotion
+		m.config.Save("")
+	case "ascii":
+		m.config.UI.ASCII = !m.config.UI.ASCII
+		m.spinner.Spinner = spinnerFor(m.config.UI)
+		m.config.Save("")
+	case "card_aging":
+		m.config.UI.Aging.Enabled = !m.config.UI.Aging.Enabled
+		m.config.Save("")
+	case "alerts":
+		m.config.UI.Alerts.Policy = value
+		m.config.Save("")
 	}
 }
 
+// openFilter edits the filter, returning to parent when done.
+func (m *Model) openFilter(parent Mode) (tea.Model, tea.Cmd) {
+	m.filterBefore = m.filterQuery
+	m.filterParent = parent
+	m.filterInput.SetValue(m.filterQuery)
+	m.filterInput.Focus()
+	m.mode = ModeFilter
+	return m, textinput.Blink
+}
+
 func (m *Model) handleFilterMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
 		m.filterInput.Blur()
-		m.mode = ModeNormal
+		m.mode = m.filterParent
 		return m, nil
 	case "esc":
-		m.filterQuery = ""
-		m.filterInput.SetValue("")
+		// Cancel the edit, keeping the filter it started from.
+		m.filterQuery = m.filterBefore
+		m.filterInput.SetValue(m.filterBefore)
 		m.filterInput.Blur()
-		m.mode = ModeNormal
+		m.mode = m.filterParent
 		m.refreshColumnTickets()
 		return m, nil
 	}
@@ -2005,22 +2796,6 @@ func (m *Model) moveColumn(delta int) {
 	m.ensureTicketVisible()
 }
 
-func (m *Model) ensureColumnVisible() {
-	colWidth := m.calcColumnWidth()
-	visibleCols := m.visibleColumnCount(colWidth)
-
-	if m.activeColumn < m.scrollOffset {
-		m.scrollOffset = m.activeColumn
-	} else if m.activeColumn >= m.scrollOffset+visibleCols {
-		m.scrollOffset = m.activeColumn - visibleCols + 1
-	}
-
-	maxOffset := max(len(m.columns)-visibleCols, 0)
-	if m.scrollOffset > maxOffset {
-		m.scrollOffset = maxOffset
-	}
-}
-
 func (m *Model) headerHeight() int {
 	const (
 		content      = 1
@@ -2083,39 +2858,85 @@ func (m *Model) moveTicket(delta int) {
 	if m.activeTicket >= len(tickets) {
 		m.activeTicket = max(len(tickets)-1, 0)
 	}
-	m.ensureTicketVisible()
+	m.ensureTicketVisible()
+}
+
+// ticketRows is how many rows a column has for cards, between the divider
+// under its header and its bottom border.
+func (m *Model) ticketRows() int {
+	const (
+		statusBar    = 1
+		columnChrome = 4 // borders, header line, and divider
+	)
+	return max(m.height-m.headerHeight()-statusBar-columnChrome-m.switcherHeight()-m.previewHeight(), 1)
+}
+
+// cardHeight is a ticket's card height as last rendered, or the nominal
+// height for a card that has not been drawn yet.
+func (m *Model) cardHeight(t *board.Ticket) int {
+	if h, ok := m.ticketHeights[t.ID]; ok {
+		return h
+	}
+	return ticketHeight
+}
+
+// columnEnd is the end of the run of tickets shown when a column is
+// scrolled to offset.
+func (m *Model) columnEnd(tickets []*board.Ticket, offset int) int {
+	return fitTickets(func(i int) int { return m.cardHeight(tickets[i]) }, len(tickets), offset, m.ticketRows())
+}
+
+// fitTickets returns the end of the run of cards from offset that fits in
+// rows, keeping a row free for the "▼ N more" line when some are left over.
+// At least one card is always shown.
+func fitTickets(height func(int) int, count, offset, rows int) int {
+	used := 0
+	for i := offset; i < count; i++ {
+		need := used + height(i)
+		if i < count-1 {
+			need++
+		}
+		if need > rows && i > offset {
+			return i
+		}
+		used += height(i)
+	}
+	return count
 }
 
-func (m *Model) visibleTicketCount() int {
-	availableHeight := m.columnContentHeight()
-	if availableHeight <= 0 {
-		return 1
+func (m *Model) ensureTicketVisible() {
+	if m.activeColumn < 0 || m.activeColumn >= len(m.columnOffsets) || m.activeColumn >= len(m.columnTickets) {
+		return
 	}
-	count := availableHeight / ticketHeight
-	return max(count, 1)
-}
 
-func (m *Model) columnContentHeight() int {
-	boardHeight := m.height - 4
-	contentHeight := boardHeight - columnHeaderHeight - 4
-	return contentHeight
+	tickets := m.columnTickets[m.activeColumn]
+	offset := max(min(m.columnOffsets[m.activeColumn], m.activeTicket), 0)
+	for offset < m.activeTicket && m.activeTicket >= m.columnEnd(tickets, offset) {
+		offset++
+	}
+	m.columnOffsets[m.activeColumn] = offset
 }
 
-func (m *Model) ensureTicketVisible() {
-	if m.activeColumn < 0 || m.activeColumn >= len(m.columnOffsets) {
+// scrollColumn scrolls a column's tickets under its header without moving
+// the selection, except to keep the active column's selection on screen.
+// Outside any column the wheel moves the selection instead.
+func (m *Model) scrollColumn(column, delta int) {
+	if column < 0 || column >= len(m.columnOffsets) || column >= len(m.columnTickets) {
+		m.moveTicket(delta)
 		return
 	}
 
-	offset := m.columnOffsets[m.activeColumn]
-	visible := m.visibleTicketCount()
-
-	if m.activeTicket < offset {
-		m.columnOffsets[m.activeColumn] = m.activeTicket
-	} else if m.activeTicket >= offset+visible {
-		m.columnOffsets[m.activeColumn] = m.activeTicket - visible + 1
+	tickets := m.columnTickets[column]
+	maxOffset := max(len(tickets)-1, 0)
+	for maxOffset > 0 && m.columnEnd(tickets, maxOffset-1) == len(tickets) {
+		maxOffset--
 	}
+	offset := min(max(m.columnOffsets[column]+delta, 0), maxOffset)
+	m.columnOffsets[column] = offset
 
-	m.columnOffsets[m.activeColumn] = max(m.columnOffsets[m.activeColumn], 0)
+	if column == m.activeColumn && len(tickets) > 0 {
+		m.activeTicket = min(max(m.activeTicket, offset), m.columnEnd(tickets, offset)-1)
+	}
 }
 
 func (m *Model) createNewTicket() (tea.Model, tea.Cmd) {
@@ -2150,11 +2971,19 @@ func (m *Model) createNewTicket() (tea.Model, tea.Cmd) {
 
 	m.ticketAgent = m.getDefaultAgent()
 	m.agentListIndex = m.getAgentIndex(m.ticketAgent)
+	m.ticketStatus = board.StatusBacklog
+	if m.activeColumn < len(m.columns) {
+		m.ticketStatus = m.columns[m.activeColumn].Status
+	}
 
 	m.titleInput.Reset()
 	m.descInput.Reset()
 	m.branchInput.Reset()
 	m.labelsInput.Reset()
+	m.assigneeInput.Reset()
+	if m.selectedProject != nil {
+		m.assigneeInput.SetValue(git.UserName(m.selectedProject.RepoPath))
+	}
 	m.ticketPriority = 3
 	m.ticketUseWorktree = true
 
@@ -2169,6 +2998,14 @@ func (m *Model) createNewTicket() (tea.Model, tea.Cmd) {
 	return m, m.titleInput.Cursor.BlinkCmd()
 }
 
+// createBacklogTicket opens the create form filed into Backlog, whichever
+// column is active.
+func (m *Model) createBacklogTicket() (tea.Model, tea.Cmd) {
+	model, cmd := m.createNewTicket()
+	m.ticketStatus = board.StatusBacklog
+	return model, cmd
+}
+
 func (m *Model) editTicket() (tea.Model, tea.Cmd) {
 	ticket := m.selectedTicket()
 	if ticket == nil {
@@ -2190,6 +3027,7 @@ func (m *Model) editTicket() (tea.Model, tea.Cmd) {
 		m.branchInput.SetValue(m.generateBranchNameFromTitle(ticket.Title, m.selectedProject))
 	}
 	m.labelsInput.SetValue(strings.Join(ticket.Labels, ", "))
+	m.assigneeInput.SetValue(ticket.Assignee)
 	m.ticketPriority = ticket.Priority
 	if m.ticketPriority < 1 || m.ticketPriority > 5 {
 		m.ticketPriority = 3
@@ -2228,6 +3066,10 @@ func (m *Model) attachToAgent() (tea.Model, tea.Cmd) {
 		m.notify("No agent running — press 's' to spawn")
 		return m, nil
 	}
+	if pane.Headless() {
+		// There's no terminal to attach to; its output is in the log.
+		return m.openSessionLog()
+	}
 
 	m.mode = ModeAgentView
 	m.focusedPane = ticket.ID
@@ -2268,22 +3110,15 @@ func (m *Model) confirmDeleteTicket() (tea.Model, tea.Cmd) {
 		}
 	}
 
-	if hasUncommitted && !m.config.Cleanup.ForceWorktreeRemoval {
-		m.showConfirm = true
-		m.confirmMsg = "Worktree has uncommitted changes. Force delete?"
-		m.confirmFn = func() tea.Cmd {
-			m.performTicketCleanup(ticket)
-			return nil
-		}
-	} else {
-		m.showConfirm = true
-		m.confirmMsg = "Delete ticket: " + ticket.Title + "?"
-		m.confirmFn = func() tea.Cmd {
-			m.performTicketCleanup(ticket)
-			return nil
-		}
+	cleanup := func() tea.Cmd {
+		m.performTicketCleanup(ticket)
+		return nil
 	}
-	return m, nil
+	confirm := m.config.Behavior.Confirm
+	if hasUncommitted && !m.config.Cleanup.ForceWorktreeRemoval && m.confirming(confirm.RemoveWorktree) {
+		return m.confirmOrRun(true, "Worktree has uncommitted changes. Force delete?", cleanup)
+	}
+	return m.confirmOrRun(confirm.DeleteTicket, "Delete ticket: "+ticket.Title+"?", cleanup)
 }
 
 func (m *Model) performTicketCleanup(ticket *board.Ticket) {
@@ -2301,24 +3136,26 @@ func (m *Model) performTicketCleanup(ticket *board.Ticket) {
 			if ticket.WorktreePath != "" && m.config.Cleanup.DeleteWorktree {
 				err := mgr.RemoveWorktree(ticket.WorktreePath)
 				if err != nil {
-					m.notify("Failed to remove worktree: " + err.Error())
+					m.notifyError("Failed to remove worktree: " + err.Error())
 				}
 			}
 
 			if ticket.BranchName != "" && m.config.Cleanup.DeleteBranch {
 				err := mgr.DeleteBranch(ticket.BranchName)
 				if err != nil {
-					m.notify("Failed to delete branch: " + err.Error())
+					m.notifyError("Failed to delete branch: " + err.Error())
 				}
 			}
 		}
 	}
 
 	m.globalStore.RemoveBlockerReferences(ticket.ID)
+	m.globalStore.RemoveParentReferences(ticket.ID)
+	delete(m.collapsedEpics, ticket.ID)
 	m.globalStore.Delete(ticket.ID)
 	m.refreshColumnTickets()
-	m.globalStore.SaveAll()
-	m.notify("Deleted: " + ticketTitle)
+	m.saveAll()
+	m.notifySuccess("Deleted: " + ticketTitle)
 }
 
 func (m *Model) quickMoveTicket() (tea.Model, tea.Cmd) {
@@ -2327,77 +3164,175 @@ func (m *Model) quickMoveTicket() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	nextStatus := m.nextStatus(ticket.Status)
-	if nextStatus == ticket.Status {
+	return m.moveTicketTo(ticket, m.nextStatus(ticket.Status))
+}
+
+// moveTicketTo moves a ticket into the column for status once the column's
+// protection lets it through.
+func (m *Model) moveTicketTo(ticket *board.Ticket, status board.TicketStatus) (tea.Model, tea.Cmd) {
+	if status == ticket.Status {
 		return m, nil
 	}
+	return m.guardMove(ticket, status, func() (tea.Model, tea.Cmd) {
+		return m.applyMove(ticket, status)
+	})
+}
 
-	if nextStatus == board.StatusInProgress && ticket.WorktreePath == "" {
+// applyMove moves a ticket into the column for status, setting up its
+// branch on the way into In Progress and asking for an outcome in Done.
+// A ticket that needs a worktree first moves once it has been created.
+func (m *Model) applyMove(ticket *board.Ticket, status board.TicketStatus) (tea.Model, tea.Cmd) {
+	if status == board.StatusInProgress && ticket.WorktreePath == "" {
 		if ticket.UseWorktree {
-			if err := m.setupWorktree(ticket); err != nil {
-				m.notify("Worktree failed: " + err.Error())
-				return m, nil
-			}
-		} else {
-			if err := m.setupMainRepoBranch(ticket); err != nil {
-				m.notify("Branch setup failed: " + err.Error())
-				return m, nil
-			}
+			return m, m.prepareWorktree(ticket, status)
+		}
+		if err := m.setupMainRepoBranch(ticket); err != nil {
+			m.notifyError("Branch setup failed: " + err.Error())
+			return m, nil
 		}
 	}
 
-	m.globalStore.Move(ticket.ID, nextStatus)
+	m.globalStore.Move(ticket.ID, status)
 	m.refreshColumnTickets()
 	m.selectTicketByID(ticket.ID)
 	m.saveTicket(ticket)
-	m.notify("Moved to " + string(nextStatus))
+	m.notifySuccess("Moved to " + string(status))
 
-	return m, nil
+	if status == board.StatusDone {
+		m.promptOutcome(ticket)
+	}
+
+	return m, m.animateMove(ticket.ID)
 }
 
-func (m *Model) quickMoveTicketBackward() (tea.Model, tea.Cmd) {
-	ticket := m.selectedTicket()
-	if ticket == nil {
+// branchReadyMsg reports a worktree created in the background for a ticket
+// on its way into a column.
+type branchReadyMsg struct {
+	ticketID     board.TicketID
+	mgr          *git.WorktreeManager
+	worktreePath string
+	branchName   string
+	baseBranch   string
+	err          error
+}
+
+// prepareWorktree creates the ticket's worktree in the background, with a
+// spinner on its card, and moves it into status when that's done.
+func (m *Model) prepareWorktree(ticket *board.Ticket, status board.TicketStatus) tea.Cmd {
+	proj := m.globalStore.GetProjectForTicket(ticket)
+	if proj == nil {
+		m.notifyError("Worktree failed: project not found for ticket")
+		return nil
+	}
+	mgr := m.worktreeMgrs[proj.ID]
+	if mgr == nil {
+		m.notifyError("Worktree failed: worktree manager not found")
+		return nil
+	}
+
+	ticketID := ticket.ID
+	branchName := m.generateBranchName(ticket, proj)
+	m.preparing[ticketID] = status
+	return tea.Batch(m.spinnerTick(), func() tea.Msg {
+		baseBranch, _ := mgr.GetDefaultBranch()
+		path, err := mgr.CreateWorktree(branchName, baseBranch)
+		return branchReadyMsg{ticketID: ticketID, mgr: mgr, worktreePath: path, branchName: branchName, baseBranch: baseBranch, err: err}
+	})
+}
+
+// handleBranchReady finishes a move that was waiting on its worktree. The
+// selection stays where it is unless it is still on the ticket.
+func (m *Model) handleBranchReady(msg branchReadyMsg) (tea.Model, tea.Cmd) {
+	status := m.preparing[msg.ticketID]
+	delete(m.preparing, msg.ticketID)
+	if msg.err != nil {
+		m.notifyError("Worktree failed: " + msg.err.Error())
 		return m, nil
 	}
+	ticket, _ := m.globalStore.Get(msg.ticketID)
+	if ticket == nil {
+		// Deleted while its worktree was being created.
+		return m, func() tea.Msg {
+			_ = msg.mgr.RemoveWorktree(msg.worktreePath)
+			return nil
+		}
+	}
 
-	prevStatus := m.previousStatus(ticket.Status)
-	if prevStatus == ticket.Status {
+	ticket.WorktreePath = msg.worktreePath
+	ticket.BranchName = msg.branchName
+	ticket.BaseBranch = msg.baseBranch
+	if ticket.Status == status {
+		m.saveTicket(ticket)
 		return m, nil
 	}
 
-	m.globalStore.Move(ticket.ID, prevStatus)
-	m.refreshColumnTickets()
-	m.selectTicketByID(ticket.ID)
-	m.saveTicket(ticket)
-	m.notify("Moved to " + string(prevStatus))
+	selected := m.selectedTicket()
+	model, cmd := m.applyMove(ticket, status)
+	if selected != nil && selected.ID != ticket.ID {
+		m.selectTicketByID(selected.ID)
+	}
+	return model, cmd
+}
 
-	return m, nil
+// promptOutcome asks for the ticket's outcome after it has been closed.
+func (m *Model) promptOutcome(ticket *board.Ticket) {
+	m.mode = ModeOutcome
+	m.outcomeTicketID = ticket.ID
+	m.outcomeIndex = 0
 }
 
-func (m *Model) setupWorktree(ticket *board.Ticket) error {
-	proj := m.globalStore.GetProjectForTicket(ticket)
-	if proj == nil {
-		return fmt.Errorf("project not found for ticket")
+func (m *Model) handleOutcomeMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	outcomes := board.TicketOutcomes
+
+	switch msg.String() {
+	case "j", "down":
+		m.outcomeIndex = (m.outcomeIndex + 1) % len(outcomes)
+		return m, nil
+	case "k", "up":
+		m.outcomeIndex = (m.outcomeIndex - 1 + len(outcomes)) % len(outcomes)
+		return m, nil
+	case "1", "2", "3":
+		m.outcomeIndex = int(msg.String()[0] - '1')
+	case "enter":
+	case "s", "esc":
+		m.mode = ModeNormal
+		m.outcomeTicketID = ""
+		return m, nil
+	default:
+		return m, nil
 	}
 
-	mgr := m.worktreeMgrs[proj.ID]
-	if mgr == nil {
-		return fmt.Errorf("worktree manager not found")
+	if ticket, _ := m.globalStore.Get(m.outcomeTicketID); ticket != nil {
+		ticket.Outcome = outcomes[m.outcomeIndex]
+		ticket.Record(board.EventEdited, "outcome: "+string(ticket.Outcome))
+		m.saveTicket(ticket)
+		m.notifySuccess("Outcome: " + string(ticket.Outcome))
 	}
+	m.mode = ModeNormal
+	m.outcomeTicketID = ""
+	return m, nil
+}
 
-	branchName := m.generateBranchName(ticket, proj)
-	baseBranch, _ := mgr.GetDefaultBranch()
+func (m *Model) quickMoveTicketBackward() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		return m, nil
+	}
 
-	path, err := mgr.CreateWorktree(branchName, baseBranch)
-	if err != nil {
-		return err
+	prevStatus := m.previousStatus(ticket.Status)
+	if prevStatus == ticket.Status {
+		return m, nil
 	}
 
-	ticket.WorktreePath = path
-	ticket.BranchName = branchName
-	ticket.BaseBranch = baseBranch
-	return nil
+	return m.guardMove(ticket, prevStatus, func() (tea.Model, tea.Cmd) {
+		m.globalStore.Move(ticket.ID, prevStatus)
+		m.refreshColumnTickets()
+		m.selectTicketByID(ticket.ID)
+		m.saveTicket(ticket)
+		m.notifySuccess("Moved to " + string(prevStatus))
+
+		return m, m.animateMove(ticket.ID)
+	})
 }
 
 func (m *Model) setupMainRepoBranch(ticket *board.Ticket) error {
@@ -2421,23 +3356,34 @@ func (m *Model) setupMainRepoBranch(ticket *board.Ticket) error {
 }
 
 func (m *Model) generateBranchNameFromTitle(title string, proj *project.Project) string {
-	maxLen := m.getSlugMaxLength(proj)
-	slug := board.Slugify(title, maxLen)
-
-	template := m.getBranchTemplate(proj)
-	prefix := m.getBranchPrefix(proj)
-
-	result := strings.ReplaceAll(template, "{prefix}", prefix)
-	result = strings.ReplaceAll(result, "{slug}", slug)
-
-	return result
+	return m.generateBranchName(&board.Ticket{Title: title}, proj)
 }
 
 func (m *Model) generateBranchName(ticket *board.Ticket, proj *project.Project) string {
 	if ticket.BranchName != "" {
 		return ticket.BranchName
 	}
-	return m.generateBranchNameFromTitle(ticket.Title, proj)
+	ctx := m.promptContext(ticket, proj)
+	name, err := agent.RenderBranchTemplate(m.getBranchTemplate(proj), ctx)
+	if err != nil || name == "" {
+		// A broken template shouldn't block the spawn; use the default.
+		name, _ = agent.RenderBranchTemplate("{prefix}{slug}", ctx)
+	}
+	return name
+}
+
+// promptContext is the template context for ticket on proj's board.
+func (m *Model) promptContext(ticket *board.Ticket, proj *project.Project) agent.PromptContext {
+	info := agent.BoardInfo{
+		BranchPrefix:  m.getBranchPrefix(proj),
+		SlugMaxLength: m.getSlugMaxLength(proj),
+		TicketLink:    m.config.UI.TicketLink,
+	}
+	if proj != nil {
+		info.Name = proj.Name
+		info.RepoPath = proj.RepoPath
+	}
+	return agent.NewPromptContext(ticket, info)
 }
 
 func (m *Model) allocateAgentPort() int {
@@ -2460,12 +3406,28 @@ func (m *Model) spawnAgent() (tea.Model, tea.Cmd) {
 	if ticket == nil {
 		return m, nil
 	}
+	if m.offerAgentPicker(ticket) {
+		return m.openAgentPicker(ticket)
+	}
+	return m.spawnAgentFor(ticket)
+}
 
-	if ticket.Status != board.StatusInProgress {
+func (m *Model) spawnAgentFor(ticket *board.Ticket) (tea.Model, tea.Cmd) {
+	// Columns with their own agent can spawn once the ticket has been started
+	// and has its branch.
+	if ticket.Status != board.StatusInProgress && (m.columnAgent(ticket.Status) == "" || ticket.StartedAt == nil) {
 		m.notify("Press Space to move to In Progress first")
 		return m, nil
 	}
 
+	if agentName, ok := m.starting[ticket.ID]; ok {
+		m.notify("Still starting " + agentName + " — " + m.keymap.label("stop_agent") + " cancels")
+		return m, nil
+	}
+	if _, ok := m.preparing[ticket.ID]; ok {
+		m.notify("Still setting up the worktree")
+		return m, nil
+	}
 	if _, exists := m.panes[ticket.ID]; exists {
 		m.notify("Agent already running — press Enter to attach")
 		return m, nil
@@ -2473,7 +3435,7 @@ func (m *Model) spawnAgent() (tea.Model, tea.Cmd) {
 
 	proj := m.globalStore.GetProjectForTicket(ticket)
 	if proj == nil {
-		m.notify("Project not found for this ticket")
+		m.notifyError("Project not found for this ticket")
 		return m, nil
 	}
 
@@ -2493,26 +3455,24 @@ func (m *Model) spawnAgent() (tea.Model, tea.Cmd) {
 		}
 	}
 
-	agentType := ticket.AgentType
-	if agentType == "" {
-		agentType = m.config.Defaults.DefaultAgent
-	}
+	agentType := m.spawnAgentType(ticket)
 	agentCfg, ok := m.config.Agents[agentType]
 	if !ok {
-		m.notify("Agent '" + agentType + "' not configured")
+		m.notifyError("Agent '" + agentType + "' not configured")
 		return m, nil
 	}
-
-	// Start opencode server on-demand if spawning opencode agent
-	if agentType == "opencode" {
-		_ = m.opencodeServer.Start() // Best effort, ignore errors
+	if ticket.AgentType != "" && agentType != ticket.AgentType {
+		// Another agent can't resume this one's session; start fresh with
+		// the init prompt.
+		ticket.AgentSpawnedAt = nil
 	}
 
-	m.mode = ModeSpawning
-	m.spawningTicketID = ticket.ID
-	m.spawningAgent = agentType
+	// The agent starts in the background with a spinner on its card, and
+	// opens full screen once it prints if the ticket is still selected.
+	m.starting[ticket.ID] = agentType
+	m.attachOnStart = ticket.ID
 
-	return m, tea.Batch(m.spinner.Tick, m.prepareSpawn(ticket, proj, agentType, agentCfg))
+	return m, tea.Batch(m.spinnerTick(), m.prepareSpawn(ticket, proj, agentType, agentCfg))
 }
 
 func (m *Model) prepareSpawn(ticket *board.Ticket, proj *project.Project, agentName string, agentCfg config.AgentConfig) tea.Cmd {
@@ -2532,21 +3492,33 @@ func (m *Model) prepareSpawn(ticket *board.Ticket, proj *project.Project, agentN
 
 	mgr := m.worktreeMgrs[proj.ID]
 	cfg := m.config
+	promptTemplate := m.initPromptFor(ticket, agentName)
+	server := m.opencodeServer
+	promptCtx := m.promptContext(ticket, proj)
+	if branchName == "" {
+		branchName = m.generateBranchName(ticket, proj)
+	}
 
-	return func() tea.Msg {
+	prepare := func() tea.Msg {
 		if mgr == nil {
 			return spawnErrorMsg{ticketID: ticketID, err: "worktree manager not found"}
 		}
+		if agentName == "opencode" {
+			_ = server.Start() // Best effort, ignore errors
+		}
 
-		generatedBranch := branchName
-		if generatedBranch == "" {
-			maxLen := m.getSlugMaxLength(proj)
-			slug := board.Slugify(ticket.Title, maxLen)
-			template := m.getBranchTemplate(proj)
-			prefix := m.getBranchPrefix(proj)
-			generatedBranch = strings.ReplaceAll(template, "{prefix}", prefix)
-			generatedBranch = strings.ReplaceAll(generatedBranch, "{slug}", slug)
+		// Fail fast on missing credentials, before any worktree or session.
+		preflightDir := proj.RepoPath
+		if worktreePath != "" {
+			if _, err := os.Stat(worktreePath); err == nil {
+				preflightDir = worktreePath
+			}
 		}
+		if err := agent.Preflight(agentName, agentCfg, preflightDir); err != nil {
+			return spawnErrorMsg{ticketID: ticketID, err: err.Error()}
+		}
+
+		generatedBranch := branchName
 
 		base, _ := mgr.GetDefaultBranch()
 		if baseBranch != "" {
@@ -2572,14 +3544,18 @@ func (m *Model) prepareSpawn(ticket *board.Ticket, proj *project.Project, agentN
 
 		pane := terminal.New(string(ticketID), width, height, 0)
 		pane.SetWorkdir(worktreePath)
+		if agentCfg.Headless {
+			pane.SetHeadless()
+		}
 
-		// Set session name for terminal identification (priority: AgentSessionID > branch > ticket)
-		sessionName := string(ticketID)
+		// Set session name for terminal identification (priority: AgentSessionID > branch > ticket),
+		// namespaced by board so reused branch names don't collide.
+		sessionName := agent.SessionPrefix(ticket.ProjectID) + string(ticketID)
 		if branchName != "" {
-			sessionName = branchName
+			sessionName = agent.SessionPrefix(ticket.ProjectID) + branchName
 		}
 		if ticket.AgentSessionID != "" {
-			sessionName = ticket.AgentSessionID
+			sessionName = agent.SessionPrefix(ticket.ProjectID) + ticket.AgentSessionID
 		}
 		pane.SetSessionName(sessionName)
 
@@ -2591,12 +3567,19 @@ func (m *Model) prepareSpawn(ticket *board.Ticket, proj *project.Project, agentN
 		args := make([]string, len(agentCfg.Args))
 		copy(args, agentCfg.Args)
 
-		promptTemplate := cfg.GetEffectiveInitPrompt(agentName)
+		promptCtx.BranchName = branchName
+		promptCtx.BaseBranch = baseBranch
+		promptCtx.WorktreePath = worktreePath
+		if useWorktree && cfg.Behavior.TicketFile {
+			// Written before the agent starts so it sees the file; failures
+			// are reported when the ticket is saved and it is retried.
+			_ = writeTicketFile(mgr, worktreePath, cfg.Behavior.TicketFileTemplate, promptCtx)
+		}
 
 		switch agentName {
 		case "claude":
 			if isNewSession && promptTemplate != "" {
-				prompt := agent.BuildContextPrompt(promptTemplate, ticket)
+				prompt := agent.BuildContextPrompt(promptTemplate, promptCtx)
 				if prompt != "" {
 					args = append(args, prompt)
 				}
@@ -2619,7 +3602,7 @@ func (m *Model) prepareSpawn(ticket *board.Ticket, proj *project.Project, agentN
 			args = []string{worktreePath, "--port", fmt.Sprintf("%d", agentPort)}
 			if isNewSession {
 				if promptTemplate != "" {
-					prompt := agent.BuildContextPrompt(promptTemplate, ticket)
+					prompt := agent.BuildContextPrompt(promptTemplate, promptCtx)
 					if prompt != "" {
 						args = append(args, "--prompt", prompt)
 					}
@@ -2646,7 +3629,7 @@ func (m *Model) prepareSpawn(ticket *board.Ticket, proj *project.Project, agentN
 					args = append(args, "--resume")
 				}
 			} else if promptTemplate != "" {
-				prompt := agent.BuildContextPrompt(promptTemplate, ticket)
+				prompt := agent.BuildContextPrompt(promptTemplate, promptCtx)
 				if prompt != "" {
 					args = append(args, "-i", prompt)
 				}
@@ -2673,7 +3656,7 @@ func (m *Model) prepareSpawn(ticket *board.Ticket, proj *project.Project, agentN
 					args = append(args, agentCfg.Args...)
 				}
 			} else if promptTemplate != "" {
-				prompt := agent.BuildContextPrompt(promptTemplate, ticket)
+				prompt := agent.BuildContextPrompt(promptTemplate, promptCtx)
 				if prompt != "" {
 					args = append(args, prompt)
 				}
@@ -2701,7 +3684,7 @@ func (m *Model) prepareSpawn(ticket *board.Ticket, proj *project.Project, agentN
 			}
 
 			if isNewSession && promptTemplate != "" {
-				prompt := agent.BuildContextPrompt(promptTemplate, ticket)
+				prompt := agent.BuildContextPrompt(promptTemplate, promptCtx)
 				if prompt != "" {
 					args = append(args, "--yolo", prompt)
 				}
@@ -2727,6 +3710,24 @@ func (m *Model) prepareSpawn(ticket *board.Ticket, proj *project.Project, agentN
 			baseBranch:   baseBranch,
 		}
 	}
+
+	return func() tea.Msg {
+		span := tracing.Begin("agent.spawn",
+			tracing.String("ticket.id", string(ticketID)),
+			tracing.String("agent.name", agentName),
+			tracing.String("project.name", proj.Name))
+		msg := prepare()
+		switch msg := msg.(type) {
+		case spawnErrorMsg:
+			span.End(errors.New(msg.err))
+		case spawnReadyMsg:
+			span.SetAttrs(tracing.String("git.branch", msg.branchName))
+			span.End(nil)
+			msg.span = span
+			return msg
+		}
+		return msg
+	}
 }
 
 func (m *Model) stopAgent() (tea.Model, tea.Cmd) {
@@ -2735,15 +3736,50 @@ func (m *Model) stopAgent() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if _, ok := m.starting[ticket.ID]; ok {
+		return m.cancelSpawn(ticket)
+	}
+
+	stop := func() tea.Cmd {
+		if pane, ok := m.panes[ticket.ID]; ok {
+			m.finishAgentRun(ticket, pane, board.RunStopped)
+			pane.Stop()
+			delete(m.panes, ticket.ID)
+		}
+
+		ticket.AgentStatus = board.AgentNone
+		m.saveTicket(ticket)
+		m.notify("Agent stopped")
+		return nil
+	}
+	if pane, ok := m.panes[ticket.ID]; !ok || !pane.Running() {
+		return m, stop()
+	}
+	return m.confirmOrRun(m.config.Behavior.Confirm.StopAgent, "Stop the agent on: "+ticket.Title+"?", stop)
+}
+
+// retireAgent stops the ticket's agent to make way for another, recording
+// its run as completed if it reported completing and as stopped otherwise.
+func (m *Model) retireAgent(ticket *board.Ticket) {
 	if pane, ok := m.panes[ticket.ID]; ok {
+		outcome := board.RunStopped
+		if ticket.AgentStatus == board.AgentCompleted {
+			outcome = board.RunCompleted
+		}
+		m.finishAgentRun(ticket, pane, outcome)
 		pane.Stop()
 		delete(m.panes, ticket.ID)
+		if m.focusedPane == ticket.ID {
+			m.mode = ModeNormal
+			m.focusedPane = ""
+		}
 	}
-
 	ticket.AgentStatus = board.AgentNone
-	m.saveTicket(ticket)
-	m.notify("Agent stopped")
-	return m, nil
+	delete(m.agentMessages, ticket.ID)
+	// The next agent starts its own session with its init prompt, even
+	// when it is the same kind of agent.
+	ticket.AgentSpawnedAt = nil
+	ticket.AgentSessionID = ""
 }
 
 func (m *Model) selectedTicket() *board.Ticket {
@@ -2772,15 +3808,20 @@ func (m *Model) selectTicketByID(ticketID board.TicketID) {
 
 func (m *Model) refreshColumnTickets() {
 	m.columnTickets = make([][]*board.Ticket, len(m.columns))
+	m.columnGroups = make([][]labelGroup, len(m.columns))
 	for i, col := range m.columns {
 		allForStatus := m.globalStore.GetByStatus(col.Status)
 		var filtered []*board.Ticket
 		for _, t := range allForStatus {
-			if !m.ticketMatchesFilter(t) {
+			if t.Archived || m.hiddenByCollapsedEpic(t) || !m.ticketMatchesFilter(t) {
 				continue
 			}
 			filtered = append(filtered, t)
 		}
+		board.SortTickets(filtered, m.columnSort(col))
+		if m.config.ColumnGroup(col.ID) == config.GroupByLabel {
+			filtered, m.columnGroups[i] = m.groupByLabel(col, filtered)
+		}
 		m.columnTickets[i] = filtered
 	}
 
@@ -2812,54 +3853,167 @@ func (m *Model) ticketMatchesFilter(t *board.Ticket) bool {
 		query = strings.TrimSpace(parts[1])
 	}
 
+	// "~name" narrows to an assignee; a bare "~" matches unassigned tickets.
+	if strings.HasPrefix(query, "~") {
+		parts := strings.SplitN(query, " ", 2)
+		assignee := strings.TrimPrefix(parts[0], "~")
+		if assignee == "" && t.Assignee != "" {
+			return false
+		}
+		if !strings.Contains(strings.ToLower(t.Assignee), assignee) {
+			return false
+		}
+		if len(parts) == 1 {
+			return true
+		}
+		query = strings.TrimSpace(parts[1])
+	}
+
+	// "+name" narrows to a sprint; a bare "+" means the current sprint.
+	if strings.HasPrefix(query, "+") {
+		parts := strings.SplitN(query, " ", 2)
+		sprint := m.findSprint(strings.TrimPrefix(parts[0], "+"))
+		if sprint == nil || t.SprintID != sprint.ID {
+			return false
+		}
+		if len(parts) == 1 {
+			return true
+		}
+		query = strings.TrimSpace(parts[1])
+	}
+
 	title := strings.ToLower(t.Title)
 	desc := strings.ToLower(t.Description)
 	return strings.Contains(title, query) || strings.Contains(desc, query)
 }
 
+// nextStatus is the status of the column right of current's, so Space walks
+// the board in its configured order.
 func (m *Model) nextStatus(current board.TicketStatus) board.TicketStatus {
-	switch current {
-	case board.StatusBacklog:
-		return board.StatusInProgress
-	case board.StatusInProgress:
-		return board.StatusDone
-	default:
-		return current
-	}
+	return m.adjacentStatus(current, 1)
 }
 
 func (m *Model) previousStatus(current board.TicketStatus) board.TicketStatus {
-	switch current {
-	case board.StatusDone:
-		return board.StatusInProgress
-	case board.StatusInProgress:
-		return board.StatusBacklog
-	default:
-		return current
-	}
+	return m.adjacentStatus(current, -1)
 }
 
-func (m *Model) notify(msg string) {
-	m.notification = msg
-	m.notifyTime = time.Now()
+func (m *Model) adjacentStatus(current board.TicketStatus, delta int) board.TicketStatus {
+	for i, col := range m.columns {
+		if col.Status != current {
+			continue
+		}
+		if j := i + delta; j >= 0 && j < len(m.columns) {
+			return m.columns[j].Status
+		}
+		break
+	}
+	return current
 }
 
 func (m *Model) saveTicket(ticket *board.Ticket) {
-	if err := m.globalStore.Save(ticket); err != nil {
-		m.notify("Failed to save: " + err.Error())
+	m.handleSaveError(m.globalStore.Save(ticket))
+	m.syncTicketFile(ticket)
+	m.queueHooks(ticket)
+	m.queueReviews(ticket)
+}
+
+func (m *Model) saveAll() {
+	m.handleSaveError(m.globalStore.SaveAll())
+	for _, ticket := range m.globalStore.All() {
+		m.queueHooks(ticket)
+		m.queueReviews(ticket)
+	}
+	if m.config.Behavior.TicketFile {
+		for _, ticket := range m.globalStore.All() {
+			m.syncTicketFile(ticket)
+		}
 	}
 }
 
-func (m *Model) resetSpawnState(ticketID board.TicketID) {
-	if ticket, _ := m.globalStore.Get(ticketID); ticket != nil {
-		ticket.AgentSpawnedAt = nil
-		ticket.AgentStatus = board.AgentNone
-		m.saveTicket(ticket)
+// handleSaveError reports a failed save. Tickets changed on disk since they
+// were loaded, by another openkanban or a sync tool, or edited by hand
+// before, are only overwritten once the user confirms. SaveAll joins the
+// errors of every project it couldn't save, and each is handled.
+func (m *Model) handleSaveError(err error) {
+	if err == nil {
+		return
 	}
-	m.mode = ModeNormal
-	m.spawningTicketID = ""
-	m.spawningAgent = ""
-	delete(m.panes, ticketID)
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+
+	var ids, names []string
+	for _, err := range errs {
+		var modified *project.ModifiedError
+		if !errors.As(err, &modified) {
+			m.notifyError("Failed to save: " + err.Error())
+			continue
+		}
+		name := modified.ProjectID
+		if p := m.globalStore.GetProject(modified.ProjectID); p != nil {
+			name = p.Name
+		}
+		ids = append(ids, modified.ProjectID)
+		names = append(names, name)
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	list := strings.Join(names, ", ")
+	// The prompt can't show over an agent or an open dialog.
+	if m.showConfirm || m.mode == ModeAgentView || m.mode == ModeShuttingDown {
+		m.notifyError("Not saved: tickets for " + list + " changed on disk; :w! overwrites them")
+		return
+	}
+	m.showConfirm = true
+	m.confirmMsg = fmt.Sprintf("Tickets for %s changed outside this board. Overwrite them with the board's copy? [y/N]", list)
+	m.confirmFn = func() tea.Cmd {
+		for _, id := range ids {
+			if err := m.globalStore.ForceSave(id); err != nil {
+				m.notifyError("Failed to save: " + err.Error())
+				return nil
+			}
+		}
+		m.notifySuccess("Saved tickets for " + list)
+		return nil
+	}
+}
+
+// finishAgentRun closes the ticket's open agent run, recording any session
+// cost the agent printed to its terminal.
+func (m *Model) finishAgentRun(ticket *board.Ticket, pane *terminal.Pane, outcome board.RunOutcome) {
+	var cost float64
+	if pane != nil {
+		cost = agent.ParseCost(pane.GetContent())
+		if run := ticket.CurrentAgentRun(); run != nil && m.config.Behavior.CaptureArtifacts {
+			m.captureRunArtifacts(ticket, run, pane)
+		}
+	}
+	ticket.EndAgentRun(outcome, cost)
+	m.endAgentTrace(ticket.ID, outcome, cost)
+}
+
+// captureRunArtifacts archives the run's prompt, transcript tail, and working
+// tree diff, and records the archive location on the run.
+func (m *Model) captureRunArtifacts(ticket *board.Ticket, run *board.AgentRun, pane *terminal.Pane) {
+	artifacts := agent.RunArtifacts{
+		Prompt:     agent.BuildContextPrompt(m.initPromptFor(ticket, run.Agent), m.promptContext(ticket, m.globalStore.GetProjectForTicket(ticket))),
+		Transcript: pane.Transcript(agent.TranscriptTailLines),
+	}
+	if workdir := pane.GetWorkdir(); workdir != "" {
+		if diff, err := git.Diff(workdir, ticket.BaseBranch); err == nil {
+			artifacts.Diff = diff
+		}
+	}
+
+	dir, err := agent.WriteRunArtifacts(agent.ArtifactsDir(), ticket.ID, run.StartedAt, artifacts)
+	if err != nil {
+		m.notifyError("Artifact capture failed: " + err.Error())
+		return
+	}
+	run.ArtifactsDir = dir
 }
 
 func (m *Model) RunningAgentCount() int {
@@ -2931,7 +4085,11 @@ func (m *Model) getAgentIndex(agentName string) int {
 const gracefulShutdownTimeout = 3 * time.Second
 
 func (m *Model) Cleanup() {
-	for _, pane := range m.panes {
+	for ticketID, pane := range m.panes {
+		if ticket, _ := m.globalStore.Get(ticketID); ticket != nil && ticket.CurrentAgentRun() != nil {
+			m.finishAgentRun(ticket, pane, board.RunStopped)
+			m.globalStore.Save(ticket)
+		}
 		if pane.Running() {
 			pane.StopGraceful(gracefulShutdownTimeout)
 		}
@@ -2941,6 +4099,7 @@ func (m *Model) Cleanup() {
 func (m *Model) pollAgentStatusesAsync() tea.Cmd {
 	type paneInfo struct {
 		ticketID        board.TicketID
+		projectID       string
 		agentType       string
 		worktreePath    string
 		branchName      string
@@ -2948,6 +4107,7 @@ func (m *Model) pollAgentStatusesAsync() tea.Cmd {
 		agentSessionID  string
 		running         bool
 		terminalContent string
+		logFile         string
 	}
 
 	var panes []paneInfo
@@ -2960,8 +4120,13 @@ func (m *Model) pollAgentStatusesAsync() tea.Cmd {
 		if worktreePath == "" {
 			worktreePath = ticket.WorktreePath
 		}
+		var logFile string
+		if run := ticket.CurrentAgentRun(); run != nil && pane.Headless() {
+			logFile = run.LogFile
+		}
 		panes = append(panes, paneInfo{
 			ticketID:        ticketID,
+			projectID:       ticket.ProjectID,
 			agentType:       ticket.AgentType,
 			worktreePath:    worktreePath,
 			branchName:      ticket.BranchName,
@@ -2969,6 +4134,7 @@ func (m *Model) pollAgentStatusesAsync() tea.Cmd {
 			agentSessionID:  ticket.AgentSessionID,
 			running:         pane.Running(),
 			terminalContent: pane.GetContent(),
+			logFile:         logFile,
 		})
 	}
 
@@ -2979,7 +4145,16 @@ func (m *Model) pollAgentStatusesAsync() tea.Cmd {
 		results := make(agentStatusResultMsg)
 		for _, p := range panes {
 			if !p.running {
-				results[p.ticketID] = board.AgentNone
+				results[p.ticketID] = agentStatusResult{status: board.AgentNone}
+				continue
+			}
+			if p.logFile != "" {
+				// A headless agent works until it exits; its card shows the
+				// last line it logged.
+				results[p.ticketID] = agentStatusResult{
+					status:  board.AgentWorking,
+					message: agent.LastSessionLogLine(p.logFile),
+				}
 				continue
 			}
 
@@ -2999,9 +4174,19 @@ func (m *Model) pollAgentStatusesAsync() tea.Cmd {
 			if sessionID == "" {
 				sessionID = string(p.ticketID)
 			}
+			sessionID = agent.SessionPrefix(p.projectID) + sessionID
 
-			status := detector.DetectStatusWithPort(p.agentType, sessionID, p.worktreePath, p.agentPort, true, p.terminalContent)
-			results[p.ticketID] = status
+			if p.agentType == "opencode" && p.agentPort > 0 && p.agentSessionID != "" {
+				results[p.ticketID] = agentStatusResult{
+					status:  detector.DetectOpencodeSessionStatus(sessionID, p.agentPort, p.agentSessionID, true, p.terminalContent),
+					message: detector.StatusMessage(sessionID),
+				}
+				continue
+			}
+			results[p.ticketID] = agentStatusResult{
+				status:  detector.DetectStatusWithPort(p.agentType, sessionID, p.worktreePath, p.agentPort, true, p.terminalContent),
+				message: detector.StatusMessage(sessionID),
+			}
 		}
 		return results
 	}
@@ -3018,7 +4203,15 @@ func (m *Model) handleTerminalMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 type agentStatusMsg time.Time
-type agentStatusResultMsg map[board.TicketID]board.AgentStatus
+type agentStatusResultMsg map[board.TicketID]agentStatusResult
+
+// agentStatusResult is one polled status plus the message an agent sent
+// with it through its status file, if any.
+type agentStatusResult struct {
+	status  board.AgentStatus
+	message string
+}
+
 type notificationMsg time.Time
 type shutdownCompleteMsg struct{}
 type updateCheckMsg update.CheckResult
@@ -3031,6 +4224,9 @@ type spawnReadyMsg struct {
 	worktreePath string
 	branchName   string
 	baseBranch   string
+	// span is the finished spawn span; the agent's session is traced
+	// under it.
+	span *tracing.Span
 }
 
 type spawnErrorMsg struct {
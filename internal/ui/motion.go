@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+const (
+	// moveSlideFrames is how many columns a moved card slides in from, one
+	// column per animation frame.
+	moveSlideFrames   = 4
+	moveHighlightHold = 600 * time.Millisecond
+
+	defaultAnimationFPS = 30
+)
+
+// moveAnimMsg advances the moved-card animation. gen guards against ticks
+// from an animation that a newer move has replaced.
+type moveAnimMsg struct {
+	gen   int
+	frame int
+}
+
+// reduceMotion reports whether animations are off, either by setting or
+// because recent frames have been rendering over budget.
+func (m *Model) reduceMotion() bool {
+	return m.config.UI.ReduceMotion || m.renderBudget.degraded
+}
+
+// frameInterval is the delay between animation frames at the configured
+// frame rate.
+func (m *Model) frameInterval() time.Duration {
+	fps := m.config.UI.AnimationFPS
+	if fps <= 0 {
+		fps = defaultAnimationFPS
+	}
+	return time.Second / time.Duration(fps)
+}
+
+// spinnerTick starts the spinner animation if something on screen shows
+// it and motion isn't reduced. It is safe to call while the spinner is
+// already running.
+func (m *Model) spinnerTick() tea.Cmd {
+	if m.spinnerRunning || m.reduceMotion() || !m.spinnerShown() {
+		return nil
+	}
+	m.spinnerRunning = true
+	return m.spinner.Tick
+}
+
+// updateSpinner advances the spinner. The tick chain is dropped when motion
+// is reduced or nothing shows the spinner, so an idle board stops redrawing
+// and the spinner costs no CPU until spinnerTick starts it again.
+func (m *Model) updateSpinner(msg spinner.TickMsg) tea.Cmd {
+	if m.reduceMotion() || !m.spinnerShown() {
+		m.spinnerRunning = false
+		return nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return cmd
+}
+
+// spinnerShown reports whether the spinner is on screen: the shutdown
+// screen, the header's working count, or the card of a ticket whose agent
+// is starting or working or whose worktree is being set up.
+func (m *Model) spinnerShown() bool {
+	if m.mode == ModeShuttingDown || len(m.starting) > 0 || len(m.preparing) > 0 {
+		return true
+	}
+	for _, tickets := range m.columnTickets {
+		for _, t := range tickets {
+			if t.AgentStatus == board.AgentWorking {
+				return true
+			}
+		}
+	}
+	for ticketID, pane := range m.panes {
+		if t, _ := m.globalStore.Get(ticketID); t != nil && t.AgentStatus == board.AgentWorking && pane.Running() {
+			return true
+		}
+	}
+	return false
+}
+
+// animateMove highlights a ticket that just changed columns and, unless
+// motion is reduced, slides it into place.
+func (m *Model) animateMove(ticketID board.TicketID) tea.Cmd {
+	m.moveAnimGen++
+	m.movedTicketID = ticketID
+	if m.reduceMotion() {
+		m.moveFrame = moveSlideFrames
+		return moveAnimTick(moveHighlightHold, m.moveAnimGen, moveSlideFrames+1)
+	}
+	m.moveFrame = 0
+	return moveAnimTick(m.frameInterval(), m.moveAnimGen, 1)
+}
+
+func (m *Model) handleMoveAnim(msg moveAnimMsg) tea.Cmd {
+	if msg.gen != m.moveAnimGen || m.movedTicketID == "" {
+		return nil
+	}
+	m.moveFrame = msg.frame
+	if m.moveFrame < moveSlideFrames && m.reduceMotion() {
+		// Motion was suspended mid-slide; settle the card and just hold.
+		m.moveFrame = moveSlideFrames
+	}
+	switch {
+	case m.moveFrame < moveSlideFrames:
+		return moveAnimTick(m.frameInterval(), msg.gen, msg.frame+1)
+	case m.moveFrame == moveSlideFrames:
+		return moveAnimTick(moveHighlightHold, msg.gen, moveSlideFrames+1)
+	default:
+		m.movedTicketID = ""
+		return nil
+	}
+}
+
+// moveSlideOffset returns how far right of its resting place a card is drawn.
+func (m *Model) moveSlideOffset(ticketID board.TicketID) int {
+	if ticketID != m.movedTicketID || m.moveFrame >= moveSlideFrames {
+		return 0
+	}
+	return moveSlideFrames - m.moveFrame
+}
+
+func moveAnimTick(d time.Duration, gen, frame int) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return moveAnimMsg{gen: gen, frame: frame}
+	})
+}
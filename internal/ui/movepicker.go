@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openMovePicker lists the columns so the selected ticket can jump straight
+// to any of them.
+func (m *Model) openMovePicker() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+
+	m.mode = ModeMovePicker
+	m.moveTicketID = ticket.ID
+	m.moveIndex = 0
+	for i, col := range m.columns {
+		if col.Status == ticket.Status {
+			m.moveIndex = i
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) handleMovePickerMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	ticket, _ := m.globalStore.Get(m.moveTicketID)
+	if ticket == nil {
+		m.mode = ModeNormal
+		return m, nil
+	}
+
+	key := msg.String()
+	switch key {
+	case "esc", "q", "m":
+		m.mode = ModeNormal
+	case "j", "down", "l", "right":
+		m.moveIndex = min(m.moveIndex+1, len(m.columns)-1)
+	case "k", "up", "h", "left":
+		m.moveIndex = max(m.moveIndex-1, 0)
+	case "enter":
+		m.mode = ModeNormal
+		return m.moveTicketTo(ticket, m.columns[m.moveIndex].Status)
+	default:
+		// Number keys pick a column directly.
+		if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= len(m.columns) {
+			m.mode = ModeNormal
+			return m.moveTicketTo(ticket, m.columns[n-1].Status)
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) renderMovePicker() string {
+	ticket, _ := m.globalStore.Get(m.moveTicketID)
+	if ticket == nil {
+		return ""
+	}
+
+	width := min(50, m.width-4)
+	width = max(width, 36)
+	innerWidth := width - 4
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.colors.text).Background(m.colors.surface).Bold(true)
+
+	lines := []string{
+		titleStyle.Render("Move: " + truncateString(ticket.Title, innerWidth-6)),
+		"",
+	}
+	for i, col := range m.columns {
+		current := "  "
+		if col.Status == ticket.Status {
+			current = "● "
+		}
+		swatch := lipgloss.NewStyle().Foreground(m.columnColor(col)).Render("■")
+		row := fmt.Sprintf("%d %s%s %s", i+1, current, swatch, truncateString(col.Name, innerWidth-10))
+		if i == m.moveIndex {
+			lines = append(lines, selectedStyle.Render("▸ "+row))
+		} else {
+			lines = append(lines, rowStyle.Render("  "+row))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, m.dimStyle().Render("[j/k] Navigate  [Enter/1-9] Move  [Esc] Cancel"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
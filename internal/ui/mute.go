@@ -0,0 +1,41 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+// toggleMute silences or restores the selected ticket's agent notifications.
+func (m *Model) toggleMute() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		return m, nil
+	}
+	ticket.Muted = !ticket.Muted
+	m.saveTicket(ticket)
+	if ticket.Muted {
+		m.notify("Muted: " + ticket.Title)
+	} else {
+		m.notify("Unmuted: " + ticket.Title)
+	}
+	return m, nil
+}
+
+// notifyAgentStatus tells the user when a ticket's agent starts waiting for
+// them or reports an error, with an alert if ui.alerts calls for one, unless
+// the ticket is muted.
+func (m *Model) notifyAgentStatus(ticket *board.Ticket, previous board.AgentStatus) {
+	if ticket.Muted || ticket.AgentStatus == previous {
+		return
+	}
+	switch ticket.AgentStatus {
+	case board.AgentWaiting:
+		m.notify("Waiting for you: " + ticket.Title)
+		m.alert(config.AlertWaiting)
+	case board.AgentError:
+		m.notifyError("Agent error: " + ticket.Title)
+		m.alert(config.AlertError)
+	}
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// ticketLinkTargets are what ":open" can open for a ticket.
+var ticketLinkTargets = []string{"pr", "issue", "worktree"}
+
+// openExternal hands a URL or directory to the desktop: open on macOS,
+// xdg-open elsewhere. It doesn't wait for the program to finish.
+func openExternal(target string) error {
+	name := "xdg-open"
+	if runtime.GOOS == "darwin" {
+		name = "open"
+	}
+	cmd := exec.Command(name, target)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// linkCommand handles ":pr <url>" and ":issue <url>", storing the link on
+// the selected ticket; "-" clears it and a bare command shows it.
+func (m *Model) linkCommand(kind, arg string) (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+	name, field := "Issue", &ticket.IssueURL
+	if kind == "pr" {
+		name, field = "Pull request", &ticket.PRURL
+	}
+
+	switch arg {
+	case "":
+		if *field == "" {
+			m.notify(fmt.Sprintf("No %s linked; :%s <url> to add one", strings.ToLower(name), kind))
+		} else {
+			m.notify(name + ": " + *field)
+		}
+		return m, nil
+	case "-":
+		if *field == "" {
+			return m, nil
+		}
+		*field = ""
+		m.notifySuccess(name + " link removed")
+	default:
+		if !isWebLink(arg) {
+			m.notifyError("Not a web link: " + arg)
+			return m, nil
+		}
+		*field = arg
+		m.notifySuccess(name + ": " + arg)
+	}
+	ticket.Record(board.EventEdited, kind)
+	ticket.Touch()
+	m.saveTicket(ticket)
+	return m, nil
+}
+
+// isWebLink accepts absolute http and https URLs.
+func isWebLink(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// openCommand handles ":open pr|issue|worktree".
+func (m *Model) openCommand(target string) (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+	switch target {
+	case "pr":
+		m.openTicketURL("pull request", ticket.PRURL)
+	case "issue":
+		m.openTicketURL("issue", ticket.IssueURL)
+	case "worktree":
+		m.openWorktreeDir(ticket)
+	default:
+		m.notify("Usage: :open pr|issue|worktree")
+	}
+	return m, nil
+}
+
+func (m *Model) openTicketURL(name, link string) {
+	if link == "" {
+		m.notify("No " + name + " linked")
+		return
+	}
+	if err := openExternal(link); err != nil {
+		m.notifyError("Can't open " + name + ": " + err.Error())
+		return
+	}
+	m.notify("Opening " + link)
+}
+
+// openWorktreeDir shows the ticket's worktree in the file manager.
+func (m *Model) openWorktreeDir(ticket *board.Ticket) {
+	if ticket.WorktreePath == "" {
+		m.notify("No worktree for this ticket")
+		return
+	}
+	if info, err := os.Stat(ticket.WorktreePath); err != nil || !info.IsDir() {
+		m.notifyError("Worktree is missing: " + ticket.WorktreePath)
+		return
+	}
+	if err := openExternal(ticket.WorktreePath); err != nil {
+		m.notifyError("Can't open worktree: " + err.Error())
+		return
+	}
+	m.notify("Opening " + ticket.WorktreePath)
+}
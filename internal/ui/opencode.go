@@ -0,0 +1,43 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// opencodeSessionMsg carries the session an opencode agent opened for a
+// ticket, once it has been titled after the ticket.
+type opencodeSessionMsg struct {
+	ticketID  board.TicketID
+	sessionID string
+}
+
+// registerOpencodeSession tags the session of a freshly spawned opencode
+// agent with the ticket through the instance's API and reports its ID.
+// Failures are quiet; status polling falls back to the whole instance.
+func registerOpencodeSession(ticket *board.Ticket, worktreePath string) tea.Cmd {
+	if ticket.AgentPort == 0 || worktreePath == "" {
+		return nil
+	}
+	ticketID, port := ticket.ID, ticket.AgentPort
+	snapshot := *ticket
+	return func() tea.Msg {
+		sessionID, _ := agent.RegisterOpencodeSession(port, worktreePath, &snapshot)
+		if sessionID == "" {
+			return nil
+		}
+		return opencodeSessionMsg{ticketID: ticketID, sessionID: sessionID}
+	}
+}
+
+func (m *Model) handleOpencodeSession(msg opencodeSessionMsg) (tea.Model, tea.Cmd) {
+	ticket, _ := m.globalStore.Get(msg.ticketID)
+	if ticket == nil || ticket.AgentSessionID == msg.sessionID {
+		return m, nil
+	}
+	ticket.AgentSessionID = msg.sessionID
+	m.saveTicket(ticket)
+	return m, nil
+}
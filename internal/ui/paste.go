@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+const (
+	maxPasteTitleLength = 100
+	pastePreviewLines   = 8
+)
+
+// listMarker matches bullets, checkboxes, and numbering at the start of a
+// pasted line, as in copied meeting notes.
+var listMarker = regexp.MustCompile(`^(?:[-*•+]\s+)?(?:\[[ xX]?\]\s+)?(?:\d+[.)]\s+)?`)
+
+// pastedTitles splits pasted text into one title per non-empty line.
+func pastedTitles(text string) []string {
+	var titles []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		title := strings.TrimSpace(listMarker.ReplaceAllString(strings.TrimSpace(line), ""))
+		if title == "" {
+			continue
+		}
+		if r := []rune(title); len(r) > maxPasteTitleLength {
+			title = string(r[:maxPasteTitleLength])
+		}
+		titles = append(titles, title)
+	}
+	return titles
+}
+
+// offerPastedTickets asks whether a multi-line paste into the title should
+// become one ticket per line. Declining keeps the first line as the title.
+func (m *Model) offerPastedTickets(titles []string) (tea.Model, tea.Cmd) {
+	m.titleInput.SetValue(titles[0])
+
+	var preview strings.Builder
+	fmt.Fprintf(&preview, "Create %d tickets, one per line?\n", len(titles))
+	for i, title := range titles {
+		if i == pastePreviewLines {
+			fmt.Fprintf(&preview, "\n  … and %d more", len(titles)-i)
+			break
+		}
+		preview.WriteString("\n  • " + truncateString(title, 50))
+	}
+
+	m.showConfirm = true
+	m.confirmMsg = preview.String()
+	m.confirmFn = func() tea.Cmd {
+		m.createPastedTickets(titles)
+		return nil
+	}
+	return m, nil
+}
+
+// createPastedTickets files one ticket per title, sharing the form's other
+// fields, and closes the form.
+func (m *Model) createPastedTickets(titles []string) {
+	if m.selectedProject == nil {
+		m.notify("No project selected")
+		return
+	}
+
+	var first board.TicketID
+	for _, title := range titles {
+		ticket := m.newTicketFromForm(title)
+		ticket.BranchName = m.generateBranchNameFromTitle(title, m.selectedProject)
+		m.globalStore.Add(ticket)
+		m.saveTicket(ticket)
+		if first == "" {
+			first = ticket.ID
+		}
+	}
+
+	m.mode = ModeNormal
+	m.blurAllFormFields()
+	m.refreshColumnTickets()
+	m.selectTicketByID(first)
+	m.ensureColumnVisible()
+	m.notifySuccess(fmt.Sprintf("Created %d tickets", len(titles)))
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// setAutomationPaused pauses or resumes what the board does on its own:
+// polling agent status, running column gates and hooks, rewriting TICKET.md,
+// linking commits to tickets and reading git for the split view. Agents keep
+// running either way. On resume statuses are polled and ticket files
+// rewritten straight away, so the board catches up with whatever changed
+// meanwhile; hooks for events while paused are not run.
+func (m *Model) setAutomationPaused(paused bool) tea.Cmd {
+	if paused == m.automationPaused {
+		if paused {
+			m.notify("Automation is already paused")
+		} else {
+			m.notify("Automation is not paused")
+		}
+		return nil
+	}
+	m.automationPaused = paused
+	if paused {
+		m.notify("Automation paused; :resume or " + m.keymap.label("pause_automation") + " to resume")
+		return nil
+	}
+
+	if m.config.Behavior.TicketFile {
+		for _, ticket := range m.globalStore.All() {
+			m.syncTicketFile(ticket)
+		}
+	}
+	m.notifySuccess("Automation resumed")
+	return m.pollAgentStatusesAsync()
+}
+
+// pollAgentStatusesIfActive polls agent status unless automation is paused.
+func (m *Model) pollAgentStatusesIfActive() tea.Cmd {
+	if m.automationPaused {
+		return nil
+	}
+	return m.pollAgentStatusesAsync()
+}
+
+// renderPausedBadge marks the header while automation is paused.
+func (m *Model) renderPausedBadge() string {
+	if !m.automationPaused {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Foreground(m.colors.base).
+		Background(m.colors.warning).
+		Bold(true).
+		Padding(0, 1).
+		Render("PAUSED")
+}
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// pipelineCommand handles ":pipeline [name|next|off]": starting the named
+// pipeline on the selected ticket, moving it on to the next stage by hand,
+// taking it out of its pipeline, or showing where it is.
+func (m *Model) pipelineCommand(arg string) (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+	switch arg {
+	case "":
+		if ticket.Pipeline == "" {
+			m.notify("Not in a pipeline — :pipeline <name> starts one")
+		} else {
+			m.notify(ticket.Title + ": " + m.pipelineStageLabel(ticket))
+		}
+		return m, nil
+	case "next":
+		return m.nextPipelineStageCommand(ticket)
+	case "off":
+		if ticket.Pipeline == "" {
+			m.notify("Not in a pipeline")
+			return m, nil
+		}
+		name := ticket.Pipeline
+		ticket.EndPipeline("stopped")
+		m.saveTicket(ticket)
+		m.notify("Left pipeline " + name + " — the agent keeps running")
+		return m, nil
+	}
+	return m.startPipeline(ticket, arg)
+}
+
+// startPipeline puts the ticket at the first stage of the named pipeline and
+// spawns that stage's agent.
+func (m *Model) startPipeline(ticket *board.Ticket, name string) (tea.Model, tea.Cmd) {
+	stages, ok := m.config.Pipelines[name]
+	if !ok || len(stages) == 0 {
+		m.notifyError("Pipeline '" + name + "' not configured")
+		return m, nil
+	}
+	if m.agentBusy(ticket.ID) {
+		m.notify("Agent already running — stop it before starting a pipeline")
+		return m, nil
+	}
+	if agent := m.columnAgent(ticket.Status); agent != "" {
+		m.notify(fmt.Sprintf("This column always runs %s", agent))
+		return m, nil
+	}
+	ticket.StartPipeline(name)
+	m.saveTicket(ticket)
+	return m.spawnWithAgent(ticket, stages[0])
+}
+
+// nextPipelineStageCommand moves the ticket on to its next stage by hand,
+// as when automation was paused while a stage completed.
+func (m *Model) nextPipelineStageCommand(ticket *board.Ticket) (tea.Model, tea.Cmd) {
+	stages := m.config.Pipelines[ticket.Pipeline]
+	switch {
+	case ticket.Pipeline == "":
+		m.notify("Not in a pipeline")
+		return m, nil
+	case ticket.PipelineStage+1 >= len(stages):
+		m.notify("Already at the last stage of " + ticket.Pipeline)
+		return m, nil
+	}
+	if _, ok := m.starting[ticket.ID]; ok {
+		m.notify("Still starting " + m.starting[ticket.ID])
+		return m, nil
+	}
+	return m, m.startNextStage(ticket, true)
+}
+
+// advancePipeline runs when the ticket's agent completes. Mid-pipeline, it
+// stops the agent and spawns the next stage's, unless automation is paused,
+// and reports true so the ticket isn't moved on_agent_done. After the last
+// stage it takes the ticket out of its pipeline.
+func (m *Model) advancePipeline(ticket *board.Ticket) (tea.Cmd, bool) {
+	if ticket.Pipeline == "" {
+		return nil, false
+	}
+	stages := m.config.Pipelines[ticket.Pipeline]
+	if ticket.PipelineStage+1 >= len(stages) {
+		name := ticket.Pipeline
+		ticket.EndPipeline("finished")
+		m.saveTicket(ticket)
+		m.notifySuccess("Pipeline " + name + " finished: " + ticket.Title)
+		return nil, false
+	}
+	if m.automationPaused {
+		m.notify(fmt.Sprintf("Automation paused — :pipeline next starts %s on %s", stages[ticket.PipelineStage+1], ticket.Title))
+		return nil, true
+	}
+	return m.startNextStage(ticket, false), true
+}
+
+// startNextStage stops the ticket's agent, if it is still running, and
+// spawns the next stage's in its place. Stages started automatically don't
+// take over the screen.
+func (m *Model) startNextStage(ticket *board.Ticket, attach bool) tea.Cmd {
+	stages := m.config.Pipelines[ticket.Pipeline]
+	next := stages[ticket.PipelineStage+1]
+
+	m.retireAgent(ticket)
+	ticket.AdvancePipeline(next)
+	m.saveTicket(ticket)
+
+	_, cmd := m.spawnWithAgent(ticket, next)
+	if !attach && m.attachOnStart == ticket.ID {
+		m.attachOnStart = ""
+	}
+	m.notify(fmt.Sprintf("%s: %s", ticket.Title, m.pipelineStageLabel(ticket)))
+	return cmd
+}
+
+// agentBusy reports whether the ticket has an agent starting or running.
+func (m *Model) agentBusy(id board.TicketID) bool {
+	if _, ok := m.starting[id]; ok {
+		return true
+	}
+	pane, ok := m.panes[id]
+	return ok && pane.Running()
+}
+
+// pipelineStageLabel describes where the ticket is in its pipeline, as
+// "ship 2/3: codex".
+func (m *Model) pipelineStageLabel(ticket *board.Ticket) string {
+	stages := m.config.Pipelines[ticket.Pipeline]
+	if ticket.PipelineStage >= len(stages) {
+		return ticket.Pipeline
+	}
+	return fmt.Sprintf("%s %d/%d: %s", ticket.Pipeline, ticket.PipelineStage+1, len(stages), stages[ticket.PipelineStage])
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// previewChrome is the preview panel's border and title rows.
+const previewChrome = 3
+
+// agentPreview is the tail of a ticket's agent output as last captured.
+type agentPreview struct {
+	ticketID board.TicketID
+	lines    []string
+}
+
+func (m *Model) togglePreview() {
+	m.showPreview = !m.showPreview
+	m.refreshPreview()
+	if m.showPreview {
+		m.ensureTicketVisible()
+	}
+}
+
+// previewHeight is how many rows the preview panel takes under the board.
+func (m *Model) previewHeight() int {
+	if !m.showPreview {
+		return 0
+	}
+	return max(m.config.UI.PreviewLines, 1) + previewChrome
+}
+
+// refreshPreview captures the last lines of the selected ticket's agent
+// pane for the preview panel and split view. It runs on the status tick and
+// after key presses rather than on every frame, since capturing copies the
+// pane's scrollback.
+func (m *Model) refreshPreview() {
+	if !m.showPreview && !m.showSplitView() {
+		return
+	}
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.preview = agentPreview{}
+		return
+	}
+	m.preview = agentPreview{ticketID: ticket.ID}
+	if pane, ok := m.panes[ticket.ID]; ok {
+		if out := pane.Transcript(max(m.config.UI.PreviewLines, 1)); out != "" {
+			m.preview.lines = strings.Split(out, "\n")
+		}
+	}
+}
+
+func (m *Model) renderPreview() string {
+	rows := max(m.config.UI.PreviewLines, 1)
+	innerWidth := max(m.width-4, 10)
+
+	title := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true).Render("◉ Preview")
+	var lines []string
+	ticket, _ := m.globalStore.Get(m.preview.ticketID)
+	switch {
+	case ticket == nil:
+		lines = append(lines, m.dimStyle().Italic(true).Render("No ticket selected"))
+	case m.preview.lines == nil:
+		title += m.dimStyle().Render(" · " + truncateString(ticket.Title, innerWidth-12))
+		lines = append(lines, m.dimStyle().Italic(true).Render("No agent session"))
+	default:
+		title += m.dimStyle().Render(" · " + truncateString(ticket.Title, innerWidth-12))
+		textStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+		for _, line := range m.preview.lines {
+			lines = append(lines, textStyle.Render(ansi.Truncate(line, innerWidth, "…")))
+		}
+	}
+	for len(lines) < rows {
+		lines = append(lines, "")
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.surface).
+		Padding(0, 1).
+		Width(m.width - 2).
+		Render(title + "\n" + strings.Join(lines, "\n"))
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+)
+
+// gatedMove is a move held back until the target column's gate command
+// has run.
+type gatedMove struct {
+	ticketID board.TicketID
+	from     board.TicketStatus
+	to       board.TicketStatus
+	apply    func() (tea.Model, tea.Cmd)
+}
+
+// gateResultMsg reports how a column's gate command went for a ticket.
+type gateResultMsg struct {
+	ticketID board.TicketID
+	err      error
+}
+
+// columnFor returns the column for status and its layout override, whether
+// or not it is hidden.
+func (m *Model) columnFor(status board.TicketStatus) (board.Column, config.ColumnLayout) {
+	for _, col := range m.config.BoardColumns() {
+		if col.Status == status {
+			return col, m.config.ColumnLayout(col.ID)
+		}
+	}
+	return board.Column{Name: string(status), Status: status}, config.ColumnLayout{}
+}
+
+// columnByID finds a board column by its ID.
+func (m *Model) columnByID(id string) (board.Column, bool) {
+	for _, col := range m.config.BoardColumns() {
+		if col.ID == id {
+			return col, true
+		}
+	}
+	return board.Column{Name: id}, false
+}
+
+// guardMove lets a move into status through the column's protection before
+// apply performs it: a gate command runs first in the background, unless
+// automation is paused, then a protected column asks for confirmation.
+// Other columns move straight away.
+func (m *Model) guardMove(ticket *board.Ticket, status board.TicketStatus, apply func() (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	if _, ok := m.preparing[ticket.ID]; ok {
+		m.notify("Still setting up the worktree")
+		return m, nil
+	}
+	col, l := m.columnFor(status)
+	if l.Gate == "" {
+		return m.confirmMove(ticket, col, l, apply)
+	}
+	if m.automationPaused {
+		m.notify(col.Name + " gate skipped: automation is paused")
+		return m.confirmMove(ticket, col, l, apply)
+	}
+	if m.pendingGate != nil {
+		m.notify("Another ticket is waiting on a gate")
+		return m, nil
+	}
+	m.pendingGate = &gatedMove{ticketID: ticket.ID, from: ticket.Status, to: status, apply: apply}
+	m.notify("Checking " + col.Name + " gate...")
+	return m, m.runGate(ticket, col, l.Gate)
+}
+
+// confirmMove asks before applying a move into a protected column.
+func (m *Model) confirmMove(ticket *board.Ticket, col board.Column, l config.ColumnLayout, apply func() (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	if !l.Protected {
+		return apply()
+	}
+	from := ticket.Status
+	m.showConfirm = true
+	m.confirmMsg = fmt.Sprintf("Move '%s' to %s? [y/N]", truncateString(ticket.Title, 40), col.Name)
+	m.confirmFn = func() tea.Cmd {
+		if ticket.Status != from {
+			return nil
+		}
+		_, cmd := apply()
+		return cmd
+	}
+	return m, nil
+}
+
+// runGate runs a column's gate command for a ticket in its worktree, or the
+// project's checkout when it has none, with the ticket described in the
+// environment.
+func (m *Model) runGate(ticket *board.Ticket, col board.Column, command string) tea.Cmd {
+	dir := ticket.WorktreePath
+	if info, err := os.Stat(dir); dir == "" || err != nil || !info.IsDir() {
+		dir = ""
+		if proj := m.globalStore.GetProjectForTicket(ticket); proj != nil {
+			dir = proj.RepoPath
+		}
+	}
+	env := map[string]string{
+		"OPENKANBAN_TICKET_ID":    string(ticket.ID),
+		"OPENKANBAN_TICKET_TITLE": ticket.Title,
+		"OPENKANBAN_BRANCH":       ticket.BranchName,
+		"OPENKANBAN_BASE_BRANCH":  ticket.BaseBranch,
+		"OPENKANBAN_COLUMN":       string(col.Status),
+	}
+	ticketID := ticket.ID
+	return func() tea.Msg {
+		return gateResultMsg{ticketID: ticketID, err: agent.RunGate(command, dir, env)}
+	}
+}
+
+// handleGateResult completes or refuses the move waiting on a gate. The
+// move is dropped if the ticket has moved meanwhile, or if the user is busy
+// elsewhere and would be surprised by it.
+func (m *Model) handleGateResult(msg gateResultMsg) (tea.Model, tea.Cmd) {
+	pending := m.pendingGate
+	if pending == nil || pending.ticketID != msg.ticketID {
+		return m, nil
+	}
+	m.pendingGate = nil
+
+	ticket, _ := m.globalStore.Get(msg.ticketID)
+	if ticket == nil || ticket.Status != pending.from {
+		return m, nil
+	}
+	col, l := m.columnFor(pending.to)
+	if msg.err != nil {
+		m.notifyError("Blocked from " + col.Name + ": " + msg.err.Error())
+		return m, nil
+	}
+	if m.mode != ModeNormal || m.showConfirm {
+		m.notify(col.Name + " gate passed; move the ticket again")
+		return m, nil
+	}
+	return m.confirmMove(ticket, col, l, pending.apply)
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// slowFramesToDegrade is how many consecutive over-budget frames suspend
+	// animations, so a single expensive frame (a resize, say) doesn't.
+	slowFramesToDegrade = 5
+	// renderAvgWeight smooths the frame-time average over roughly this many
+	// frames.
+	renderAvgWeight = 8
+)
+
+// renderBudget tracks how long View takes per frame. Animations are suspended
+// once frames run consistently over budget and resume when the average drops
+// back under half of it.
+type renderBudget struct {
+	avg       time.Duration
+	slow      int
+	degraded  bool
+	announced bool
+}
+
+func (b *renderBudget) record(d, budget time.Duration) {
+	if budget <= 0 {
+		b.degraded = false
+		b.slow = 0
+		return
+	}
+
+	b.avg += (d - b.avg) / renderAvgWeight
+	if b.degraded {
+		if b.avg < budget/2 {
+			b.degraded = false
+			b.slow = 0
+		}
+		return
+	}
+
+	if d > budget {
+		b.slow++
+	} else {
+		b.slow = 0
+	}
+	if b.slow >= slowFramesToDegrade {
+		b.degraded = true
+	}
+}
+
+func (m *Model) renderBudgetLimit() time.Duration {
+	return time.Duration(m.config.UI.RenderBudgetMS) * time.Millisecond
+}
+
+// checkRenderBudget reports a change in degradation since the last check.
+// View can't return commands, so this runs from the periodic status tick and
+// restarts the spinner once rendering has recovered.
+func (m *Model) checkRenderBudget() tea.Cmd {
+	b := &m.renderBudget
+	if b.degraded == b.announced {
+		return nil
+	}
+	b.announced = b.degraded
+	if b.degraded {
+		m.notify("Rendering is slow; animations paused")
+		return nil
+	}
+	m.notify("Animations resumed")
+	return m.spinnerTick()
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// confirmRetryTicket offers to start the selected ticket over on a fresh
+// branch and worktree, for when untangling an agent's work costs more than
+// redoing it.
+func (m *Model) confirmRetryTicket() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		return m, nil
+	}
+	if !ticket.UseWorktree {
+		m.notify("Retry needs a ticket with its own worktree")
+		return m, nil
+	}
+	if ticket.WorktreePath == "" || ticket.BranchName == "" {
+		m.notify("Nothing to retry yet — press s to spawn an agent")
+		return m, nil
+	}
+
+	proj := m.globalStore.GetProjectForTicket(ticket)
+	if proj == nil {
+		m.notifyError("Project not found for this ticket")
+		return m, nil
+	}
+	mgr := m.worktreeMgrs[proj.ID]
+	if mgr == nil {
+		m.notifyError("Worktree manager not found")
+		return m, nil
+	}
+
+	branchName := mgr.RetryBranch(ticket.BranchName)
+	msg := "Retry on " + branchName + "? The current worktree is removed; " + ticket.BranchName + " is kept."
+	if dirty, err := mgr.HasUncommittedChanges(ticket.WorktreePath); err == nil && dirty {
+		msg += " Its uncommitted changes will be lost."
+	}
+
+	return m.confirmOrRun(m.config.Behavior.Confirm.RemoveWorktree, msg, func() tea.Cmd {
+		return m.retryTicket(ticket, branchName)
+	})
+}
+
+// retryTicket stops the ticket's agent, removes its worktree while keeping
+// the branch, and spawns the agent again from scratch on branchName.
+func (m *Model) retryTicket(ticket *board.Ticket, branchName string) tea.Cmd {
+	oldBranch := ticket.BranchName
+	if !m.releaseWorktree(ticket) {
+		return nil
+	}
+
+	ticket.Retry(branchName)
+	delete(m.agentMessages, ticket.ID)
+	m.saveTicket(ticket)
+
+	_, cmd := m.spawnAgentFor(ticket)
+	if _, starting := m.starting[ticket.ID]; starting {
+		m.notifySuccess("Retrying on " + branchName + " — previous work kept on " + oldBranch)
+	}
+	return cmd
+}
+
+// releaseWorktree stops the ticket's agent and removes its worktree, leaving
+// the branch in place. It reports false, with a notification, if the
+// worktree could not be removed.
+func (m *Model) releaseWorktree(ticket *board.Ticket) bool {
+	if pane, ok := m.panes[ticket.ID]; ok {
+		m.finishAgentRun(ticket, pane, board.RunStopped)
+		pane.Stop()
+		delete(m.panes, ticket.ID)
+	}
+	if m.focusedPane == ticket.ID {
+		m.focusedPane = ""
+	}
+
+	if ticket.WorktreePath == "" {
+		return true
+	}
+	if proj := m.globalStore.GetProjectForTicket(ticket); proj != nil {
+		if mgr := m.worktreeMgrs[proj.ID]; mgr != nil {
+			if err := mgr.RemoveWorktree(ticket.WorktreePath); err != nil {
+				m.notifyError("Failed to remove worktree: " + err.Error())
+				return false
+			}
+		}
+	}
+	return true
+}
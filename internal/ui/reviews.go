@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// initPromptFor is the init prompt template agentType is spawned with on
+// the ticket: the column's review prompt for its reviewer, and the agent's
+// own otherwise.
+func (m *Model) initPromptFor(ticket *board.Ticket, agentType string) string {
+	col, l := m.columnFor(ticket.Status)
+	if l.Reviewer != "" && l.Reviewer == agentType {
+		return m.config.ReviewPromptFor(col.ID, ticket.Labels)
+	}
+	return m.config.InitPromptFor(agentType, ticket.Labels)
+}
+
+// queueReviews queues a review for a ticket that has moved into a column
+// with a reviewer since it was last saved. Like hooks, it reads the moves
+// from the ticket's history, so every way of moving a ticket is seen.
+func (m *Model) queueReviews(ticket *board.Ticket) {
+	since, ok := m.reviewCursor[ticket.ID]
+	if !ok {
+		since = m.hooksFrom
+	}
+	entered := false
+	for _, ev := range ticket.History {
+		if !ev.At.After(since) {
+			continue
+		}
+		since = ev.At
+		if ev.Kind != board.EventMoved {
+			continue
+		}
+		_, to, _ := strings.Cut(ev.Detail, " → ")
+		if _, l := m.columnFor(board.TicketStatus(to)); l.Reviewer != "" {
+			entered = true
+		}
+	}
+	m.reviewCursor[ticket.ID] = since
+	if entered && !slices.Contains(m.pendingReviews, ticket.ID) {
+		m.pendingReviews = append(m.pendingReviews, ticket.ID)
+	}
+}
+
+// runPendingReviews spawns the reviewers of the tickets that entered their
+// columns during the update and are still there. A review waits while the
+// ticket's agent is still at work, and is skipped, not held back, while
+// automation is paused.
+func (m *Model) runPendingReviews() tea.Cmd {
+	if len(m.pendingReviews) == 0 {
+		return nil
+	}
+	var cmds []tea.Cmd
+	var waiting []board.TicketID
+	for _, id := range m.pendingReviews {
+		ticket, _ := m.globalStore.Get(id)
+		if ticket == nil {
+			delete(m.heldReviews, id)
+			continue
+		}
+		col, l := m.columnFor(ticket.Status)
+		if l.Reviewer == "" {
+			delete(m.heldReviews, id)
+			continue
+		}
+		if m.automationPaused {
+			delete(m.heldReviews, id)
+			m.notify("Automation paused — " + m.keymap.label("spawn_agent") + " in " + col.Name + " starts the review of " + ticket.Title)
+			continue
+		}
+		if m.agentAtWork(ticket) {
+			if !m.heldReviews[id] {
+				m.heldReviews[id] = true
+				m.notify("Review of " + ticket.Title + " waits for its agent to finish")
+			}
+			waiting = append(waiting, id)
+			continue
+		}
+		delete(m.heldReviews, id)
+		cmds = append(cmds, m.startReview(ticket, l.Reviewer))
+	}
+	m.pendingReviews = waiting
+	return tea.Batch(cmds...)
+}
+
+// agentAtWork reports whether the ticket's agent is running and hasn't
+// reported being idle or completed, so stopping it could lose work.
+func (m *Model) agentAtWork(ticket *board.Ticket) bool {
+	pane, ok := m.panes[ticket.ID]
+	if !ok || !pane.Running() {
+		return false
+	}
+	return ticket.AgentStatus != board.AgentIdle && ticket.AgentStatus != board.AgentCompleted
+}
+
+// startReview stops the ticket's idle or completed agent and spawns the reviewer in a session
+// of its own, in the background. A ticket entering review leaves its
+// pipeline, if it is in one.
+func (m *Model) startReview(ticket *board.Ticket, reviewer string) tea.Cmd {
+	if agentName, ok := m.starting[ticket.ID]; ok {
+		m.notify("Still starting " + agentName + " on " + ticket.Title + " — not spawning the reviewer")
+		return nil
+	}
+	if ticket.StartedAt == nil {
+		m.notify(ticket.Title + " hasn't been started — nothing to review")
+		return nil
+	}
+
+	m.retireAgent(ticket)
+	ticket.EndPipeline("stopped for review")
+	m.saveTicket(ticket)
+
+	_, cmd := m.spawnAgentFor(ticket)
+	if m.attachOnStart == ticket.ID {
+		m.attachOnStart = ""
+	}
+	if _, ok := m.starting[ticket.ID]; ok {
+		m.notify("Reviewing " + ticket.Title + " with " + reviewer)
+	}
+	return cmd
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/project"
+)
+
+func TestBulkMoveQueuesReviews(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("OPENKANBAN_CONFIG_DIR", dir)
+
+	cfg := config.DefaultConfig()
+	cfg.Defaults.ExtraColumns = []string{"review"}
+	cfg.Defaults.Columns = map[string]config.ColumnLayout{"review": {Reviewer: "claude"}}
+	registry := &project.ProjectRegistry{}
+	store := project.NewGlobalTicketStore(registry)
+	proj := project.NewProject("proj", dir)
+	store.AddProject(proj)
+
+	var tickets []*board.Ticket
+	for _, title := range []string{"Login", "Signup"} {
+		ticket := board.NewTicket(title, proj.ID)
+		ticket.Status = board.StatusInProgress
+		if err := store.Add(ticket); err != nil {
+			t.Fatal(err)
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	m := NewModel(cfg, store, registry, nil, nil, nil, "", nil)
+	m.applyBulkMove(tickets, "review")
+
+	for _, ticket := range tickets {
+		if !slices.Contains(m.pendingReviews, ticket.ID) {
+			t.Errorf("%s moved into review, but no review was queued: %v", ticket.Title, m.pendingReviews)
+		}
+	}
+}
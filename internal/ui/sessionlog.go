@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/terminal"
+)
+
+// sessionLogView is the log viewer's state: the ticket's logged runs,
+// newest first, and the one being read.
+type sessionLogView struct {
+	ticketID  board.TicketID
+	runs      []board.AgentRun
+	index     int
+	lines     []string
+	truncated bool
+	err       error
+	offset    int
+}
+
+// startSessionLog tees the pane's output into a log for the ticket's
+// current run, and a recording of it if enabled. Both are best effort; the
+// agent spawns either way. A headless agent is always logged, since the log
+// is the only place its output can be read.
+func (m *Model) startSessionLog(ticket *board.Ticket, pane *terminal.Pane) {
+	run := ticket.CurrentAgentRun()
+	if run == nil {
+		return
+	}
+	var writers agent.SessionWriters
+	if m.config.Behavior.SessionLogs || pane.Headless() {
+		f, err := agent.CreateSessionLog(agent.SessionLogsDir(), ticket.ID, run.StartedAt)
+		if err != nil {
+			m.notifyError("Session log not written: " + err.Error())
+		} else {
+			writers = append(writers, f)
+			run.LogFile = f.Name()
+		}
+	}
+	if m.config.Behavior.RecordSessions {
+		width, height := pane.Size()
+		rec, err := agent.CreateRecording(agent.SessionLogsDir(), ticket.ID, run.StartedAt, width, height, ticket.Title)
+		if err != nil {
+			m.notifyError("Session not recorded: " + err.Error())
+		} else {
+			writers = append(writers, rec)
+			run.Recording = rec.Name()
+		}
+	}
+	if len(writers) > 0 {
+		pane.SetLog(writers)
+	}
+}
+
+// openSessionLog shows the output of the selected ticket's logged runs,
+// starting at the end of the latest one.
+func (m *Model) openSessionLog() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+	var runs []board.AgentRun
+	for i := len(ticket.AgentRuns) - 1; i >= 0; i-- {
+		if ticket.AgentRuns[i].LogFile != "" {
+			runs = append(runs, ticket.AgentRuns[i])
+		}
+	}
+	if len(runs) == 0 {
+		m.notify("No session logs for this ticket")
+		return m, nil
+	}
+
+	m.sessionLog = sessionLogView{ticketID: ticket.ID, runs: runs}
+	m.loadSessionLog()
+	m.mode = ModeSessionLog
+	return m, nil
+}
+
+// loadSessionLog reads the selected run's log and scrolls to its end.
+func (m *Model) loadSessionLog() {
+	v := &m.sessionLog
+	v.lines, v.truncated, v.err = agent.ReadSessionLog(v.runs[v.index].LogFile, agent.SessionLogTailBytes)
+	v.offset = max(len(v.lines)-m.sessionLogRows(), 0)
+}
+
+// followSessionLog reloads the log being read if it is the live run of a
+// headless agent and the viewer is at its end, to keep up with its output.
+func (m *Model) followSessionLog(ticketID board.TicketID) {
+	v := &m.sessionLog
+	if m.mode != ModeSessionLog || v.ticketID != ticketID || v.index != 0 {
+		return
+	}
+	pane, ok := m.panes[ticketID]
+	if !ok || !pane.Headless() || v.offset < len(v.lines)-m.sessionLogRows() {
+		return
+	}
+	ticket, _ := m.globalStore.Get(ticketID)
+	if ticket == nil || len(ticket.AgentRuns) == 0 {
+		return
+	}
+	latest := ticket.AgentRuns[len(ticket.AgentRuns)-1]
+	if latest.LogFile != v.runs[0].LogFile {
+		return
+	}
+	v.runs[0] = latest
+	m.loadSessionLog()
+}
+
+// sessionLogRows is how many log lines fit in the viewer.
+func (m *Model) sessionLogRows() int {
+	return max(m.height-12, 5)
+}
+
+func (m *Model) handleSessionLogMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.sessionLog
+	rows := m.sessionLogRows()
+	last := max(len(v.lines)-rows, 0)
+
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = ModeNormal
+	case "j", "down":
+		v.offset = min(v.offset+1, last)
+	case "k", "up":
+		v.offset = max(v.offset-1, 0)
+	case "ctrl+d", "pgdown", " ":
+		v.offset = min(v.offset+rows/2, last)
+	case "ctrl+u", "pgup":
+		v.offset = max(v.offset-rows/2, 0)
+	case "g", "home":
+		v.offset = 0
+	case "G", "end":
+		v.offset = last
+	case "h", "left":
+		if v.index < len(v.runs)-1 {
+			v.index++
+			m.loadSessionLog()
+		}
+	case "l", "right":
+		if v.index > 0 {
+			v.index--
+			m.loadSessionLog()
+		}
+	case "r":
+		m.loadSessionLog()
+	}
+	return m, nil
+}
+
+func (m *Model) renderSessionLog() string {
+	ticket, _ := m.globalStore.Get(m.sessionLog.ticketID)
+	if ticket == nil {
+		return ""
+	}
+	v := m.sessionLog
+	run := v.runs[v.index]
+	width := max(min(m.width-4, 160), 40)
+	innerWidth := width - 6
+	rows := m.sessionLogRows()
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	header := titleStyle.Render("Session log") + m.dimStyle().Render("  "+truncateString(ticket.Title, innerWidth-14))
+
+	outcome := string(run.Outcome)
+	if d := run.Duration(); d > 0 {
+		outcome += " after " + formatDuration(d)
+	}
+	meta := fmt.Sprintf("Run %d of %d · %s · %s · %s",
+		len(v.runs)-v.index, len(v.runs), run.Agent, run.StartedAt.Local().Format("Jan 2 15:04"), outcome)
+
+	lines := []string{header, m.dimStyle().Render(truncateString(meta, innerWidth)), ""}
+
+	textStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+	var body []string
+	switch {
+	case v.err != nil:
+		body = append(body, lipgloss.NewStyle().Foreground(m.colors.err).Width(innerWidth).Render("Can't read log: "+v.err.Error()))
+	case len(v.lines) == 0:
+		body = append(body, m.dimStyle().Italic(true).Render("No output"))
+	default:
+		if v.truncated && v.offset == 0 {
+			body = append(body, m.dimStyle().Italic(true).Render("… earlier output trimmed"))
+		}
+		end := min(v.offset+rows-len(body), len(v.lines))
+		for _, line := range v.lines[v.offset:end] {
+			body = append(body, textStyle.Render(truncateString(line, innerWidth)))
+		}
+	}
+	for len(body) < rows {
+		body = append(body, "")
+	}
+	lines = append(lines, body...)
+
+	position := ""
+	if len(v.lines) > rows {
+		position = fmt.Sprintf("  %d%%", 100*min(v.offset+rows, len(v.lines))/len(v.lines))
+	}
+	lines = append(lines, "",
+		m.dimStyle().Render("[j/k] Scroll  [g/G] Top/Bottom  [h/l] Older/Newer run  [r] Reload  [Esc] Close"+position))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// columnSort is the configured sort mode for a column, manual by default.
+func (m *Model) columnSort(col board.Column) board.SortMode {
+	return m.config.ColumnSort(col.ID)
+}
+
+// cycleColumnSort switches the active column to the next sort mode and
+// saves it to the board settings.
+func (m *Model) cycleColumnSort() (tea.Model, tea.Cmd) {
+	if m.activeColumn >= len(m.columns) {
+		return m, nil
+	}
+	col := m.columns[m.activeColumn]
+	selected := m.selectedTicket()
+
+	mode := m.columnSort(col).Next()
+	l := m.config.ColumnLayout(col.ID)
+	l.Sort = string(mode)
+	if mode == board.SortManual {
+		l.Sort = ""
+	}
+	m.config.SetColumnLayout(col.ID, l)
+
+	m.refreshColumnTickets()
+	if selected != nil {
+		m.selectTicketByID(selected.ID)
+	}
+	if err := m.config.Save(""); err != nil {
+		m.notifyError("Failed to save config: " + err.Error())
+		return m, nil
+	}
+	m.notify(col.Name + " sorted by " + string(mode))
+	return m, nil
+}
+
+// resizeColumn widens (delta 1) or narrows (delta -1) the active column and
+// saves its new size to the board settings. A fixed-width column changes by
+// columnResizeStep cells and a flexible one by one weight; narrowing a
+// flexible column of weight 1 doubles the others' weights instead. A change
+// the layout can't honour, because some column would be squeezed too far,
+// is undone.
+func (m *Model) resizeColumn(delta int) (tea.Model, tea.Cmd) {
+	if m.activeColumn >= len(m.columns) {
+		return m, nil
+	}
+	if len(m.boardLayout().slots) < 2 {
+		m.notify("Columns can be resized while several are on screen")
+		return m, nil
+	}
+	col := m.columns[m.activeColumn]
+	before := m.visibleColumnWidths()
+	saved := maps.Clone(m.config.Defaults.Columns)
+
+	if l := m.config.ColumnLayout(col.ID); l.Width > 0 {
+		l.Width = max(l.Width+delta*columnResizeStep, minColumnWidth)
+		m.config.SetColumnLayout(col.ID, l)
+	} else {
+		weights := make(map[string]int)
+		for _, c := range m.columns {
+			if cl := m.config.ColumnLayout(c.ID); cl.Width == 0 {
+				weights[c.ID] = max(cl.Weight, 1)
+			}
+		}
+		if delta < 0 && weights[col.ID] == 1 {
+			for id := range weights {
+				weights[id] *= 2
+			}
+		}
+		weights[col.ID] += delta
+		divisor := 0
+		for _, w := range weights {
+			divisor = gcd(divisor, w)
+		}
+		for id, w := range weights {
+			cl := m.config.ColumnLayout(id)
+			cl.Weight = w / divisor
+			if cl.Weight == 1 {
+				cl.Weight = 0
+			}
+			m.config.SetColumnLayout(id, cl)
+		}
+	}
+
+	after := m.visibleColumnWidths()
+	if !resized(before, after, m.activeColumn, delta) {
+		m.config.Defaults.Columns = saved
+		if delta > 0 {
+			m.notify(col.Name + " can't get any wider")
+		} else {
+			m.notify(col.Name + " can't get any narrower")
+		}
+		return m, nil
+	}
+	clear(m.ticketHeights)
+	if err := m.config.Save(""); err != nil {
+		m.notifyError("Failed to save config: " + err.Error())
+		return m, nil
+	}
+	m.notify(fmt.Sprintf("%s is %d cells wide", col.Name, after[m.activeColumn]))
+	return m, nil
+}
+
+// visibleColumnWidths maps each on-screen column to its current width.
+func (m *Model) visibleColumnWidths() map[int]int {
+	widths := make(map[int]int)
+	for _, slot := range m.boardLayout().slots {
+		widths[slot.column] = slot.width
+	}
+	return widths
+}
+
+// resized reports whether column grew (delta 1) or shrank (delta -1) at the
+// expense of the others. Falling back to equal widths moves some other
+// column the same way, or leaves every width as it was.
+func resized(before, after map[int]int, column, delta int) bool {
+	for col, w := range after {
+		change := (w - before[col]) * delta
+		if col == column && change <= 0 || col != column && change > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// nudgeTicket moves the selected ticket up or down its column. Only manually
+// sorted columns can be rearranged; the new order is stored as positions on
+// every ticket with that status, including ones hidden by the filter.
+func (m *Model) nudgeTicket(delta int) (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		return m, nil
+	}
+	col := m.columns[m.activeColumn]
+	if mode := m.columnSort(col); mode != board.SortManual {
+		m.notify(col.Name + " is sorted by " + string(mode) + "; press o for manual")
+		return m, nil
+	}
+	visible := m.columnTickets[m.activeColumn]
+	target := m.activeTicket + delta
+	if target < 0 || target >= len(visible) {
+		return m, nil
+	}
+	neighbor := visible[target]
+
+	all := m.globalStore.GetByStatus(col.Status)
+	board.SortTickets(all, board.SortManual)
+	all = slices.DeleteFunc(all, func(t *board.Ticket) bool { return t.ID == ticket.ID })
+	at := slices.IndexFunc(all, func(t *board.Ticket) bool { return t.ID == neighbor.ID })
+	if delta > 0 {
+		at++
+	}
+	all = slices.Insert(all, at, ticket)
+	for i, t := range all {
+		t.Position = i + 1
+	}
+
+	m.saveAll()
+	m.refreshColumnTickets()
+	m.selectTicketByID(ticket.ID)
+	return m, nil
+}
@@ -0,0 +1,251 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/git"
+)
+
+// gitSummaryTTL is how long the split view trusts a ticket's git summary
+// before reading it again.
+const gitSummaryTTL = 15 * time.Second
+
+// gitSummary is what a ticket's branch holds, as last read for the split
+// view.
+type gitSummary struct {
+	fetched time.Time
+	loaded  bool
+	base    string
+	ahead   int
+	dirty   bool
+	stat    string
+	err     error
+}
+
+// gitSummaryMsg delivers a ticket's git summary read in the background.
+type gitSummaryMsg struct {
+	ticketID board.TicketID
+	summary  gitSummary
+}
+
+// showSplitView reports whether the detail panel is beside the board. It
+// only fits the wide layout; narrower screens keep the whole width for the
+// columns.
+func (m *Model) showSplitView() bool {
+	return m.splitView && m.layoutMode() == layoutWide
+}
+
+// splitWidth is the width the detail panel takes from the board: the right
+// third of the screen.
+func (m *Model) splitWidth() int {
+	if !m.showSplitView() {
+		return 0
+	}
+	return m.width / 3
+}
+
+func (m *Model) toggleSplitView() {
+	m.splitView = !m.splitView
+	m.refreshPreview()
+	if !m.showSplitView() && m.splitView {
+		m.notify("Split view needs a wider terminal")
+	}
+}
+
+// refreshGitSummary reads the selected ticket's branch in the background
+// when the split view shows it and the last reading is stale. Git runs off
+// the render path, so moving through tickets stays quick. While automation
+// is paused, the last reading stands.
+func (m *Model) refreshGitSummary() tea.Cmd {
+	if !m.showSplitView() || m.automationPaused {
+		return nil
+	}
+	ticket := m.selectedTicket()
+	if ticket == nil || ticket.BranchName == "" {
+		return nil
+	}
+	proj := m.globalStore.GetProjectForTicket(ticket)
+	if proj == nil {
+		return nil
+	}
+	mgr := m.worktreeMgrs[proj.ID]
+	if mgr == nil {
+		return nil
+	}
+	cached := m.gitSummaries[ticket.ID]
+	if time.Since(cached.fetched) < gitSummaryTTL {
+		return nil
+	}
+	// Mark it read now so the keys pressed meanwhile don't start another.
+	cached.fetched = time.Now()
+	m.gitSummaries[ticket.ID] = cached
+
+	ticketID, repoPath := ticket.ID, proj.RepoPath
+	branch, base, worktree := ticket.BranchName, ticket.BaseBranch, ticket.WorktreePath
+	return func() tea.Msg {
+		s := gitSummary{fetched: time.Now(), loaded: true, base: base}
+		if s.base == "" {
+			s.base, _ = mgr.GetDefaultBranch()
+		}
+		if !mgr.BranchExists(branch) {
+			s.err = fmt.Errorf("branch %s not found", branch)
+			return gitSummaryMsg{ticketID: ticketID, summary: s}
+		}
+		var commits []string
+		if commits, s.err = git.Commits(repoPath, s.base, branch); s.err == nil {
+			s.ahead = len(commits)
+			var stat string
+			if stat, s.err = git.DiffStat(repoPath, s.base, branch, 80); stat != "" {
+				lines := strings.Split(stat, "\n")
+				s.stat = strings.TrimSpace(lines[len(lines)-1])
+			}
+		}
+		if worktree != "" {
+			s.dirty, _ = mgr.HasUncommittedChanges(worktree)
+		}
+		return gitSummaryMsg{ticketID: ticketID, summary: s}
+	}
+}
+
+// splitAgentLines shows the ticket's agent and what it is doing.
+func (m *Model) splitAgentLines(ticket *board.Ticket, innerWidth int) []string {
+	c := cardContext{ticket: ticket, status: ticket.AgentStatus, width: innerWidth, busy: m.busyLabel(ticket.ID)}
+	if ticket.AgentType == "" && c.busy == "" {
+		return nil
+	}
+	line := strings.TrimSpace(m.cardElement("agent", c) + " " + m.cardElement("status", c))
+	lines := []string{line}
+	if message := m.cardElement("message", c); message != "" {
+		lines = append(lines, message)
+	}
+	return lines
+}
+
+// splitChecklistLines shows how far the description's task list has got.
+// The items themselves are in the description below.
+func (m *Model) splitChecklistLines(ticket *board.Ticket, innerWidth int) []string {
+	items, _ := agent.SplitChecklist(ticket.Description)
+	if len(items) == 0 {
+		return nil
+	}
+	done := 0
+	for _, item := range items {
+		if item.Done {
+			done++
+		}
+	}
+	count := fmt.Sprintf(" %d/%d", done, len(items))
+	barWidth := min(max(innerWidth-lipgloss.Width(count)-10, 4), 20)
+	filled := barWidth * done / len(items)
+	color := m.colors.warning
+	if done == len(items) {
+		color = m.colors.success
+	}
+	bar := lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(m.colors.surface).Render(strings.Repeat("░", barWidth-filled))
+	return []string{lipgloss.NewStyle().Foreground(m.colors.subtext).Render("Checklist ") + bar +
+		lipgloss.NewStyle().Foreground(m.colors.text).Render(count)}
+}
+
+// splitGitLines summarizes the ticket's branch against its base: commits
+// ahead, uncommitted work in the worktree, and the size of the change.
+func (m *Model) splitGitLines(ticket *board.Ticket) []string {
+	if ticket.BranchName == "" {
+		return nil
+	}
+	textStyle := lipgloss.NewStyle().Foreground(m.colors.text)
+	subStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+
+	s := m.gitSummaries[ticket.ID]
+	head := textStyle.Render(ticket.BranchName)
+	if s.base != "" {
+		head += subStyle.Render(" → " + s.base)
+	}
+	lines := []string{head}
+	switch {
+	case !s.loaded:
+		lines = append(lines, m.dimStyle().Italic(true).Render("Reading branch..."))
+	case s.err != nil:
+		lines = append(lines, lipgloss.NewStyle().Foreground(m.colors.err).Render(s.err.Error()))
+	default:
+		ahead := fmt.Sprintf("%d commits ahead", s.ahead)
+		if s.ahead == 1 {
+			ahead = "1 commit ahead"
+		}
+		line := subStyle.Render(ahead)
+		if s.dirty {
+			line += subStyle.Render(" · ") + lipgloss.NewStyle().Foreground(m.colors.warning).Render("uncommitted changes")
+		}
+		lines = append(lines, line)
+		if s.stat != "" {
+			lines = append(lines, subStyle.Render(s.stat))
+		}
+	}
+	return lines
+}
+
+// renderSplitPanel shows the selected ticket beside the board: its agent's
+// status and latest output, checklist progress, and branch, then the
+// fields, description, and comments the detail view shows.
+func (m *Model) renderSplitPanel() string {
+	width := m.splitWidth()
+	innerWidth := max(width-3, 10)
+	height := m.height - m.headerHeight() - 1 - m.previewHeight()
+
+	var lines []string
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		lines = append(lines, m.dimStyle().Italic(true).Render("No ticket selected"))
+	} else {
+		titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true).Width(innerWidth)
+		sectionStyle := lipgloss.NewStyle().Foreground(m.colors.secondary).Bold(true)
+
+		lines = append(lines, strings.Split(titleStyle.Render("◈ "+ticket.Title), "\n")...)
+		lines = append(lines, "")
+		if agentLines := m.splitAgentLines(ticket, innerWidth); agentLines != nil {
+			lines = append(lines, sectionStyle.Render("Agent"))
+			lines = append(lines, agentLines...)
+			if m.preview.ticketID == ticket.ID && m.preview.lines != nil {
+				textStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
+				for _, line := range m.preview.lines {
+					lines = append(lines, textStyle.Render(line))
+				}
+			}
+			lines = append(lines, "")
+		}
+		if checklist := m.splitChecklistLines(ticket, innerWidth); checklist != nil {
+			lines = append(lines, checklist...)
+			lines = append(lines, "")
+		}
+		if gitLines := m.splitGitLines(ticket); gitLines != nil {
+			lines = append(lines, sectionStyle.Render("Git"))
+			lines = append(lines, gitLines...)
+			lines = append(lines, "")
+		}
+		lines = append(lines, m.ticketInfoLines(ticket, innerWidth)...)
+	}
+
+	if len(lines) > height {
+		lines = append(lines[:height-1], m.dimStyle().Render("… i for full details"))
+	}
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, innerWidth, "…")
+	}
+
+	return lipgloss.NewStyle().
+		Width(width - 1).
+		Height(height).
+		PaddingLeft(1).
+		BorderLeft(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(m.colors.surface).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// findSprint resolves a sprint by name prefix or ID; an empty name means the
+// sprint running today.
+func (m *Model) findSprint(name string) *board.Sprint {
+	if m.sprints == nil {
+		return nil
+	}
+	if name == "" {
+		return m.sprints.Current(time.Now())
+	}
+	sprint, _ := m.sprints.Find(name)
+	return sprint
+}
+
+func (m *Model) sprintFor(ticket *board.Ticket) *board.Sprint {
+	if m.sprints == nil || ticket.SprintID == "" {
+		return nil
+	}
+	return m.sprints.Get(ticket.SprintID)
+}
+
+// assignSprint handles ":sprint <name>". Without a name the selected ticket
+// leaves its sprint.
+func (m *Model) assignSprint(name string) (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+
+	if name == "" {
+		if ticket.SprintID == "" {
+			m.notify("Ticket is not in a sprint")
+			return m, nil
+		}
+		ticket.SprintID = ""
+		ticket.Record(board.EventEdited, "sprint")
+		ticket.Touch()
+		m.saveTicket(ticket)
+		m.refreshColumnTickets()
+		m.notifySuccess("Removed from sprint: " + ticket.Title)
+		return m, nil
+	}
+
+	if m.sprints == nil {
+		m.notify("No sprints loaded")
+		return m, nil
+	}
+	sprint, err := m.sprints.Find(name)
+	if err != nil {
+		m.notifyError(fmt.Sprintf("%s: %s", err, name))
+		return m, nil
+	}
+
+	ticket.SprintID = sprint.ID
+	ticket.Record(board.EventEdited, "sprint")
+	ticket.Touch()
+	m.saveTicket(ticket)
+	m.refreshColumnTickets()
+	m.selectTicketByID(ticket.ID)
+	m.notifySuccess("Added to " + sprint.Name)
+	return m, nil
+}
+
+// createSprint handles ":sprint-new <name> [days]"; the sprint starts today.
+func (m *Model) createSprint(args string) (tea.Model, tea.Cmd) {
+	if m.sprints == nil {
+		m.notify("No sprints loaded")
+		return m, nil
+	}
+
+	name := args
+	length := board.DefaultSprintLength
+	if i := strings.LastIndex(args, " "); i > 0 {
+		if days, err := strconv.Atoi(args[i+1:]); err == nil && days > 0 {
+			name = strings.TrimSpace(args[:i])
+			length = time.Duration(days) * 24 * time.Hour
+		}
+	}
+	if name == "" {
+		m.notify("Usage: sprint-new <name> [days]")
+		return m, nil
+	}
+
+	start := time.Now()
+	sprint, err := m.sprints.Add(name, start, start.Add(length-24*time.Hour))
+	if err != nil {
+		m.notifyError("Failed to create sprint: " + err.Error())
+		return m, nil
+	}
+	m.notifySuccess(fmt.Sprintf("Created %s (until %s)", sprint.Name, sprint.End.Format("Jan 2")))
+	return m, nil
+}
+
+// headerSprint is the sprint summarized in the header: the one the filter
+// narrows to, otherwise the sprint running today.
+func (m *Model) headerSprint() *board.Sprint {
+	for _, field := range strings.Fields(m.filterQuery) {
+		if strings.HasPrefix(field, "+") {
+			return m.findSprint(strings.TrimPrefix(field, "+"))
+		}
+	}
+	return m.findSprint("")
+}
+
+func (m *Model) renderSprintSummary() string {
+	sprint := m.headerSprint()
+	if sprint == nil {
+		return ""
+	}
+
+	p := board.SummarizeProgress(m.globalStore.GetBySprint(sprint.ID))
+	now := time.Now()
+	var remaining string
+	switch {
+	case sprint.Active(now):
+		remaining = fmt.Sprintf("%dd left", sprint.DaysLeft(now))
+	case now.Before(sprint.Start):
+		remaining = "starts " + sprint.Start.Format("Jan 2")
+	default:
+		remaining = "ended"
+	}
+
+	name := lipgloss.NewStyle().Foreground(m.colors.info).Bold(true).Render("⏱ " + sprint.Name)
+	return name + m.dimStyle().Render(fmt.Sprintf(" %d/%d done · %s", p.Done, p.Total, remaining))
+}
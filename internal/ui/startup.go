@@ -0,0 +1,263 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/terminal"
+)
+
+// defaultFirstOutputTimeout is how long a spawned agent may stay silent
+// before it is treated as having failed to start.
+const defaultFirstOutputTimeout = 30 * time.Second
+
+// firstOutputTimeoutMsg fires when a spawned agent's first-output window
+// closes.
+type firstOutputTimeoutMsg struct {
+	ticketID board.TicketID
+	pane     *terminal.Pane
+	timeout  time.Duration
+}
+
+func (m *Model) firstOutputTimeout(agentType string) time.Duration {
+	if secs := m.config.Agents[agentType].FirstOutputTimeout; secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return defaultFirstOutputTimeout
+}
+
+// watchFirstOutput schedules the check for an agent that never prints
+// anything, such as one blocked on a login it can't show.
+func (m *Model) watchFirstOutput(ticketID board.TicketID, pane *terminal.Pane, agentType string) tea.Cmd {
+	timeout := m.firstOutputTimeout(agentType)
+	return tea.Tick(timeout, func(time.Time) tea.Msg {
+		return firstOutputTimeoutMsg{ticketID: ticketID, pane: pane, timeout: timeout}
+	})
+}
+
+// handleFirstOutputTimeout fails the spawn if the agent is still silent.
+// Agents that have printed something are left alone.
+func (m *Model) handleFirstOutputTimeout(msg firstOutputTimeoutMsg) (tea.Model, tea.Cmd) {
+	if _, starting := m.starting[msg.ticketID]; !starting || m.panes[msg.ticketID] != msg.pane {
+		return m, nil
+	}
+	return m.failAgentStart(msg.ticketID, fmt.Sprintf("no output after %s", msg.timeout))
+}
+
+// exitedDuringStartup reports whether an agent exited inside its
+// first-output window, which for an interactive agent means it never really
+// started: a bad API key or missing login usually prints an error and quits.
+func (m *Model) exitedDuringStartup(ticket *board.Ticket) bool {
+	run := ticket.CurrentAgentRun()
+	return run != nil && time.Since(run.StartedAt) < m.firstOutputTimeout(run.Agent)
+}
+
+// failAgentStart stops an agent that failed to start and marks its ticket
+// AgentError, keeping the tail of its output as the reason.
+func (m *Model) failAgentStart(ticketID board.TicketID, reason string) (tea.Model, tea.Cmd) {
+	pane := m.panes[ticketID]
+	snippet := reason
+	if pane != nil {
+		if out := agent.OutputSnippet(pane.GetContent(), 3); out != "" {
+			snippet = out
+		}
+		if pane.Running() {
+			pane.Stop()
+		}
+	}
+
+	if ticket, _ := m.globalStore.Get(ticketID); ticket != nil {
+		run := ticket.CurrentAgentRun()
+		m.finishAgentRun(ticket, pane, board.RunError)
+		if run != nil {
+			run.StartupError = snippet
+		}
+		ticket.AgentStatus = board.AgentError
+		// The prompt never reached the agent; send it again next time.
+		ticket.AgentSpawnedAt = nil
+		m.saveTicket(ticket)
+	}
+	m.agentMessages[ticketID] = snippet
+	delete(m.panes, ticketID)
+
+	m.endSpawn(ticketID)
+	if m.focusedPane == ticketID {
+		m.mode = ModeNormal
+		m.focusedPane = ""
+	}
+	m.notifyError("Agent failed to start: " + snippet)
+	if ticket, _ := m.globalStore.Get(ticketID); ticket != nil && !ticket.Muted {
+		m.alert(config.AlertError)
+	}
+
+	if ticketID == m.batchTicketID {
+		return m.finishQueuedSpawn(false)
+	}
+	return m, nil
+}
+
+// handleSpawnReady starts the agent once its worktree and command are
+// ready. A spawn cancelled in the meantime is dropped.
+func (m *Model) handleSpawnReady(msg spawnReadyMsg) (tea.Model, tea.Cmd) {
+	agentName, ok := m.starting[msg.ticketID]
+	if !ok {
+		return m, nil
+	}
+
+	ticket, _ := m.globalStore.Get(msg.ticketID)
+	if ticket != nil {
+		ticket.AgentType = agentName
+		ticket.AgentStatus = board.AgentNone
+		if ticket.AgentSpawnedAt == nil {
+			now := time.Now()
+			ticket.AgentSpawnedAt = &now
+		}
+		if msg.worktreePath != "" && ticket.WorktreePath == "" {
+			ticket.WorktreePath = msg.worktreePath
+			ticket.BranchName = msg.branchName
+			ticket.BaseBranch = msg.baseBranch
+		}
+		ticket.StartAgentRun(agentName)
+		agent.ClaimRun(ticket.CurrentAgentRun())
+		m.beginAgentTrace(ticket, msg.span)
+		countAgentSpawn(agentName)
+		m.startSessionLog(ticket, msg.pane)
+		m.saveTicket(ticket)
+	}
+
+	m.panes[msg.ticketID] = msg.pane
+	delete(m.agentMessages, msg.ticketID)
+	start := msg.pane.Start(msg.command, msg.args...)
+	if msg.pane.Headless() {
+		// A headless agent may print nothing until it's done, so there is
+		// no first output to wait for.
+		return m, tea.Batch(start, m.agentStarted(msg.ticketID))
+	}
+	watch := m.watchFirstOutput(msg.ticketID, msg.pane, agentName)
+	if ticket != nil && ticket.AgentType == "opencode" {
+		return m, tea.Batch(start, watch, registerOpencodeSession(ticket, msg.worktreePath))
+	}
+	return m, tea.Batch(start, watch)
+}
+
+// handleSpawnError reports a spawn that failed before the agent ran.
+func (m *Model) handleSpawnError(msg spawnErrorMsg) (tea.Model, tea.Cmd) {
+	if _, ok := m.starting[msg.ticketID]; !ok {
+		return m, nil
+	}
+	m.endSpawn(msg.ticketID)
+	m.notifyError(msg.err)
+	if msg.ticketID == m.batchTicketID {
+		return m.finishQueuedSpawn(false)
+	}
+	return m, nil
+}
+
+// agentStarted ends the startup of an agent that has printed its first
+// output. The agent opens full screen if it was spawned from the board and
+// its ticket is still selected; otherwise, or if it is headless, it is left
+// running on its card.
+func (m *Model) agentStarted(ticketID board.TicketID) tea.Cmd {
+	agentName := m.starting[ticketID]
+	attach := m.attachOnStart == ticketID
+	headless := m.panes[ticketID] != nil && m.panes[ticketID].Headless()
+	m.endSpawn(ticketID)
+	m.endAgentStartup(ticketID)
+
+	if attach {
+		if selected := m.selectedTicket(); !headless && m.mode == ModeNormal && !m.showConfirm && selected != nil && selected.ID == ticketID {
+			m.attachToAgent()
+		} else if ticket, _ := m.globalStore.Get(ticketID); ticket != nil {
+			m.notifySuccess(fmt.Sprintf("%s started on %s", agentName, truncateString(ticket.Title, 40)))
+		}
+	}
+	if ticketID == m.batchTicketID {
+		_, cmd := m.finishQueuedSpawn(true)
+		return cmd
+	}
+	return nil
+}
+
+// cancelSpawn stops an agent that hasn't started yet.
+func (m *Model) cancelSpawn(ticket *board.Ticket) (tea.Model, tea.Cmd) {
+	if pane, ok := m.panes[ticket.ID]; ok {
+		m.finishAgentRun(ticket, pane, board.RunStopped)
+		pane.Stop()
+		delete(m.panes, ticket.ID)
+		ticket.AgentStatus = board.AgentNone
+		m.saveTicket(ticket)
+	}
+	m.endSpawn(ticket.ID)
+	m.notify("Spawn cancelled")
+	if ticket.ID == m.batchTicketID {
+		return m.finishQueuedSpawn(false)
+	}
+	return m, nil
+}
+
+// endSpawn forgets a spawn that has finished starting, one way or another.
+func (m *Model) endSpawn(ticketID board.TicketID) {
+	delete(m.starting, ticketID)
+	if m.attachOnStart == ticketID {
+		m.attachOnStart = ""
+	}
+}
+
+// exitReason explains an agent exiting with an error: its last lines of
+// output, or failing that the exit status.
+func exitReason(pane *terminal.Pane, err error) string {
+	if pane != nil {
+		if out := agent.OutputSnippet(pane.GetContent(), 3); out != "" {
+			return out
+		}
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// lostSessionMessage is shown on the card of a ticket whose session was lost.
+const lostSessionMessage = "Session lost when openkanban last exited"
+
+// recoverLostSessions closes the runs left open by an openkanban that
+// crashed, was killed, or went down with the machine while its agents ran,
+// recording them as failed and showing the tickets' agents as errored
+// rather than quietly idle. With respawn_lost_agents they are queued to
+// respawn, resuming their sessions, once the board has its size.
+func (m *Model) recoverLostSessions() {
+	var lost []*board.Ticket
+	for _, ticket := range m.globalStore.All() {
+		run := ticket.CurrentAgentRun()
+		if run == nil || !agent.SessionLost(run) {
+			continue
+		}
+		ticket.EndAgentRun(board.RunError, 0)
+		ticket.AgentStatus = board.AgentError
+		m.agentMessages[ticket.ID] = lostSessionMessage
+		m.saveTicket(ticket)
+		lost = append(lost, ticket)
+	}
+	if len(lost) == 0 {
+		return
+	}
+
+	if m.config.Behavior.RespawnLostAgents {
+		for _, ticket := range lost {
+			m.spawnQueue = append(m.spawnQueue, ticket.ID)
+		}
+		m.notify(fmt.Sprintf("Respawning %d agent(s) lost when openkanban last exited", len(lost)))
+		return
+	}
+	respawn := m.keymap.label("spawn_agent") + " respawns"
+	if len(lost) == 1 {
+		m.notifyError("Agent session lost: " + lost[0].Title + " — " + respawn)
+	} else {
+		m.notifyError(fmt.Sprintf("%d agent sessions lost when openkanban last exited — %s", len(lost), respawn))
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/project"
+	"github.com/techdufus/openkanban/internal/terminal"
+)
+
+func TestStaleExitKeepsNewAgent(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("OPENKANBAN_CONFIG_DIR", dir)
+
+	registry := &project.ProjectRegistry{}
+	store := project.NewGlobalTicketStore(registry)
+	proj := project.NewProject("proj", dir)
+	store.AddProject(proj)
+	ticket := board.NewTicket("Login", proj.ID)
+	if err := store.Add(ticket); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultConfig(), store, registry, nil, nil, nil, "", nil)
+
+	// The reviewer's pane is registered and starting when the exit of the
+	// pane it replaced arrives.
+	retired := terminal.New(string(ticket.ID), 80, 24, 100)
+	current := terminal.New(string(ticket.ID), 80, 24, 100)
+	m.panes[ticket.ID] = current
+	m.starting[ticket.ID] = "claude"
+
+	m.Update(terminal.ExitMsg{PaneID: string(ticket.ID), Pane: retired})
+
+	if m.panes[ticket.ID] != current {
+		t.Error("the retired pane's exit removed its replacement")
+	}
+	if _, ok := m.starting[ticket.ID]; !ok {
+		t.Error("the retired pane's exit failed its replacement's start")
+	}
+
+	m.Update(terminal.ExitMsg{PaneID: string(ticket.ID), Pane: current})
+	if _, ok := m.panes[ticket.ID]; ok {
+		t.Error("the current pane's exit was ignored")
+	}
+}
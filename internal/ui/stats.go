@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// heatmapWeeks is how far back the activity heatmap goes, about six months.
+const heatmapWeeks = 26
+
+// heatmapMetric is what the activity heatmap counts.
+type heatmapMetric int
+
+const (
+	metricAll heatmapMetric = iota
+	metricCompleted
+	metricRuns
+)
+
+var heatmapMetricNames = []string{"All activity", "Tickets completed", "Agent runs"}
+
+func (metric heatmapMetric) count(d board.DayActivity) int {
+	switch metric {
+	case metricCompleted:
+		return d.Completed
+	case metricRuns:
+		return d.Runs
+	}
+	return d.Total()
+}
+
+// heatmapLevels shade a day from least to most active.
+var heatmapLevels = []string{"░", "▒", "▓", "█"}
+
+func (m *Model) openStats() (tea.Model, tea.Cmd) {
+	m.statsMetric = metricAll
+	m.mode = ModeStats
+	return m, nil
+}
+
+func (m *Model) handleStatsMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = ModeNormal
+	case "tab", "l", "right":
+		m.statsMetric = (m.statsMetric + 1) % heatmapMetric(len(heatmapMetricNames))
+	case "shift+tab", "h", "left":
+		m.statsMetric = (m.statsMetric + heatmapMetric(len(heatmapMetricNames)) - 1) % heatmapMetric(len(heatmapMetricNames))
+	}
+	return m, nil
+}
+
+func (m *Model) renderStats() string {
+	width := min(100, m.width-4)
+	width = max(width, 50)
+	innerWidth := width - 6
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.colors.primary).Bold(true)
+	sectionStyle := lipgloss.NewStyle().Foreground(m.colors.secondary).Bold(true)
+
+	now := time.Now()
+	weeks := min(heatmapWeeks, (innerWidth-4)/2)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	start := today.AddDate(0, 0, -int(today.Weekday())-(weeks-1)*7)
+	activity := board.DailyActivity(m.globalStore.All(), start)
+
+	lines := []string{
+		titleStyle.Render("Stats") + m.dimStyle().Render(fmt.Sprintf("  last %d weeks", weeks)),
+		"",
+		sectionStyle.Render(heatmapMetricNames[m.statsMetric]),
+	}
+	lines = append(lines, m.renderHeatmap(activity, start, today, weeks)...)
+	lines = append(lines, "", m.activitySummary(activity, start, today))
+
+	if stats := agent.ComputeStats(m.globalStore.All()); len(stats) > 0 {
+		lines = append(lines, "", sectionStyle.Render("Agents"))
+		for _, s := range stats {
+			lines = append(lines, truncateString(fmt.Sprintf("  %-10s %4d runs  %3.0f%% completed  %3.0f%% errors  avg %s",
+				s.Agent, s.Runs, s.CompletionRate()*100, s.ErrorRate()*100, formatDuration(s.AverageRuntime())), innerWidth))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, m.dimStyle().Render("[Tab] Switch count  [Esc] Close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.colors.primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderHeatmap draws a GitHub-style grid with a column per week, starting
+// on Sunday, shaded by the selected metric relative to the busiest day.
+func (m *Model) renderHeatmap(activity board.Activity, start, today time.Time, weeks int) []string {
+	most := 0
+	for d := start; !d.After(today); d = d.AddDate(0, 0, 1) {
+		most = max(most, m.statsMetric.count(activity.On(d)))
+	}
+
+	cellStyle := lipgloss.NewStyle().Foreground(m.colors.success)
+	emptyCell := m.dimStyle().Render("·")
+
+	// Label each month over its first week; a month cut short at the left
+	// edge gives way to the next one.
+	months := []byte(strings.Repeat(" ", 4+weeks*2+1))
+	labelAt := -4
+	for w := 0; w < weeks; w++ {
+		first := start.AddDate(0, 0, w*7)
+		if w > 0 && first.Month() == first.AddDate(0, 0, -7).Month() {
+			continue
+		}
+		pos := 4 + w*2
+		if pos < labelAt+4 {
+			copy(months[labelAt:], "   ")
+		}
+		copy(months[pos:], first.Month().String()[:3])
+		labelAt = pos
+	}
+	lines := []string{m.dimStyle().Render(strings.TrimRight(string(months), " "))}
+
+	dayLabels := []string{"", "Mon", "", "Wed", "", "Fri", ""}
+	for weekday := 0; weekday < 7; weekday++ {
+		var row strings.Builder
+		row.WriteString(m.dimStyle().Render(fmt.Sprintf("%-4s", dayLabels[weekday])))
+		for w := 0; w < weeks; w++ {
+			d := start.AddDate(0, 0, w*7+weekday)
+			if d.After(today) {
+				break
+			}
+			n := m.statsMetric.count(activity.On(d))
+			if n == 0 {
+				row.WriteString(emptyCell + " ")
+				continue
+			}
+			level := (n*len(heatmapLevels) - 1) / most
+			row.WriteString(cellStyle.Render(heatmapLevels[level]) + " ")
+		}
+		lines = append(lines, row.String())
+	}
+
+	legend := m.dimStyle().Render("    Less ") + emptyCell
+	for _, l := range heatmapLevels {
+		legend += " " + cellStyle.Render(l)
+	}
+	lines = append(lines, legend+m.dimStyle().Render(" More"))
+	return lines
+}
+
+// activitySummary totals the period and names its busiest day and the
+// current run of active days.
+func (m *Model) activitySummary(activity board.Activity, start, today time.Time) string {
+	var completed, runs, busiest int
+	var busiestDay time.Time
+	for d := start; !d.After(today); d = d.AddDate(0, 0, 1) {
+		day := activity.On(d)
+		completed += day.Completed
+		runs += day.Runs
+		if n := m.statsMetric.count(day); n > busiest {
+			busiest, busiestDay = n, d
+		}
+	}
+	if completed+runs == 0 {
+		return m.dimStyle().Italic(true).Render("No tickets completed or agents run yet")
+	}
+
+	streak := 0
+	d := today
+	if m.statsMetric.count(activity.On(d)) == 0 {
+		d = d.AddDate(0, 0, -1) // today isn't over yet
+	}
+	for ; !d.Before(start) && m.statsMetric.count(activity.On(d)) > 0; d = d.AddDate(0, 0, -1) {
+		streak++
+	}
+
+	parts := []string{fmt.Sprintf("%d completed, %d agent runs", completed, runs)}
+	if busiest > 0 {
+		parts = append(parts, fmt.Sprintf("busiest %s (%d)", busiestDay.Format("Jan 2"), busiest))
+	}
+	parts = append(parts, fmt.Sprintf("streak %d day(s)", streak))
+	return strings.Join(parts, m.dimStyle().Render(" · "))
+}
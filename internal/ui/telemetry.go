@@ -0,0 +1,15 @@
+package ui
+
+import (
+	"github.com/techdufus/openkanban/internal/config"
+	"github.com/techdufus/openkanban/internal/telemetry"
+)
+
+// countAgentSpawn counts an agent start for the usage report. Agents users
+// configured themselves are counted together, since their names are theirs.
+func countAgentSpawn(agentType string) {
+	if !config.IsBuiltinAgent(agentType) {
+		agentType = "custom"
+	}
+	telemetry.Count("agent." + agentType)
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/techdufus/openkanban/internal/agent"
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/git"
+	"github.com/techdufus/openkanban/internal/terminal"
+)
+
+// pasteSubmitDelay separates a paste from the Enter that submits it; sent
+// together, some agents take the Enter as part of the paste.
+const pasteSubmitDelay = 150 * time.Millisecond
+
+// syncTicketFile rewrites TICKET.md in the ticket's worktree when
+// behavior.ticket_file is on, so it follows edits to the ticket. Tickets
+// worked on in the main checkout get none, and while automation is paused
+// the files wait for it to resume.
+func (m *Model) syncTicketFile(ticket *board.Ticket) {
+	if !m.config.Behavior.TicketFile || m.automationPaused || !ticket.UseWorktree || ticket.WorktreePath == "" {
+		return
+	}
+	proj := m.globalStore.GetProjectForTicket(ticket)
+	if proj == nil || filepath.Clean(ticket.WorktreePath) == filepath.Clean(proj.RepoPath) {
+		return
+	}
+	if info, err := os.Stat(ticket.WorktreePath); err != nil || !info.IsDir() {
+		return
+	}
+	err := writeTicketFile(m.worktreeMgrs[proj.ID], ticket.WorktreePath, m.config.Behavior.TicketFileTemplate, m.promptContext(ticket, proj))
+	if err != nil {
+		m.notifyError("TICKET.md not updated: " + err.Error())
+	}
+}
+
+// writeTicketFile renders TICKET.md into a worktree. The first time, the
+// file is added to the repository's excludes so agents don't commit it.
+func writeTicketFile(mgr *git.WorktreeManager, dir, tmpl string, ctx agent.PromptContext) error {
+	content, err := agent.RenderTicketFile(tmpl, ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(dir, agent.TicketFileName)); os.IsNotExist(err) && mgr != nil {
+		if err := mgr.ExcludeFile(agent.TicketFileName); err != nil {
+			return err
+		}
+	}
+	return agent.WriteTicketFile(dir, content)
+}
+
+// refreshAgentContext brings the selected ticket's agent up to date after
+// the ticket was edited: TICKET.md is rewritten, and a running agent is sent
+// its init prompt again, rendered from the ticket as it is now.
+func (m *Model) refreshAgentContext() (tea.Model, tea.Cmd) {
+	ticket := m.selectedTicket()
+	if ticket == nil {
+		m.notify("No ticket selected")
+		return m, nil
+	}
+	m.syncTicketFile(ticket)
+
+	pane, ok := m.panes[ticket.ID]
+	if !ok || !pane.Running() {
+		m.notify("No agent running for this ticket")
+		return m, nil
+	}
+	proj := m.globalStore.GetProjectForTicket(ticket)
+	prompt := agent.BuildRefreshPrompt(m.initPromptFor(ticket, ticket.AgentType), m.promptContext(ticket, proj))
+	if m.config.Behavior.TicketFile && ticket.UseWorktree {
+		prompt += "\n\n" + agent.TicketFileName + " in your working directory has been updated to match."
+	}
+	if err := pane.Paste(prompt); err != nil {
+		m.notifyError("Can't reach the agent: " + err.Error())
+		return m, nil
+	}
+	m.notifySuccess("Sent the updated ticket to the agent")
+	return m, submitPaste(pane)
+}
+
+// submitPaste presses Enter in the pane shortly after a paste.
+func submitPaste(pane *terminal.Pane) tea.Cmd {
+	return tea.Tick(pasteSubmitDelay, func(time.Time) tea.Msg {
+		pane.WriteInput([]byte("\r"))
+		return nil
+	})
+}
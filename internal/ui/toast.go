@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// toastLevel sets a toast's color, icon and how long it stays.
+type toastLevel int
+
+const (
+	toastInfo toastLevel = iota
+	toastSuccess
+	toastError
+)
+
+const (
+	// Errors stay up longer than other toasts so they can be read.
+	toastTimeout      = 3 * time.Second
+	errorToastTimeout = 6 * time.Second
+
+	// maxToasts caps the stack; the oldest toast gives way to a new one.
+	maxToasts = 3
+)
+
+// toast is one notification in the stack, newest last.
+type toast struct {
+	level     toastLevel
+	text      string
+	expires   time.Time
+	scheduled bool
+}
+
+// notify shows an informational toast.
+func (m *Model) notify(msg string) {
+	m.pushToast(toastInfo, msg)
+}
+
+// notifySuccess shows a toast for something that was done.
+func (m *Model) notifySuccess(msg string) {
+	m.pushToast(toastSuccess, msg)
+}
+
+// notifyError shows a toast for something that went wrong.
+func (m *Model) notifyError(msg string) {
+	m.pushToast(toastError, msg)
+}
+
+// pushToast adds a toast to the stack. Repeating the newest toast restarts
+// its timeout instead of stacking a copy.
+func (m *Model) pushToast(level toastLevel, msg string) {
+	timeout := toastTimeout
+	if level == toastError {
+		timeout = errorToastTimeout
+	}
+	t := toast{level: level, text: msg, expires: time.Now().Add(timeout)}
+
+	m.toasts = m.liveToasts(time.Now())
+	if n := len(m.toasts); n > 0 && m.toasts[n-1].level == level && m.toasts[n-1].text == msg {
+		m.toasts[n-1] = t
+		return
+	}
+	m.toasts = append(m.toasts, t)
+	if len(m.toasts) > maxToasts {
+		m.toasts = slices.Delete(m.toasts, 0, len(m.toasts)-maxToasts)
+	}
+}
+
+// clearToasts dismisses every toast.
+func (m *Model) clearToasts() {
+	m.toasts = nil
+}
+
+// liveToasts returns the toasts that haven't timed out at now.
+func (m *Model) liveToasts(now time.Time) []toast {
+	return slices.DeleteFunc(slices.Clone(m.toasts), func(t toast) bool {
+		return !now.Before(t.expires)
+	})
+}
+
+// latestToast returns the newest toast still showing.
+func (m *Model) latestToast() (toast, bool) {
+	live := m.liveToasts(time.Now())
+	if len(live) == 0 {
+		return toast{}, false
+	}
+	return live[len(live)-1], true
+}
+
+// toastTicks schedules a redraw for when each new toast times out.
+func (m *Model) toastTicks() tea.Cmd {
+	var cmds []tea.Cmd
+	for i := range m.toasts {
+		if m.toasts[i].scheduled {
+			continue
+		}
+		m.toasts[i].scheduled = true
+		cmds = append(cmds, tea.Tick(time.Until(m.toasts[i].expires), func(t time.Time) tea.Msg {
+			return notificationMsg(t)
+		}))
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderToast draws a toast as a badge in its level's color.
+func (m *Model) renderToast(t toast) string {
+	bg, icon := m.colors.info, "•"
+	switch t.level {
+	case toastSuccess:
+		bg, icon = m.colors.success, "✓"
+	case toastError:
+		bg, icon = m.colors.err, "✗"
+	}
+	return lipgloss.NewStyle().
+		Foreground(m.colors.base).
+		Background(bg).
+		Padding(0, 1).
+		Render(icon + " " + t.text)
+}
+
+// overlayToasts stacks the toasts older than the newest, which the status
+// bar shows, over the right end of the last lines of the board.
+func (m *Model) overlayToasts(view string) string {
+	live := m.liveToasts(time.Now())
+	if len(live) < 2 {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	older := live[:len(live)-1]
+	for i := range older {
+		row := len(lines) - len(older) + i
+		if row < 0 {
+			continue
+		}
+		badge := ansi.Truncate(m.renderToast(older[i]), m.width, "…")
+		left := ansi.Truncate(lines[row], m.width-lipgloss.Width(badge), "")
+		gap := max(m.width-lipgloss.Width(left)-lipgloss.Width(badge), 0)
+		lines[row] = left + strings.Repeat(" ", gap) + badge
+	}
+	return strings.Join(lines, "\n")
+}
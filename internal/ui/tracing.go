@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/techdufus/openkanban/internal/board"
+	"github.com/techdufus/openkanban/internal/tracing"
+)
+
+// agentTrace holds the spans open while an agent runs: its whole session,
+// and its startup until the first output arrives.
+type agentTrace struct {
+	session *tracing.Span
+	startup *tracing.Span
+}
+
+// beginAgentTrace starts tracing an agent run under the span that spawned
+// it.
+func (m *Model) beginAgentTrace(ticket *board.Ticket, spawn *tracing.Span) {
+	if spawn == nil {
+		return
+	}
+	m.endAgentTrace(ticket.ID, board.RunStopped, 0)
+	session := spawn.Child("agent.session",
+		tracing.String("ticket.id", string(ticket.ID)),
+		tracing.String("ticket.title", ticket.Title),
+		tracing.String("agent.name", ticket.AgentType))
+	m.agentTraces[ticket.ID] = agentTrace{session: session, startup: session.Child("agent.startup")}
+}
+
+// endAgentStartup marks the agent's first output.
+func (m *Model) endAgentStartup(ticketID board.TicketID) {
+	if t, ok := m.agentTraces[ticketID]; ok {
+		t.startup.End(nil)
+	}
+}
+
+// endAgentTrace closes the run's spans with how it ended.
+func (m *Model) endAgentTrace(ticketID board.TicketID, outcome board.RunOutcome, cost float64) {
+	t, ok := m.agentTraces[ticketID]
+	if !ok {
+		return
+	}
+	delete(m.agentTraces, ticketID)
+
+	var err error
+	if outcome == board.RunError {
+		err = errors.New("agent run failed")
+	}
+	t.startup.End(err)
+	t.session.SetAttrs(tracing.String("agent.outcome", string(outcome)))
+	if cost > 0 {
+		t.session.SetAttrs(tracing.String("agent.cost_usd", strconv.FormatFloat(cost, 'f', 4, 64)))
+	}
+	t.session.End(err)
+}
@@ -8,12 +8,24 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/techdufus/openkanban/internal/board"
 	"github.com/techdufus/openkanban/internal/config"
 )
 
 func (m *Model) View() string {
+	start := time.Now()
+	out := m.renderView()
+	// The agent view converts its own chrome; the agent's output is its own.
+	if m.mode != ModeAgentView || m.focusedPane == "" {
+		out = m.asciify(out)
+	}
+	m.renderBudget.record(time.Since(start), m.renderBudgetLimit())
+	return out
+}
+
+func (m *Model) renderView() string {
 	if m.width == 0 || m.height == 0 {
 		loadingStyle := lipgloss.NewStyle().
 			Foreground(m.colors.primary).
@@ -25,14 +37,12 @@ func (m *Model) View() string {
 		)
 	}
 
+	m.headerRegions, m.statusRegions = nil, nil
+
 	if m.mode == ModeShuttingDown {
 		return m.renderShuttingDown()
 	}
 
-	if m.mode == ModeSpawning {
-		return m.renderSpawning()
-	}
-
 	if m.mode == ModeAgentView && m.focusedPane != "" {
 		return m.renderAgentView()
 	}
@@ -44,11 +54,20 @@ func (m *Model) View() string {
 
 	sidebar := m.renderSidebar()
 	board := m.renderBoard()
+	if m.showSplitView() {
+		board = lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.PlaceHorizontal(m.boardWidth(), lipgloss.Left, board),
+			m.renderSplitPanel())
+	}
 	if sidebar != "" {
 		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, sidebar, board))
 	} else {
 		b.WriteString(board)
 	}
+	if m.showPreview {
+		b.WriteString("\n")
+		b.WriteString(m.renderPreview())
+	}
 
 	if m.showHelp {
 		return m.renderWithOverlay(m.renderHelp())
@@ -65,22 +84,74 @@ func (m *Model) View() string {
 	if m.mode == ModeCreateProject {
 		return m.renderWithOverlay(m.renderCreateProjectForm())
 	}
+	if m.mode == ModeTicketDetail {
+		return m.renderWithOverlay(m.renderTicketDetail())
+	}
+	if m.mode == ModeCustomFields {
+		return m.renderWithOverlay(m.renderCustomFields())
+	}
+	if m.mode == ModeArchive {
+		return m.renderWithOverlay(m.renderArchive())
+	}
+	if m.mode == ModeParentPicker {
+		return m.renderWithOverlay(m.renderParentPicker())
+	}
+	if m.mode == ModeBoardEditor {
+		return m.renderWithOverlay(m.renderBoardEditor())
+	}
+	if m.mode == ModeMovePicker {
+		return m.renderWithOverlay(m.renderMovePicker())
+	}
+	if m.mode == ModeAgentPicker {
+		return m.renderWithOverlay(m.renderAgentPicker())
+	}
+	if m.mode == ModeActions {
+		return m.renderWithOverlay(m.renderActions())
+	}
+	if m.mode == ModeHygiene {
+		return m.renderWithOverlay(m.renderHygiene())
+	}
+	if m.mode == ModeStats {
+		return m.renderWithOverlay(m.renderStats())
+	}
+	if m.mode == ModeLink {
+		return m.renderWithOverlay(m.renderTicketLink())
+	}
+	if m.mode == ModeSessionLog {
+		return m.renderWithOverlay(m.renderSessionLog())
+	}
+	if m.mode == ModeAttempts {
+		return m.renderWithOverlay(m.renderAttempts())
+	}
+	if m.mode == ModeLogSearch {
+		return m.renderWithOverlay(m.renderLogSearch())
+	}
+	if m.mode == ModeOutcome {
+		return m.renderWithOverlay(m.renderOutcomePicker())
+	}
 
-	b.WriteString("\n")
-	b.WriteString(m.renderStatusBar())
-
-	return b.String()
+	view := m.overlayToasts(b.String())
+	return view + "\n" + m.renderStatusBar()
 }
 
 func (m *Model) renderHeader() string {
-	logo := lipgloss.NewStyle().
+	logoStyle := lipgloss.NewStyle().
 		Foreground(m.colors.primary).
-		Bold(true).
-		Render("◈ OpenKanban")
+		Bold(true)
+	border := m.colors.surface
+	if color, flashing := m.flashColor(); flashing {
+		logoStyle = logoStyle.Foreground(m.colors.base).Background(color)
+		border = color
+	}
+	logo := logoStyle.Render("◈ " + m.boardTitle())
 
 	var filterSection string
 	if m.mode == ModeFilter {
 		filterSection = m.renderFilterInput()
+	} else if m.mode == ModeCommand {
+		filterSection = m.renderCommandInput()
+	} else if m.mode == ModeVisual {
+		filterSection = m.renderVisualSection()
 	} else if m.filterQuery != "" || len(m.filterProjectIDs) > 0 {
 		filterSection = m.renderActiveFilter()
 	} else {
@@ -98,6 +169,10 @@ func (m *Model) renderHeader() string {
 	}
 
 	left := lipgloss.JoinHorizontal(lipgloss.Center, logo, "  ", filterSection, "  ", stats)
+	narrow := m.layoutMode() == layoutNarrow
+	if narrow {
+		left = lipgloss.JoinHorizontal(lipgloss.Center, logo, "  ", filterSection)
+	}
 
 	workingCount, waitingCount, idleCount := 0, 0, 0
 	for ticketID, pane := range m.panes {
@@ -105,7 +180,7 @@ func (m *Model) renderHeader() string {
 			continue
 		}
 		ticket, _ := m.globalStore.Get(ticketID)
-		if ticket == nil {
+		if ticket == nil || ticket.Muted {
 			continue
 		}
 
@@ -126,17 +201,20 @@ func (m *Model) renderHeader() string {
 		var bgColor lipgloss.Color
 
 		if waitingCount > 0 {
-			bgColor = m.colors.secondary
-			statusText = fmt.Sprintf("◐ %d waiting", waitingCount)
+			glyph, color, _ := m.agentMark(board.AgentWaiting)
+			bgColor = color
+			statusText = fmt.Sprintf("%s %d waiting", glyph, waitingCount)
 			if workingCount > 0 {
-				statusText = fmt.Sprintf("◐ %d waiting, %d working", waitingCount, workingCount)
+				statusText = fmt.Sprintf("%s %d waiting, %d working", glyph, waitingCount, workingCount)
 			}
 		} else if workingCount > 0 {
-			bgColor = m.colors.warning
-			statusText = fmt.Sprintf("%s %d working", m.spinner.View(), workingCount)
+			glyph, color, _ := m.agentMark(board.AgentWorking)
+			bgColor = color
+			statusText = fmt.Sprintf("%s %d working", glyph, workingCount)
 		} else {
-			bgColor = m.colors.primary
-			statusText = fmt.Sprintf("◆ %d idle", idleCount)
+			glyph, color, _ := m.agentMark(board.AgentIdle)
+			bgColor = color
+			statusText = fmt.Sprintf("%s %d idle", glyph, idleCount)
 		}
 
 		activityBadge := lipgloss.NewStyle().
@@ -147,12 +225,25 @@ func (m *Model) renderHeader() string {
 			Render(statusText)
 		activity = activityBadge
 	}
+	if paused := m.renderPausedBadge(); paused != "" && activity != "" {
+		activity = lipgloss.JoinHorizontal(lipgloss.Center, paused, " ", activity)
+	} else if paused != "" {
+		activity = paused
+	}
 
 	helpStyle := lipgloss.NewStyle().Foreground(m.colors.muted)
-	help := helpStyle.Render("? help  q quit")
+	help := helpStyle.Render(m.headerHelp())
 
 	right := help
-	if activity != "" {
+	if sprint := m.renderSprintSummary(); sprint != "" {
+		right = lipgloss.JoinHorizontal(lipgloss.Center, sprint, "  ", help)
+	}
+	if narrow {
+		right = ""
+	}
+	if activity != "" && narrow {
+		right = activity
+	} else if activity != "" {
 		right = lipgloss.JoinHorizontal(lipgloss.Center, activity, "  ", help)
 	}
 
@@ -160,73 +251,90 @@ func (m *Model) renderHeader() string {
 	spacing = max(spacing, 0)
 
 	header := lipgloss.JoinHorizontal(lipgloss.Center, left, strings.Repeat(" ", spacing), right)
+	m.recordHeaderRegions(lipgloss.Width(logo)+2, lipgloss.Width(filterSection), strings.HasSuffix(right, help))
 
 	return lipgloss.NewStyle().
 		PaddingTop(1).
 		PaddingBottom(1).
 		BorderBottom(true).
 		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(m.colors.surface).
+		BorderForeground(border).
 		Width(m.width).
 		Render(header)
 }
 
-func (m *Model) renderBoard() string {
-	columnWidth := m.calcColumnWidth()
-	visibleCols := m.visibleColumnCount(columnWidth)
+// recordHeaderRegions makes the filter hint or active filter at column x and
+// the help hint at the right edge clickable.
+func (m *Model) recordHeaderRegions(x, filterWidth int, showsHelp bool) {
+	search, _ := hintKey("/")
+	if m.mode == ModeNormal {
+		if m.filterQuery != "" || len(m.filterProjectIDs) > 0 {
+			clearWidth := lipgloss.Width("× clear") + 2
+			clearKey, _ := hintKey("Esc")
+			m.headerRegions = append(m.headerRegions,
+				clickRegion{start: x, end: x + filterWidth - clearWidth - 1, key: search},
+				clickRegion{start: x + filterWidth - clearWidth, end: x + filterWidth, key: clearKey})
+		} else {
+			m.headerRegions = append(m.headerRegions,
+				clickRegion{start: x, end: x + filterWidth, key: search})
+		}
+	}
+	if help, ok := hintKey(m.keymap.label("help")); showsHelp && ok {
+		start := m.width - lipgloss.Width(m.headerHelp())
+		m.headerRegions = append(m.headerRegions,
+			clickRegion{start: start, end: start + lipgloss.Width(m.keymap.label("help")+" help"), key: help})
+	}
+}
 
-	startCol := m.scrollOffset
-	endCol := min(startCol+visibleCols, len(m.columns))
+// headerHelp is the "? help  q quit" reminder, with the configured keys.
+func (m *Model) headerHelp() string {
+	return m.keymap.label("help") + " help  " + m.keymap.label("quit") + " quit"
+}
 
-	numVisible := endCol - startCol
-	baseWidth, remainder := m.distributeWidth(numVisible)
+func (m *Model) renderBoard() string {
+	layout := m.boardLayout()
+	narrow := m.layoutMode() == layoutNarrow
 
 	var columns []string
 
-	if startCol > 0 {
+	if layout.hiddenLeft > 0 && !narrow {
 		indicator := lipgloss.NewStyle().
 			Foreground(m.colors.muted).
 			Background(m.colors.surface).
 			Padding(0, 1).
-			Render(fmt.Sprintf("◀ %d", startCol))
+			Render(fmt.Sprintf("◀ %d", layout.hiddenLeft))
 		columns = append(columns, indicator)
 	}
 
-	for i := startCol; i < endCol; i++ {
+	for n, slot := range layout.slots {
+		i := slot.column
 		col := m.columns[i]
 		isActive := i == m.activeColumn && !m.sidebarFocused
-		isLast := i == endCol-1
+		isLast := n == len(layout.slots)-1
 		isDragTarget := m.dragging && i == m.dragTargetColumn && i != m.dragSourceColumn
 		isHovered := i == m.hoverColumn && !m.dragging
 
-		colWidth := baseWidth
-		if i-startCol < remainder {
-			colWidth++
-		}
-
-		ticketOffset := 0
-		if i < len(m.columnOffsets) {
-			ticketOffset = m.columnOffsets[i]
-		}
-
-		columns = append(columns, m.renderColumn(col, m.columnTickets[i], isActive, isDragTarget, isHovered, colWidth, isLast, ticketOffset))
+		columns = append(columns, m.renderColumn(i, col, m.columnTickets[i], isActive, isDragTarget, isHovered, slot.width, isLast))
 	}
 
-	if endCol < len(m.columns) {
-		remaining := len(m.columns) - endCol
+	if layout.hiddenRight > 0 && !narrow {
 		indicator := lipgloss.NewStyle().
 			Foreground(m.colors.muted).
 			Background(m.colors.surface).
 			Padding(0, 1).
-			Render(fmt.Sprintf("%d ▶", remaining))
+			Render(fmt.Sprintf("%d ▶", layout.hiddenRight))
 		columns = append(columns, indicator)
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+	row := lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+	if m.showSwitcher() {
+		return lipgloss.JoinVertical(lipgloss.Left, m.renderStatusSwitcher(), row)
+	}
+	return row
 }
 
-func (m *Model) renderColumn(col board.Column, tickets []*board.Ticket, isActive, isDragTarget, isHovered bool, width int, isLast bool, ticketOffset int) string {
-	headerColor := m.columnColor(col.Status)
+func (m *Model) renderColumn(column int, col board.Column, tickets []*board.Ticket, isActive, isDragTarget, isHovered bool, width int, isLast bool) string {
+	headerColor := m.columnColor(col)
 
 	columnIcons := map[board.TicketStatus]string{
 		board.StatusBacklog:    "📋",
@@ -243,11 +351,16 @@ func (m *Model) renderColumn(col board.Column, tickets []*board.Ticket, isActive
 
 	headerText := fmt.Sprintf("%s %s", icon, col.Name)
 
+	var groups []labelGroup
+	if column < len(m.columnGroups) {
+		groups = m.columnGroups[column]
+	}
+	total := m.columnCount(column)
 	countStyle := lipgloss.NewStyle().Foreground(m.colors.muted)
-	countText := fmt.Sprintf("(%d)", len(tickets))
+	countText := fmt.Sprintf("(%d)", total)
 	if col.Limit > 0 {
-		countText = fmt.Sprintf("(%d/%d)", len(tickets), col.Limit)
-		if len(tickets) >= col.Limit {
+		countText = fmt.Sprintf("(%d/%d)", total, col.Limit)
+		if total >= col.Limit {
 			countStyle = lipgloss.NewStyle().
 				Foreground(m.colors.base).
 				Background(m.colors.err).
@@ -263,11 +376,56 @@ func (m *Model) renderColumn(col board.Column, tickets []*board.Ticket, isActive
 	count := countStyle.Render(" " + countText)
 
 	headerLine := header + count
+	if mode := m.columnSort(col); mode != board.SortManual {
+		sortLabel := m.dimStyle().Render(" ⇅ " + string(mode))
+		if lipgloss.Width(headerLine+sortLabel) <= width-4 {
+			headerLine += sortLabel
+		}
+	}
 
-	visibleCount := m.visibleTicketCount()
-	endIdx := min(ticketOffset+visibleCount, len(tickets))
+	offset := 0
+	if column < len(m.columnOffsets) {
+		offset = min(m.columnOffsets[column], max(len(tickets)-1, 0))
+	}
 
-	hasMoreAbove := ticketOffset > 0
+	cards := make(map[int]string)
+	card := func(i int) string {
+		if c, ok := cards[i]; ok {
+			return c
+		}
+		isSelected := isActive && i == m.activeTicket
+		isTicketHovered := isHovered && i == m.hoverTicket
+		c := m.renderTicket(tickets[i], isSelected, isTicketHovered, width-4, headerColor)
+		if headers := m.groupHeaders(groups, i, width-4); len(headers) > 0 {
+			c = strings.Join(headers, "\n") + "\n" + c
+		}
+		cards[i] = c
+		m.ticketHeights[tickets[i].ID] = lipgloss.Height(c)
+		return c
+	}
+	cardHeight := func(i int) int { return lipgloss.Height(card(i)) }
+
+	// Collapsed groups after the last ticket keep their headers at the
+	// bottom.
+	trailing := m.groupHeaders(groups, len(tickets), width-4)
+	rows := m.ticketRows() - len(trailing)
+	endIdx := fitTickets(cardHeight, len(tickets), offset, rows)
+	// Cards taller than when last drawn can push the selection off the
+	// bottom; scroll until it fits again.
+	for isActive && offset < m.activeTicket && m.activeTicket >= endIdx {
+		offset++
+		endIdx = fitTickets(cardHeight, len(tickets), offset, rows)
+	}
+	// Cards shorter than when last drawn, as after switching to compact
+	// cards, can leave room at the bottom; scroll back up to fill it.
+	for offset > 0 && fitTickets(cardHeight, len(tickets), offset-1, rows) == len(tickets) {
+		offset--
+	}
+	if column < len(m.columnOffsets) {
+		m.columnOffsets[column] = offset
+	}
+
+	hasMoreAbove := offset > 0
 	hasMoreBelow := endIdx < len(tickets)
 
 	indicatorStyle := lipgloss.NewStyle().
@@ -275,26 +433,29 @@ func (m *Model) renderColumn(col board.Column, tickets []*board.Ticket, isActive
 		Width(width - 4).
 		Align(lipgloss.Center)
 
-	var ticketViews []string
-
+	// The header stays put while the tickets scroll beneath it; the row
+	// under it turns into a rule once cards are hidden above.
+	divider := ""
 	if hasMoreAbove {
-		ticketViews = append(ticketViews, indicatorStyle.Render(fmt.Sprintf("▲ %d more", ticketOffset)))
+		divider = m.renderScrollShadow(width-4, offset)
 	}
 
-	for i := ticketOffset; i < endIdx; i++ {
-		ticket := tickets[i]
-		isSelected := isActive && i == m.activeTicket
-		isTicketHovered := isHovered && i == m.hoverTicket
-		ticketViews = append(ticketViews, m.renderTicket(ticket, isSelected, isTicketHovered, width-4, headerColor))
+	var ticketViews []string
+	heights := make([]int, 0, endIdx-offset)
+	for i := offset; i < endIdx; i++ {
+		ticketViews = append(ticketViews, card(i))
+		heights = append(heights, cardHeight(i))
 	}
+	m.cardHeights[col.Status] = heights
 
 	if hasMoreBelow {
 		remaining := len(tickets) - endIdx
 		ticketViews = append(ticketViews, indicatorStyle.Render(fmt.Sprintf("▼ %d more", remaining)))
 	}
+	ticketViews = append(ticketViews, trailing...)
 
 	ticketsView := strings.Join(ticketViews, "\n")
-	if len(tickets) == 0 {
+	if len(tickets) == 0 && len(trailing) == 0 {
 		emptyIcon := "○"
 		emptyText := "Drag or Space to move here"
 		if col.Status == board.StatusBacklog {
@@ -313,7 +474,7 @@ func (m *Model) renderColumn(col board.Column, tickets []*board.Ticket, isActive
 		ticketsView = emptyStyle.Render(emptyIcon + "\n" + emptyText)
 	}
 
-	content := lipgloss.JoinVertical(lipgloss.Left, headerLine, "", ticketsView)
+	content := lipgloss.JoinVertical(lipgloss.Left, headerLine, divider, ticketsView)
 
 	border := columnBorder
 	borderColor := m.colors.surface
@@ -340,172 +501,23 @@ func (m *Model) renderColumn(col board.Column, tickets []*board.Ticket, isActive
 	return style.Render(content)
 }
 
+// renderScrollShadow draws the rule under a scrolled column's header with
+// the number of tickets above the viewport.
+func (m *Model) renderScrollShadow(width, hidden int) string {
+	label := fmt.Sprintf(" ▲ %d ", hidden)
+	side := max(width-lipgloss.Width(label), 0)
+	left := side / 2
+	return lipgloss.NewStyle().Foreground(m.colors.overlay).Render(
+		strings.Repeat("╌", left) + label + strings.Repeat("╌", side-left))
+}
+
 func (m *Model) renderTicket(ticket *board.Ticket, isSelected, isHovered bool, width int, columnColor lipgloss.Color) string {
 	pane, hasPane := m.panes[ticket.ID]
 	isRunning := hasPane && pane.Running()
 
 	effectiveStatus := ticket.AgentStatus
 
-	var projectBadge string
-	if proj := m.globalStore.GetProjectForTicket(ticket); proj != nil {
-		shortName := proj.Name
-		if len(shortName) > 12 {
-			shortName = shortName[:10] + ".."
-		}
-		bracketStyle := lipgloss.NewStyle().Foreground(m.colors.info)
-		textStyle := lipgloss.NewStyle().Foreground(m.colors.info).Bold(true)
-		projectBadge = bracketStyle.Render("❨") + textStyle.Render(shortName) + bracketStyle.Render("❩")
-	}
-
-	var sessionBadge string
-	switch effectiveStatus {
-	case board.AgentWaiting:
-		sessionBadge = lipgloss.NewStyle().
-			Foreground(m.colors.secondary).
-			Render("◐")
-	case board.AgentIdle:
-		if hasPane {
-			sessionBadge = lipgloss.NewStyle().
-				Foreground(m.colors.primary).
-				Render("◆")
-		}
-	case board.AgentCompleted:
-		sessionBadge = lipgloss.NewStyle().
-			Foreground(m.colors.success).
-			Render("✓")
-	case board.AgentError:
-		sessionBadge = lipgloss.NewStyle().
-			Foreground(m.colors.err).
-			Render("✗")
-	}
-
-	var priorityBadge string
-	if ticket.Priority > 0 && ticket.Priority <= 2 {
-		priorityColors := map[int]lipgloss.Color{
-			1: m.colors.err,
-			2: lipgloss.Color("#fab387"),
-		}
-		priorityLabels := map[int]string{
-			1: "!!",
-			2: "!",
-		}
-		pColor := priorityColors[ticket.Priority]
-		priorityBadge = lipgloss.NewStyle().Foreground(pColor).Bold(true).Render(priorityLabels[ticket.Priority])
-	}
-
-	var depBadge string
-	blockedByCount := len(m.globalStore.GetBlockedBy(ticket.ID))
-	blocksCount := len(m.globalStore.GetBlocks(ticket.ID))
-	if blockedByCount > 0 || blocksCount > 0 {
-		depStyle := lipgloss.NewStyle().Foreground(m.colors.muted)
-		if blockedByCount > 0 && blocksCount > 0 {
-			depBadge = depStyle.Render(fmt.Sprintf("⛓%d↑%d↓", blockedByCount, blocksCount))
-		} else if blockedByCount > 0 {
-			depBadge = depStyle.Render(fmt.Sprintf("⛓%d↑", blockedByCount))
-		} else {
-			depBadge = depStyle.Render(fmt.Sprintf("⛓%d↓", blocksCount))
-		}
-	}
-
-	var headerParts []string
-	if priorityBadge != "" {
-		headerParts = append(headerParts, priorityBadge)
-	}
-	if projectBadge != "" {
-		headerParts = append(headerParts, projectBadge)
-	}
-	if depBadge != "" {
-		headerParts = append(headerParts, depBadge)
-	}
-	if sessionBadge != "" {
-		headerParts = append(headerParts, sessionBadge)
-	}
-	headerLine := strings.Join(headerParts, "  ")
-
-	titleStyle := lipgloss.NewStyle().
-		Foreground(m.colors.text).
-		Bold(isSelected).
-		Width(width)
-	wrappedTitle := titleStyle.Render(ticket.Title)
-
-	var descLine string
-	if ticket.Description != "" {
-		desc := ticket.Description
-		if len(desc) > 60 {
-			desc = desc[:57] + "..."
-		}
-		desc = strings.ReplaceAll(desc, "\n", " ")
-		descLine = lipgloss.NewStyle().
-			Foreground(m.colors.muted).
-			Italic(true).
-			Width(width).
-			Render(desc)
-	}
-
-	var statusParts []string
-	if ticket.AgentType != "" {
-		agentBadge := lipgloss.NewStyle().
-			Foreground(m.colors.base).
-			Background(m.colors.primary).
-			Padding(0, 1).
-			Render(ticket.AgentType)
-		statusParts = append(statusParts, agentBadge)
-	}
-
-	if effectiveStatus != board.AgentNone {
-		var statusIcon, statusText string
-		var statusColor lipgloss.Color
-		switch effectiveStatus {
-		case board.AgentIdle:
-			statusIcon = "◆"
-			statusText = "idle"
-			statusColor = m.colors.primary
-		case board.AgentWorking:
-			statusIcon = m.spinner.View()
-			statusText = "working"
-			statusColor = m.colors.warning
-		case board.AgentWaiting:
-			statusIcon = "◐"
-			statusText = "waiting"
-			statusColor = m.colors.secondary
-		case board.AgentCompleted:
-			statusIcon = "✓"
-			statusText = "done"
-			statusColor = m.colors.success
-		case board.AgentError:
-			statusIcon = "✗"
-			statusText = "error"
-			statusColor = m.colors.err
-		}
-		statusStyle := lipgloss.NewStyle().Foreground(statusColor)
-		statusParts = append(statusParts, statusStyle.Render(statusIcon+" "+statusText))
-	}
-
-	statusLine := strings.Join(statusParts, " ")
-
-	var labelParts []string
-	for _, label := range ticket.Labels {
-		lbl := lipgloss.NewStyle().
-			Foreground(m.colors.subtext).
-			Background(m.colors.overlay).
-			Padding(0, 1).
-			Render(label)
-		labelParts = append(labelParts, lbl)
-	}
-	labelsLine := strings.Join(labelParts, " ")
-
-	lines := []string{headerLine, wrappedTitle}
-	if descLine != "" {
-		lines = append(lines, descLine)
-	}
-	if statusLine != "" {
-		lines = append(lines, statusLine)
-	}
-	if labelsLine != "" {
-		lines = append(lines, labelsLine)
-	}
-
-	content := strings.Join(lines, "\n")
+	card := cardContext{ticket: ticket, status: effectiveStatus, hasPane: hasPane, selected: isSelected, focus: m.focusMode, width: width, busy: m.busyLabel(ticket.ID)}
 
 	var accentColor lipgloss.Color = m.colors.surface
 	switch effectiveStatus {
@@ -525,14 +537,34 @@ func (m *Model) renderTicket(ticket *board.Ticket, isSelected, isHovered bool, w
 	if isRunning {
 		accentColor = m.colors.success
 	}
+	if card.busy != "" {
+		accentColor = m.colors.warning
+	}
+
+	if m.compactCards {
+		return m.renderCompactTicket(card, accentColor, isHovered, width+2, columnColor)
+	}
+
+	var lines []string
+	for _, line := range m.cardLines() {
+		if rendered := m.renderCardLine(line, card); rendered != "" {
+			lines = append(lines, rendered)
+		}
+	}
+	content := strings.Join(lines, "\n")
 
 	border := ticketBorder
-	borderColor := m.colors.surface
+	borderColor := m.agingColor(ticket, time.Now())
 
 	if isHovered && !isSelected {
 		borderColor = m.colors.overlay
 	}
 
+	if m.isVisualSelected(ticket) {
+		border = ticketBorderSelected
+		borderColor = m.colors.secondary
+	}
+
 	if isSelected {
 		border = ticketBorderSelected
 		borderColor = columnColor
@@ -542,13 +574,21 @@ func (m *Model) renderTicket(ticket *board.Ticket, isSelected, isHovered bool, w
 		borderColor = m.colors.success
 	}
 
+	// Briefly highlight a card that just changed columns.
+	slide := 0
+	if ticket.ID == m.movedTicketID {
+		borderColor = m.colors.warning
+		slide = m.moveSlideOffset(ticket.ID)
+	}
+
 	cardStyle := lipgloss.NewStyle().
 		Border(border).
 		BorderForeground(borderColor).
 		BorderLeftForeground(accentColor).
 		Padding(0, 1).
 		MarginBottom(1).
-		Width(width)
+		MarginLeft(slide).
+		Width(width - slide)
 
 	return cardStyle.Render(content)
 }
@@ -570,17 +610,43 @@ func (m *Model) renderStatusBar() string {
 		ModeConfirm:       {"!", m.colors.err},
 		ModeFilter:        {"/", m.colors.info},
 		ModeCreateProject: {"📁", m.colors.success},
+		ModeTicketDetail:  {"≡", m.colors.primary},
+		ModeOutcome:       {"✓", m.colors.success},
+		ModeCustomFields:  {"≡", m.colors.primary},
+		ModeLogSearch:     {"⌕", m.colors.info},
+		ModeArchive:       {"▤", m.colors.secondary},
+		ModeParentPicker:  {"◇", m.colors.secondary},
+		ModeBoardEditor:   {"▦", m.colors.secondary},
+		ModeVisual:        {"▣", m.colors.secondary},
+		ModeMovePicker:    {"⇄", m.colors.secondary},
+		ModeAgentPicker:   {"◈", m.colors.secondary},
+		ModeActions:       {"☰", m.colors.secondary},
+		ModeAttempts:      {"⑂", m.colors.secondary},
+		ModeHygiene:       {"✧", m.colors.warning},
+		ModeStats:         {"▦", m.colors.success},
+		ModeLink:          {"⌁", m.colors.info},
+		ModeSessionLog:    {"☰", m.colors.info},
+	}
+	top := m.mode
+	if m.showConfirm {
+		top = ModeConfirm
 	}
-	cfg := modeConfigs[m.mode]
+	cfg := modeConfigs[top]
 	if cfg.bg == "" {
 		cfg = modeConfig{"◆", m.colors.primary}
 	}
+	// The badge is a breadcrumb of the open layers, the one Esc closes last.
+	layers := m.layers()
+	crumbs := string(ModeNormal)
+	if len(layers) > 0 {
+		crumbs = strings.Join(layers, " › ")
+	}
 	modeStr := lipgloss.NewStyle().
 		Foreground(m.colors.base).
 		Background(cfg.bg).
 		Bold(true).
 		Padding(0, 1).
-		Render(cfg.icon + " " + string(m.mode))
+		Render(cfg.icon + " " + crumbs)
 
 	sep := lipgloss.NewStyle().Foreground(m.colors.overlay).Render(" │ ")
 	hintStyle := lipgloss.NewStyle().Foreground(m.colors.subtext)
@@ -588,25 +654,17 @@ func (m *Model) renderStatusBar() string {
 	hints := m.contextualHints(hintStyle, sep)
 
 	notif := ""
-	if m.notification != "" {
-		isError := strings.HasPrefix(m.notification, "Failed") ||
-			strings.HasPrefix(m.notification, "Error") ||
-			strings.Contains(m.notification, "failed")
-		bgColor := m.colors.success
-		icon := "✓"
-		if isError {
-			bgColor = m.colors.err
-			icon = "✗"
-		}
-		notifBadge := lipgloss.NewStyle().
-			Foreground(m.colors.base).
-			Background(bgColor).
-			Padding(0, 1).
-			Render(icon + " " + m.notification)
-		notif = notifBadge
+	if t, ok := m.latestToast(); ok {
+		notif = m.renderToast(t)
 	}
 
 	left := lipgloss.JoinHorizontal(lipgloss.Center, modeStr, sep, hints)
+	m.statusRegions = hintRegions(hints, lipgloss.Width(modeStr)+lipgloss.Width(sep))
+	if len(layers) > 0 {
+		back, _ := hintKey("Esc")
+		m.statusRegions = append(m.statusRegions,
+			clickRegion{start: 0, end: lipgloss.Width(modeStr), key: back})
+	}
 	spacing := m.width - lipgloss.Width(left) - lipgloss.Width(notif)
 	spacing = max(spacing, 0)
 
@@ -614,11 +672,24 @@ func (m *Model) renderStatusBar() string {
 }
 
 func (m *Model) contextualHints(hintStyle lipgloss.Style, sep string) string {
+	if m.showConfirm {
+		return hintStyle.Render("y") + m.dimStyle().Render(" confirm") + sep +
+			hintStyle.Render("n/Esc") + m.dimStyle().Render(" cancel")
+	}
 	switch m.mode {
+	case ModeCommand:
+		if len(m.completions) > 1 {
+			return hintStyle.Render("Tab") + m.dimStyle().Render(" next") + sep + m.renderCompletions(hintStyle)
+		}
+		return hintStyle.Render("Enter") + m.dimStyle().Render(" run") + sep +
+			hintStyle.Render("Esc") + m.dimStyle().Render(" cancel") + sep +
+			hintStyle.Render("Tab") + m.dimStyle().Render(" complete") + sep +
+			m.dimStyle().Render("↑/↓ history")
+
 	case ModeFilter:
 		return hintStyle.Render("Enter") + m.dimStyle().Render(" apply") + sep +
 			hintStyle.Render("Esc") + m.dimStyle().Render(" cancel") + sep +
-			m.dimStyle().Render("@project to filter by project")
+			m.dimStyle().Render("@project ~assignee +sprint to narrow")
 
 	case ModeSettings:
 		return hintStyle.Render("j/k") + m.dimStyle().Render(" navigate") + sep +
@@ -634,11 +705,40 @@ func (m *Model) contextualHints(hintStyle lipgloss.Style, sep string) string {
 			hintStyle.Render("Ctrl+S") + m.dimStyle().Render(" "+action) + sep +
 			hintStyle.Render("Esc") + m.dimStyle().Render(" cancel")
 
+	case ModeTicketDetail:
+		if m.composingComment {
+			return hintStyle.Render("Ctrl+S") + m.dimStyle().Render(" save comment") + sep +
+				hintStyle.Render("Esc") + m.dimStyle().Render(" cancel")
+		}
+		return hintStyle.Render("c") + m.dimStyle().Render(" comment") + sep +
+			hintStyle.Render("e") + m.dimStyle().Render(" edit") + sep +
+			hintStyle.Render("f") + m.dimStyle().Render(" fields") + sep +
+			hintStyle.Render("Esc") + m.dimStyle().Render(" close")
+
+	case ModeVisual:
+		if m.visualLabeling {
+			return hintStyle.Render("Enter") + m.dimStyle().Render(" apply") + sep +
+				hintStyle.Render("Esc") + m.dimStyle().Render(" cancel") + sep +
+				m.dimStyle().Render("-label removes")
+		}
+		return hintStyle.Render("j/k") + m.dimStyle().Render(" extend") + sep +
+			hintStyle.Render("Space/-") + m.dimStyle().Render(" move") + sep +
+			hintStyle.Render("t") + m.dimStyle().Render(" label") + sep +
+			hintStyle.Render("a") + m.dimStyle().Render(" archive") + sep +
+			hintStyle.Render("d") + m.dimStyle().Render(" delete") + sep +
+			hintStyle.Render("s") + m.dimStyle().Render(" spawn") + sep +
+			hintStyle.Render("Esc") + m.dimStyle().Render(" exit")
+
 	case ModeAgentView:
 		return hintStyle.Render("Ctrl+G") + m.dimStyle().Render(" back to board") + sep +
 			m.dimStyle().Render("Shift+click to select text")
 
 	case ModeNormal:
+		if ticket := m.draggedTicket(); ticket != nil && m.dragTargetColumn != m.dragSourceColumn {
+			return hintStyle.Render("Release") + m.dimStyle().Render(" to move to "+m.columns[m.dragTargetColumn].Name) + sep +
+				m.dimStyle().Render("release over the header to cancel")
+		}
+
 		if m.sidebarFocused {
 			return hintStyle.Render("j/k") + m.dimStyle().Render(" navigate") + sep +
 				hintStyle.Render("Space/Enter") + m.dimStyle().Render(" toggle") + sep +
@@ -647,31 +747,32 @@ func (m *Model) contextualHints(hintStyle lipgloss.Style, sep string) string {
 
 		if m.filterQuery != "" || len(m.filterProjectIDs) > 0 {
 			return hintStyle.Render("Esc") + m.dimStyle().Render(" clear filter") + sep +
-				hintStyle.Render("/") + m.dimStyle().Render(" edit filter") + sep +
-				hintStyle.Render("?") + m.dimStyle().Render(" help")
+				hintStyle.Render(m.keymap.label("filter")) + m.dimStyle().Render(" edit filter") + sep +
+				hintStyle.Render(m.keymap.label("help")) + m.dimStyle().Render(" help")
 		}
 
 		ticket := m.selectedTicket()
 		if ticket != nil {
 			if _, hasPane := m.panes[ticket.ID]; hasPane {
-				return hintStyle.Render("Enter") + m.dimStyle().Render(" attach") + sep +
-					hintStyle.Render("S") + m.dimStyle().Render(" stop agent") + sep +
-					hintStyle.Render("Space") + m.dimStyle().Render(" move") + sep +
-					hintStyle.Render("?") + m.dimStyle().Render(" help")
+				return hintStyle.Render(m.keymap.label("attach_agent")) + m.dimStyle().Render(" attach") + sep +
+					hintStyle.Render(m.keymap.label("stop_agent")) + m.dimStyle().Render(" stop agent") + sep +
+					hintStyle.Render(m.keymap.label("move_forward")) + m.dimStyle().Render(" move") + sep +
+					hintStyle.Render(m.keymap.label("help")) + m.dimStyle().Render(" help")
 			}
 			if ticket.Status == board.StatusInProgress {
-				return hintStyle.Render("s") + m.dimStyle().Render(" spawn agent") + sep +
-					hintStyle.Render("Space") + m.dimStyle().Render(" move") + sep +
-					hintStyle.Render("e") + m.dimStyle().Render(" edit") + sep +
-					hintStyle.Render("?") + m.dimStyle().Render(" help")
+				return hintStyle.Render(m.keymap.label("spawn_agent")) + m.dimStyle().Render(" spawn agent") + sep +
+					hintStyle.Render(m.keymap.label("ticket_details")) + m.dimStyle().Render(" details") + sep +
+					hintStyle.Render(m.keymap.label("move_forward")) + m.dimStyle().Render(" move") + sep +
+					hintStyle.Render(m.keymap.label("edit_ticket")) + m.dimStyle().Render(" edit") + sep +
+					hintStyle.Render(m.keymap.label("help")) + m.dimStyle().Render(" help")
 			}
 		}
 
-		return hintStyle.Render("h/l") + m.dimStyle().Render(" columns") + sep +
-			hintStyle.Render("n") + m.dimStyle().Render(" new") + sep +
-			hintStyle.Render("Space") + m.dimStyle().Render(" move") + sep +
-			hintStyle.Render("/") + m.dimStyle().Render(" search") + sep +
-			hintStyle.Render("?") + m.dimStyle().Render(" help")
+		return hintStyle.Render(m.keymap.label("column_left")+"/"+m.keymap.label("column_right")) + m.dimStyle().Render(" columns") + sep +
+			hintStyle.Render(m.keymap.label("new_ticket")) + m.dimStyle().Render(" new") + sep +
+			hintStyle.Render(m.keymap.label("move_forward")) + m.dimStyle().Render(" move") + sep +
+			hintStyle.Render(m.keymap.label("filter")) + m.dimStyle().Render(" search") + sep +
+			hintStyle.Render(m.keymap.label("help")) + m.dimStyle().Render(" help")
 
 	default:
 		return hintStyle.Render("Esc") + m.dimStyle().Render(" back") + sep +
@@ -679,59 +780,6 @@ func (m *Model) contextualHints(hintStyle lipgloss.Style, sep string) string {
 	}
 }
 
-func (m *Model) renderHelp() string {
-	titleStyle := lipgloss.NewStyle().
-		Foreground(m.colors.primary).
-		Bold(true)
-
-	sectionStyle := lipgloss.NewStyle().
-		Foreground(m.colors.secondary).
-		Bold(true)
-
-	keyStyle := lipgloss.NewStyle().
-		Foreground(m.colors.info).
-		Bold(true)
-
-	descStyle := lipgloss.NewStyle().
-		Foreground(m.colors.subtext)
-
-	sepStyle := lipgloss.NewStyle().
-		Foreground(m.colors.surface)
-
-	sep := sepStyle.Render("────────────────────────────────────────────")
-
-	help := titleStyle.Render("◈ Keyboard Shortcuts") + "\n\n" +
-		sep + "\n" +
-		sectionStyle.Render("  🧭 Navigation") + "                 " + sectionStyle.Render("📝 Actions") + "\n" +
-		sep + "\n" +
-		"  " + keyStyle.Render("h/l") + descStyle.Render("   Move between columns  ") + keyStyle.Render("n") + descStyle.Render("       New ticket") + "\n" +
-		"  " + keyStyle.Render("j/k") + descStyle.Render("   Move between tickets  ") + keyStyle.Render("e") + descStyle.Render("       Edit ticket") + "\n" +
-		"  " + keyStyle.Render("g") + descStyle.Render("     Go to first ticket    ") + keyStyle.Render("d") + descStyle.Render("       Delete ticket") + "\n" +
-		"  " + keyStyle.Render("G") + descStyle.Render("     Go to last ticket     ") + keyStyle.Render("Space") + descStyle.Render("   Move forward") + "\n" +
-		"  " + keyStyle.Render(" ") + descStyle.Render("                            ") + keyStyle.Render("-") + descStyle.Render("       Move backward") + "\n\n" +
-		sep + "\n" +
-		sectionStyle.Render("  📂 Sidebar") + "                    " + sectionStyle.Render("🤖 Agent") + "\n" +
-		sep + "\n" +
-		"  " + keyStyle.Render("[") + descStyle.Render("     Toggle sidebar        ") + keyStyle.Render("s") + descStyle.Render("       Spawn agent") + "\n" +
-		"  " + keyStyle.Render("h") + descStyle.Render("     Enter sidebar         ") + keyStyle.Render("S") + descStyle.Render("       Stop agent") + "\n" +
-		"  " + keyStyle.Render("l") + descStyle.Render("     Exit sidebar          ") + keyStyle.Render("Enter") + descStyle.Render("   Attach to agent") + "\n" +
-		"  " + keyStyle.Render("j/k") + descStyle.Render("   Navigate projects     ") + keyStyle.Render("Ctrl+g") + descStyle.Render("  Exit agent view") + "\n\n" +
-		sep + "\n" +
-		sectionStyle.Render("  👁 View") + "\n" +
-		sep + "\n" +
-		"  " + keyStyle.Render("/") + descStyle.Render("     Search/filter         ") + keyStyle.Render("O") + descStyle.Render("       Settings") + "\n" +
-		"  " + keyStyle.Render("?") + descStyle.Render("     Toggle help           ") + keyStyle.Render("q") + descStyle.Render("       Quit") + "\n\n" +
-		sep + "\n" +
-		"  " + lipgloss.NewStyle().Foreground(m.colors.warning).Render("💡") + m.dimStyle().Render(" Tip: Hold Shift to select text in agent view") + "\n\n" +
-		"  " + m.dimStyle().Render("Press any key to close")
-
-	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(m.colors.primary).
-		Padding(1, 2).
-		Render(help)
-}
-
 func (m *Model) renderConfirmDialog() string {
 	titleStyle := lipgloss.NewStyle().
 		Foreground(m.colors.err).
@@ -750,48 +798,58 @@ func (m *Model) renderConfirmDialog() string {
 		Render(content)
 }
 
-func (m *Model) renderShuttingDown() string {
-	count := m.RunningAgentCount()
-	msg := fmt.Sprintf("Stopping %d agent(s)...", count)
-
+func (m *Model) renderOutcomePicker() string {
 	titleStyle := lipgloss.NewStyle().
-		Foreground(m.colors.warning).
+		Foreground(m.colors.success).
 		Bold(true)
 
-	content := titleStyle.Render(m.spinner.View()+" Shutting Down") + "\n\n" +
-		"  " + lipgloss.NewStyle().Foreground(m.colors.text).Render(msg)
+	title := "Ticket closed"
+	if ticket, _ := m.globalStore.Get(m.outcomeTicketID); ticket != nil {
+		title = "Closed: " + ticket.Title
+	}
 
-	dialog := lipgloss.NewStyle().
+	lines := []string{
+		titleStyle.Render("✓ " + title),
+		"",
+		"  " + lipgloss.NewStyle().Foreground(m.colors.text).Render("How did it turn out?"),
+		"",
+	}
+
+	for i, outcome := range board.TicketOutcomes {
+		style := lipgloss.NewStyle().Foreground(m.colors.subtext)
+		prefix := fmt.Sprintf("  %d ○ ", i+1)
+		if i == m.outcomeIndex {
+			style = lipgloss.NewStyle().Foreground(m.colors.info).Bold(true)
+			prefix = fmt.Sprintf("  %d ● ", i+1)
+		}
+		lines = append(lines, prefix+style.Render(string(outcome)))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, "  "+lipgloss.NewStyle().Foreground(m.colors.info).Render("[Enter]")+m.dimStyle().Render(" Record  ")+
+		lipgloss.NewStyle().Foreground(m.colors.muted).Render("[s/Esc]")+m.dimStyle().Render(" Skip"))
+
+	return lipgloss.NewStyle().
 		Border(columnBorder).
-		BorderForeground(m.colors.warning).
+		BorderForeground(m.colors.success).
 		Padding(1, 2).
-		Render(content)
-
-	return lipgloss.Place(
-		m.width,
-		m.height,
-		lipgloss.Center,
-		lipgloss.Center,
-		dialog,
-	)
+		Render(strings.Join(lines, "\n"))
 }
 
-func (m *Model) renderSpawning() string {
-	agentName := m.spawningAgent
-	if agentName == "" {
-		agentName = "agent"
-	}
+func (m *Model) renderShuttingDown() string {
+	count := m.RunningAgentCount()
+	msg := fmt.Sprintf("Stopping %d agent(s)...", count)
 
 	titleStyle := lipgloss.NewStyle().
-		Foreground(m.colors.success).
+		Foreground(m.colors.warning).
 		Bold(true)
 
-	content := titleStyle.Render(m.spinner.View()+" Starting "+agentName) + "\n\n" +
-		"  " + m.dimStyle().Render("[Esc] Cancel")
+	content := titleStyle.Render(m.spinner.View()+" Shutting Down") + "\n\n" +
+		"  " + lipgloss.NewStyle().Foreground(m.colors.text).Render(msg)
 
 	dialog := lipgloss.NewStyle().
 		Border(columnBorder).
-		BorderForeground(m.colors.success).
+		BorderForeground(m.colors.warning).
 		Padding(1, 2).
 		Render(content)
 
@@ -831,12 +889,15 @@ func (m *Model) renderTicketForm() string {
 	activeLabelStyle := lipgloss.NewStyle().Foreground(m.colors.info).Bold(true)
 	lockedStyle := lipgloss.NewStyle().Foreground(m.colors.muted).Italic(true)
 	descriptionStyle := lipgloss.NewStyle().Foreground(m.colors.muted).Italic(true)
+	lintStyle := lipgloss.NewStyle().Foreground(m.colors.warning)
 
 	titleLabel := labelStyle
 	descLabel := labelStyle
 	branchLabel := labelStyle
 	labelsLabel := labelStyle
+	assigneeLabel := labelStyle
 	priorityLabel := labelStyle
+	statusLabel := labelStyle
 	worktreeLabel := labelStyle
 	agentLabel := labelStyle
 	blockerLabel := labelStyle
@@ -854,8 +915,12 @@ func (m *Model) renderTicketForm() string {
 		branchLabel = activeLabelStyle
 	case formFieldLabels:
 		labelsLabel = activeLabelStyle
+	case formFieldAssignee:
+		assigneeLabel = activeLabelStyle
 	case formFieldPriority:
 		priorityLabel = activeLabelStyle
+	case formFieldStatus:
+		statusLabel = activeLabelStyle
 	case formFieldWorktree:
 		worktreeLabel = activeLabelStyle
 	case formFieldAgent:
@@ -895,7 +960,7 @@ func (m *Model) renderTicketForm() string {
 	focusIndicator := lipgloss.NewStyle().Foreground(m.colors.info).Render("▸ ")
 	noFocus := "  "
 
-	titleFocus, descFocus, branchFocus, labelsFocus, priorityFocus, worktreeFocus, agentFocus, blockerFocus, projectFocus := noFocus, noFocus, noFocus, noFocus, noFocus, noFocus, noFocus, noFocus, noFocus
+	titleFocus, descFocus, branchFocus, labelsFocus, assigneeFocus, priorityFocus, statusFocus, worktreeFocus, agentFocus, blockerFocus, projectFocus := noFocus, noFocus, noFocus, noFocus, noFocus, noFocus, noFocus, noFocus, noFocus, noFocus, noFocus
 	switch m.ticketFormField {
 	case formFieldTitle:
 		titleFocus = focusIndicator
@@ -905,8 +970,12 @@ func (m *Model) renderTicketForm() string {
 		branchFocus = focusIndicator
 	case formFieldLabels:
 		labelsFocus = focusIndicator
+	case formFieldAssignee:
+		assigneeFocus = focusIndicator
 	case formFieldPriority:
 		priorityFocus = focusIndicator
+	case formFieldStatus:
+		statusFocus = focusIndicator
 	case formFieldWorktree:
 		worktreeFocus = focusIndicator
 	case formFieldAgent:
@@ -924,6 +993,9 @@ func (m *Model) renderTicketForm() string {
 	lines = append(lines, titleFocus+titleLabel.Render("Title")+"  "+titleCharStyle.Render(titleCharCount))
 	lines = append(lines, "  "+descriptionStyle.Render("Brief summary of the task"))
 	lines = append(lines, "  "+m.titleInput.View())
+	for _, warning := range m.config.Defaults.TitleLint.Check(strings.TrimSpace(m.titleInput.Value())) {
+		lines = append(lines, "  "+lintStyle.Render("⚠ "+warning))
+	}
 	lines = append(lines, "")
 	fieldEndLines[formFieldTitle] = len(lines) - 1
 	currentLine = len(lines)
@@ -955,6 +1027,14 @@ func (m *Model) renderTicketForm() string {
 	fieldEndLines[formFieldLabels] = len(lines) - 1
 	currentLine = len(lines)
 
+	fieldStartLines[formFieldAssignee] = currentLine
+	lines = append(lines, assigneeFocus+assigneeLabel.Render("Assignee"))
+	lines = append(lines, "  "+descriptionStyle.Render("Who owns this ticket (defaults to git user.name)"))
+	lines = append(lines, "  "+m.assigneeInput.View())
+	lines = append(lines, "")
+	fieldEndLines[formFieldAssignee] = len(lines) - 1
+	currentLine = len(lines)
+
 	fieldStartLines[formFieldPriority] = currentLine
 	lines = append(lines, priorityFocus+priorityLabel.Render("Priority"))
 	lines = append(lines, "  "+descriptionStyle.Render("1 = highest, 5 = lowest"))
@@ -963,6 +1043,16 @@ func (m *Model) renderTicketForm() string {
 	fieldEndLines[formFieldPriority] = len(lines) - 1
 	currentLine = len(lines)
 
+	if !isEdit {
+		fieldStartLines[formFieldStatus] = currentLine
+		lines = append(lines, statusFocus+statusLabel.Render("Status"))
+		lines = append(lines, "  "+descriptionStyle.Render("Column the ticket is created in"))
+		lines = append(lines, "  "+m.renderStatusSelector())
+		lines = append(lines, "")
+		fieldEndLines[formFieldStatus] = len(lines) - 1
+		currentLine = len(lines)
+	}
+
 	fieldStartLines[formFieldWorktree] = currentLine
 	lines = append(lines, worktreeFocus+worktreeLabel.Render("Worktree"))
 	lines = append(lines, "  "+descriptionStyle.Render("Use isolated worktree or work in main repo"))
@@ -1096,18 +1186,17 @@ func (m *Model) renderPrioritySelector() string {
 	priorities := []struct {
 		level int
 		label string
-		color lipgloss.Color
 	}{
-		{1, "Critical", m.colors.err},
-		{2, "High", lipgloss.Color("#fab387")},
-		{3, "Medium", m.colors.warning},
-		{4, "Low", m.colors.primary},
-		{5, "Lowest", m.colors.muted},
+		{1, "Critical"},
+		{2, "High"},
+		{3, "Medium"},
+		{4, "Low"},
+		{5, "Lowest"},
 	}
 
 	var parts []string
 	for _, p := range priorities {
-		style := lipgloss.NewStyle().Foreground(p.color)
+		style := lipgloss.NewStyle().Foreground(m.colors.priority(p.level))
 		if m.ticketPriority == p.level {
 			style = style.Bold(true).Background(m.colors.surface).Padding(0, 1)
 			parts = append(parts, style.Render(fmt.Sprintf("● %s", p.label)))
@@ -1124,6 +1213,26 @@ func (m *Model) renderPrioritySelector() string {
 	return strings.Join(parts, "  ") + hint
 }
 
+func (m *Model) renderStatusSelector() string {
+	var parts []string
+	for _, col := range m.columns {
+		style := lipgloss.NewStyle().Foreground(m.columnColor(col))
+		if col.Status == m.ticketStatus {
+			style = style.Bold(true).Background(m.colors.surface).Padding(0, 1)
+			parts = append(parts, style.Render("● "+col.Name))
+		} else {
+			parts = append(parts, style.Render("○ "+col.Name))
+		}
+	}
+
+	hint := ""
+	if m.ticketFormField == formFieldStatus {
+		hint = "  " + m.dimStyle().Render("← →")
+	}
+
+	return strings.Join(parts, "  ") + hint
+}
+
 func (m *Model) renderWorktreeSelector() string {
 	worktreeStyle := lipgloss.NewStyle().Foreground(m.colors.success)
 	mainRepoStyle := lipgloss.NewStyle().Foreground(m.colors.warning)
@@ -1264,7 +1373,7 @@ func (m *Model) renderBlockerSelector() string {
 }
 
 func (m *Model) renderWithOverlay(overlay string) string {
-	return lipgloss.Place(
+	placed := lipgloss.Place(
 		m.width,
 		m.height,
 		lipgloss.Center,
@@ -1273,6 +1382,13 @@ func (m *Model) renderWithOverlay(overlay string) string {
 		lipgloss.WithWhitespaceChars(" "),
 		lipgloss.WithWhitespaceForeground(m.colors.base),
 	)
+	// The status bar stays on the bottom line, under the overlay's margin,
+	// so the breadcrumb of open layers shows what Esc will close.
+	lines := strings.Split(placed, "\n")
+	if last := len(lines) - 1; last > 0 && strings.TrimSpace(ansi.Strip(lines[last])) == "" {
+		lines[last] = m.renderStatusBar()
+	}
+	return strings.Join(lines, "\n")
 }
 
 func (m *Model) renderSettingsView() string {
@@ -1331,6 +1447,8 @@ func (m *Model) renderSettingsView() string {
 	switch field.kind {
 	case "toggle":
 		actionHint = "Toggle"
+	case "alerts":
+		actionHint = "Cycle"
 	case "project", "theme":
 		actionHint = "Select"
 	default:
@@ -1463,13 +1581,13 @@ func (m *Model) renderAgentView() string {
 	spacing := m.width - lipgloss.Width(header) - lipgloss.Width(hints)
 	spacing = max(spacing, 0)
 
-	b.WriteString(header)
+	b.WriteString(m.asciify(header))
 	b.WriteString(strings.Repeat(" ", spacing))
-	b.WriteString(hints)
+	b.WriteString(m.asciify(hints))
 	b.WriteString("\n")
 
 	if depsLine != "" {
-		b.WriteString(depsLine)
+		b.WriteString(m.asciify(depsLine))
 		b.WriteString("\n")
 	}
 
@@ -1501,6 +1619,14 @@ func (m *Model) renderFilterInput() string {
 	return inputStyle.Render("/ " + m.filterInput.View())
 }
 
+func (m *Model) renderCommandInput() string {
+	inputStyle := lipgloss.NewStyle().
+		Foreground(m.colors.base).
+		Background(m.colors.secondary).
+		Padding(0, 1)
+	return inputStyle.Render(": " + m.commandInput.View())
+}
+
 func (m *Model) renderActiveFilter() string {
 	filterStyle := lipgloss.NewStyle().
 		Foreground(m.colors.base).
@@ -1617,9 +1743,9 @@ func (m *Model) renderCreateProjectForm() string {
 	descStyle := lipgloss.NewStyle().Foreground(m.colors.muted).Italic(true)
 
 	var errorLine string
-	if m.notification != "" {
+	if t, ok := m.latestToast(); ok {
 		errorStyle := lipgloss.NewStyle().Foreground(m.colors.err).Bold(true)
-		errorLine = "\n  " + errorStyle.Render("⚠ "+m.notification) + "\n"
+		errorLine = "\n  " + errorStyle.Render("⚠ "+t.text) + "\n"
 	}
 
 	content := titleStyle.Render("◈ Add Project") + "\n\n" +
@@ -1652,14 +1778,22 @@ func shortenPath(path string) string {
 	return path
 }
 
+func truncateString(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n || n < 3 {
+		return s
+	}
+	return string(r[:n-3]) + "..."
+}
+
 func (m *Model) renderSidebar() string {
-	if !m.sidebarVisible {
+	if !m.showSidebar() {
 		return ""
 	}
 
 	projects := m.globalStore.Projects()
 	statusHeight := 1
-	availableHeight := m.height - m.headerHeight() - statusHeight
+	availableHeight := m.height - m.headerHeight() - statusHeight - m.previewHeight()
 
 	titleStyle := lipgloss.NewStyle().
 		Foreground(m.colors.primary).
@@ -1772,10 +1906,11 @@ func (m *Model) renderSidebar() string {
 }
 
 func (m *Model) boardWidth() int {
-	if m.sidebarVisible {
-		return m.width - m.sidebarWidth - 1
+	width := m.width - m.splitWidth()
+	if m.showSidebar() {
+		return width - m.sidebarWidth - 1
 	}
-	return m.width
+	return width
 }
 
 type uiColors struct {
@@ -1810,6 +1945,22 @@ func newUIColors(theme config.Theme) uiColors {
 	}
 }
 
+// priority is the theme color for a ticket priority, from Critical (1) to
+// Lowest (5).
+func (c uiColors) priority(level int) lipgloss.Color {
+	switch level {
+	case 1:
+		return c.err
+	case 2:
+		return c.secondary
+	case 3:
+		return c.warning
+	case 4:
+		return c.primary
+	}
+	return c.muted
+}
+
 var (
 	columnBorder = lipgloss.Border{
 		Top:         "━",
@@ -1871,8 +2022,11 @@ func (m *Model) dimStyle() lipgloss.Style {
 	return lipgloss.NewStyle().Foreground(m.colors.muted)
 }
 
-func (m *Model) columnColor(status board.TicketStatus) lipgloss.Color {
-	switch status {
+func (m *Model) columnColor(col board.Column) lipgloss.Color {
+	if c := m.config.ColumnLayout(col.ID).Color; c != "" {
+		return lipgloss.Color(c)
+	}
+	switch col.Status {
 	case board.StatusBacklog:
 		return m.colors.primary
 	case board.StatusInProgress:
@@ -1885,7 +2039,7 @@ func (m *Model) columnColor(status board.TicketStatus) lipgloss.Color {
 }
 
 func (m *Model) renderThemeDropdown() string {
-	themes := config.ThemeNames()
+	themes := themeChoices()
 	if len(themes) == 0 {
 		return m.dimStyle().Render("    No themes available")
 	}
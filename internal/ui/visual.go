@@ -0,0 +1,363 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/techdufus/openkanban/internal/board"
+)
+
+// enterVisualMode starts a selection at the active ticket. Like vim's
+// line-wise visual mode, the selection runs from the anchor to the cursor
+// within the active column.
+func (m *Model) enterVisualMode() (tea.Model, tea.Cmd) {
+	if m.selectedTicket() == nil {
+		return m, nil
+	}
+	m.mode = ModeVisual
+	m.visualAnchor = m.activeTicket
+	m.visualLabeling = false
+	return m, nil
+}
+
+func (m *Model) exitVisualMode() {
+	m.mode = ModeNormal
+	m.visualLabeling = false
+	m.visualInput.Blur()
+}
+
+// visualTickets is the selection, top to bottom.
+func (m *Model) visualTickets() []*board.Ticket {
+	if m.activeColumn >= len(m.columnTickets) {
+		return nil
+	}
+	tickets := m.columnTickets[m.activeColumn]
+	if len(tickets) == 0 {
+		return nil
+	}
+	lo := min(m.visualAnchor, m.activeTicket)
+	hi := min(max(m.visualAnchor, m.activeTicket), len(tickets)-1)
+	return tickets[max(lo, 0) : hi+1]
+}
+
+func (m *Model) isVisualSelected(ticket *board.Ticket) bool {
+	return m.mode == ModeVisual && slices.Contains(m.visualTickets(), ticket)
+}
+
+func (m *Model) handleVisualMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.visualLabeling {
+		return m.handleVisualLabelInput(msg)
+	}
+
+	switch msg.String() {
+	case "esc", "v":
+		m.exitVisualMode()
+	case "j", "down":
+		m.moveTicket(1)
+	case "k", "up":
+		m.moveTicket(-1)
+	case "g":
+		m.activeTicket = 0
+		m.ensureTicketVisible()
+	case "G":
+		if m.activeColumn < len(m.columnTickets) {
+			m.activeTicket = max(len(m.columnTickets[m.activeColumn])-1, 0)
+		}
+		m.ensureTicketVisible()
+	case " ":
+		return m.bulkMove(1)
+	case "-", "backspace":
+		return m.bulkMove(-1)
+	case "t":
+		m.visualLabeling = true
+		m.visualInput.Reset()
+		m.visualInput.Focus()
+		return m, textinput.Blink
+	case "a":
+		return m.bulkArchive()
+	case "d":
+		return m.confirmBulkDelete()
+	case "s":
+		return m.bulkSpawn()
+	}
+	return m, nil
+}
+
+func (m *Model) handleVisualLabelInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.visualLabeling = false
+		m.visualInput.Blur()
+		return m, nil
+	case "enter":
+		value := m.visualInput.Value()
+		m.visualLabeling = false
+		m.visualInput.Blur()
+		return m.bulkLabel(value)
+	}
+
+	var cmd tea.Cmd
+	m.visualInput, cmd = m.visualInput.Update(msg)
+	return m, cmd
+}
+
+// bulkMove moves the selection one column right (delta 1) or left (-1).
+func (m *Model) bulkMove(delta int) (tea.Model, tea.Cmd) {
+	tickets := m.visualTickets()
+	if len(tickets) == 0 {
+		return m, nil
+	}
+	from := tickets[0].Status
+	target := m.adjacentStatus(from, delta)
+	if target == from {
+		return m, nil
+	}
+	col, l := m.columnFor(target)
+	if l.Gate != "" {
+		m.notify(col.Name + " has a gate; move tickets there one at a time")
+		return m, nil
+	}
+	if l.Protected {
+		m.showConfirm = true
+		m.confirmMsg = fmt.Sprintf("Move %d ticket(s) to %s? [y/N]", len(tickets), col.Name)
+		m.confirmFn = func() tea.Cmd {
+			_, cmd := m.applyBulkMove(tickets, target)
+			return cmd
+		}
+		return m, nil
+	}
+	return m.applyBulkMove(tickets, target)
+}
+
+// applyBulkMove moves the tickets into target. Tickets that need a worktree
+// first have them created one at a time in the background and follow once
+// theirs is ready.
+func (m *Model) applyBulkMove(tickets []*board.Ticket, target board.TicketStatus) (tea.Model, tea.Cmd) {
+	moved, failed := 0, 0
+	var setups []tea.Cmd
+	for _, ticket := range tickets {
+		if _, busy := m.preparing[ticket.ID]; busy {
+			failed++
+			continue
+		}
+		if target == board.StatusInProgress && ticket.WorktreePath == "" {
+			if ticket.UseWorktree {
+				if cmd := m.prepareWorktree(ticket, target); cmd != nil {
+					setups = append(setups, cmd)
+				} else {
+					failed++
+				}
+				continue
+			}
+			if err := m.setupMainRepoBranch(ticket); err != nil {
+				m.notifyError("Branch setup failed: " + err.Error())
+				failed++
+				continue
+			}
+		}
+		m.globalStore.Move(ticket.ID, target)
+		moved++
+	}
+	m.saveAll()
+	m.exitVisualMode()
+	m.refreshColumnTickets()
+	m.clampActiveTicket()
+	cmd := tea.Sequence(setups...)
+	if failed > 0 {
+		// The failures have already said why those stayed behind.
+		return m, cmd
+	}
+	if len(setups) > 0 {
+		m.notifySuccess(fmt.Sprintf("Moved %d ticket(s) to %s; %d more once their worktrees are ready", moved, target, len(setups)))
+		return m, cmd
+	}
+	m.notifySuccess(fmt.Sprintf("Moved %d ticket(s) to %s", moved, target))
+	return m, nil
+}
+
+// bulkLabel applies comma-separated labels to the selection; a leading "-"
+// removes the label instead.
+func (m *Model) bulkLabel(value string) (tea.Model, tea.Cmd) {
+	var add, remove []string
+	for _, label := range strings.Split(value, ",") {
+		label = strings.TrimSpace(label)
+		if name, ok := strings.CutPrefix(label, "-"); ok {
+			if name = strings.TrimSpace(name); name != "" {
+				remove = append(remove, name)
+			}
+		} else if label != "" {
+			add = append(add, label)
+		}
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return m, nil
+	}
+
+	changed := 0
+	for _, ticket := range m.visualTickets() {
+		labels := slices.DeleteFunc(slices.Clone(ticket.Labels), func(l string) bool {
+			return slices.Contains(remove, l)
+		})
+		for _, label := range add {
+			if !slices.Contains(labels, label) {
+				labels = append(labels, label)
+			}
+		}
+		if slices.Equal(labels, ticket.Labels) {
+			continue
+		}
+		ticket.Labels = labels
+		ticket.Record(board.EventEdited, "labels")
+		ticket.Touch()
+		changed++
+	}
+	m.saveAll()
+	m.exitVisualMode()
+	m.refreshColumnTickets()
+	m.notifySuccess(fmt.Sprintf("Relabeled %d ticket(s)", changed))
+	return m, nil
+}
+
+// bulkArchive archives the selected Done tickets without a running agent.
+func (m *Model) bulkArchive() (tea.Model, tea.Cmd) {
+	count := 0
+	for _, ticket := range m.visualTickets() {
+		if ticket.Status != board.StatusDone {
+			continue
+		}
+		if pane, ok := m.panes[ticket.ID]; ok && pane.Running() {
+			continue
+		}
+		ticket.Archive()
+		count++
+	}
+	if count == 0 {
+		m.notify("Only Done tickets without a running agent can be archived")
+		return m, nil
+	}
+
+	m.saveAll()
+	m.exitVisualMode()
+	m.refreshColumnTickets()
+	m.clampActiveTicket()
+	m.notifySuccess(fmt.Sprintf("Archived %d tickets", count))
+	return m, nil
+}
+
+func (m *Model) confirmBulkDelete() (tea.Model, tea.Cmd) {
+	tickets := slices.Clone(m.visualTickets())
+	if len(tickets) == 0 {
+		return m, nil
+	}
+
+	dirty := 0
+	if m.config.Cleanup.DeleteWorktree && !m.config.Cleanup.ForceWorktreeRemoval {
+		for _, ticket := range tickets {
+			proj := m.globalStore.GetProjectForTicket(ticket)
+			if ticket.WorktreePath == "" || proj == nil || m.worktreeMgrs[proj.ID] == nil {
+				continue
+			}
+			if uncommitted, err := m.worktreeMgrs[proj.ID].HasUncommittedChanges(ticket.WorktreePath); err == nil && uncommitted {
+				dirty++
+			}
+		}
+	}
+
+	msg := fmt.Sprintf("Delete %d tickets?", len(tickets))
+	ask := m.config.Behavior.Confirm.DeleteTicket
+	if dirty > 0 {
+		msg = fmt.Sprintf("Delete %d tickets? %d worktree(s) have uncommitted changes.", len(tickets), dirty)
+		ask = ask || m.config.Behavior.Confirm.RemoveWorktree
+	}
+	return m.confirmOrRun(ask, msg, func() tea.Cmd {
+		for _, ticket := range tickets {
+			m.performTicketCleanup(ticket)
+		}
+		m.exitVisualMode()
+		m.clampActiveTicket()
+		m.notifySuccess(fmt.Sprintf("Deleted %d tickets", len(tickets)))
+		return nil
+	})
+}
+
+// bulkSpawn queues an agent for every selected ticket. They start one after
+// another in the background instead of attaching; a selection made while a
+// batch is starting joins the end of its queue.
+func (m *Model) bulkSpawn() (tea.Model, tea.Cmd) {
+	queued := len(m.spawnQueue)
+	for _, ticket := range m.visualTickets() {
+		_, running := m.panes[ticket.ID]
+		_, starting := m.starting[ticket.ID]
+		if !running && !starting && !slices.Contains(m.spawnQueue, ticket.ID) {
+			m.spawnQueue = append(m.spawnQueue, ticket.ID)
+		}
+	}
+	m.exitVisualMode()
+	if len(m.spawnQueue) == queued {
+		m.notify("Agents already running for the selection")
+		return m, nil
+	}
+	if m.batchSpawning {
+		m.notify(fmt.Sprintf("Queued %d more agent(s)", len(m.spawnQueue)-queued))
+		return m, nil
+	}
+	m.batchSpawning = true
+	m.batchSpawned = 0
+	return m.spawnQueued()
+}
+
+// spawnQueued starts the next queued spawn, skipping tickets that can't
+// spawn, and reports once the queue is empty.
+func (m *Model) spawnQueued() (tea.Model, tea.Cmd) {
+	for len(m.spawnQueue) > 0 {
+		id := m.spawnQueue[0]
+		m.spawnQueue = m.spawnQueue[1:]
+		ticket, _ := m.globalStore.Get(id)
+		if _, starting := m.starting[id]; ticket == nil || starting {
+			continue
+		}
+		_, cmd := m.spawnAgentFor(ticket)
+		if _, starting := m.starting[id]; starting {
+			m.batchTicketID = id
+			if m.attachOnStart == id {
+				m.attachOnStart = ""
+			}
+			return m, cmd
+		}
+	}
+
+	if m.batchSpawning {
+		m.batchSpawning = false
+		// With nothing started, the last skip reason is more useful.
+		if m.batchSpawned > 0 {
+			m.notifySuccess(fmt.Sprintf("Started %d agent(s)", m.batchSpawned))
+		}
+	}
+	return m, nil
+}
+
+// finishQueuedSpawn moves on to the next queued spawn once the last one
+// has started or failed.
+func (m *Model) finishQueuedSpawn(started bool) (tea.Model, tea.Cmd) {
+	if started {
+		m.batchSpawned++
+	}
+	m.batchTicketID = ""
+	return m.spawnQueued()
+}
+
+func (m *Model) renderVisualSection() string {
+	style := lipgloss.NewStyle().
+		Foreground(m.colors.base).
+		Background(m.colors.secondary).
+		Padding(0, 1)
+	if m.visualLabeling {
+		return style.Render("labels: " + m.visualInput.View())
+	}
+	return style.Bold(true).Render(fmt.Sprintf("%d selected", len(m.visualTickets())))
+}